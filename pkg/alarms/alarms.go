@@ -0,0 +1,139 @@
+// Package alarms parses SNMP alarm tables and tracks the lifecycle of alarms
+// for each SNMP agent.
+package alarms
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// ActiveAlarm is a single row of an SNMP alarm table.
+type ActiveAlarm struct {
+	ID    int         // The alarm id. This is the row index in the alarm table.
+	Name  string      // The well-known name of the alarm, or the raw description.
+	Descr interface{} // The raw alarm description. Generally an OID.
+	Time  interface{} // The raw alarm time. A TimeStamp in sysUpTime ticks.
+
+	// The time the alarm was raised, converted from Time. Zero if unknown.
+	RaiseTime time.Time
+}
+
+// ParseActiveAlarms translates the raw walk of an alarm table into the active
+// alarms, sorted by alarm id.
+// data is the map associated with a synse device. It must contain descr_oid
+// and time_oid, the column OIDs for the alarm description and alarm time.
+func ParseActiveAlarms(results []core.ReadResult, data map[string]interface{}) ([]*ActiveAlarm, error) {
+	descrOid, ok := data["descr_oid"].(string)
+	if !ok {
+		return nil, fmt.Errorf("descr_oid is not a string, %T, %+v", data["descr_oid"], data["descr_oid"])
+	}
+	timeOid, ok := data["time_oid"].(string)
+	if !ok {
+		return nil, fmt.Errorf("time_oid is not a string, %T, %+v", data["time_oid"], data["time_oid"])
+	}
+
+	// The row index is the alarm id. Line up the columns by it.
+	alarmMap := map[int]*ActiveAlarm{}
+	getAlarm := func(index string) (*ActiveAlarm, error) {
+		id, err := strconv.Atoi(index)
+		if err != nil {
+			return nil, fmt.Errorf("alarm index %v is not an integer", index)
+		}
+		alarm, ok := alarmMap[id]
+		if !ok {
+			alarm = &ActiveAlarm{ID: id}
+			alarmMap[id] = alarm
+		}
+		return alarm, nil
+	}
+
+	for _, result := range results {
+		oid := "." + strings.TrimPrefix(result.Oid, ".")
+		switch {
+		case strings.HasPrefix(oid, descrOid+"."):
+			alarm, err := getAlarm(oid[len(descrOid)+1:])
+			if err != nil {
+				return nil, err
+			}
+			alarm.Descr = result.Data
+			alarm.Name = TranslateAlarmDescr(result.Data, data)
+		case strings.HasPrefix(oid, timeOid+"."):
+			alarm, err := getAlarm(oid[len(timeOid)+1:])
+			if err != nil {
+				return nil, err
+			}
+			alarm.Time = result.Data
+		}
+	}
+
+	alarms := make([]*ActiveAlarm, 0, len(alarmMap))
+	for _, alarm := range alarmMap {
+		alarms = append(alarms, alarm)
+	}
+	sort.Slice(alarms, func(i, j int) bool {
+		return alarms[i].ID < alarms[j].ID
+	})
+	return alarms, nil
+}
+
+// TranslateAlarmDescr translates an alarm description to an alarm name.
+// Alarm descriptions are generally OIDs of well-known alarms. When the
+// description is under well_known_oid in the device data, the name is looked
+// up by the final OID segment, similar to an enumeration, e.g.
+// well_known1: upsAlarmBatteryBad. Anything else is returned as a string.
+func TranslateAlarmDescr(descr interface{}, data map[string]interface{}) string {
+	if descr == nil {
+		return ""
+	}
+
+	descrString := fmt.Sprint(descr)
+	wellKnownOid, ok := data["well_known_oid"].(string)
+	if !ok {
+		return descrString
+	}
+
+	oid := "." + strings.TrimPrefix(descrString, ".")
+	if !strings.HasPrefix(oid, wellKnownOid+".") {
+		return descrString
+	}
+
+	index, err := strconv.Atoi(oid[len(wellKnownOid)+1:])
+	if err != nil {
+		return descrString
+	}
+
+	// Key lookup to find the well-known name.
+	name, ok := data[fmt.Sprintf("well_known%d", index)]
+	if !ok {
+		// Not found. Return something with the raw int.
+		return fmt.Sprintf("undefined%d", index)
+	}
+	return fmt.Sprint(name)
+}
+
+// SetRaiseTimes converts the raw alarm times to the time each alarm was
+// raised. sysUpTime must be read together with the alarms, and now is the
+// local time at which it was read. See core.TimeStampToTime for wrapped.
+func SetRaiseTimes(alarms []*ActiveAlarm, sysUpTime interface{}, now time.Time, wrapped bool) error {
+	upTime, err := core.ToTicks(sysUpTime)
+	if err != nil {
+		return err
+	}
+
+	for _, alarm := range alarms {
+		if alarm.Time == nil {
+			continue
+		}
+		timeStamp, err := core.ToTicks(alarm.Time)
+		if err != nil {
+			return err
+		}
+		alarm.RaiseTime, _ = core.TimeStampToTime(core.TimeStamp(timeStamp), upTime, now, wrapped)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package alarms
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// alarmsTestData is the alarm related device data from the UPS-MIB alarms
+// table enumerator, trimmed to a few well-known alarms.
+var alarmsTestData = map[string]interface{}{
+	"oid":            ".1.3.6.1.2.1.33.1.6.2",
+	"descr_oid":      ".1.3.6.1.2.1.33.1.6.2.1.2",
+	"time_oid":       ".1.3.6.1.2.1.33.1.6.2.1.3",
+	"well_known_oid": ".1.3.6.1.2.1.33.1.6.3",
+	"well_known1":    "upsAlarmBatteryBad",
+	"well_known2":    "upsAlarmOnBattery",
+	"well_known3":    "upsAlarmLowBattery",
+}
+
+// TestTranslateAlarmDescr tests translating alarm descriptions to names.
+func TestTranslateAlarmDescr(t *testing.T) {
+	assert.Equal(t, "upsAlarmBatteryBad", TranslateAlarmDescr(".1.3.6.1.2.1.33.1.6.3.1", alarmsTestData))
+	assert.Equal(t, "upsAlarmLowBattery", TranslateAlarmDescr(".1.3.6.1.2.1.33.1.6.3.3", alarmsTestData))
+	// gosnmp may strip the leading dot.
+	assert.Equal(t, "upsAlarmOnBattery", TranslateAlarmDescr("1.3.6.1.2.1.33.1.6.3.2", alarmsTestData))
+	// Well-known OID with no name.
+	assert.Equal(t, "undefined99", TranslateAlarmDescr(".1.3.6.1.2.1.33.1.6.3.99", alarmsTestData))
+	// Not a well-known alarm. The raw description is returned.
+	assert.Equal(t, "Test Alarm", TranslateAlarmDescr("Test Alarm", alarmsTestData))
+	assert.Equal(t, ".1.3.6.1.4.1.534.1.7.3", TranslateAlarmDescr(".1.3.6.1.4.1.534.1.7.3", alarmsTestData))
+	// Nil description.
+	assert.Equal(t, "", TranslateAlarmDescr(nil, alarmsTestData))
+}
+
+// TestParseActiveAlarms tests parsing the walk of an alarm table.
+func TestParseActiveAlarms(t *testing.T) {
+	// Walk results are in column order, so the descriptions come first.
+	results := []core.ReadResult{
+		{Oid: ".1.3.6.1.2.1.33.1.6.2.1.1.4", Data: 4},
+		{Oid: ".1.3.6.1.2.1.33.1.6.2.1.1.7", Data: 7},
+		{Oid: ".1.3.6.1.2.1.33.1.6.2.1.2.4", Data: ".1.3.6.1.2.1.33.1.6.3.2"},
+		{Oid: ".1.3.6.1.2.1.33.1.6.2.1.2.7", Data: ".1.3.6.1.2.1.33.1.6.3.3"},
+		{Oid: ".1.3.6.1.2.1.33.1.6.2.1.3.4", Data: uint32(1200)},
+		{Oid: ".1.3.6.1.2.1.33.1.6.2.1.3.7", Data: uint32(3400)},
+	}
+
+	alarms, err := ParseActiveAlarms(results, alarmsTestData)
+	assert.NoError(t, err)
+	assert.Len(t, alarms, 2)
+
+	assert.Equal(t, 4, alarms[0].ID)
+	assert.Equal(t, "upsAlarmOnBattery", alarms[0].Name)
+	assert.Equal(t, ".1.3.6.1.2.1.33.1.6.3.2", alarms[0].Descr)
+	assert.Equal(t, uint32(1200), alarms[0].Time)
+
+	assert.Equal(t, 7, alarms[1].ID)
+	assert.Equal(t, "upsAlarmLowBattery", alarms[1].Name)
+	assert.Equal(t, ".1.3.6.1.2.1.33.1.6.3.3", alarms[1].Descr)
+	assert.Equal(t, uint32(3400), alarms[1].Time)
+}
+
+// TestParseActiveAlarmsEmpty tests parsing the walk of an empty alarm table.
+func TestParseActiveAlarmsEmpty(t *testing.T) {
+	alarms, err := ParseActiveAlarms([]core.ReadResult{}, alarmsTestData)
+	assert.NoError(t, err)
+	assert.Len(t, alarms, 0)
+}
+
+// TestParseActiveAlarmsBadData tests that missing column OIDs are an error.
+func TestParseActiveAlarmsBadData(t *testing.T) {
+	_, err := ParseActiveAlarms([]core.ReadResult{}, map[string]interface{}{})
+	assert.Error(t, err)
+
+	_, err = ParseActiveAlarms([]core.ReadResult{}, map[string]interface{}{
+		"descr_oid": ".1.3.6.1.2.1.33.1.6.2.1.2",
+	})
+	assert.Error(t, err)
+}
+
+// TestSetRaiseTimes tests converting alarm times to raise times.
+func TestSetRaiseTimes(t *testing.T) {
+	now := time.Date(2021, time.March, 4, 12, 0, 0, 0, time.UTC)
+	alarms := []*ActiveAlarm{
+		{ID: 1, Time: uint32(1000)},
+		{ID: 2, Time: uint32(0)},    // Before the last reinit.
+		{ID: 3},                     // Not read.
+		{ID: 4, Time: uint32(8000)}, // After sysUpTime, and no wrap seen.
+	}
+
+	assert.NoError(t, SetRaiseTimes(alarms, uint32(7000), now, false))
+	assert.Equal(t, now.Add(-time.Minute), alarms[0].RaiseTime)
+	assert.True(t, alarms[1].RaiseTime.IsZero())
+	assert.True(t, alarms[2].RaiseTime.IsZero())
+	assert.True(t, alarms[3].RaiseTime.IsZero())
+
+	assert.Error(t, SetRaiseTimes(alarms, "bad", now, false))
+}
@@ -0,0 +1,403 @@
+package alarms
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultHistorySize is the default number of cleared alarms kept in memory
+// for each agent.
+const DefaultHistorySize = 100
+
+// Sources of alarm lifecycle events.
+const (
+	SourcePoll = "poll" // The alarm table was polled.
+	SourceTrap = "trap" // A trap was received.
+)
+
+// Record is the lifecycle of a single alarm. Records of cleared alarms are
+// persisted as JSON lines.
+type Record struct {
+	ID        int       `json:"id"`         // The alarm id when the alarm was raised.
+	Name      string    `json:"name"`       // The well-known name of the alarm.
+	Descr     string    `json:"descr"`      // The raw alarm description.
+	FirstSeen time.Time `json:"first_seen"` // When the alarm was raised, or first seen if that is unknown.
+	ClearedAt time.Time `json:"cleared_at"` // When the alarm cleared. Zero while the alarm is active.
+	Source    string    `json:"source"`     // How the alarm was first seen, poll or trap.
+}
+
+// Active is true when the alarm has not cleared.
+func (record Record) Active() bool {
+	return record.ClearedAt.IsZero()
+}
+
+// Duration is how long the alarm lasted. For active alarms this is how long
+// the alarm has lasted as of now.
+func (record Record) Duration(now time.Time) time.Duration {
+	end := record.ClearedAt
+	if record.Active() {
+		end = now
+	}
+	if end.Before(record.FirstSeen) {
+		return 0
+	}
+	return end.Sub(record.FirstSeen)
+}
+
+// alarmKey identifies an alarm while it is active. Alarm ids may be reused
+// once an alarm clears, so the description is part of the key.
+func alarmKey(id int, descr string) string {
+	return fmt.Sprintf("%d/%s", id, "."+strings.TrimPrefix(descr, "."))
+}
+
+// Tracker tracks the lifecycle of the alarms on a single SNMP agent. It is
+// fed by polling the alarm table and by traps. Active alarms are tracked
+// until they clear, then moved to a bounded history.
+type Tracker struct {
+	HistorySize int    // The maximum number of cleared alarms to keep.
+	HistoryFile string // Optional JSON lines file for cleared alarms.
+
+	mutex     sync.Mutex
+	active    map[string]*Record // Active alarms by alarm key.
+	history   []*Record          // Cleared alarms, oldest first.
+	persisted int                // Records in the history file.
+}
+
+// NewTracker creates a Tracker. If historyFile is not empty, cleared alarms
+// are appended to it and the most recent are loaded from it. The file is
+// compacted to the most recent alarms when it grows to twice the history
+// size.
+func NewTracker(historySize int, historyFile string) (*Tracker, error) {
+	if historySize <= 0 {
+		return nil, fmt.Errorf("alarm history size must be positive, got %d", historySize)
+	}
+
+	tracker := &Tracker{
+		HistorySize: historySize,
+		HistoryFile: historyFile,
+		active:      map[string]*Record{},
+		history:     []*Record{},
+	}
+
+	if historyFile != "" {
+		if err := tracker.load(); err != nil {
+			return nil, err
+		}
+	}
+	return tracker, nil
+}
+
+// Update reconciles the tracker with the alarms currently in the alarm table.
+// Alarms not yet tracked are raised and tracked alarms which are no longer in
+// the table are cleared.
+func (tracker *Tracker) Update(now time.Time, alarms []*ActiveAlarm) error {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	present := map[string]bool{}
+	for _, alarm := range alarms {
+		key := alarmKey(alarm.ID, fmt.Sprint(alarm.Descr))
+		present[key] = true
+		tracker.raise(now, alarm, SourcePoll)
+	}
+
+	// Sort the keys so the history is in a stable order.
+	var cleared []string
+	for key := range tracker.active {
+		if !present[key] {
+			cleared = append(cleared, key)
+		}
+	}
+	sort.Strings(cleared)
+
+	for _, key := range cleared {
+		if err := tracker.clear(now, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Raise tracks a raised alarm. This is for traps. Raising an alarm which is
+// already active only updates its name.
+func (tracker *Tracker) Raise(now time.Time, alarm *ActiveAlarm) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+	tracker.raise(now, alarm, SourceTrap)
+}
+
+// Clear clears an alarm. This is for traps. Clearing an alarm which is not
+// active does nothing.
+func (tracker *Tracker) Clear(now time.Time, alarm *ActiveAlarm) error {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+	return tracker.clear(now, alarmKey(alarm.ID, fmt.Sprint(alarm.Descr)))
+}
+
+// Records returns a copy of the active alarms and the alarm history, ordered
+// by when they were first seen.
+func (tracker *Tracker) Records() []Record {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	records := make([]Record, 0, len(tracker.history)+len(tracker.active))
+	for _, record := range tracker.history {
+		records = append(records, *record)
+	}
+	for _, record := range tracker.active {
+		records = append(records, *record)
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].FirstSeen.Equal(records[j].FirstSeen) {
+			return records[i].ID < records[j].ID
+		}
+		return records[i].FirstSeen.Before(records[j].FirstSeen)
+	})
+	return records
+}
+
+// raise tracks a raised alarm. The caller must hold the mutex.
+func (tracker *Tracker) raise(now time.Time, alarm *ActiveAlarm, source string) {
+	descr := fmt.Sprint(alarm.Descr)
+	key := alarmKey(alarm.ID, descr)
+
+	record, ok := tracker.active[key]
+	if ok {
+		// Traps do not have well-known names until the next poll.
+		if alarm.Name != "" {
+			record.Name = alarm.Name
+		}
+		return
+	}
+
+	firstSeen := now
+	if !alarm.RaiseTime.IsZero() {
+		firstSeen = alarm.RaiseTime
+	}
+	name := alarm.Name
+	if name == "" {
+		name = descr
+	}
+
+	tracker.active[key] = &Record{
+		ID:        alarm.ID,
+		Name:      name,
+		Descr:     descr,
+		FirstSeen: firstSeen,
+		Source:    source,
+	}
+	log.WithFields(log.Fields{
+		"id":     alarm.ID,
+		"name":   name,
+		"source": source,
+	}).Info("[snmp] alarm raised")
+}
+
+// clear moves an active alarm to the history. The caller must hold the mutex.
+func (tracker *Tracker) clear(now time.Time, key string) error {
+	record, ok := tracker.active[key]
+	if !ok {
+		return nil
+	}
+	delete(tracker.active, key)
+
+	record.ClearedAt = now
+	tracker.history = append(tracker.history, record)
+	if len(tracker.history) > tracker.HistorySize {
+		tracker.history = tracker.history[len(tracker.history)-tracker.HistorySize:]
+	}
+	log.WithFields(log.Fields{
+		"id":       record.ID,
+		"name":     record.Name,
+		"duration": record.Duration(now),
+	}).Info("[snmp] alarm cleared")
+
+	return tracker.persist(record)
+}
+
+// persist appends a cleared alarm to the history file, if any. The file is
+// compacted when it has twice the history size. The caller must hold the
+// mutex.
+func (tracker *Tracker) persist(record *Record) error {
+	if tracker.HistoryFile == "" {
+		return nil
+	}
+	if tracker.persisted+1 >= 2*tracker.HistorySize {
+		// The record is already in the history.
+		return tracker.compact()
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(tracker.HistoryFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close() // nolint: errcheck
+
+	if _, err = file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	tracker.persisted++
+	return nil
+}
+
+// compact replaces the history file with the history. The file is written
+// aside and renamed, so a failure leaves the old file. The caller must hold
+// the mutex.
+func (tracker *Tracker) compact() error {
+	var contents []byte
+	for _, record := range tracker.history {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		contents = append(append(contents, line...), '\n')
+	}
+
+	compacted := tracker.HistoryFile + ".tmp"
+	if err := ioutil.WriteFile(compacted, contents, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(compacted, tracker.HistoryFile); err != nil {
+		return err
+	}
+	tracker.persisted = len(tracker.history)
+	log.WithFields(log.Fields{
+		"file":    tracker.HistoryFile,
+		"records": tracker.persisted,
+	}).Debug("[snmp] compacted alarm history file")
+	return nil
+}
+
+// load loads the most recent cleared alarms from the history file. A missing
+// file is an empty history.
+func (tracker *Tracker) load() error {
+	file, err := os.Open(tracker.HistoryFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer file.Close() // nolint: errcheck
+
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		record := &Record{}
+		if err := json.Unmarshal([]byte(line), record); err != nil {
+			log.WithFields(log.Fields{
+				"file":  tracker.HistoryFile,
+				"line":  lineNumber,
+				"error": err,
+			}).Warn("[snmp] skipping bad line in alarm history file")
+			continue
+		}
+
+		tracker.persisted++
+		tracker.history = append(tracker.history, record)
+		if len(tracker.history) > tracker.HistorySize {
+			tracker.history = tracker.history[1:]
+		}
+	}
+	return scanner.Err()
+}
+
+// configure changes the history size and file of the tracker. The active
+// alarms are kept. The history is loaded from a new file, and is trimmed to
+// the history size.
+func (tracker *Tracker) configure(historySize int, historyFile string) error {
+	if historySize <= 0 {
+		return fmt.Errorf("alarm history size must be positive, got %d", historySize)
+	}
+
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	tracker.HistorySize = historySize
+	if historyFile != tracker.HistoryFile {
+		tracker.HistoryFile = historyFile
+		tracker.persisted = 0
+		if historyFile != "" {
+			tracker.history = []*Record{}
+			if err := tracker.load(); err != nil {
+				return err
+			}
+		}
+	}
+	if len(tracker.history) > historySize {
+		tracker.history = tracker.history[len(tracker.history)-historySize:]
+	}
+	return nil
+}
+
+// trackers are the alarm trackers for each agent, keyed by AgentKey.
+var (
+	trackers      = map[string]*Tracker{}
+	trackersMutex sync.Mutex
+)
+
+// AgentKey is the key for an SNMP agent's alarm tracker.
+func AgentKey(endpoint interface{}, port interface{}) string {
+	return fmt.Sprintf("%v:%v", endpoint, port)
+}
+
+// ConfigureTracker configures the alarm tracker for an agent. An existing
+// tracker for the agent, e.g. from a poll before the agent was configured or
+// from an earlier configuration, is reconfigured rather than replaced, so its
+// active alarms are not raised again.
+func ConfigureTracker(agent string, historySize int, historyFile string) (*Tracker, error) {
+	trackersMutex.Lock()
+	defer trackersMutex.Unlock()
+
+	if tracker, ok := trackers[agent]; ok {
+		if err := tracker.configure(historySize, historyFile); err != nil {
+			return nil, err
+		}
+		return tracker, nil
+	}
+
+	tracker, err := NewTracker(historySize, historyFile)
+	if err != nil {
+		return nil, err
+	}
+	trackers[agent] = tracker
+	return tracker, nil
+}
+
+// GetTracker gets the alarm tracker for an agent. If the tracker has not been
+// configured, one is created with the default history size and no file.
+func GetTracker(agent string) *Tracker {
+	trackersMutex.Lock()
+	defer trackersMutex.Unlock()
+
+	tracker, ok := trackers[agent]
+	if !ok {
+		tracker = &Tracker{
+			HistorySize: DefaultHistorySize,
+			active:      map[string]*Record{},
+			history:     []*Record{},
+		}
+		trackers[agent] = tracker
+	}
+	return tracker
+}
@@ -0,0 +1,271 @@
+package alarms
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// trackerTestNow is a fixed time for the tracker tests.
+var trackerTestNow = time.Date(2021, time.March, 4, 12, 0, 0, 0, time.UTC)
+
+// testAlarm creates an active alarm for a well-known alarm.
+func testAlarm(id int, wellKnown int, name string) *ActiveAlarm {
+	return &ActiveAlarm{
+		ID:    id,
+		Name:  name,
+		Descr: fmt.Sprintf(".1.3.6.1.2.1.33.1.6.3.%d", wellKnown),
+	}
+}
+
+// TestTrackerRaiseAndClear tests polling an alarm through its lifecycle.
+func TestTrackerRaiseAndClear(t *testing.T) {
+	tracker, err := NewTracker(10, "")
+	assert.NoError(t, err)
+
+	onBattery := testAlarm(1, 2, "upsAlarmOnBattery")
+
+	// Raised.
+	assert.NoError(t, tracker.Update(trackerTestNow, []*ActiveAlarm{onBattery}))
+	records := tracker.Records()
+	assert.Len(t, records, 1)
+	assert.True(t, records[0].Active())
+	assert.Equal(t, "upsAlarmOnBattery", records[0].Name)
+	assert.Equal(t, trackerTestNow, records[0].FirstSeen)
+	assert.Equal(t, SourcePoll, records[0].Source)
+
+	// Still raised. Nothing changes.
+	later := trackerTestNow.Add(90 * time.Second)
+	assert.NoError(t, tracker.Update(later, []*ActiveAlarm{onBattery}))
+	records = tracker.Records()
+	assert.Len(t, records, 1)
+	assert.True(t, records[0].Active())
+	assert.Equal(t, 90*time.Second, records[0].Duration(later))
+
+	// Cleared.
+	assert.NoError(t, tracker.Update(later, []*ActiveAlarm{}))
+	records = tracker.Records()
+	assert.Len(t, records, 1)
+	assert.False(t, records[0].Active())
+	assert.Equal(t, later, records[0].ClearedAt)
+	assert.Equal(t, 90*time.Second, records[0].Duration(later.Add(time.Hour)))
+}
+
+// TestTrackerRaiseTime tests that the raise time is used when known.
+func TestTrackerRaiseTime(t *testing.T) {
+	tracker, err := NewTracker(10, "")
+	assert.NoError(t, err)
+
+	alarm := testAlarm(1, 2, "upsAlarmOnBattery")
+	alarm.RaiseTime = trackerTestNow.Add(-time.Minute)
+	assert.NoError(t, tracker.Update(trackerTestNow, []*ActiveAlarm{alarm}))
+
+	records := tracker.Records()
+	assert.Len(t, records, 1)
+	assert.Equal(t, trackerTestNow.Add(-time.Minute), records[0].FirstSeen)
+}
+
+// TestTrackerIDReuse tests that a reused alarm id is a new alarm.
+func TestTrackerIDReuse(t *testing.T) {
+	tracker, err := NewTracker(10, "")
+	assert.NoError(t, err)
+
+	assert.NoError(t, tracker.Update(trackerTestNow, []*ActiveAlarm{testAlarm(1, 2, "upsAlarmOnBattery")}))
+	later := trackerTestNow.Add(time.Minute)
+	assert.NoError(t, tracker.Update(later, []*ActiveAlarm{testAlarm(1, 3, "upsAlarmLowBattery")}))
+
+	records := tracker.Records()
+	assert.Len(t, records, 2)
+	assert.Equal(t, "upsAlarmOnBattery", records[0].Name)
+	assert.False(t, records[0].Active())
+	assert.Equal(t, "upsAlarmLowBattery", records[1].Name)
+	assert.True(t, records[1].Active())
+}
+
+// TestTrackerTraps tests raising and clearing alarms from traps.
+func TestTrackerTraps(t *testing.T) {
+	tracker, err := NewTracker(10, "")
+	assert.NoError(t, err)
+
+	// Traps have no well-known name, so the description is the name.
+	trapAlarm := &ActiveAlarm{ID: 3, Descr: ".1.3.6.1.2.1.33.1.6.3.2"}
+	tracker.Raise(trackerTestNow, trapAlarm)
+	records := tracker.Records()
+	assert.Len(t, records, 1)
+	assert.Equal(t, ".1.3.6.1.2.1.33.1.6.3.2", records[0].Name)
+	assert.Equal(t, SourceTrap, records[0].Source)
+
+	// A poll fills in the name. gosnmp may strip the leading dot.
+	later := trackerTestNow.Add(time.Second)
+	polled := &ActiveAlarm{ID: 3, Name: "upsAlarmOnBattery", Descr: "1.3.6.1.2.1.33.1.6.3.2"}
+	assert.NoError(t, tracker.Update(later, []*ActiveAlarm{polled}))
+	records = tracker.Records()
+	assert.Len(t, records, 1)
+	assert.Equal(t, "upsAlarmOnBattery", records[0].Name)
+	assert.Equal(t, trackerTestNow, records[0].FirstSeen)
+
+	// Cleared by trap.
+	assert.NoError(t, tracker.Clear(later, trapAlarm))
+	records = tracker.Records()
+	assert.Len(t, records, 1)
+	assert.False(t, records[0].Active())
+
+	// Clearing again does nothing.
+	assert.NoError(t, tracker.Clear(later, trapAlarm))
+	assert.Len(t, tracker.Records(), 1)
+}
+
+// TestTrackerHistorySize tests that the history is bounded.
+func TestTrackerHistorySize(t *testing.T) {
+	tracker, err := NewTracker(2, "")
+	assert.NoError(t, err)
+
+	now := trackerTestNow
+	for id := 1; id <= 3; id++ {
+		assert.NoError(t, tracker.Update(now, []*ActiveAlarm{testAlarm(id, 2, "upsAlarmOnBattery")}))
+		now = now.Add(time.Minute)
+		assert.NoError(t, tracker.Update(now, []*ActiveAlarm{}))
+	}
+
+	records := tracker.Records()
+	assert.Len(t, records, 2)
+	assert.Equal(t, 2, records[0].ID)
+	assert.Equal(t, 3, records[1].ID)
+}
+
+// TestTrackerHistoryFile tests persisting the history.
+func TestTrackerHistoryFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "alarm-history")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir) // nolint: errcheck
+	historyFile := filepath.Join(dir, "history.jsonl")
+
+	tracker, err := NewTracker(10, historyFile)
+	assert.NoError(t, err)
+	assert.NoError(t, tracker.Update(trackerTestNow, []*ActiveAlarm{testAlarm(1, 2, "upsAlarmOnBattery")}))
+	assert.NoError(t, tracker.Update(trackerTestNow.Add(time.Minute), []*ActiveAlarm{}))
+
+	contents, err := ioutil.ReadFile(historyFile)
+	assert.NoError(t, err)
+	assert.Len(t, strings.Split(strings.TrimSpace(string(contents)), "\n"), 1)
+
+	// Add a bad line. It is skipped on load.
+	file, err := os.OpenFile(historyFile, os.O_APPEND|os.O_WRONLY, 0644)
+	assert.NoError(t, err)
+	_, err = file.WriteString("not json\n")
+	assert.NoError(t, err)
+	assert.NoError(t, file.Close())
+
+	// A new tracker loads the history.
+	tracker, err = NewTracker(10, historyFile)
+	assert.NoError(t, err)
+	records := tracker.Records()
+	assert.Len(t, records, 1)
+	assert.Equal(t, "upsAlarmOnBattery", records[0].Name)
+	assert.Equal(t, trackerTestNow, records[0].FirstSeen.UTC())
+	assert.Equal(t, trackerTestNow.Add(time.Minute), records[0].ClearedAt.UTC())
+}
+
+// TestNewTrackerBadSize tests that the history size must be positive.
+func TestNewTrackerBadSize(t *testing.T) {
+	_, err := NewTracker(0, "")
+	assert.Error(t, err)
+}
+
+// TestGetTracker tests getting a tracker for an agent.
+func TestGetTracker(t *testing.T) {
+	agent := AgentKey("127.0.0.1", 1024)
+	assert.Equal(t, "127.0.0.1:1024", agent)
+
+	// Default tracker.
+	tracker := GetTracker(agent)
+	assert.Equal(t, DefaultHistorySize, tracker.HistorySize)
+	assert.Equal(t, tracker, GetTracker(agent))
+
+	// Configuring reuses it, so the active alarms are kept.
+	tracker.Raise(trackerTestNow, testAlarm(1, 2, "upsAlarmOnBattery"))
+	configured, err := ConfigureTracker(agent, 5, "")
+	assert.NoError(t, err)
+	assert.Same(t, tracker, configured)
+	assert.Same(t, configured, GetTracker(agent))
+	assert.Equal(t, 5, GetTracker(agent).HistorySize)
+	assert.Len(t, configured.Records(), 1)
+
+	_, err = ConfigureTracker(agent, 0, "")
+	assert.Error(t, err)
+}
+
+// TestConfigureTracker tests reconfiguring the tracker of an agent.
+func TestConfigureTracker(t *testing.T) {
+	dir, err := ioutil.TempDir("", "alarm-history")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir) // nolint: errcheck
+	historyFile := filepath.Join(dir, "history.jsonl")
+
+	previous, err := NewTracker(10, historyFile)
+	assert.NoError(t, err)
+	for id := 1; id <= 3; id++ {
+		assert.NoError(t, previous.Update(trackerTestNow, []*ActiveAlarm{testAlarm(id, 2, "upsAlarmOnBattery")}))
+		assert.NoError(t, previous.Update(trackerTestNow.Add(time.Minute), []*ActiveAlarm{}))
+	}
+
+	agent := AgentKey("127.0.0.2", 161)
+	tracker, err := ConfigureTracker(agent, 10, "")
+	assert.NoError(t, err)
+	assert.NoError(t, tracker.Update(trackerTestNow, []*ActiveAlarm{testAlarm(7, 3, "upsAlarmLowBattery")}))
+
+	// A new file loads its history. The history is trimmed to the size.
+	configured, err := ConfigureTracker(agent, 2, historyFile)
+	assert.NoError(t, err)
+	assert.Same(t, tracker, configured)
+	records := configured.Records()
+	assert.Len(t, records, 3)
+	assert.Equal(t, 2, records[0].ID)
+	assert.Equal(t, 3, records[1].ID)
+	assert.True(t, records[2].Active())
+	assert.Equal(t, 7, records[2].ID)
+}
+
+// TestTrackerHistoryFileCompaction tests that the history file is bounded.
+func TestTrackerHistoryFileCompaction(t *testing.T) {
+	dir, err := ioutil.TempDir("", "alarm-history")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir) // nolint: errcheck
+	historyFile := filepath.Join(dir, "history.jsonl")
+
+	tracker, err := NewTracker(2, historyFile)
+	assert.NoError(t, err)
+	now := trackerTestNow
+	lines := func() []string {
+		contents, err := ioutil.ReadFile(historyFile)
+		assert.NoError(t, err)
+		return strings.Split(strings.TrimSpace(string(contents)), "\n")
+	}
+	for id := 1; id <= 3; id++ {
+		assert.NoError(t, tracker.Update(now, []*ActiveAlarm{testAlarm(id, 2, "upsAlarmOnBattery")}))
+		now = now.Add(time.Minute)
+		assert.NoError(t, tracker.Update(now, []*ActiveAlarm{}))
+	}
+
+	// The file never has more than twice the history size.
+	assert.Len(t, lines(), 3)
+	assert.NoError(t, tracker.Update(now, []*ActiveAlarm{testAlarm(4, 2, "upsAlarmOnBattery")}))
+	assert.NoError(t, tracker.Update(now.Add(time.Minute), []*ActiveAlarm{}))
+	assert.Len(t, lines(), 2)
+	assert.Contains(t, lines()[0], `"id":3`)
+	assert.Contains(t, lines()[1], `"id":4`)
+
+	// A new tracker loads the compacted history.
+	tracker, err = NewTracker(2, historyFile)
+	assert.NoError(t, err)
+	records := tracker.Records()
+	assert.Len(t, records, 2)
+	assert.Equal(t, 3, records[0].ID)
+	assert.Equal(t, 4, records[1].ID)
+}
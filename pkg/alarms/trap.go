@@ -0,0 +1,189 @@
+package alarms
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	log "github.com/sirupsen/logrus"
+)
+
+// OIDs used to parse UPS-MIB alarm traps.
+const (
+	snmpTrapOid             = ".1.3.6.1.6.3.1.1.4.1.0"    // SNMPv2-MIB snmpTrapOID.0
+	upsTrapAlarmEntryAdded  = ".1.3.6.1.2.1.33.2.3"       // A row was added to upsAlarmTable.
+	upsTrapAlarmEntryRemove = ".1.3.6.1.2.1.33.2.4"       // A row was removed from upsAlarmTable.
+	upsAlarmIDOid           = ".1.3.6.1.2.1.33.1.6.2.1.1" // upsAlarmId column.
+	upsAlarmDescrOid        = ".1.3.6.1.2.1.33.1.6.2.1.2" // upsAlarmDescr column.
+)
+
+// trap listener state. There is one listener per address, shared by all
+// agents. Traps are routed to trackers by the source IP address.
+var (
+	trapMutex     sync.Mutex
+	trapListeners = map[string]*gosnmp.TrapListener{}
+	trapAgents    = map[string]string{} // Source IP to AgentKey.
+)
+
+// normalizeOid gives an OID a leading dot. gosnmp is not consistent here.
+func normalizeOid(oid string) string {
+	return "." + strings.TrimPrefix(oid, ".")
+}
+
+// ParseAlarmTrap parses the variables of a UPS-MIB upsTrapAlarmEntryAdded or
+// upsTrapAlarmEntryRemoved trap. ok is false for any other trap. added is
+// true when the alarm was raised and false when it cleared.
+func ParseAlarmTrap(variables []gosnmp.SnmpPDU) (alarm *ActiveAlarm, added bool, ok bool) {
+	var trapOid string
+	for _, variable := range variables {
+		if normalizeOid(variable.Name) == snmpTrapOid {
+			trapOid, _ = variable.Value.(string)
+			trapOid = normalizeOid(trapOid)
+		}
+	}
+
+	switch trapOid {
+	case upsTrapAlarmEntryAdded:
+		added = true
+	case upsTrapAlarmEntryRemove:
+		added = false
+	default:
+		return nil, false, false
+	}
+
+	// The alarm id is the row index of both the upsAlarmId and
+	// upsAlarmDescr variables.
+	alarm = &ActiveAlarm{}
+	haveID := false
+	for _, variable := range variables {
+		name := normalizeOid(variable.Name)
+		switch {
+		case strings.HasPrefix(name, upsAlarmIDOid+"."):
+			id, err := strconv.Atoi(name[len(upsAlarmIDOid)+1:])
+			if err != nil {
+				return nil, false, false
+			}
+			alarm.ID = id
+			haveID = true
+		case strings.HasPrefix(name, upsAlarmDescrOid+"."):
+			id, err := strconv.Atoi(name[len(upsAlarmDescrOid)+1:])
+			if err != nil {
+				return nil, false, false
+			}
+			alarm.ID = id
+			haveID = true
+			if descr, isString := variable.Value.(string); isString {
+				alarm.Descr = normalizeOid(descr)
+			} else {
+				alarm.Descr = variable.Value
+			}
+		}
+	}
+	if !haveID || alarm.Descr == nil {
+		return nil, false, false
+	}
+	return alarm, added, true
+}
+
+// RegisterTrapAgent routes traps from an agent's endpoint to the agent's
+// alarm tracker.
+func RegisterTrapAgent(endpoint string, agent string) error {
+	ips, err := net.LookupIP(endpoint)
+	if err != nil {
+		return err
+	}
+
+	trapMutex.Lock()
+	defer trapMutex.Unlock()
+	for _, ip := range ips {
+		trapAgents[ip.String()] = agent
+	}
+	return nil
+}
+
+// ListenForTraps starts a trap listener on address, e.g. 0.0.0.0:162, if one
+// is not already running. params are the SNMP parameters for decoding traps.
+// A listener decodes every trap with the same parameters, so agents which
+// share an address must share their credentials too. Otherwise their traps
+// would fail to decode, so an error is returned.
+func ListenForTraps(address string, params *gosnmp.GoSNMP) error {
+	trapMutex.Lock()
+	defer trapMutex.Unlock()
+
+	if listener, ok := trapListeners[address]; ok {
+		if !sameCredentials(listener.Params, params) {
+			return fmt.Errorf("trap listener on %v decodes traps with the credentials of another agent, "+
+				"agents with different credentials need different trap addresses", address)
+		}
+		return nil
+	}
+
+	listener := gosnmp.NewTrapListener()
+	listener.Params = params
+	listener.OnNewTrap = handleTrap
+	trapListeners[address] = listener
+
+	go func() {
+		log.WithField("address", address).Info("[snmp] listening for traps")
+		if err := listener.Listen(address); err != nil {
+			log.WithFields(log.Fields{
+				"address": address,
+				"error":   err,
+			}).Error("[snmp] trap listener failed")
+		}
+	}()
+	return nil
+}
+
+// sameCredentials is true if traps are decoded the same with both SNMP
+// parameters.
+func sameCredentials(a *gosnmp.GoSNMP, b *gosnmp.GoSNMP) bool {
+	if a.Version != b.Version || a.Community != b.Community ||
+		a.SecurityModel != b.SecurityModel || a.MsgFlags != b.MsgFlags {
+		return false
+	}
+	usmA, okA := a.SecurityParameters.(*gosnmp.UsmSecurityParameters)
+	usmB, okB := b.SecurityParameters.(*gosnmp.UsmSecurityParameters)
+	if okA != okB {
+		return false
+	}
+	if !okA {
+		return true
+	}
+	return usmA.UserName == usmB.UserName &&
+		usmA.AuthenticationProtocol == usmB.AuthenticationProtocol &&
+		usmA.AuthenticationPassphrase == usmB.AuthenticationPassphrase &&
+		usmA.PrivacyProtocol == usmB.PrivacyProtocol &&
+		usmA.PrivacyPassphrase == usmB.PrivacyPassphrase
+}
+
+// handleTrap is the gosnmp trap handler. Alarm traps update the tracker for
+// the agent which sent them.
+func handleTrap(packet *gosnmp.SnmpPacket, addr *net.UDPAddr) {
+	alarm, added, ok := ParseAlarmTrap(packet.Variables)
+	if !ok {
+		return
+	}
+
+	trapMutex.Lock()
+	agent, ok := trapAgents[addr.IP.String()]
+	trapMutex.Unlock()
+	if !ok {
+		log.WithField("source", addr.IP.String()).Debug("[snmp] ignoring alarm trap from unknown agent")
+		return
+	}
+
+	tracker := GetTracker(agent)
+	now := time.Now()
+	if added {
+		tracker.Raise(now, alarm)
+		return
+	}
+	if err := tracker.Clear(now, alarm); err != nil {
+		log.WithError(err).Error("[snmp] failed to clear alarm from trap")
+	}
+}
@@ -0,0 +1,87 @@
+package alarms
+
+import (
+	"testing"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+)
+
+// alarmTrap creates the variables for a UPS-MIB alarm trap.
+func alarmTrap(trapOid string, id int, descr string) []gosnmp.SnmpPDU {
+	return []gosnmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(1234)},
+		{Name: ".1.3.6.1.6.3.1.1.4.1.0", Type: gosnmp.ObjectIdentifier, Value: trapOid},
+		{Name: ".1.3.6.1.2.1.33.1.6.2.1.1.7", Type: gosnmp.Integer, Value: id},
+		{Name: ".1.3.6.1.2.1.33.1.6.2.1.2.7", Type: gosnmp.ObjectIdentifier, Value: descr},
+	}
+}
+
+// TestParseAlarmTrapAdded tests parsing upsTrapAlarmEntryAdded.
+func TestParseAlarmTrapAdded(t *testing.T) {
+	alarm, added, ok := ParseAlarmTrap(alarmTrap(".1.3.6.1.2.1.33.2.3", 7, ".1.3.6.1.2.1.33.1.6.3.2"))
+	assert.True(t, ok)
+	assert.True(t, added)
+	assert.Equal(t, 7, alarm.ID)
+	assert.Equal(t, ".1.3.6.1.2.1.33.1.6.3.2", alarm.Descr)
+}
+
+// TestParseAlarmTrapRemoved tests parsing upsTrapAlarmEntryRemoved, with
+// OIDs that have no leading dot.
+func TestParseAlarmTrapRemoved(t *testing.T) {
+	alarm, added, ok := ParseAlarmTrap(alarmTrap("1.3.6.1.2.1.33.2.4", 7, "1.3.6.1.2.1.33.1.6.3.2"))
+	assert.True(t, ok)
+	assert.False(t, added)
+	assert.Equal(t, 7, alarm.ID)
+	assert.Equal(t, ".1.3.6.1.2.1.33.1.6.3.2", alarm.Descr)
+}
+
+// TestParseAlarmTrapOther tests that other traps are ignored.
+func TestParseAlarmTrapOther(t *testing.T) {
+	// upsTrapOnBattery
+	_, _, ok := ParseAlarmTrap(alarmTrap(".1.3.6.1.2.1.33.2.1", 7, ".1.3.6.1.2.1.33.1.6.3.2"))
+	assert.False(t, ok)
+
+	// No trap OID.
+	_, _, ok = ParseAlarmTrap([]gosnmp.SnmpPDU{})
+	assert.False(t, ok)
+
+	// No alarm description.
+	_, _, ok = ParseAlarmTrap(alarmTrap(".1.3.6.1.2.1.33.2.3", 7, ".1.3.6.1.2.1.33.1.6.3.2")[:3])
+	assert.False(t, ok)
+}
+
+// TestListenForTrapsCredentials tests that agents which share a trap listener
+// must share their credentials.
+func TestListenForTrapsCredentials(t *testing.T) {
+	newParams := func(userName string) *gosnmp.GoSNMP {
+		return &gosnmp.GoSNMP{
+			Version:       gosnmp.Version3,
+			SecurityModel: gosnmp.UserSecurityModel,
+			MsgFlags:      gosnmp.AuthPriv,
+			SecurityParameters: &gosnmp.UsmSecurityParameters{
+				UserName:                 userName,
+				AuthenticationProtocol:   gosnmp.SHA,
+				AuthenticationPassphrase: "auctoritas",
+				PrivacyProtocol:          gosnmp.AES,
+				PrivacyPassphrase:        "privatus",
+			},
+		}
+	}
+
+	// The listener of the first agent, without listening.
+	address := "test-credentials:162"
+	listener := gosnmp.NewTrapListener()
+	listener.Params = newParams("simulator")
+	trapMutex.Lock()
+	trapListeners[address] = listener
+	trapMutex.Unlock()
+
+	assert.NoError(t, ListenForTraps(address, newParams("simulator")))
+	assert.Error(t, ListenForTraps(address, newParams("other")))
+
+	community := &gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "public"}
+	assert.Error(t, ListenForTraps(address, community))
+	assert.True(t, sameCredentials(community, &gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "public"}))
+	assert.False(t, sameCredentials(community, &gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "private"}))
+}
@@ -0,0 +1,59 @@
+package devices
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/vapor-ware/synse-sdk/sdk"
+	"github.com/vapor-ware/synse-sdk/sdk/output"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/alarms"
+)
+
+// SnmpAlarmHistory is the handler for devices which report the history of the
+// alarms on an SNMP agent.
+var SnmpAlarmHistory = sdk.DeviceHandler{
+	Name: "alarm-history",
+	Read: SnmpAlarmHistoryRead,
+}
+
+// SnmpAlarmHistoryRead is the read handler function for alarm history devices.
+// The alarm table is polled to update the alarm tracker for the agent, then
+// there is one reading per tracked alarm, active or cleared. Each reading has
+// the alarm_id, state, first_seen, cleared_at and duration context. cleared_at
+// is empty for active alarms.
+func SnmpAlarmHistoryRead(device *sdk.Device) (readings []*output.Reading, err error) {
+
+	// Poll to update the tracker.
+	_, err = pollActiveAlarms(device)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	tracker := alarms.GetTracker(alarms.AgentKey(device.Data["endpoint"], device.Data["port"]))
+
+	readings = []*output.Reading{}
+	for _, record := range tracker.Records() {
+		reading, err := output.Status.MakeReading(record.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		state := "active"
+		var clearedAt string
+		if !record.Active() {
+			state = "cleared"
+			clearedAt = record.ClearedAt.UTC().Format(time.RFC3339)
+		}
+		reading.WithContext(map[string]string{
+			"alarm_id":   strconv.Itoa(record.ID),
+			"state":      state,
+			"source":     record.Source,
+			"first_seen": record.FirstSeen.UTC().Format(time.RFC3339),
+			"cleared_at": clearedAt,
+			"duration":   record.Duration(now).Round(time.Second).String(),
+		})
+		readings = append(readings, reading)
+	}
+	return readings, nil
+}
@@ -0,0 +1,226 @@
+package devices
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/vapor-ware/synse-sdk/sdk"
+	"github.com/vapor-ware/synse-sdk/sdk/output"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/alarms"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// SnmpAlarms is the handler for devices which report the active alarms in an
+// SNMP alarm table, e.g. the UPS-MIB upsAlarmTable.
+var SnmpAlarms = sdk.DeviceHandler{
+	Name: "alarms",
+	Read: SnmpAlarmsRead,
+}
+
+// SnmpAlarmsRead is the read handler function for alarms devices. The alarm
+// table is walked on each read so that alarms raised after startup are seen.
+// There is one reading per active alarm, and no readings when there are no
+// active alarms. The raise_time context is the RFC3339 time the alarm was
+// raised, or empty if unknown.
+func SnmpAlarmsRead(device *sdk.Device) (readings []*output.Reading, err error) {
+
+	activeAlarms, err := pollActiveAlarms(device)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, debounced, err := getDebounceSettings(device.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	readings = []*output.Reading{}
+	if !debounced {
+		for _, alarm := range activeAlarms {
+			reading, err := makeAlarmReading(alarm)
+			if err != nil {
+				return nil, err
+			}
+			readings = append(readings, reading)
+		}
+		return readings, nil
+	}
+
+	// With debounce, there is a reading for each alarm which is either raw or
+	// debounced active, with both states in the context.
+	for _, state := range debounceAlarms(deviceKey(device), settings, time.Now(), activeAlarms) {
+		reading, err := makeAlarmReading(state.alarm)
+		if err != nil {
+			return nil, err
+		}
+		reading.WithContext(map[string]string{
+			"raw":       alarmStateString(state.raw),
+			"debounced": alarmStateString(state.debounced),
+		})
+		readings = append(readings, reading)
+	}
+	return readings, nil
+}
+
+// makeAlarmReading makes the reading for an active alarm.
+func makeAlarmReading(alarm *alarms.ActiveAlarm) (*output.Reading, error) {
+	reading, err := output.Status.MakeReading(alarm.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// An unknown raise time is an empty string.
+	var raiseTime string
+	if !alarm.RaiseTime.IsZero() {
+		raiseTime = alarm.RaiseTime.UTC().Format(time.RFC3339)
+	}
+	return reading.WithContext(map[string]string{
+		"alarm_id":   strconv.Itoa(alarm.ID),
+		"raise_time": raiseTime,
+	}), nil
+}
+
+// alarmStateString is the context string for whether an alarm is active.
+func alarmStateString(active bool) string {
+	if active {
+		return "active"
+	}
+	return "cleared"
+}
+
+// debouncedAlarm is the raw and debounced state of a single alarm.
+type debouncedAlarm struct {
+	alarm     *alarms.ActiveAlarm
+	raw       bool
+	debounced bool
+}
+
+// debouncedAlarms are the alarms being debounced for each alarms device, by
+// alarm key. An alarm is forgotten once it is neither raw nor debounced active.
+var (
+	debouncedAlarms      = map[string]map[string]*alarms.ActiveAlarm{}
+	debouncedAlarmsMutex sync.Mutex
+)
+
+// debounceAlarms debounces whether each alarm is active, sorted by alarm id.
+// key identifies the alarms device.
+func debounceAlarms(key string, settings debounceSettings, now time.Time, activeAlarms []*alarms.ActiveAlarm) []debouncedAlarm {
+	debouncedAlarmsMutex.Lock()
+	defer debouncedAlarmsMutex.Unlock()
+
+	known, ok := debouncedAlarms[key]
+	if !ok {
+		known = map[string]*alarms.ActiveAlarm{}
+		debouncedAlarms[key] = known
+	}
+
+	present := map[string]bool{}
+	for _, alarm := range activeAlarms {
+		alarmKey := fmt.Sprintf("%d/%v", alarm.ID, alarm.Descr)
+		present[alarmKey] = true
+		if _, ok := known[alarmKey]; !ok {
+			// Newly raised alarms have been cleared until now.
+			debounce(key+"/"+alarmKey, settings, now, false)
+		}
+		known[alarmKey] = alarm
+	}
+
+	states := []debouncedAlarm{}
+	for alarmKey, alarm := range known {
+		raw := present[alarmKey]
+		debounced := debounce(key+"/"+alarmKey, settings, now, raw).(bool)
+		if !raw && !debounced {
+			delete(known, alarmKey)
+			forgetDebounce(key + "/" + alarmKey)
+			continue
+		}
+		states = append(states, debouncedAlarm{alarm: alarm, raw: raw, debounced: debounced})
+	}
+
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].alarm.ID < states[j].alarm.ID
+	})
+	return states
+}
+
+// pollActiveAlarms reads the active alarms from the SNMP server and updates
+// the alarm tracker for the agent.
+//
+// The alarm count in present_oid is read first, together with sysUpTime. The
+// alarm table in walk_oid is only walked when there are alarms present, or
+// when the count is not supported.
+func pollActiveAlarms(device *sdk.Device) (activeAlarms []*alarms.ActiveAlarm, err error) {
+	snmpClient, err := newSnmpClient(device)
+	if err != nil {
+		return nil, err
+	}
+
+	data := device.Data
+	presentOid, ok := data["present_oid"].(string)
+	if !ok {
+		return nil, fmt.Errorf("present_oid is not a string, %T, %+v", data["present_oid"], data["present_oid"])
+	}
+
+	now := time.Now()
+	results, err := snmpClient.GetMany([]string{presentOid, core.SysUpTimeOid})
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != 2 {
+		return nil, fmt.Errorf("expected 2 results, got %d", len(results))
+	}
+	present, sysUpTime := results[0].Data, results[1].Data
+
+	activeAlarms = []*alarms.ActiveAlarm{}
+	if count, ok := alarmCount(present); !ok || count > 0 {
+		var walk []core.ReadResult
+		walk, err = snmpClient.Walk(fmt.Sprint(data["walk_oid"]))
+		if err != nil {
+			return nil, err
+		}
+
+		activeAlarms, err = alarms.ParseActiveAlarms(walk, data)
+		if err != nil {
+			return nil, err
+		}
+
+		// The alarm times are TimeStamps relative to sysUpTime.
+		if sysUpTime != nil {
+			var upTime uint32
+			upTime, err = core.ToTicks(sysUpTime)
+			if err != nil {
+				return nil, err
+			}
+			_, wrapped := recordUpTime(alarms.AgentKey(data["endpoint"], data["port"])+"/"+presentOid, upTime, now)
+			if err = alarms.SetRaiseTimes(activeAlarms, sysUpTime, now, wrapped); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	tracker := alarms.GetTracker(alarms.AgentKey(data["endpoint"], data["port"]))
+	if err = tracker.Update(now, activeAlarms); err != nil {
+		return nil, err
+	}
+	return activeAlarms, nil
+}
+
+// alarmCount converts the raw alarms present reading to an int. ok is false
+// if the reading is nil or not an integer.
+func alarmCount(present interface{}) (count int, ok bool) {
+	switch p := present.(type) {
+	case int:
+		return p, true
+	case uint:
+		return int(p), true
+	case uint32:
+		return int(p), true
+	case int32:
+		return int(p), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,52 @@
+package devices
+
+import (
+	"github.com/vapor-ware/synse-sdk/sdk"
+	"github.com/vapor-ware/synse-sdk/sdk/output"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/outputs"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// SnmpApparentPower is the handler for SNMP OIDs that report apparent power,
+// in volt-amperes. Real power, in watts, is reported by SnmpPower.
+var SnmpApparentPower = sdk.DeviceHandler{
+	Name: "apparent-power",
+	Read: withThresholds(withValidity(SnmpApparentPowerRead)),
+}
+
+// SnmpApparentPowerRead is the read handler function for synse SNMP devices that report apparent power.
+func SnmpApparentPowerRead(device *sdk.Device) (readings []*output.Reading, err error) {
+
+	// Get the raw reading from the SNMP server.
+	var result core.ReadResult
+	result, err = getRawReading(device)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for nil reading.
+	var reading *output.Reading
+	if result.Data == nil {
+		reading, err = outputs.VAPower.MakeReading(nil)
+		if err != nil {
+			return nil, err
+		}
+		readings = []*output.Reading{reading}
+		return readings, nil
+	}
+
+	// Account for a multiplier if any and convert to float.
+	var resultFloat float32
+	resultFloat, err = MultiplyReading(result, device.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the reading.
+	reading, err = outputs.VAPower.MakeReading(resultFloat)
+	if err != nil {
+		return nil, err
+	}
+	readings = []*output.Reading{reading}
+	return readings, nil
+}
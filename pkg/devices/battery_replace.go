@@ -0,0 +1,90 @@
+package devices
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vapor-ware/synse-sdk/sdk"
+	"github.com/vapor-ware/synse-sdk/sdk/output"
+)
+
+// SnmpBatteryReplace is the handler for the date the batteries were last
+// replaced, e.g. xupsBatteryLastReplacedDate. The reading is the time until
+// the batteries are due for replacement, from the date and the service life
+// of the batteries in the device settings.
+var SnmpBatteryReplace = sdk.DeviceHandler{
+	Name: "battery-replace",
+	Read: withThresholds(SnmpBatteryReplaceRead),
+}
+
+// batteryDateLayouts are the layouts of the dates the batteries were last
+// replaced. The date is free text entered on the agent, e.g. 03/15/2021.
+var batteryDateLayouts = []string{"01/02/2006", "2006-01-02"}
+
+// SnmpBatteryReplaceRead is the read handler function for Synse SNMP devices
+// that report the date the batteries were last replaced. The reading is the
+// seconds until the batteries are due for replacement, which is negative once
+// they are overdue. The date is in the lastReplaced reading context. The
+// reading is nil when the date is not set or the service life is not
+// configured.
+func SnmpBatteryReplaceRead(device *sdk.Device) (readings []*output.Reading, err error) {
+
+	// Get the raw reading from the SNMP server.
+	result, err := getRawReading(device)
+	if err != nil {
+		return nil, err
+	}
+
+	value, lastReplaced, err := timeToReplace(result.Data, device.Data, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the reading.
+	reading, err := output.Seconds.MakeReading(value)
+	if err != nil {
+		return nil, err
+	}
+	if lastReplaced != "" {
+		reading.WithContext(map[string]string{"lastReplaced": lastReplaced})
+	}
+	readings = []*output.Reading{reading}
+	return
+}
+
+// timeToReplace gets the seconds from now until the batteries are due for
+// replacement. value is nil when the date is not set, e.g. Not Set, or the
+// device has no service life. lastReplaced is the date, formatted 2006-01-02.
+func timeToReplace(data interface{}, deviceData map[string]interface{}, now time.Time) (
+	value interface{}, lastReplaced string, err error) {
+
+	if data == nil {
+		return nil, "", nil
+	}
+	text, ok := data.(string)
+	if !ok {
+		return nil, "", fmt.Errorf("expected a date string, got type: %T, value: %v", data, data)
+	}
+	text = strings.TrimSpace(text)
+	if text == "" || strings.EqualFold(text, "Not Set") {
+		return nil, "", nil
+	}
+
+	var date time.Time
+	for _, layout := range batteryDateLayouts {
+		if date, err = time.Parse(layout, text); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("battery replacement date %q is not in a layout of %v", text, batteryDateLayouts)
+	}
+	lastReplaced = date.Format("2006-01-02")
+
+	serviceLife, ok, err := getServiceLife(deviceData)
+	if err != nil || !ok {
+		return nil, lastReplaced, err
+	}
+	return int64(date.Add(serviceLife).Sub(now) / time.Second), lastReplaced, nil
+}
@@ -0,0 +1,46 @@
+package devices
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTimeToReplace tests the time until the batteries are due for
+// replacement.
+func TestTimeToReplace(t *testing.T) {
+	now := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	data := map[string]interface{}{serviceLifeDaysKey: 1461}
+
+	// Replaced three years ago with a four year service life.
+	value, lastReplaced, err := timeToReplace("03/15/2021", data, now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(365*24*60*60), value)
+	assert.Equal(t, "2021-03-15", lastReplaced)
+
+	// Overdue.
+	value, _, err = timeToReplace("2019-03-15", data, now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-366*24*60*60), value)
+
+	// Without a service life only the date is known.
+	value, lastReplaced, err = timeToReplace("03/15/2021", map[string]interface{}{}, now)
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+	assert.Equal(t, "2021-03-15", lastReplaced)
+
+	for _, notSet := range []interface{}{nil, "", "Not Set"} {
+		value, lastReplaced, err = timeToReplace(notSet, data, now)
+		assert.NoError(t, err, notSet)
+		assert.Nil(t, value, notSet)
+		assert.Equal(t, "", lastReplaced, notSet)
+	}
+
+	for _, bad := range []interface{}{"last spring", 20210315} {
+		_, _, err = timeToReplace(bad, data, now)
+		assert.Error(t, err, bad)
+	}
+	_, _, err = timeToReplace("03/15/2021", map[string]interface{}{serviceLifeDaysKey: "4y"}, now)
+	assert.Error(t, err)
+}
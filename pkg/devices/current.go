@@ -9,7 +9,7 @@ import (
 // SnmpCurrent is the handler for the SNMP OIDs that report current.
 var SnmpCurrent = sdk.DeviceHandler{
 	Name: "current",
-	Read: SnmpCurrentRead,
+	Read: withThresholds(withValidity(SnmpCurrentRead)),
 }
 
 // SnmpCurrentRead is the read handler function for Synse SNMP devices that report current.
@@ -0,0 +1,122 @@
+package devices
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/vapor-ware/synse-sdk/sdk"
+	"github.com/vapor-ware/synse-sdk/sdk/output"
+)
+
+// debouncer debounces the readings of a single value. Readings which flap
+// are not reported until they settle.
+type debouncer struct {
+	settings debounceSettings
+
+	initialized bool
+	stable      interface{} // The debounced value.
+	candidate   interface{} // A new value which is not yet stable.
+	since       time.Time   // When the candidate was first read.
+	count       int         // Consecutive reads of the candidate.
+}
+
+// update adds a raw reading and returns the debounced value. The first
+// reading is stable immediately since there is nothing to debounce against.
+func (d *debouncer) update(now time.Time, raw interface{}) interface{} {
+	if !d.initialized {
+		d.initialized = true
+		d.stable = raw
+		return d.stable
+	}
+
+	if raw == d.stable {
+		d.count = 0
+		return d.stable
+	}
+
+	if d.count > 0 && raw == d.candidate {
+		d.count++
+	} else {
+		d.candidate = raw
+		d.since = now
+		d.count = 1
+	}
+
+	if d.count >= d.settings.Samples && now.Sub(d.since) >= d.settings.HoldTime {
+		d.stable = d.candidate
+		d.count = 0
+	}
+	return d.stable
+}
+
+// debouncers hold the debounce state for each device reading.
+var (
+	debouncers      = map[string]*debouncer{}
+	debouncersMutex sync.Mutex
+)
+
+// deviceKey uniquely identifies a device across agents.
+func deviceKey(device *sdk.Device) string {
+	return fmt.Sprintf("%v:%v/%v", device.Data["endpoint"], device.Data["port"], device.Data["oid"])
+}
+
+// debounce adds a raw reading for key to its debouncer and returns the
+// debounced value.
+func debounce(key string, settings debounceSettings, now time.Time, raw interface{}) interface{} {
+	debouncersMutex.Lock()
+	defer debouncersMutex.Unlock()
+
+	d, ok := debouncers[key]
+	if !ok || d.settings != settings {
+		d = &debouncer{settings: settings}
+		debouncers[key] = d
+	}
+	return d.update(now, raw)
+}
+
+// forgetDebounce removes the debounce state for key.
+func forgetDebounce(key string) {
+	debouncersMutex.Lock()
+	defer debouncersMutex.Unlock()
+	delete(debouncers, key)
+}
+
+// withDebounce wraps the read function of a status device. When the device
+// has debounce settings, the reading value is the debounced value and the raw
+// value is in the raw context.
+func withDebounce(read func(*sdk.Device) ([]*output.Reading, error)) func(*sdk.Device) ([]*output.Reading, error) {
+	return func(device *sdk.Device) ([]*output.Reading, error) {
+		readings, err := read(device)
+		if err != nil {
+			return nil, err
+		}
+
+		settings, ok, err := getDebounceSettings(device.Data)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return readings, nil
+		}
+
+		now := time.Now()
+		key := deviceKey(device)
+		for i, reading := range readings {
+			raw := reading.Value
+			reading.Value = debounce(fmt.Sprintf("%s/%d", key, i), settings, now, raw)
+			reading.WithContext(map[string]string{
+				"raw": contextString(raw),
+			})
+		}
+		return readings, nil
+	}
+}
+
+// contextString formats a reading value for a reading context. nil is empty.
+func contextString(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,95 @@
+package devices
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-sdk/sdk"
+	"github.com/vapor-ware/synse-sdk/sdk/output"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/alarms"
+)
+
+// debounceTestNow is a fixed time for the debounce tests.
+var debounceTestNow = time.Date(2021, time.March, 4, 12, 0, 0, 0, time.UTC)
+
+// TestDebouncerSamples tests debouncing by consecutive samples.
+func TestDebouncerSamples(t *testing.T) {
+	d := &debouncer{settings: debounceSettings{Samples: 3}}
+	now := debounceTestNow
+
+	assert.Equal(t, "utility", d.update(now, "utility")) // First reading is stable.
+	assert.Equal(t, "utility", d.update(now, "battery"))
+	assert.Equal(t, "utility", d.update(now, "battery"))
+	assert.Equal(t, "utility", d.update(now, "utility")) // Flapped back. Start over.
+	assert.Equal(t, "utility", d.update(now, "battery"))
+	assert.Equal(t, "utility", d.update(now, "battery"))
+	assert.Equal(t, "battery", d.update(now, "battery")) // Third consecutive.
+}
+
+// TestDebouncerHoldTime tests debouncing by minimum hold time.
+func TestDebouncerHoldTime(t *testing.T) {
+	d := &debouncer{settings: debounceSettings{HoldTime: 10 * time.Second, Samples: 1}}
+	now := debounceTestNow
+
+	assert.Equal(t, "utility", d.update(now, "utility"))
+	assert.Equal(t, "utility", d.update(now.Add(1*time.Second), "battery"))
+	assert.Equal(t, "utility", d.update(now.Add(5*time.Second), "battery"))
+	assert.Equal(t, "battery", d.update(now.Add(11*time.Second), "battery"))
+	assert.Equal(t, "battery", d.update(now.Add(12*time.Second), "battery"))
+}
+
+// TestWithDebounce tests that debounced readings have the raw value in context.
+func TestWithDebounce(t *testing.T) {
+	values := []interface{}{"utility", "battery", "battery"}
+	read := withDebounce(func(device *sdk.Device) ([]*output.Reading, error) {
+		reading, err := output.Status.MakeReading(values[0])
+		values = values[1:]
+		return []*output.Reading{reading}, err
+	})
+	device := &sdk.Device{Data: map[string]interface{}{
+		"endpoint":         "debounce-test",
+		"port":             1024,
+		"oid":              ".1.3.6.1.2.1.33.1.4.1.0",
+		debounceSamplesKey: 2,
+	}}
+
+	readings, err := read(device)
+	assert.NoError(t, err)
+	assert.Equal(t, "utility", readings[0].Value)
+	assert.Equal(t, "utility", readings[0].Context["raw"])
+
+	readings, err = read(device)
+	assert.NoError(t, err)
+	assert.Equal(t, "utility", readings[0].Value)
+	assert.Equal(t, "battery", readings[0].Context["raw"])
+
+	readings, err = read(device)
+	assert.NoError(t, err)
+	assert.Equal(t, "battery", readings[0].Value)
+	assert.Equal(t, "battery", readings[0].Context["raw"])
+}
+
+// TestDebounceAlarms tests debouncing whether alarms are active.
+func TestDebounceAlarms(t *testing.T) {
+	settings := debounceSettings{Samples: 2}
+	onBattery := &alarms.ActiveAlarm{ID: 1, Name: "upsAlarmOnBattery", Descr: ".1.3.6.1.2.1.33.1.6.3.2"}
+	key := "debounce-alarms-test"
+	now := debounceTestNow
+
+	// Raised, but not yet debounced.
+	states := debounceAlarms(key, settings, now, []*alarms.ActiveAlarm{onBattery})
+	assert.Equal(t, []debouncedAlarm{{alarm: onBattery, raw: true, debounced: false}}, states)
+
+	// Raised and debounced.
+	states = debounceAlarms(key, settings, now, []*alarms.ActiveAlarm{onBattery})
+	assert.Equal(t, []debouncedAlarm{{alarm: onBattery, raw: true, debounced: true}}, states)
+
+	// Cleared, but not yet debounced.
+	states = debounceAlarms(key, settings, now, []*alarms.ActiveAlarm{})
+	assert.Equal(t, []debouncedAlarm{{alarm: onBattery, raw: false, debounced: true}}, states)
+
+	// Cleared and debounced. The alarm is forgotten.
+	states = debounceAlarms(key, settings, now, []*alarms.ActiveAlarm{})
+	assert.Len(t, states, 0)
+}
@@ -4,6 +4,8 @@ package devices
 
 import (
 	"fmt"
+	"time"
+
 	"github.com/gosnmp/gosnmp"
 
 	"github.com/vapor-ware/synse-sdk/sdk"
@@ -13,35 +15,51 @@ import (
 // SNMPDeviceHandlers holds a reference to all of the device handlers used by
 // the SNMP plugin.
 var SNMPDeviceHandlers = []*sdk.DeviceHandler{
+	&SnmpAlarmHistory,
+	&SnmpAlarms,
+	&SnmpApparentPower,
+	&SnmpBatteryReplace,
 	&SnmpCurrent,
+	&SnmpEnergy,
+	&SnmpErrorRate,
 	&SnmpFrequency,
+	&SnmpHumidity,
+	&SnmpHumiditySetpoint,
 	&SnmpIdentity,
 	&SnmpMinutes,
+	&SnmpOutlet,
 	&SnmpPercentage,
 	&SnmpPower,
+	&SnmpRpm,
 	&SnmpSeconds,
+	&SnmpSpeed,
 	&SnmpStatus,
 	&SnmpTemperature,
+	&SnmpTemperatureSetpoint,
+	&SnmpThroughput,
+	&SnmpTimestamp,
+	&SnmpTransferSource,
+	&SnmpUptime,
 	&SnmpVoltage,
 }
 
-// Get the raw reading from the SNMP server with error checks.
-// Factors out common code.
-func getRawReading(device *sdk.Device) (result core.ReadResult, err error) {
+// newSnmpClient creates an SnmpClient from the SNMP configuration in the
+// device data.
+func newSnmpClient(device *sdk.Device) (*core.SnmpClient, error) {
 	// Arg checks.
 	if device == nil {
-		return result, fmt.Errorf("device is nil")
+		return nil, fmt.Errorf("device is nil")
 	}
 
 	// Get the SNMP device config from the strings in data.
 	data := device.Data
 	snmpConfig, err := core.GetDeviceConfig(data)
 	if err != nil {
-		return result, err
+		return nil, err
 	}
 
 	if err := snmpConfig.CheckPrivacyAndAuthFromData(data); err != nil {
-		return result, err
+		return nil, err
 	}
 
 	if snmpConfig.Endpoint == "ups" {
@@ -50,11 +68,80 @@ func getRawReading(device *sdk.Device) (result core.ReadResult, err error) {
 	}
 
 	// Create SnmpClient.
-	snmpClient, err := core.NewSnmpClient(snmpConfig)
+	return core.NewSnmpClient(snmpConfig)
+}
+
+// Get the raw reading from the SNMP server with error checks.
+// Factors out common code.
+func getRawReading(device *sdk.Device) (result core.ReadResult, err error) {
+	snmpClient, err := newSnmpClient(device)
 	if err != nil {
 		return result, err
 	}
 
 	// Read the SNMP OID in the device config.
-	return snmpClient.Get(fmt.Sprint(data["oid"]))
+	result, err = snmpClient.Get(fmt.Sprint(device.Data["oid"]))
+	if err != nil {
+		return result, err
+	}
+	return supportedReading(result, device.Data), nil
+}
+
+// supportedReading translates readings which mean that the agent does not
+// support the object into nil readings. See core.Quirks.
+func supportedReading(result core.ReadResult, data map[string]interface{}) core.ReadResult {
+	if core.IsUnsupportedReading(result, data) {
+		result.Data = nil
+	}
+	return result
+}
+
+// getRawReadingWithUpTime gets the raw reading along with sysUpTime in the same
+// request. This is needed to convert TimeStamp readings to wall-clock time.
+// now is the local time at which the request was made.
+func getRawReadingWithUpTime(device *sdk.Device) (result, sysUpTime core.ReadResult, now time.Time, err error) {
+	snmpClient, err := newSnmpClient(device)
+	if err != nil {
+		return result, sysUpTime, now, err
+	}
+
+	now = time.Now()
+	results, err := snmpClient.GetMany([]string{fmt.Sprint(device.Data["oid"]), core.SysUpTimeOid})
+	if err != nil {
+		return result, sysUpTime, now, err
+	}
+	if len(results) != 2 {
+		return result, sysUpTime, now, fmt.Errorf("expected 2 results, got %d", len(results))
+	}
+	return supportedReading(results[0], device.Data), results[1], now, nil
+}
+
+// getRawCounterReading gets the raw reading of a counter along with sysUpTime
+// and the discontinuity time of the counter, if the device has one, in the
+// same request. These show whether the counter was reset between readings.
+// now is the local time at which the request was made.
+func getRawCounterReading(device *sdk.Device) (
+	result, sysUpTime, discontinuity core.ReadResult, now time.Time, err error) {
+
+	snmpClient, err := newSnmpClient(device)
+	if err != nil {
+		return result, sysUpTime, discontinuity, now, err
+	}
+
+	oids := []string{fmt.Sprint(device.Data["oid"]), core.SysUpTimeOid}
+	if discontinuityOid, ok := device.Data[core.DiscontinuityOidKey]; ok {
+		oids = append(oids, fmt.Sprint(discontinuityOid))
+	}
+	now = time.Now()
+	results, err := snmpClient.GetMany(oids)
+	if err != nil {
+		return result, sysUpTime, discontinuity, now, err
+	}
+	if len(results) != len(oids) {
+		return result, sysUpTime, discontinuity, now, fmt.Errorf("expected %d results, got %d", len(oids), len(results))
+	}
+	if len(results) > 2 {
+		discontinuity = results[2]
+	}
+	return supportedReading(results[0], device.Data), results[1], discontinuity, now, nil
 }
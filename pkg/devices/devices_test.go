@@ -114,7 +114,7 @@ func TestDevices(t *testing.T) { // nolint: gocyclo
 	//  by location
 	snmpDevices, err := testUpsMib.EnumerateDevices(map[string]interface{}{})
 	assert.NoError(t, err)
-	assert.Len(t, snmpDevices, 24) // all DeviceProtos from all tables.
+	assert.Len(t, snmpDevices, 26) // all DeviceProtos from all tables.
 
 	logDeviceProtos(t, snmpDevices, "Devices from UPS-MIB")
 
@@ -135,18 +135,21 @@ func TestDevices(t *testing.T) { // nolint: gocyclo
 		}
 	}
 	// Check the total number of unique number of device proto types
-	assert.Len(t, protos, 10, protos)
+	assert.Len(t, protos, 13, protos)
 	// Check the total number of device instances
-	assert.Equal(t, 54, instanceCount)
+	assert.Equal(t, 53, instanceCount)
 
 	// Check the number of device instances for each device prototype.
 	t.Logf("device prototype map: %#v", protos)
 	assert.Equal(t, 9, protos["power"])
 	assert.Equal(t, 6, protos["identity"])
-	assert.Equal(t, 8, protos["status"])
+	assert.Equal(t, 4, protos["status"])
+	assert.Equal(t, 1, protos["alarms"])
+	assert.Equal(t, 1, protos["alarm-history"])
 	assert.Equal(t, 10, protos["voltage"])
 	assert.Equal(t, 10, protos["current"])
 	assert.Equal(t, 1, protos["temperature"])
+	assert.Equal(t, 1, protos["timestamp"])
 	assert.Equal(t, 4, protos["frequency"])
 	assert.Equal(t, 4, protos["percentage"])
 	assert.Equal(t, 1, protos["minutes"])
@@ -175,8 +178,13 @@ func TestDevices(t *testing.T) { // nolint: gocyclo
 		assert.NoError(t, err)
 
 		readings := context.Reading
-		// Each device currently has one reading,
-		assert.Len(t, readings, 1)
+		if devices[i].Type == "alarms" || devices[i].Type == "alarm-history" {
+			// One reading per alarm. The emulator has two active alarms.
+			assert.Len(t, readings, 2)
+		} else {
+			// Each other device currently has one reading,
+			assert.Len(t, readings, 1)
+		}
 		for j := 0; j < len(readings); j++ {
 			t.Logf("Reading[%d][%d]: %T, %+v", i, j, readings[j], readings[j])
 		}
@@ -189,3 +197,12 @@ func TestDevices(t *testing.T) { // nolint: gocyclo
 	}
 	t.Log("Finished reading each device.")
 }
+
+// TestSupportedReading tests that readings marked as unsupported by quirks
+// read as nil.
+func TestSupportedReading(t *testing.T) {
+	data := map[string]interface{}{core.UnsupportedKey: "0"}
+	assert.Nil(t, supportedReading(core.ReadResult{Oid: ".1.3.6.1.2.1.33.1.4.4.1.3.1", Data: 0}, data).Data)
+	assert.Equal(t, 12, supportedReading(core.ReadResult{Oid: ".1.3.6.1.2.1.33.1.4.4.1.3.1", Data: 12}, data).Data)
+	assert.Equal(t, 0, supportedReading(core.ReadResult{Data: 0}, map[string]interface{}{}).Data)
+}
@@ -0,0 +1,51 @@
+package devices
+
+import (
+	"github.com/vapor-ware/synse-sdk/sdk"
+	"github.com/vapor-ware/synse-sdk/sdk/output"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// SnmpEnergy is the handler for SNMP OIDs that report energy, in
+// kilowatt-hours, e.g. the energy delivered by a PDU since it was reset.
+var SnmpEnergy = sdk.DeviceHandler{
+	Name: "energy",
+	Read: withThresholds(withValidity(SnmpEnergyRead)),
+}
+
+// SnmpEnergyRead is the read handler function for synse SNMP devices that report energy.
+func SnmpEnergyRead(device *sdk.Device) (readings []*output.Reading, err error) {
+
+	// Get the raw reading from the SNMP server.
+	var result core.ReadResult
+	result, err = getRawReading(device)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for nil reading.
+	var reading *output.Reading
+	if result.Data == nil {
+		reading, err = output.KilowattHour.MakeReading(nil)
+		if err != nil {
+			return nil, err
+		}
+		readings = []*output.Reading{reading}
+		return readings, nil
+	}
+
+	// Account for a multiplier if any and convert to float.
+	var resultFloat float32
+	resultFloat, err = MultiplyReading(result, device.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the reading.
+	reading, err = output.KilowattHour.MakeReading(resultFloat)
+	if err != nil {
+		return nil, err
+	}
+	readings = []*output.Reading{reading}
+	return readings, nil
+}
@@ -2,6 +2,7 @@ package devices
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
 )
@@ -46,3 +47,49 @@ func TranslateEnumeration(result core.ReadResult, data map[string]interface{}) (
 	}
 	return fmt.Sprint(translation), nil
 }
+
+// IsFlags returns whether or not the SNMP device reading is a string of flags,
+// e.g. the PowerNet-MIB upsBasicStateOutputState. Each character of the string
+// is a flag, 1 when set and 0 when not.
+// data is the map associated with a synse device.
+func IsFlags(data map[string]interface{}) bool {
+	return data["flags"] == "true"
+}
+
+// TranslateFlags translates a flag string read result to the names of the set
+// flags, comma separated. The name of the flag at position N, 1 based, is in
+// the device data as flagN. The caller should call IsFlags first for this
+// translation to make sense.
+func TranslateFlags(result core.ReadResult, data map[string]interface{}) (string, error) {
+	var flags string
+	switch value := result.Data.(type) {
+	case nil:
+		return "", nil // Nil reading data. Return empty string.
+	case string:
+		flags = value
+	case []byte:
+		flags = string(value)
+	default:
+		return "", fmt.Errorf(
+			"expected string reading, got type: %T, value: %v",
+			result.Data, result.Data)
+	}
+
+	var names []string
+	for i, flag := range flags {
+		switch flag {
+		case '0':
+			continue
+		case '1':
+		default:
+			return "", fmt.Errorf("expected flags of 0 or 1, got %q", flags)
+		}
+		name, ok := data[fmt.Sprintf("flag%d", i+1)]
+		if !ok {
+			// Not found. Return something with the position.
+			name = fmt.Sprintf("undefined%d", i+1)
+		}
+		names = append(names, fmt.Sprint(name))
+	}
+	return strings.Join(names, ","), nil
+}
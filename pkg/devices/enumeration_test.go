@@ -0,0 +1,40 @@
+package devices
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// TestTranslateFlags tests translating flag strings to the set flag names.
+func TestTranslateFlags(t *testing.T) {
+	data := map[string]interface{}{
+		"flags": "true",
+		"flag1": "Abnormal Condition Present",
+		"flag2": "On Battery",
+		"flag4": "On Line",
+	}
+	assert.True(t, IsFlags(data))
+	assert.False(t, IsFlags(map[string]interface{}{"enumeration": "true"}))
+
+	for _, test := range []struct {
+		data     interface{}
+		expected string
+	}{
+		{"0001", "On Line"},
+		{"1100", "Abnormal Condition Present,On Battery"},
+		{[]byte("0000"), ""},
+		{"00101", "undefined3,undefined5"},
+		{nil, ""},
+	} {
+		flags, err := TranslateFlags(core.ReadResult{Data: test.data}, data)
+		assert.NoError(t, err, test)
+		assert.Equal(t, test.expected, flags, test)
+	}
+
+	for _, bad := range []interface{}{"01x1", 5} {
+		_, err := TranslateFlags(core.ReadResult{Data: bad}, data)
+		assert.Error(t, err, bad)
+	}
+}
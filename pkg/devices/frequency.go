@@ -9,7 +9,7 @@ import (
 // SnmpFrequency is the handler for the SNMP OIDs that report frequency.
 var SnmpFrequency = sdk.DeviceHandler{
 	Name: "frequency",
-	Read: SnmpFrequencyRead,
+	Read: withThresholds(withValidity(SnmpFrequencyRead)),
 }
 
 // SnmpFrequencyRead is the read handler function for synse SNMP devices that report frequency.
@@ -0,0 +1,163 @@
+package devices
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/vapor-ware/synse-sdk/sdk"
+)
+
+// writeConfirmation is the write data required for guarded writes.
+const writeConfirmation = "confirm"
+
+// The device data keys for the actions of guarded writes. The MIBs set the
+// OID and INTEGER value to set for each action they support, e.g.
+// action_oid_off and action_value_off.
+const (
+	actionOidKey   = "action_oid_"   // Prefix, followed by the action.
+	actionValueKey = "action_value_" // Prefix, followed by the action.
+)
+
+// writeGuard guards the writes of a device handler which disrupt the load,
+// e.g. switching an outlet off:
+//   - The agent must opt in with the control setting in the dynamic
+//     registration config.
+//   - The write data must be "confirm", e.g. {"action": "off", "data": "confirm"}.
+//   - Writes to the same device must be at least the cooldown apart.
+type writeGuard struct {
+	what            string // What is written, for errors, e.g. outlet.
+	controlSetting  string // Dynamic registration config key, e.g. outletControl.
+	cooldownSetting string // Dynamic registration config key, e.g. outletControlCooldown.
+	controlKey      string // Device data key for the control setting, a bool.
+	cooldownKey     string // Device data key for the cooldown, a Duration string.
+
+	// The time of the last write to each device.
+	writes      map[string]time.Time
+	writesMutex sync.Mutex
+}
+
+// newWriteGuard creates a writeGuard.
+func newWriteGuard(what, controlSetting, controlKey, cooldownKey string) *writeGuard {
+	return &writeGuard{
+		what:            what,
+		controlSetting:  controlSetting,
+		cooldownSetting: controlSetting + "Cooldown",
+		controlKey:      controlKey,
+		cooldownKey:     cooldownKey,
+		writes:          map[string]time.Time{},
+	}
+}
+
+// parse parses the control settings from the dynamic registration config
+// into device data. Both are optional. Writes are not allowed by default, and
+// the default cooldown is a minute.
+func (guard *writeGuard) parse(data map[string]interface{}) (settings map[string]interface{}, err error) {
+	control := false
+	if raw, ok := data[guard.controlSetting]; ok {
+		if control, ok = raw.(bool); !ok {
+			return nil, fmt.Errorf("%v should be a bool, %T, %+v", guard.controlSetting, raw, raw)
+		}
+	}
+
+	cooldown := DefaultOutletCooldown
+	if raw, ok := data[guard.cooldownSetting]; ok {
+		cooldownString, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("%v should be a duration string, %T, %+v", guard.cooldownSetting, raw, raw)
+		}
+		if cooldown, err = time.ParseDuration(cooldownString); err != nil {
+			return nil, fmt.Errorf("%v: %v", guard.cooldownSetting, err)
+		}
+		if cooldown < 0 {
+			return nil, fmt.Errorf("%v must not be negative", guard.cooldownSetting)
+		}
+	}
+
+	return map[string]interface{}{
+		guard.controlKey:  control,
+		guard.cooldownKey: cooldown.String(),
+	}, nil
+}
+
+// write checks that the write is allowed and sets the INTEGER value of the
+// write action.
+func (guard *writeGuard) write(device *sdk.Device, data *sdk.WriteData) error {
+	if device == nil {
+		return fmt.Errorf("device is nil")
+	}
+	if data == nil {
+		return fmt.Errorf("write data is nil")
+	}
+
+	oid, value, err := guard.command(deviceKey(device), device.Data, data.Action, string(data.Data), time.Now())
+	if err != nil {
+		return err
+	}
+
+	snmpClient, err := newSnmpClient(device)
+	if err != nil {
+		return err
+	}
+	return snmpClient.SetInteger(oid, value)
+}
+
+// command checks that a write to the device is allowed and gets the OID and
+// value to set for the action.
+func (guard *writeGuard) command(key string, deviceData map[string]interface{}, action string, confirmation string,
+	now time.Time) (oid string, value int, err error) {
+
+	if err = guard.check(deviceData, confirmation); err != nil {
+		return "", 0, err
+	}
+
+	rawOid, ok := deviceData[actionOidKey+action]
+	if !ok {
+		return "", 0, fmt.Errorf("unsupported %v action %q", guard.what, action)
+	}
+	oid = fmt.Sprint(rawOid)
+	value, err = strconv.Atoi(fmt.Sprint(deviceData[actionValueKey+action]))
+	if err != nil {
+		return "", 0, fmt.Errorf("%v action %q has no valid value: %v", guard.what, action, err)
+	}
+
+	if err = guard.claim(key, deviceData, now); err != nil {
+		return "", 0, err
+	}
+	return oid, value, nil
+}
+
+// check checks that writes are enabled for the agent of the device, and that
+// the write is confirmed.
+func (guard *writeGuard) check(deviceData map[string]interface{}, confirmation string) error {
+	if control, _ := deviceData[guard.controlKey].(bool); !control {
+		return fmt.Errorf("%v control is not enabled for this agent, see %v", guard.what, guard.controlSetting)
+	}
+	if confirmation != writeConfirmation {
+		return fmt.Errorf("%v writes must be confirmed with data %q", guard.what, writeConfirmation)
+	}
+	return nil
+}
+
+// claim starts the cooldown of a write to the device, or fails if the last
+// write was within the cooldown. The cooldown starts once the write is
+// allowed, whether or not the set succeeds, so that a failing agent is not
+// retried in a tight loop.
+func (guard *writeGuard) claim(key string, deviceData map[string]interface{}, now time.Time) (err error) {
+	cooldown := DefaultOutletCooldown
+	if raw, ok := deviceData[guard.cooldownKey]; ok {
+		if cooldown, err = time.ParseDuration(fmt.Sprint(raw)); err != nil {
+			return err
+		}
+	}
+
+	guard.writesMutex.Lock()
+	defer guard.writesMutex.Unlock()
+	if last, ok := guard.writes[key]; ok && now.Sub(last) < cooldown {
+		return fmt.Errorf("%v was written %v ago, wait %v between writes",
+			guard.what, now.Sub(last).Round(time.Second), cooldown)
+	}
+	guard.writes[key] = now
+	return nil
+}
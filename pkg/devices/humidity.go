@@ -0,0 +1,55 @@
+package devices
+
+import (
+	"github.com/vapor-ware/synse-sdk/sdk"
+	"github.com/vapor-ware/synse-sdk/sdk/output"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/outputs"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// SnmpHumidity is the handler for the SNMP OIDs that report relative
+// humidity, e.g. from the probes of environmental monitors.
+var SnmpHumidity = sdk.DeviceHandler{
+	Name: "humidity",
+	Read: withThresholds(withValidity(SnmpHumidityRead)),
+}
+
+// SnmpHumidityRead is the read handler function for Synse SNMP devices that report relative humidity.
+func SnmpHumidityRead(device *sdk.Device) (readings []*output.Reading, err error) {
+
+	// Get the raw reading from the SNMP server.
+	var result core.ReadResult
+	result, err = getRawReading(device)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for nil reading.
+	var reading *output.Reading
+	if result.Data == nil {
+		reading, err = outputs.RelativeHumidity.MakeReading(nil)
+		if err != nil {
+			return nil, err
+		}
+		readings = []*output.Reading{reading}
+		return
+	}
+
+	// Account for a multiplier if any, e.g. for the precision of an entity
+	// sensor. Without one, the raw reading is the relative humidity.
+	value := result.Data
+	if _, ok := device.Data["multiplier"]; ok {
+		value, err = MultiplyReading(result, device.Data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Create the reading.
+	reading, err = outputs.RelativeHumidity.MakeReading(value)
+	if err != nil {
+		return nil, err
+	}
+	readings = []*output.Reading{reading}
+	return
+}
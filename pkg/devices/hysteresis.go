@@ -0,0 +1,83 @@
+package devices
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/vapor-ware/synse-sdk/sdk"
+	"github.com/vapor-ware/synse-sdk/sdk/output"
+)
+
+// Threshold states for numeric devices with hysteresis settings.
+const (
+	thresholdLow    = "low"
+	thresholdNormal = "normal"
+	thresholdHigh   = "high"
+)
+
+// thresholdState returns the new threshold state for a value given the
+// previous state. The band keeps the state from flapping when the value hovers
+// around a threshold.
+func thresholdState(settings hysteresisSettings, previous string, value float64) string {
+	switch {
+	case settings.HasHigh && value >= settings.High:
+		return thresholdHigh
+	case settings.HasLow && value <= settings.Low:
+		return thresholdLow
+	case previous == thresholdHigh && value > settings.High-settings.Band:
+		return thresholdHigh
+	case previous == thresholdLow && value < settings.Low+settings.Band:
+		return thresholdLow
+	default:
+		return thresholdNormal
+	}
+}
+
+// thresholdStates hold the threshold state for each device reading.
+var (
+	thresholdStates      = map[string]string{}
+	thresholdStatesMutex sync.Mutex
+)
+
+// withThresholds wraps the read function of a numeric device. When the device
+// has hysteresis settings, each reading gets a threshold context of low,
+// normal or high. The thresholds apply to the reading before any scaling
+// factor in the device config.
+func withThresholds(read func(*sdk.Device) ([]*output.Reading, error)) func(*sdk.Device) ([]*output.Reading, error) {
+	return func(device *sdk.Device) ([]*output.Reading, error) {
+		readings, err := read(device)
+		if err != nil {
+			return nil, err
+		}
+
+		settings, ok, err := getHysteresisSettings(device.Data)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return readings, nil
+		}
+
+		key := deviceKey(device)
+		thresholdStatesMutex.Lock()
+		defer thresholdStatesMutex.Unlock()
+
+		for i, reading := range readings {
+			if reading.Value == nil {
+				continue // No reading, so the state is unknown.
+			}
+			value, err := toFloat64(reading.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			readingKey := fmt.Sprintf("%s/%d", key, i)
+			state := thresholdState(settings, thresholdStates[readingKey], value)
+			thresholdStates[readingKey] = state
+			reading.WithContext(map[string]string{
+				"threshold": state,
+			})
+		}
+		return readings, nil
+	}
+}
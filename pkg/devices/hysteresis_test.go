@@ -0,0 +1,83 @@
+package devices
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-sdk/sdk"
+	"github.com/vapor-ware/synse-sdk/sdk/output"
+)
+
+// TestThresholdState tests threshold states with hysteresis.
+func TestThresholdState(t *testing.T) {
+	settings := hysteresisSettings{Low: 200, HasLow: true, High: 250, HasHigh: true, Band: 5}
+
+	state := thresholdState(settings, "", 230)
+	assert.Equal(t, thresholdNormal, state)
+
+	// Rise past high, then hover within the band.
+	state = thresholdState(settings, state, 250)
+	assert.Equal(t, thresholdHigh, state)
+	state = thresholdState(settings, state, 247)
+	assert.Equal(t, thresholdHigh, state)
+	state = thresholdState(settings, state, 245)
+	assert.Equal(t, thresholdNormal, state)
+
+	// Drop past low, then hover within the band.
+	state = thresholdState(settings, state, 199)
+	assert.Equal(t, thresholdLow, state)
+	state = thresholdState(settings, state, 204.9)
+	assert.Equal(t, thresholdLow, state)
+	state = thresholdState(settings, state, 205)
+	assert.Equal(t, thresholdNormal, state)
+}
+
+// TestThresholdStateHighOnly tests a high threshold with no low threshold.
+func TestThresholdStateHighOnly(t *testing.T) {
+	settings := hysteresisSettings{High: 40, HasHigh: true, Band: 2}
+
+	assert.Equal(t, thresholdNormal, thresholdState(settings, "", -100))
+	assert.Equal(t, thresholdHigh, thresholdState(settings, thresholdNormal, 40))
+	assert.Equal(t, thresholdHigh, thresholdState(settings, thresholdHigh, 39))
+	assert.Equal(t, thresholdNormal, thresholdState(settings, thresholdHigh, 38))
+}
+
+// TestWithThresholds tests that readings get the threshold context.
+func TestWithThresholds(t *testing.T) {
+	values := []interface{}{float32(230), float32(251), float32(248), nil}
+	read := withThresholds(func(device *sdk.Device) ([]*output.Reading, error) {
+		reading, err := output.Voltage.MakeReading(values[0])
+		values = values[1:]
+		return []*output.Reading{reading}, err
+	})
+	device := &sdk.Device{Data: map[string]interface{}{
+		"endpoint":        "hysteresis-test",
+		"port":            1024,
+		"oid":             ".1.3.6.1.2.1.33.1.3.3.1.3.1",
+		hysteresisHighKey: 250,
+		hysteresisBandKey: 5,
+	}}
+
+	for _, expected := range []string{thresholdNormal, thresholdHigh, thresholdHigh} {
+		readings, err := read(device)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, readings[0].Context["threshold"])
+	}
+
+	// No reading, no state.
+	readings, err := read(device)
+	assert.NoError(t, err)
+	assert.NotContains(t, readings[0].Context, "threshold")
+}
+
+// TestWithThresholdsNoSettings tests that readings are unchanged without settings.
+func TestWithThresholdsNoSettings(t *testing.T) {
+	read := withThresholds(func(device *sdk.Device) ([]*output.Reading, error) {
+		reading, err := output.Voltage.MakeReading(float32(230))
+		return []*output.Reading{reading}, err
+	})
+
+	readings, err := read(&sdk.Device{Data: map[string]interface{}{}})
+	assert.NoError(t, err)
+	assert.NotContains(t, readings[0].Context, "threshold")
+}
@@ -9,7 +9,7 @@ import (
 // SnmpMinutes is the handler for the SNMP OIDs that report minutes.
 var SnmpMinutes = sdk.DeviceHandler{
 	Name: "minutes",
-	Read: SnmpMinutesRead,
+	Read: withThresholds(SnmpMinutesRead),
 }
 
 // SnmpMinutesRead is the read handler function for Synse SNMP devices that report minutes.
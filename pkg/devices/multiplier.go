@@ -10,9 +10,16 @@ import (
 // appropriately.
 func MultiplyReading(result core.ReadResult, data map[string]interface{}) (resultFloat float32, err error) {
 
-	// Raw SNMP reading should be an int.
-	resultInt, ok := result.Data.(int)
-	if !ok {
+	// Raw SNMP reading should be an int. Gauges and TimeTicks are unsigned.
+	var resultInt int64
+	switch value := result.Data.(type) {
+	case int:
+		resultInt = int64(value)
+	case uint:
+		resultInt = int64(value)
+	case uint32:
+		resultInt = int64(value)
+	default:
 		return 0.0, fmt.Errorf(
 			"expected int reading, got type: %T, value: %v",
 			result.Data, result.Data)
@@ -0,0 +1,31 @@
+package devices
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// TestMultiplyReading tests multiplying signed and unsigned readings.
+func TestMultiplyReading(t *testing.T) {
+	data := map[string]interface{}{"multiplier": float32(0.01)}
+
+	// TimeTicks, e.g. upsAdvBatteryRunTimeRemaining.
+	value, err := MultiplyReading(core.ReadResult{Data: uint32(360000)}, data)
+	assert.NoError(t, err)
+	assert.Equal(t, float32(3600), value)
+
+	value, err = MultiplyReading(core.ReadResult{Data: -500}, data)
+	assert.NoError(t, err)
+	assert.Equal(t, float32(-5), value)
+
+	value, err = MultiplyReading(core.ReadResult{Data: uint(42)}, map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, float32(42), value)
+
+	_, err = MultiplyReading(core.ReadResult{Data: "42"}, data)
+	assert.Error(t, err)
+	_, err = MultiplyReading(core.ReadResult{Data: 42}, map[string]interface{}{"multiplier": 0.01})
+	assert.Error(t, err)
+}
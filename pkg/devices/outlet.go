@@ -0,0 +1,52 @@
+package devices
+
+import (
+	"time"
+
+	"github.com/vapor-ware/synse-sdk/sdk"
+)
+
+// SnmpOutlet is the handler for snmp-outlet devices, outlets and load segments
+// which report their status and may be switched. Reads are the same as for
+// status devices.
+//
+// Switching an outlet drops its load, so writes are guarded:
+//   - The agent must opt in with outletControl in the dynamic registration
+//     config. See ParseOutletControl.
+//   - The write data must be "confirm", e.g. {"action": "off", "data": "confirm"}.
+//   - Writes to the same outlet must be at least the cooldown apart.
+var SnmpOutlet = sdk.DeviceHandler{
+	Name:  "outlet",
+	Read:  withDebounce(SnmpStatusRead),
+	Write: SnmpOutletWrite,
+}
+
+// DefaultOutletCooldown is the default minimum time between writes to the
+// same outlet, or other device with guarded writes.
+const DefaultOutletCooldown = time.Minute
+
+// The device data keys for outlet control. The agent settings are shimmed in
+// from the dynamic registration config.
+const (
+	outletControlKey  = "outlet_control"  // bool
+	outletCooldownKey = "outlet_cooldown" // Duration string.
+)
+
+// outletGuard guards the writes to outlets.
+var outletGuard = newWriteGuard("outlet", "outletControl", outletControlKey, outletCooldownKey)
+
+// ParseOutletControl parses the outlet control settings from the dynamic
+// registration config into device data for the outlet devices of the agent.
+// Both are optional:
+//
+//	outletControl: Whether outlets may be switched. Default false.
+//	outletControlCooldown: Minimum time between writes to an outlet. Default 1m.
+func ParseOutletControl(data map[string]interface{}) (settings map[string]interface{}, err error) {
+	return outletGuard.parse(data)
+}
+
+// SnmpOutletWrite is the write handler function for snmp-outlet devices. The
+// write action is the name of the command, e.g. on or off.
+func SnmpOutletWrite(device *sdk.Device, data *sdk.WriteData) error {
+	return outletGuard.write(device, data)
+}
@@ -0,0 +1,136 @@
+package devices
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-sdk/sdk"
+)
+
+// TestParseOutletControl tests parsing the outlet control agent settings.
+func TestParseOutletControl(t *testing.T) {
+	settings, err := ParseOutletControl(map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"outlet_control":  false,
+		"outlet_cooldown": "1m0s",
+	}, settings)
+
+	settings, err = ParseOutletControl(map[string]interface{}{
+		"outletControl":         true,
+		"outletControlCooldown": "5m",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"outlet_control":  true,
+		"outlet_cooldown": "5m0s",
+	}, settings)
+
+	for _, bad := range []map[string]interface{}{
+		{"outletControl": "true"},
+		{"outletControlCooldown": 60},
+		{"outletControlCooldown": "soon"},
+		{"outletControlCooldown": "-1s"},
+	} {
+		_, err = ParseOutletControl(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+// TestSnmpOutletWrite tests the guards on outlet writes.
+func TestSnmpOutletWrite(t *testing.T) {
+	device := &sdk.Device{Data: map[string]interface{}{
+		"endpoint":         "outlet-test",
+		"port":             161,
+		"oid":              ".1.3.6.1.4.1.534.1.12.2.1.2.1",
+		"outlet_control":   false,
+		"outlet_cooldown":  "1m0s",
+		"action_oid_off":   ".1.3.6.1.4.1.534.1.12.2.1.3.1",
+		"action_value_off": "0",
+		"action_oid_on":    ".1.3.6.1.4.1.534.1.12.2.1.4.1",
+		"action_value_on":  "0",
+	}}
+	key := deviceKey(device)
+	now := debounceTestNow
+
+	assert.Error(t, SnmpOutletWrite(nil, &sdk.WriteData{Action: "off", Data: []byte("confirm")}))
+	assert.Error(t, SnmpOutletWrite(device, nil))
+
+	// Not enabled for the agent.
+	err := SnmpOutletWrite(device, &sdk.WriteData{Action: "off", Data: []byte("confirm")})
+	assert.EqualError(t, err, "outlet control is not enabled for this agent, see outletControl")
+
+	device.Data["outlet_control"] = true
+
+	// Not confirmed.
+	err = SnmpOutletWrite(device, &sdk.WriteData{Action: "off"})
+	assert.EqualError(t, err, `outlet writes must be confirmed with data "confirm"`)
+
+	// Not supported.
+	err = SnmpOutletWrite(device, &sdk.WriteData{Action: "reboot", Data: []byte("confirm")})
+	assert.EqualError(t, err, `unsupported outlet action "reboot"`)
+
+	// The rejected writes did not start the cooldown. There is no agent to
+	// set, so the allowed writes go straight to the guard.
+	oid, value, err := outletGuard.command(key, device.Data, "off", "confirm", now)
+	assert.NoError(t, err)
+	assert.Equal(t, ".1.3.6.1.4.1.534.1.12.2.1.3.1", oid)
+	assert.Equal(t, 0, value)
+
+	// Too soon after the last write, whatever the action.
+	_, _, err = outletGuard.command(key, device.Data, "on", "confirm", now.Add(30*time.Second))
+	assert.Error(t, err)
+
+	// Rejected writes do not restart the cooldown.
+	oid, _, err = outletGuard.command(key, device.Data, "on", "confirm", now.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, ".1.3.6.1.4.1.534.1.12.2.1.4.1", oid)
+
+	// Other outlets have their own cooldown.
+	_, _, err = outletGuard.command(key+"2", device.Data, "off", "confirm", now.Add(time.Minute))
+	assert.NoError(t, err)
+}
+
+// TestTransferSourceCommand tests that preferred source writes have their own
+// opt in and cooldown.
+func TestTransferSourceCommand(t *testing.T) {
+	settings, err := ParseTransferSourceControl(map[string]interface{}{
+		"outletControl":                 true,
+		"transferSourceControlCooldown": "5m",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"transfer_source_control":  false,
+		"transfer_source_cooldown": "5m0s",
+	}, settings)
+
+	_, err = ParseTransferSourceControl(map[string]interface{}{"transferSourceControl": "yes"})
+	assert.Error(t, err)
+
+	key := "ats-test:161/.1.3.6.1.4.1.318.1.1.8.4.2.0"
+	now := debounceTestNow
+	data := map[string]interface{}{
+		"outlet_control":           true,
+		"action_oid_sourceB":       ".1.3.6.1.4.1.318.1.1.8.4.2.0",
+		"action_value_sourceB":     "2",
+		"transfer_source_cooldown": "5m0s",
+	}
+
+	// Outlet control does not allow it.
+	_, _, err = transferSourceGuard.command(key, data, "sourceB", "confirm", now)
+	assert.Error(t, err)
+
+	data["transfer_source_control"] = true
+	oid, value, err := transferSourceGuard.command(key, data, "sourceB", "confirm", now)
+	assert.NoError(t, err)
+	assert.Equal(t, ".1.3.6.1.4.1.318.1.1.8.4.2.0", oid)
+	assert.Equal(t, 2, value)
+
+	_, _, err = transferSourceGuard.command(key, data, "sourceB", "confirm", now.Add(time.Minute))
+	assert.Error(t, err)
+
+	// Outlet writes have their own cooldown.
+	_, _, err = outletGuard.command(key, data, "sourceB", "confirm", now.Add(time.Minute))
+	assert.NoError(t, err)
+}
@@ -9,7 +9,7 @@ import (
 // SnmpPercentage is the handler for the SNMP OIDs that report percentage.
 var SnmpPercentage = sdk.DeviceHandler{
 	Name: "percentage",
-	Read: SnmpPercentageRead,
+	Read: withThresholds(withValidity(SnmpPercentageRead)),
 }
 
 // SnmpPercentageRead is the read handler function for Synse SNMP devices that report percentage.
@@ -33,8 +33,18 @@ func SnmpPercentageRead(device *sdk.Device) (readings []*output.Reading, err err
 		return
 	}
 
+	// Account for a multiplier if any, e.g. for the precision of an entity
+	// sensor. Without one, the raw reading is the percentage.
+	value := result.Data
+	if _, ok := device.Data["multiplier"]; ok {
+		value, err = MultiplyReading(result, device.Data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Create the reading.
-	reading, err = output.Percentage.MakeReading(result.Data)
+	reading, err = output.Percentage.MakeReading(value)
 	if err != nil {
 		return nil, err
 	}
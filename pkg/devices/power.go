@@ -9,7 +9,7 @@ import (
 // SnmpPower is the handler for SNMP OIDs that report power.
 var SnmpPower = sdk.DeviceHandler{
 	Name: "power",
-	Read: SnmpPowerRead,
+	Read: withThresholds(withValidity(SnmpPowerRead)),
 }
 
 // SnmpPowerRead is the read handler function for synse SNMP devices that report power.
@@ -0,0 +1,98 @@
+package devices
+
+import (
+	"sync"
+	"time"
+
+	"github.com/vapor-ware/synse-sdk/sdk"
+	"github.com/vapor-ware/synse-sdk/sdk/output"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/outputs"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// SnmpThroughput is the handler for the SNMP OIDs that count octets, e.g.
+// ifHCInOctets. Readings are the rate in bytes per second.
+var SnmpThroughput = sdk.DeviceHandler{
+	Name: "throughput",
+	Read: withThresholds(newRateRead(&outputs.ByteRate)),
+}
+
+// SnmpErrorRate is the handler for the SNMP OIDs that count errors, e.g.
+// ifInErrors. Readings are the rate in errors per second.
+var SnmpErrorRate = sdk.DeviceHandler{
+	Name: "error-rate",
+	Read: withThresholds(newRateRead(&outputs.ErrorRate)),
+}
+
+// counters hold the last reading of each counter device, so rates can be
+// computed between readings.
+var (
+	counters      = map[string]core.CounterReading{}
+	countersMutex sync.Mutex
+)
+
+// counterRate records a reading of a counter for key and gets the rate per
+// second since the last reading. ok is false when there is no rate yet: on
+// the first reading, and when the counter was reset.
+func counterRate(key string, reading core.CounterReading) (rate float64, ok bool) {
+	countersMutex.Lock()
+	defer countersMutex.Unlock()
+
+	previous, found := counters[key]
+	counters[key] = reading
+	if !found {
+		return 0, false
+	}
+	elapsed := reading.Now.Sub(previous.Now).Seconds()
+	if elapsed <= 0 {
+		return 0, false
+	}
+	delta, ok := core.CounterDelta(previous, reading)
+	if !ok {
+		return 0, false
+	}
+	return float64(delta) / elapsed, true
+}
+
+// newRateRead creates the read handler function for Synse SNMP devices that
+// report a counter as a rate. The rate is computed between readings, so the
+// first reading, and the first reading after a counter reset, have no value.
+// Counters are reset when sysUpTime shows that the agent was re-initialized,
+// and when the discontinuity time of the counter changes.
+func newRateRead(rateOutput *output.Output) func(*sdk.Device) ([]*output.Reading, error) {
+	return func(device *sdk.Device) (readings []*output.Reading, err error) {
+
+		// Get the raw reading from the SNMP server, with the objects which
+		// show whether the counter was reset.
+		result, sysUpTime, discontinuity, now, err := getRawCounterReading(device)
+		if err != nil {
+			return nil, err
+		}
+
+		reading, err := makeRateReading(rateOutput, deviceKey(device), result.Data, sysUpTime.Data, discontinuity.Data, now)
+		if err != nil {
+			return nil, err
+		}
+		return []*output.Reading{reading}, nil
+	}
+}
+
+// makeRateReading creates the rate reading for counter data read at now, with
+// the sysUpTime and discontinuity time data read with it, which may be nil.
+func makeRateReading(rateOutput *output.Output, key string,
+	data interface{}, sysUpTime interface{}, discontinuity interface{}, now time.Time) (*output.Reading, error) {
+	// Check for nil reading.
+	if data == nil {
+		return rateOutput.MakeReading(nil)
+	}
+
+	counter, err := core.NewCounterReading(data, sysUpTime, discontinuity, now)
+	if err != nil {
+		return nil, err
+	}
+	rate, ok := counterRate(key, counter)
+	if !ok {
+		return rateOutput.MakeReading(nil)
+	}
+	return rateOutput.MakeReading(rate)
+}
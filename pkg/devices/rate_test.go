@@ -0,0 +1,102 @@
+package devices
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/outputs"
+)
+
+// TestMakeRateReading tests counter rates between readings.
+func TestMakeRateReading(t *testing.T) {
+	key := "rate-test:161/.1.3.6.1.2.1.31.1.1.1.6.2"
+	now := debounceTestNow
+
+	// No rate on the first reading.
+	reading, err := makeRateReading(&outputs.ByteRate, key, uint64(5353869), nil, nil, now)
+	assert.NoError(t, err)
+	assert.Nil(t, reading.Value)
+	assert.Equal(t, "bytes-per-second", reading.GetOutput().Name)
+
+	reading, err = makeRateReading(&outputs.ByteRate, key, uint64(5356869), nil, nil, now.Add(10*time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, float64(300), reading.Value)
+
+	// Counter64 reset, e.g. the agent rebooted.
+	reading, err = makeRateReading(&outputs.ByteRate, key, uint64(1000), nil, nil, now.Add(20*time.Second))
+	assert.NoError(t, err)
+	assert.Nil(t, reading.Value)
+
+	// No data.
+	reading, err = makeRateReading(&outputs.ByteRate, key, nil, nil, nil, now.Add(30*time.Second))
+	assert.NoError(t, err)
+	assert.Nil(t, reading.Value)
+
+	_, err = makeRateReading(&outputs.ByteRate, key, "1000", nil, nil, now.Add(40*time.Second))
+	assert.Error(t, err)
+}
+
+// TestMakeRateReadingWrap tests the rate of a Counter32 which wrapped.
+func TestMakeRateReadingWrap(t *testing.T) {
+	key := "rate-test:161/.1.3.6.1.2.1.2.2.1.14.2"
+	now := debounceTestNow
+
+	_, err := makeRateReading(&outputs.ErrorRate, key, uint(4294967295), nil, nil, now)
+	assert.NoError(t, err)
+	reading, err := makeRateReading(&outputs.ErrorRate, key, uint(3), nil, nil, now.Add(2*time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), reading.Value)
+	assert.Equal(t, "errors-per-second", reading.GetOutput().Name)
+}
+
+// TestMakeRateReadingReset tests that counters reset by an agent reboot or a
+// discontinuity have no rate, even when they went up since the last reading.
+func TestMakeRateReadingReset(t *testing.T) {
+	key := "rate-test:161/.1.3.6.1.2.1.31.1.1.1.10.3"
+	now := debounceTestNow
+
+	_, err := makeRateReading(&outputs.ByteRate, key, uint64(1000), uint32(500000), uint32(0), now)
+	assert.NoError(t, err)
+	reading, err := makeRateReading(&outputs.ByteRate, key, uint64(3000), uint32(501000), uint32(0), now.Add(10*time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, float64(200), reading.Value)
+
+	// The agent rebooted, sysUpTime went back.
+	reading, err = makeRateReading(&outputs.ByteRate, key, uint64(9000), uint32(1000), uint32(0), now.Add(20*time.Second))
+	assert.NoError(t, err)
+	assert.Nil(t, reading.Value)
+	reading, err = makeRateReading(&outputs.ByteRate, key, uint64(10000), uint32(2000), uint32(0), now.Add(30*time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, float64(100), reading.Value)
+
+	// The interface had a discontinuity.
+	reading, err = makeRateReading(&outputs.ByteRate, key, uint64(20000), uint32(3000), uint32(2500), now.Add(40*time.Second))
+	assert.NoError(t, err)
+	assert.Nil(t, reading.Value)
+
+	_, err = makeRateReading(&outputs.ByteRate, key, uint64(20000), "3000", nil, now.Add(50*time.Second))
+	assert.Error(t, err)
+}
+
+// TestMakeSpeedReading tests link speed readings.
+func TestMakeSpeedReading(t *testing.T) {
+	// ifSpeed in bits per second.
+	reading, err := makeSpeedReading(uint(100000000), map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(100000000), reading.Value)
+
+	// ifHighSpeed in units of 1,000,000 bits per second.
+	reading, err = makeSpeedReading(uint(10000), map[string]interface{}{"multiplier": float32(1000000)})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(10000000000), reading.Value)
+
+	reading, err = makeSpeedReading(nil, map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Nil(t, reading.Value)
+
+	_, err = makeSpeedReading(uint(10), map[string]interface{}{"multiplier": 1000000})
+	assert.Error(t, err)
+	_, err = makeSpeedReading("10", map[string]interface{}{})
+	assert.Error(t, err)
+}
@@ -9,7 +9,7 @@ import (
 // SnmpSeconds is the handler for the SNMP OIDs that report seconds.
 var SnmpSeconds = sdk.DeviceHandler{
 	Name: "seconds",
-	Read: SnmpSecondsRead,
+	Read: withThresholds(SnmpSecondsRead),
 }
 
 // SnmpSecondsRead is the read handler function for Synse SNMP devices that report seconds.
@@ -33,8 +33,18 @@ func SnmpSecondsRead(device *sdk.Device) (readings []*output.Reading, err error)
 		return
 	}
 
+	// Account for a multiplier if any, e.g. 0.01 for TimeTicks. Without one,
+	// the raw reading is in seconds.
+	value := result.Data
+	if _, ok := device.Data["multiplier"]; ok {
+		value, err = MultiplyReading(result, device.Data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Create the reading.
-	reading, err = output.Seconds.MakeReading(result.Data)
+	reading, err = output.Seconds.MakeReading(value)
 	if err != nil {
 		return nil, err
 	}
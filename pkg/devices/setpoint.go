@@ -0,0 +1,147 @@
+package devices
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/vapor-ware/synse-sdk/sdk"
+)
+
+// SnmpTemperatureSetpoint is the handler for the temperature setpoints of
+// cooling units, e.g. the supply or return air temperature the unit controls
+// to. Reads are the same as for temperature devices, without thresholds.
+// Writes change the setpoint. See SnmpSetpointWrite.
+var SnmpTemperatureSetpoint = sdk.DeviceHandler{
+	Name:  "temperature-setpoint",
+	Read:  SnmpTemperatureRead,
+	Write: SnmpSetpointWrite,
+}
+
+// SnmpHumiditySetpoint is the handler for the relative humidity setpoints of
+// cooling units. Reads are the same as for humidity devices, without
+// thresholds. Writes change the setpoint. See SnmpSetpointWrite.
+var SnmpHumiditySetpoint = sdk.DeviceHandler{
+	Name:  "humidity-setpoint",
+	Read:  SnmpHumidityRead,
+	Write: SnmpSetpointWrite,
+}
+
+// The device data keys for setpoint control. The agent settings are shimmed
+// in from the dynamic registration config.
+const (
+	setpointControlKey  = "setpoint_control"  // bool
+	setpointCooldownKey = "setpoint_cooldown" // Duration string.
+)
+
+// The device data keys for the range of values a setpoint may be written
+// with, in the units of the reading. The MIBs set these. Both are optional.
+const (
+	setpointMinKey = "setpoint_min"
+	setpointMaxKey = "setpoint_max"
+)
+
+// setpointGuard guards the writes to setpoints.
+var setpointGuard = newWriteGuard("setpoint", "setpointControl", setpointControlKey, setpointCooldownKey)
+
+// ParseSetpointControl parses the setpoint control settings from the dynamic
+// registration config into device data for the setpoint devices of the
+// agent. Both are optional:
+//
+//	setpointControl: Whether the setpoints may be changed. Default false.
+//	setpointControlCooldown: Minimum time between writes to a setpoint. Default 1m.
+func ParseSetpointControl(data map[string]interface{}) (settings map[string]interface{}, err error) {
+	return setpointGuard.parse(data)
+}
+
+// SnmpSetpointWrite is the write handler function for setpoint devices.
+// Changing a setpoint changes how hard the cooling unit works, so writes are
+// guarded as for outlets:
+//   - The agent must opt in with setpointControl in the dynamic registration
+//     config. See ParseSetpointControl.
+//   - The write data must be "confirm".
+//   - Writes to the same setpoint must be at least the cooldown apart.
+//
+// The write action is the new setpoint in the units of the reading, e.g.
+// {"action": "22", "data": "confirm"} for 22 degrees C. It must be within the
+// range the MIB allows for the setpoint.
+func SnmpSetpointWrite(device *sdk.Device, data *sdk.WriteData) error {
+	if device == nil {
+		return fmt.Errorf("device is nil")
+	}
+	if data == nil {
+		return fmt.Errorf("write data is nil")
+	}
+
+	oid, value, err := setpointCommand(deviceKey(device), device.Data, data.Action, string(data.Data), time.Now())
+	if err != nil {
+		return err
+	}
+
+	snmpClient, err := newSnmpClient(device)
+	if err != nil {
+		return err
+	}
+	return snmpClient.SetInteger(oid, value)
+}
+
+// setpointCommand checks that a write to the setpoint is allowed and gets
+// the OID and raw INTEGER value to set for the new setpoint in action. The
+// raw value is the setpoint divided by the multiplier of the device, if any.
+func setpointCommand(key string, deviceData map[string]interface{}, action string, confirmation string,
+	now time.Time) (oid string, value int, err error) {
+
+	if err = setpointGuard.check(deviceData, confirmation); err != nil {
+		return "", 0, err
+	}
+
+	setpoint, err := strconv.ParseFloat(action, 64)
+	if err != nil || math.IsNaN(setpoint) || math.IsInf(setpoint, 0) {
+		return "", 0, fmt.Errorf("setpoint %q is not a number", action)
+	}
+	if min, ok, err := setpointLimit(deviceData, setpointMinKey); err != nil {
+		return "", 0, err
+	} else if ok && setpoint < min {
+		return "", 0, fmt.Errorf("setpoint %v is below the minimum %v", setpoint, min)
+	}
+	if max, ok, err := setpointLimit(deviceData, setpointMaxKey); err != nil {
+		return "", 0, err
+	} else if ok && setpoint > max {
+		return "", 0, fmt.Errorf("setpoint %v is above the maximum %v", setpoint, max)
+	}
+
+	raw := setpoint
+	if multiplier, ok := deviceData["multiplier"]; ok {
+		multiplierFloat, isOk := multiplier.(float32)
+		if !isOk || multiplierFloat == 0 {
+			return "", 0, fmt.Errorf(
+				"expected non-zero float multiplier, got type: %T, value: %v", multiplier, multiplier)
+		}
+		raw = setpoint / float64(multiplierFloat)
+	}
+
+	rawOid, ok := deviceData["oid"]
+	if !ok {
+		return "", 0, fmt.Errorf("setpoint has no oid")
+	}
+
+	if err = setpointGuard.claim(key, deviceData, now); err != nil {
+		return "", 0, err
+	}
+	return fmt.Sprint(rawOid), int(math.Round(raw)), nil
+}
+
+// setpointLimit gets a limit of the setpoint range from the device data. ok
+// is false when the device has no such limit.
+func setpointLimit(deviceData map[string]interface{}, key string) (limit float64, ok bool, err error) {
+	raw, ok := deviceData[key]
+	if !ok {
+		return 0, false, nil
+	}
+	limit, err = strconv.ParseFloat(fmt.Sprint(raw), 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("%v is not a number, %T, %+v", key, raw, raw)
+	}
+	return limit, true, nil
+}
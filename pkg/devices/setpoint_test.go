@@ -0,0 +1,71 @@
+package devices
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseSetpointControl tests parsing the setpoint control agent settings.
+func TestParseSetpointControl(t *testing.T) {
+	settings, err := ParseSetpointControl(map[string]interface{}{
+		"setpointControl":         true,
+		"setpointControlCooldown": "10m",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"setpoint_control":  true,
+		"setpoint_cooldown": "10m0s",
+	}, settings)
+
+	_, err = ParseSetpointControl(map[string]interface{}{"setpointControl": 1})
+	assert.Error(t, err)
+}
+
+// TestSetpointCommand tests the guards on setpoint writes.
+func TestSetpointCommand(t *testing.T) {
+	key := "crac-test:161/.1.3.6.1.4.1.476.1.42.3.4.1.3.2.1.3.1"
+	now := debounceTestNow
+	data := map[string]interface{}{
+		"oid":               ".1.3.6.1.4.1.476.1.42.3.4.1.3.2.1.3.1",
+		"setpoint_min":      float32(15),
+		"setpoint_max":      float32(30),
+		"setpoint_cooldown": "1m0s",
+	}
+
+	// Not enabled.
+	_, _, err := setpointCommand(key, data, "22", "confirm", now)
+	assert.Error(t, err)
+
+	data["setpoint_control"] = true
+	for _, bad := range []struct {
+		action       string
+		confirmation string
+	}{
+		{"22", ""},          // Not confirmed.
+		{"warm", "confirm"}, // Not a number.
+		{"NaN", "confirm"},
+		{"14.5", "confirm"}, // Below the range.
+		{"31", "confirm"},   // Above the range.
+	} {
+		_, _, err = setpointCommand(key, data, bad.action, bad.confirmation, now)
+		assert.Error(t, err, bad)
+	}
+
+	// None of the failed writes started the cooldown.
+	oid, value, err := setpointCommand(key, data, "22", "confirm", now)
+	assert.NoError(t, err)
+	assert.Equal(t, ".1.3.6.1.4.1.476.1.42.3.4.1.3.2.1.3.1", oid)
+	assert.Equal(t, 22, value)
+
+	_, _, err = setpointCommand(key, data, "23", "confirm", now.Add(30*time.Second))
+	assert.Error(t, err)
+
+	// Setpoints in tenths are set as the raw value.
+	data["multiplier"] = float32(0.1)
+	oid, value, err = setpointCommand(key, data, "22.5", "confirm", now.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, ".1.3.6.1.4.1.476.1.42.3.4.1.3.2.1.3.1", oid)
+	assert.Equal(t, 225, value)
+}
@@ -0,0 +1,225 @@
+package devices
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// Per-device settings are configured in the dynamic registration config under
+// deviceSettings, keyed by the device info, e.g.
+//
+//	deviceSettings:
+//	  upsOutputSource:
+//	    debounceHoldTime: 10s
+//	    debounceSamples: 3
+//	  upsInputVoltage:
+//	    hysteresisLow: 200
+//	    hysteresisHigh: 250
+//	    hysteresisBand: 5
+//	  xupsBatteryLastReplacedDate:
+//	    serviceLifeDays: 1460
+//
+// The settings are shimmed into the device instance data under the keys below.
+const (
+	debounceHoldTimeKey = "debounce_hold_time" // Duration string.
+	debounceSamplesKey  = "debounce_samples"   // int
+	hysteresisLowKey    = "hysteresis_low"     // float64
+	hysteresisHighKey   = "hysteresis_high"    // float64
+	hysteresisBandKey   = "hysteresis_band"    // float64
+	serviceLifeDaysKey  = "service_life_days"  // int
+)
+
+// settingKeys maps the configuration keys to the device data keys.
+var settingKeys = map[string]string{
+	"debounceHoldTime": debounceHoldTimeKey,
+	"debounceSamples":  debounceSamplesKey,
+	"hysteresisLow":    hysteresisLowKey,
+	"hysteresisHigh":   hysteresisHighKey,
+	"hysteresisBand":   hysteresisBandKey,
+	"serviceLifeDays":  serviceLifeDaysKey,
+}
+
+// ParseDeviceSettings parses the deviceSettings from the dynamic registration
+// config into device data for each device info. The config is generally
+// decoded from YAML, so maps may be map[interface{}]interface{}.
+func ParseDeviceSettings(raw interface{}) (settings map[string]map[string]interface{}, err error) {
+	settings = map[string]map[string]interface{}{}
+	if raw == nil {
+		return settings, nil
+	}
+
+	devices, err := core.ToStringMap(raw)
+	if err != nil {
+		return nil, fmt.Errorf("deviceSettings: %v", err)
+	}
+
+	for info, rawDeviceSettings := range devices {
+		deviceSettings, err := core.ToStringMap(rawDeviceSettings)
+		if err != nil {
+			return nil, fmt.Errorf("deviceSettings %v: %v", info, err)
+		}
+
+		data := map[string]interface{}{}
+		for key, value := range deviceSettings {
+			dataKey, ok := settingKeys[key]
+			if !ok {
+				return nil, fmt.Errorf("deviceSettings %v: unknown setting %v", info, key)
+			}
+			data[dataKey] = value
+		}
+
+		// Check the settings now rather than on each read.
+		if _, _, err := getDebounceSettings(data); err != nil {
+			return nil, fmt.Errorf("deviceSettings %v: %v", info, err)
+		}
+		if _, _, err := getHysteresisSettings(data); err != nil {
+			return nil, fmt.Errorf("deviceSettings %v: %v", info, err)
+		}
+		if _, _, err := getServiceLife(data); err != nil {
+			return nil, fmt.Errorf("deviceSettings %v: %v", info, err)
+		}
+		settings[info] = data
+	}
+	return settings, nil
+}
+
+// toFloat64 converts a numeric setting or reading value to float64.
+func toFloat64(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case uint:
+		return float64(v), nil
+	case uint32:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	default:
+		return 0, fmt.Errorf("expected a number, got type: %T, value: %v", value, value)
+	}
+}
+
+// debounceSettings are the debounce settings for a device. A new value must
+// be read for Samples consecutive reads and at least HoldTime before it is
+// reported as the debounced value.
+type debounceSettings struct {
+	HoldTime time.Duration
+	Samples  int
+}
+
+// getDebounceSettings gets the debounce settings from the device data. ok is
+// false if the device is not debounced.
+func getDebounceSettings(data map[string]interface{}) (settings debounceSettings, ok bool, err error) {
+	settings.Samples = 1
+
+	if holdTime, exists := data[debounceHoldTimeKey]; exists {
+		holdTimeString, isString := holdTime.(string)
+		if !isString {
+			return settings, false, fmt.Errorf(
+				"expected duration string for %v, got type: %T, value: %v", debounceHoldTimeKey, holdTime, holdTime)
+		}
+		settings.HoldTime, err = time.ParseDuration(holdTimeString)
+		if err != nil {
+			return settings, false, err
+		}
+		if settings.HoldTime < 0 {
+			return settings, false, fmt.Errorf("%v must not be negative", debounceHoldTimeKey)
+		}
+		ok = true
+	}
+
+	if samples, exists := data[debounceSamplesKey]; exists {
+		samplesInt, isInt := samples.(int)
+		if !isInt {
+			return settings, false, fmt.Errorf(
+				"expected int for %v, got type: %T, value: %v", debounceSamplesKey, samples, samples)
+		}
+		if samplesInt < 1 {
+			return settings, false, fmt.Errorf("%v must be at least 1", debounceSamplesKey)
+		}
+		settings.Samples = samplesInt
+		ok = true
+	}
+	return settings, ok, nil
+}
+
+// hysteresisSettings are the threshold settings for a numeric device. The
+// value is high once it reaches High and stays high until it drops below
+// High - Band. Similarly it is low once it reaches Low and stays low until it
+// rises above Low + Band.
+type hysteresisSettings struct {
+	Low     float64
+	HasLow  bool
+	High    float64
+	HasHigh bool
+	Band    float64
+}
+
+// getHysteresisSettings gets the threshold settings from the device data. ok
+// is false if the device has no thresholds. Devices configured with neither a
+// low nor a high threshold use the probe limits from the agent, if any. See
+// core.AddLimits.
+func getHysteresisSettings(data map[string]interface{}) (settings hysteresisSettings, ok bool, err error) {
+	lowKey, highKey := hysteresisLowKey, hysteresisHighKey
+	_, configuredLow := data[lowKey]
+	_, configuredHigh := data[highKey]
+	if !configuredLow && !configuredHigh {
+		lowKey, highKey = core.LimitLowKey, core.LimitHighKey
+	}
+
+	if low, exists := data[lowKey]; exists {
+		if settings.Low, err = toFloat64(low); err != nil {
+			return settings, false, fmt.Errorf("%v: %v", lowKey, err)
+		}
+		settings.HasLow = true
+	}
+
+	if high, exists := data[highKey]; exists {
+		if settings.High, err = toFloat64(high); err != nil {
+			return settings, false, fmt.Errorf("%v: %v", highKey, err)
+		}
+		settings.HasHigh = true
+	}
+
+	if band, exists := data[hysteresisBandKey]; exists {
+		if settings.Band, err = toFloat64(band); err != nil {
+			return settings, false, fmt.Errorf("%v: %v", hysteresisBandKey, err)
+		}
+		if settings.Band < 0 {
+			return settings, false, fmt.Errorf("%v must not be negative", hysteresisBandKey)
+		}
+	}
+
+	if settings.HasLow && settings.HasHigh && settings.Low >= settings.High {
+		return settings, false, fmt.Errorf("%v must be less than %v", lowKey, highKey)
+	}
+	return settings, settings.HasLow || settings.HasHigh, nil
+}
+
+// getServiceLife gets the service life of the batteries from the device data.
+// ok is false if the device has no service life. See SnmpBatteryReplace.
+func getServiceLife(data map[string]interface{}) (serviceLife time.Duration, ok bool, err error) {
+	days, exists := data[serviceLifeDaysKey]
+	if !exists {
+		return 0, false, nil
+	}
+	daysInt, isInt := days.(int)
+	if !isInt {
+		return 0, false, fmt.Errorf(
+			"expected int for %v, got type: %T, value: %v", serviceLifeDaysKey, days, days)
+	}
+	if daysInt < 1 {
+		return 0, false, fmt.Errorf("%v must be at least 1", serviceLifeDaysKey)
+	}
+	return time.Duration(daysInt) * 24 * time.Hour, true, nil
+}
@@ -0,0 +1,106 @@
+package devices
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseDeviceSettings tests parsing per-device settings decoded from YAML.
+func TestParseDeviceSettings(t *testing.T) {
+	raw := map[interface{}]interface{}{
+		"upsOutputSource": map[interface{}]interface{}{
+			"debounceHoldTime": "10s",
+			"debounceSamples":  3,
+		},
+		"upsInputVoltage": map[interface{}]interface{}{
+			"hysteresisLow":  200,
+			"hysteresisHigh": 250.5,
+			"hysteresisBand": 5,
+		},
+	}
+
+	settings, err := ParseDeviceSettings(raw)
+	assert.NoError(t, err)
+	assert.Len(t, settings, 2)
+	assert.Equal(t, map[string]interface{}{
+		"debounce_hold_time": "10s",
+		"debounce_samples":   3,
+	}, settings["upsOutputSource"])
+
+	debounce, ok, err := getDebounceSettings(settings["upsOutputSource"])
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, debounceSettings{HoldTime: 10 * time.Second, Samples: 3}, debounce)
+
+	hysteresis, ok, err := getHysteresisSettings(settings["upsInputVoltage"])
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, hysteresisSettings{Low: 200, HasLow: true, High: 250.5, HasHigh: true, Band: 5}, hysteresis)
+}
+
+// TestParseDeviceSettingsNone tests that there may be no settings.
+func TestParseDeviceSettingsNone(t *testing.T) {
+	settings, err := ParseDeviceSettings(nil)
+	assert.NoError(t, err)
+	assert.Len(t, settings, 0)
+
+	_, ok, err := getDebounceSettings(map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, ok, err = getHysteresisSettings(map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, ok, err = getServiceLife(map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+// TestParseDeviceSettingsErrors tests bad per-device settings.
+func TestParseDeviceSettingsErrors(t *testing.T) {
+	for _, raw := range []interface{}{
+		"not a map",
+		map[string]interface{}{"upsOutputSource": "not a map"},
+		map[string]interface{}{"upsOutputSource": map[string]interface{}{"unknown": 1}},
+		map[string]interface{}{"upsOutputSource": map[string]interface{}{"debounceHoldTime": 10}},
+		map[string]interface{}{"upsOutputSource": map[string]interface{}{"debounceHoldTime": "soon"}},
+		map[string]interface{}{"upsOutputSource": map[string]interface{}{"debounceSamples": 0}},
+		map[string]interface{}{"upsInputVoltage": map[string]interface{}{"hysteresisLow": "low"}},
+		map[string]interface{}{"upsInputVoltage": map[string]interface{}{"hysteresisBand": -1}},
+		map[string]interface{}{"upsInputVoltage": map[string]interface{}{"hysteresisLow": 250, "hysteresisHigh": 200}},
+		map[string]interface{}{"xupsBatteryLastReplacedDate": map[string]interface{}{"serviceLifeDays": "4y"}},
+		map[string]interface{}{"xupsBatteryLastReplacedDate": map[string]interface{}{"serviceLifeDays": 0}},
+	} {
+		_, err := ParseDeviceSettings(raw)
+		assert.Error(t, err, raw)
+	}
+}
+
+// TestHysteresisSettingsProbeLimits tests falling back to the probe limits
+// from the agent.
+func TestHysteresisSettingsProbeLimits(t *testing.T) {
+	hysteresis, ok, err := getHysteresisSettings(map[string]interface{}{
+		"limit_low":       10,
+		"limit_high":      uint(40),
+		"hysteresis_band": 2,
+	})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, hysteresisSettings{Low: 10, HasLow: true, High: 40, HasHigh: true, Band: 2}, hysteresis)
+
+	// Configured thresholds replace the probe limits.
+	hysteresis, ok, err = getHysteresisSettings(map[string]interface{}{
+		"limit_low":      10,
+		"limit_high":     40,
+		"hysteresis_low": 45,
+	})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, hysteresisSettings{Low: 45, HasLow: true}, hysteresis)
+
+	_, _, err = getHysteresisSettings(map[string]interface{}{"limit_low": "low"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,61 @@
+package devices
+
+import (
+	"fmt"
+
+	"github.com/vapor-ware/synse-sdk/sdk"
+	"github.com/vapor-ware/synse-sdk/sdk/output"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/outputs"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// SnmpSpeed is the handler for the SNMP OIDs that report link speed, e.g.
+// ifSpeed in bits per second or ifHighSpeed in units of 1,000,000 bits per
+// second, with a multiplier.
+var SnmpSpeed = sdk.DeviceHandler{
+	Name: "speed",
+	Read: withThresholds(SnmpSpeedRead),
+}
+
+// SnmpSpeedRead is the read handler function for Synse SNMP devices that
+// report link speed. Readings are in bits per second.
+func SnmpSpeedRead(device *sdk.Device) (readings []*output.Reading, err error) {
+
+	// Get the raw reading from the SNMP server.
+	var result core.ReadResult
+	result, err = getRawReading(device)
+	if err != nil {
+		return nil, err
+	}
+
+	reading, err := makeSpeedReading(result.Data, device.Data)
+	if err != nil {
+		return nil, err
+	}
+	return []*output.Reading{reading}, nil
+}
+
+// makeSpeedReading creates the speed reading for Gauge32 data.
+func makeSpeedReading(data interface{}, deviceData map[string]interface{}) (*output.Reading, error) {
+	// Check for nil reading.
+	if data == nil {
+		return outputs.BitRate.MakeReading(nil)
+	}
+
+	// Gauge32 decodes like Counter32.
+	value, _, err := core.ToCounter(data)
+	if err != nil {
+		return nil, err
+	}
+	speed := float64(value)
+	if multiplier, ok := deviceData["multiplier"]; ok {
+		multiplierFloat, isOk := multiplier.(float32)
+		if !isOk {
+			return nil, fmt.Errorf(
+				"expected float multiplier, got type: %T, value: %v", multiplier, multiplier,
+			)
+		}
+		speed *= float64(multiplierFloat)
+	}
+	return outputs.BitRate.MakeReading(speed)
+}
@@ -9,7 +9,7 @@ import (
 // SnmpStatus is the handler for the snmp-status device.
 var SnmpStatus = sdk.DeviceHandler{
 	Name: "status",
-	Read: SnmpStatusRead,
+	Read: withDebounce(SnmpStatusRead),
 }
 
 // SnmpStatusRead is the read handler function for snmp-status devices.
@@ -29,7 +29,7 @@ func SnmpStatusRead(device *sdk.Device) (readings []*output.Reading, err error)
 	// The value we get back may need additional processing if the device reading is
 	// an enumeration. Here, we check if the device data has an enumeration defined,
 	// and if so, translate the enumeration. Otherwise, we return whatever the raw
-	// reading is.
+	// reading is. Flag strings are translated to the names of the set flags.
 	var value interface{}
 	var reading *output.Reading
 	if result.Data != nil {
@@ -38,6 +38,11 @@ func SnmpStatusRead(device *sdk.Device) (readings []*output.Reading, err error)
 			if err != nil {
 				return nil, err
 			}
+		} else if IsFlags(device.Data) {
+			value, err = TranslateFlags(result, device.Data)
+			if err != nil {
+				return nil, err
+			}
 		} else {
 			value = result.Data
 		}
@@ -9,7 +9,7 @@ import (
 // SnmpTemperature is the handler for the SNMP OIDs that report temperature.
 var SnmpTemperature = sdk.DeviceHandler{
 	Name: "temperature",
-	Read: SnmpTemperatureRead,
+	Read: withThresholds(withValidity(SnmpTemperatureRead)),
 }
 
 // SnmpTemperatureRead is the read handler function for synse SNMP devices that report temperature.
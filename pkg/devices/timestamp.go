@@ -0,0 +1,63 @@
+package devices
+
+import (
+	"github.com/vapor-ware/synse-sdk/sdk"
+	"github.com/vapor-ware/synse-sdk/sdk/output"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// SnmpTimestamp is the handler for the SNMP OIDs that report a TimeStamp.
+var SnmpTimestamp = sdk.DeviceHandler{
+	Name: "timestamp",
+	Read: SnmpTimestampRead,
+}
+
+// SnmpTimestampRead is the read handler function for Synse SNMP devices that
+// report a TimeStamp. A TimeStamp is the value of sysUpTime at the time of an
+// event, so sysUpTime is read in the same request and the reading is the
+// RFC3339 wall-clock time of the event. The reading is nil when the time of
+// the event is unknown.
+func SnmpTimestampRead(device *sdk.Device) (readings []*output.Reading, err error) {
+
+	// Get the raw reading and sysUpTime from the SNMP server.
+	result, sysUpTime, now, err := getRawReadingWithUpTime(device)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for nil reading.
+	var reading *output.Reading
+	if result.Data == nil {
+		reading, err = output.Timestamp.MakeReading(nil)
+		if err != nil {
+			return nil, err
+		}
+		readings = []*output.Reading{reading}
+		return
+	}
+
+	// A TimeStamp from before a wrap of sysUpTime can only be converted once
+	// the wrap has been seen.
+	var wrapped bool
+	if sysUpTime.Data != nil {
+		if upTime, err := core.ToTicks(sysUpTime.Data); err == nil {
+			_, wrapped = recordUpTime(deviceKey(device), upTime, now)
+		}
+	}
+	timestamp, err := core.FormatTimeStamp(result.Data, sysUpTime.Data, now, wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the reading.
+	var value interface{}
+	if timestamp != "" {
+		value = timestamp
+	}
+	reading, err = output.Timestamp.MakeReading(value)
+	if err != nil {
+		return nil, err
+	}
+	readings = []*output.Reading{reading}
+	return
+}
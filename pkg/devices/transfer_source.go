@@ -0,0 +1,48 @@
+package devices
+
+import (
+	"github.com/vapor-ware/synse-sdk/sdk"
+)
+
+// SnmpTransferSource is the handler for the preferred source of an automatic
+// transfer switch (ATS). Reads are the same as for status devices, e.g.
+// sourceA.
+//
+// Changing the preferred source transfers the load when both sources are
+// good, so writes are guarded as for outlets:
+//   - The agent must opt in with transferSourceControl in the dynamic
+//     registration config. See ParseTransferSourceControl.
+//   - The write data must be "confirm", e.g. {"action": "sourceB", "data": "confirm"}.
+//   - Writes to the same switch must be at least the cooldown apart.
+var SnmpTransferSource = sdk.DeviceHandler{
+	Name:  "transfer-source",
+	Read:  withDebounce(SnmpStatusRead),
+	Write: SnmpTransferSourceWrite,
+}
+
+// The device data keys for preferred source control. The agent settings are
+// shimmed in from the dynamic registration config.
+const (
+	transferSourceControlKey  = "transfer_source_control"  // bool
+	transferSourceCooldownKey = "transfer_source_cooldown" // Duration string.
+)
+
+// transferSourceGuard guards the writes to the preferred source.
+var transferSourceGuard = newWriteGuard(
+	"transfer source", "transferSourceControl", transferSourceControlKey, transferSourceCooldownKey)
+
+// ParseTransferSourceControl parses the preferred source control settings
+// from the dynamic registration config into device data for the
+// transfer-source devices of the agent. Both are optional:
+//
+//	transferSourceControl: Whether the preferred source may be changed. Default false.
+//	transferSourceControlCooldown: Minimum time between writes to a switch. Default 1m.
+func ParseTransferSourceControl(data map[string]interface{}) (settings map[string]interface{}, err error) {
+	return transferSourceGuard.parse(data)
+}
+
+// SnmpTransferSourceWrite is the write handler function for transfer-source
+// devices. The write action is the name of the source, e.g. sourceA.
+func SnmpTransferSourceWrite(device *sdk.Device, data *sdk.WriteData) error {
+	return transferSourceGuard.write(device, data)
+}
@@ -0,0 +1,109 @@
+package devices
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/vapor-ware/synse-sdk/sdk"
+	"github.com/vapor-ware/synse-sdk/sdk/output"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// SnmpUptime is the handler for sysUpTime, the time since the agent was last
+// re-initialized.
+var SnmpUptime = sdk.DeviceHandler{
+	Name: "uptime",
+	Read: SnmpUptimeRead,
+}
+
+// upTimeReading is the last reading of sysUpTime for a device.
+type upTimeReading struct {
+	sysUpTime uint32
+	now       time.Time
+	wrapped   bool // sysUpTime wrapped since the agent was re-initialized.
+}
+
+// upTimes hold the last reading of sysUpTime for each device, so agent
+// reboots and sysUpTime wraps can be detected between readings.
+var (
+	upTimes      = map[string]upTimeReading{}
+	upTimesMutex sync.Mutex
+)
+
+// rebooted records a reading of sysUpTime for key and is true if the agent
+// was re-initialized since the last reading.
+func rebooted(key string, sysUpTime uint32, now time.Time) bool {
+	rebooted, _ := recordUpTime(key, sysUpTime, now)
+	return rebooted
+}
+
+// recordUpTime records a reading of sysUpTime for key. rebooted is true if the
+// agent was re-initialized since the last reading. wrapped is true if
+// sysUpTime is known to have wrapped since the agent was re-initialized, which
+// is only the case when a wrap was seen between two readings. See
+// core.TimeStampToTime.
+func recordUpTime(key string, sysUpTime uint32, now time.Time) (rebooted bool, wrapped bool) {
+	upTimesMutex.Lock()
+	defer upTimesMutex.Unlock()
+
+	previous, ok := upTimes[key]
+	if ok {
+		rebooted = core.Rebooted(previous.sysUpTime, previous.now, sysUpTime, now)
+		wrapped = !rebooted &&
+			(previous.wrapped || core.Wrapped(previous.sysUpTime, previous.now, sysUpTime, now))
+	}
+	upTimes[key] = upTimeReading{sysUpTime: sysUpTime, now: now, wrapped: wrapped}
+	return rebooted, wrapped
+}
+
+// SnmpUptimeRead is the read handler function for sysUpTime. The reading is
+// the uptime in seconds. The context has the uptime as a duration, the time
+// the agent booted, and whether the agent rebooted since the last reading.
+func SnmpUptimeRead(device *sdk.Device) (readings []*output.Reading, err error) {
+
+	// Get the raw reading from the SNMP server.
+	now := time.Now()
+	var result core.ReadResult
+	result, err = getRawReading(device)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for nil reading.
+	var reading *output.Reading
+	if result.Data == nil {
+		reading, err = output.Seconds.MakeReading(nil)
+		if err != nil {
+			return nil, err
+		}
+		readings = []*output.Reading{reading}
+		return
+	}
+
+	reading, err = makeUptimeReading(deviceKey(device), result.Data, now)
+	if err != nil {
+		return nil, err
+	}
+	readings = []*output.Reading{reading}
+	return
+}
+
+// makeUptimeReading creates the reading for sysUpTime data read at now.
+func makeUptimeReading(key string, data interface{}, now time.Time) (*output.Reading, error) {
+	sysUpTime, err := core.ToTicks(data)
+	if err != nil {
+		return nil, fmt.Errorf("sysUpTime: %v", err)
+	}
+	upTime := core.UpTimeDuration(sysUpTime)
+
+	reading, err := output.Seconds.MakeReading(upTime.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	return reading.WithContext(map[string]string{
+		"duration": upTime.String(),
+		"bootTime": core.BootTime(sysUpTime, now).UTC().Truncate(time.Second).Format(time.RFC3339),
+		"rebooted": fmt.Sprint(rebooted(key, sysUpTime, now)),
+	}), nil
+}
@@ -0,0 +1,67 @@
+package devices
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMakeUptimeReading tests the sysUpTime reading and reboot detection.
+func TestMakeUptimeReading(t *testing.T) {
+	key := "uptime-test:161/.1.3.6.1.2.1.1.3.0"
+	now := debounceTestNow
+
+	// 1 day, 2 hours and 3.5 seconds.
+	reading, err := makeUptimeReading(key, uint32(9360350), now)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(93603.5), reading.Value)
+	assert.Equal(t, "seconds", reading.Unit.Name)
+	assert.Equal(t, map[string]string{
+		"duration": "26h0m3.5s",
+		"bootTime": "2021-03-03T09:59:56Z",
+		"rebooted": "false",
+	}, reading.Context)
+
+	// A minute later, the agent has been up a minute longer.
+	reading, err = makeUptimeReading(key, 9366350, now.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, "false", reading.Context["rebooted"])
+
+	// Another minute later, the agent has been up for 30 seconds.
+	reading, err = makeUptimeReading(key, int64(3000), now.Add(2*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, float64(30), reading.Value)
+	assert.Equal(t, "true", reading.Context["rebooted"])
+	assert.Equal(t, "2021-03-04T12:01:30Z", reading.Context["bootTime"])
+
+	_, err = makeUptimeReading(key, "3000", now)
+	assert.Error(t, err)
+}
+
+// TestRecordUpTimeWrapped tests that sysUpTime wraps are only known once seen
+// between readings, and are forgotten when the agent reboots.
+func TestRecordUpTimeWrapped(t *testing.T) {
+	key := "uptime-test:161/.1.3.6.1.2.1.1.3.0/wrap"
+	now := debounceTestNow
+
+	rebooted, wrapped := recordUpTime(key, math.MaxUint32-10000, now)
+	assert.False(t, rebooted)
+	assert.False(t, wrapped)
+
+	// Wrapped, up for five more minutes.
+	rebooted, wrapped = recordUpTime(key, 19999, now.Add(5*time.Minute))
+	assert.False(t, rebooted)
+	assert.True(t, wrapped)
+
+	// Still known to have wrapped.
+	rebooted, wrapped = recordUpTime(key, 49999, now.Add(10*time.Minute))
+	assert.False(t, rebooted)
+	assert.True(t, wrapped)
+
+	// Rebooted.
+	rebooted, wrapped = recordUpTime(key, 3000, now.Add(15*time.Minute))
+	assert.True(t, rebooted)
+	assert.False(t, wrapped)
+}
@@ -0,0 +1,68 @@
+package devices
+
+import (
+	"fmt"
+
+	"github.com/vapor-ware/synse-sdk/sdk"
+	"github.com/vapor-ware/synse-sdk/sdk/output"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// withValidity wraps the read function of a numeric device. When the device
+// data has a validity_oid, e.g. entPhySensorOperStatus for an entity sensor,
+// it is read along with the device. Each reading gets a validity context from
+// the validityN enumeration in the data, and readings are nil unless the
+// validity is the validity_ok value.
+func withValidity(read func(*sdk.Device) ([]*output.Reading, error)) func(*sdk.Device) ([]*output.Reading, error) {
+	return func(device *sdk.Device) ([]*output.Reading, error) {
+		readings, err := read(device)
+		if err != nil {
+			return nil, err
+		}
+
+		validityOid, ok := device.Data["validity_oid"]
+		if !ok {
+			return readings, nil
+		}
+		snmpClient, err := newSnmpClient(device)
+		if err != nil {
+			return nil, err
+		}
+		result, err := snmpClient.Get(fmt.Sprint(validityOid))
+		if err != nil {
+			return nil, err
+		}
+		return applyValidity(readings, result, device.Data)
+	}
+}
+
+// applyValidity applies the validity of the readings of a device, read from
+// its validity_oid. See withValidity.
+func applyValidity(readings []*output.Reading, result core.ReadResult, data map[string]interface{}) ([]*output.Reading, error) {
+	var validity string
+	valid := false
+	if result.Data != nil {
+		status, ok := result.Data.(int)
+		if !ok {
+			return nil, fmt.Errorf(
+				"expected int validity, got type: %T, value: %v", result.Data, result.Data)
+		}
+		validity = fmt.Sprintf("undefined%d", status)
+		if name, ok := data[fmt.Sprintf("validity%d", status)]; ok {
+			validity = fmt.Sprint(name)
+		}
+		valid = fmt.Sprint(status) == fmt.Sprint(data["validity_ok"])
+	}
+
+	for _, reading := range readings {
+		if !valid {
+			reading.Value = nil
+		}
+		if validity != "" {
+			reading.WithContext(map[string]string{
+				"validity": validity,
+			})
+		}
+	}
+	return readings, nil
+}
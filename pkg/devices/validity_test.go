@@ -0,0 +1,49 @@
+package devices
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-sdk/sdk/output"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// TestApplyValidity tests reading validity from entPhySensorOperStatus.
+func TestApplyValidity(t *testing.T) {
+	data := map[string]interface{}{
+		"validity_oid": ".1.3.6.1.2.1.99.1.1.1.5.10",
+		"validity_ok":  "1",
+		"validity1":    "ok",
+		"validity2":    "unavailable",
+		"validity3":    "nonoperational",
+	}
+	newReadings := func() []*output.Reading {
+		reading, err := output.Temperature.MakeReading(float32(23.5))
+		assert.NoError(t, err)
+		return []*output.Reading{reading}
+	}
+
+	readings, err := applyValidity(newReadings(), core.ReadResult{Data: 1}, data)
+	assert.NoError(t, err)
+	assert.Equal(t, float32(23.5), readings[0].Value)
+	assert.Equal(t, "ok", readings[0].Context["validity"])
+
+	readings, err = applyValidity(newReadings(), core.ReadResult{Data: 3}, data)
+	assert.NoError(t, err)
+	assert.Nil(t, readings[0].Value)
+	assert.Equal(t, "nonoperational", readings[0].Context["validity"])
+
+	readings, err = applyValidity(newReadings(), core.ReadResult{Data: 7}, data)
+	assert.NoError(t, err)
+	assert.Nil(t, readings[0].Value)
+	assert.Equal(t, "undefined7", readings[0].Context["validity"])
+
+	// The agent does not serve the validity.
+	readings, err = applyValidity(newReadings(), core.ReadResult{}, data)
+	assert.NoError(t, err)
+	assert.Nil(t, readings[0].Value)
+	assert.NotContains(t, readings[0].Context, "validity")
+
+	_, err = applyValidity(newReadings(), core.ReadResult{Data: "ok"}, data)
+	assert.Error(t, err)
+}
@@ -9,7 +9,7 @@ import (
 // SnmpVoltage is the handler for the SNMP OIDs that report voltage.
 var SnmpVoltage = sdk.DeviceHandler{
 	Name: "voltage",
-	Read: SnmpVoltageRead,
+	Read: withThresholds(withValidity(SnmpVoltageRead)),
 }
 
 // SnmpVoltageRead is the read handler function for synse SNMP devices that report voltage.
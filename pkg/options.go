@@ -6,6 +6,8 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	"github.com/vapor-ware/synse-sdk/sdk/config"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/alarms"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/devices"
 	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
 	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/servers"
 )
@@ -47,10 +49,9 @@ func mapOidsToInstances(deviceProtos []*config.DeviceProto) (oidMap map[string]*
 }
 
 // deviceIdentifier defines the SNMP-specific way of uniquely identifying a
-// device through its device configuration.
-// TODO: This will work for the initial cut. This may change later if/when
-// we need to support the entity mib and entity sensor mib where joins may be
-// required.
+// device through its device configuration. Devices from joined tables (see
+// core.TableJoin), e.g. the entity sensor mib joined to the entity mib, are
+// still read from a single column, so the OID remains unique.
 func deviceIdentifier(data map[string]interface{}) string {
 	return fmt.Sprint(data["oid"])
 }
@@ -65,6 +66,37 @@ func deviceEnumerator(data map[string]interface{}) (deviceConfigs []*config.Devi
 		os.Exit(1)
 	}
 	log.Info("[snmp] UPS initialized")
+	snmpServer.LogSupport()
+
+	// Set up alarm tracking for the agent.
+	if err := configureAlarms(snmpServer, data); err != nil {
+		log.WithError(err).Error("[snmp] failed to configure alarm tracking")
+		return nil, err
+	}
+
+	// Shim in any per-device settings.
+	if err := applyDeviceSettings(snmpServer.DeviceConfigs, data["deviceSettings"]); err != nil {
+		log.WithError(err).Error("[snmp] failed to apply device settings")
+		return nil, err
+	}
+
+	// Shim in whether the outlets of the agent may be switched.
+	if err := applyOutletControl(snmpServer.DeviceConfigs, data); err != nil {
+		log.WithError(err).Error("[snmp] failed to apply outlet control")
+		return nil, err
+	}
+
+	// Shim in whether the preferred source of transfer switches may be changed.
+	if err := applyTransferSourceControl(snmpServer.DeviceConfigs, data); err != nil {
+		log.WithError(err).Error("[snmp] failed to apply transfer source control")
+		return nil, err
+	}
+
+	// Shim in whether the setpoints of cooling units may be changed.
+	if err := applySetpointControl(snmpServer.DeviceConfigs, data); err != nil {
+		log.WithError(err).Error("[snmp] failed to apply setpoint control")
+		return nil, err
+	}
 
 	// First get a map of each OID to each device instance.
 	oidMap, oidList, err := mapOidsToInstances(snmpServer.DeviceConfigs)
@@ -94,3 +126,134 @@ func deviceEnumerator(data map[string]interface{}) (deviceConfigs []*config.Devi
 	core.DumpDeviceConfigs(snmpServer.DeviceConfigs)
 	return snmpServer.DeviceConfigs, nil
 }
+
+// configureAlarms sets up the alarm tracker for the SNMP agent from the
+// dynamic registration configuration. All of these are optional:
+//
+//	alarmHistorySize: The number of cleared alarms to keep. Default 100.
+//	alarmHistoryFile: A JSON lines file to persist cleared alarms to.
+//	trapAddress: Address to listen for alarm traps on, e.g. 0.0.0.0:162.
+func configureAlarms(snmpServer *servers.SnmpServer, data map[string]interface{}) error {
+	historySize := alarms.DefaultHistorySize
+	if size, ok := data["alarmHistorySize"]; ok {
+		sizeInt, ok := size.(int)
+		if !ok {
+			return fmt.Errorf("alarmHistorySize should be an int, %T, %+v", size, size)
+		}
+		historySize = sizeInt
+	}
+
+	var historyFile string
+	if file, ok := data["alarmHistoryFile"]; ok {
+		historyFile, ok = file.(string)
+		if !ok {
+			return fmt.Errorf("alarmHistoryFile should be a string, %T, %+v", file, file)
+		}
+	}
+
+	deviceConfig := snmpServer.DeviceConfig
+	agent := alarms.AgentKey(deviceConfig.Endpoint, deviceConfig.Port)
+	if _, err := alarms.ConfigureTracker(agent, historySize, historyFile); err != nil {
+		return err
+	}
+
+	address, ok := data["trapAddress"]
+	if !ok {
+		return nil
+	}
+	trapAddress, ok := address.(string)
+	if !ok {
+		return fmt.Errorf("trapAddress should be a string, %T, %+v", address, address)
+	}
+
+	params, err := snmpServer.SnmpClient.TrapParams()
+	if err != nil {
+		return err
+	}
+	if err := alarms.ListenForTraps(trapAddress, params); err != nil {
+		return err
+	}
+	return alarms.RegisterTrapAgent(deviceConfig.Endpoint, agent)
+}
+
+// applyDeviceSettings shims the per-device settings from the dynamic
+// registration configuration into the device instance data. Settings are
+// keyed by device info. See devices.ParseDeviceSettings.
+func applyDeviceSettings(deviceProtos []*config.DeviceProto, rawSettings interface{}) error {
+	settings, err := devices.ParseDeviceSettings(rawSettings)
+	if err != nil {
+		return err
+	}
+
+	for _, proto := range deviceProtos {
+		for _, instance := range proto.Instances {
+			deviceSettings, ok := settings[instance.Info]
+			if !ok {
+				continue
+			}
+			instance.Data, err = core.MergeMapStringInterface(instance.Data, deviceSettings)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// applyOutletControl shims the outlet control settings from the dynamic
+// registration configuration into the data of each outlet device. Outlets
+// may only be switched when the agent opts in. See
+// devices.ParseOutletControl.
+func applyOutletControl(deviceProtos []*config.DeviceProto, data map[string]interface{}) error {
+	settings, err := devices.ParseOutletControl(data)
+	if err != nil {
+		return err
+	}
+	return applyControl(deviceProtos, devices.SnmpOutlet.Name, settings)
+}
+
+// applyTransferSourceControl shims the preferred source control settings
+// from the dynamic registration configuration into the data of each
+// transfer-source device. The preferred source of a transfer switch may only
+// be changed when the agent opts in. See devices.ParseTransferSourceControl.
+func applyTransferSourceControl(deviceProtos []*config.DeviceProto, data map[string]interface{}) error {
+	settings, err := devices.ParseTransferSourceControl(data)
+	if err != nil {
+		return err
+	}
+	return applyControl(deviceProtos, devices.SnmpTransferSource.Name, settings)
+}
+
+// applySetpointControl shims the setpoint control settings from the dynamic
+// registration configuration into the data of each temperature-setpoint and
+// humidity-setpoint device. The setpoints of a cooling unit may only be
+// changed when the agent opts in. See devices.ParseSetpointControl.
+func applySetpointControl(deviceProtos []*config.DeviceProto, data map[string]interface{}) error {
+	settings, err := devices.ParseSetpointControl(data)
+	if err != nil {
+		return err
+	}
+	for _, deviceType := range []string{devices.SnmpTemperatureSetpoint.Name, devices.SnmpHumiditySetpoint.Name} {
+		if err = applyControl(deviceProtos, deviceType, settings); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyControl shims control settings into the data of each device of a
+// type.
+func applyControl(deviceProtos []*config.DeviceProto, deviceType string, settings map[string]interface{}) (err error) {
+	for _, proto := range deviceProtos {
+		if proto.Type != deviceType {
+			continue
+		}
+		for _, instance := range proto.Instances {
+			instance.Data, err = core.MergeMapStringInterface(instance.Data, settings)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
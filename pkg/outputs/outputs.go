@@ -19,4 +19,52 @@ var (
 		Name: "identity",
 		Type: "identity",
 	}
+
+	// BitRate describes readings with link speed outputs, in bits per second.
+	BitRate = output.Output{
+		Name:      "bits-per-second",
+		Type:      "speed",
+		Precision: 0,
+		Unit: &output.Unit{
+			Name:   "bits per second",
+			Symbol: "bit/s",
+		},
+	}
+
+	// ByteRate describes readings with traffic rate outputs, in bytes
+	// (octets) per second.
+	ByteRate = output.Output{
+		Name:      "bytes-per-second",
+		Type:      "rate",
+		Precision: 2,
+		Unit: &output.Unit{
+			Name:   "bytes per second",
+			Symbol: "B/s",
+		},
+	}
+
+	// ErrorRate describes readings with error rate outputs, in errors per
+	// second.
+	ErrorRate = output.Output{
+		Name:      "errors-per-second",
+		Type:      "rate",
+		Precision: 3,
+		Unit: &output.Unit{
+			Name:   "errors per second",
+			Symbol: "errors/s",
+		},
+	}
+
+	// RelativeHumidity describes readings with relative humidity outputs, in
+	// percent. It is named apart from the SDK humidity output so that the two
+	// may both be registered.
+	RelativeHumidity = output.Output{
+		Name:      "relative-humidity",
+		Type:      "humidity",
+		Precision: 1,
+		Unit: &output.Unit{
+			Name:   "percent relative humidity",
+			Symbol: "%RH",
+		},
+	}
 )
@@ -21,7 +21,11 @@ func MakePlugin() *sdk.Plugin {
 
 	// Register custom output types.
 	err = plugin.RegisterOutputs(
+		&outputs.BitRate,
+		&outputs.ByteRate,
+		&outputs.ErrorRate,
 		&outputs.Identity,
+		&outputs.RelativeHumidity,
 		&outputs.VAPower,
 	)
 	if err != nil {
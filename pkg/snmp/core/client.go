@@ -282,10 +282,34 @@ func (d *DeviceConfig) ToMap() (m map[string]interface{}, err error) {
 	return m, nil
 }
 
+// Transport is a connection to an SNMP agent. SnmpClient opens one for each
+// request and closes it when done. The connections to agents are gosnmp
+// connections, and tests may serve canned results instead.
+type Transport interface {
+	Get(oids []string) (*gosnmp.SnmpPacket, error)
+	BulkWalkAll(rootOid string) ([]gosnmp.SnmpPDU, error)
+	WalkAll(rootOid string) ([]gosnmp.SnmpPDU, error)
+	Set(pdus []gosnmp.SnmpPDU) (*gosnmp.SnmpPacket, error)
+	Close() error
+}
+
+// goSnmpTransport is the Transport of a connected gosnmp.GoSNMP.
+type goSnmpTransport struct {
+	*gosnmp.GoSNMP
+}
+
+// Close closes the connection.
+func (transport goSnmpTransport) Close() error {
+	return transport.Conn.Close()
+}
+
 // SnmpClient is a thin wrapper around gosnmp.
 type SnmpClient struct {
 	DeviceConfig *DeviceConfig
 	SupportBulk  bool
+	// Opens the Transport for each request. When nil, a gosnmp connection
+	// to the agent in the DeviceConfig is opened.
+	Dial func() (Transport, error)
 }
 
 // NewSnmpClient constructs SnmpClient.
@@ -306,22 +330,35 @@ type ReadResult struct {
 	Data interface{} // The data for the OID. See gosnmp decodeValue() https://github.com/gosnmp/gosnmp/blob/6cf8f245c42ae575709cd3e0c880abb7c861595a/helper.go#L59
 }
 
-// Get performs an SNMP get on the given OID.
+// Get performs an SNMP get on the given OID. The OID may be a registered
+// name, see OidNames.
 func (client *SnmpClient) Get(oid string) (result ReadResult, err error) {
 
-	goSnmp, err := client.createGoSNMP()
+	oid, err = OidNames.Oid(oid)
 	if err != nil {
 		return result, err
 	}
+	if log.IsLevelEnabled(log.DebugLevel) {
+		log.Debugf("[snmp] get %v", OidNames.Format(oid))
+	}
 
-	snmpPacket, err := goSnmp.Get([]string{oid})
+	transport, err := client.open()
 	if err != nil {
 		return result, err
 	}
+
+	// A failure to close does not hide the result of the request.
 	defer func() {
-		err = goSnmp.Conn.Close()
+		if closeErr := transport.Close(); err == nil {
+			err = closeErr
+		}
 	}()
 
+	snmpPacket, err := transport.Get([]string{oid})
+	if err != nil {
+		return result, err
+	}
+
 	data := snmpPacket.Variables[0]
 
 	// If it looks like an ASCII string, try to translate it.
@@ -337,38 +374,113 @@ func (client *SnmpClient) Get(oid string) (result ReadResult, err error) {
 	return ReadResult{
 		Oid:  data.Name,
 		Data: data.Value,
-	}, err
+	}, nil
+}
+
+// GetMany performs an SNMP get on the given OIDs in a single request. This
+// is useful when values must be read together, e.g. a TimeStamp and the
+// sysUpTime it is relative to. Results are in the same order as the OIDs.
+// The OIDs may be registered names, see OidNames.
+func (client *SnmpClient) GetMany(oids []string) (results []ReadResult, err error) {
+
+	numericOids := make([]string, len(oids))
+	for i, oid := range oids {
+		if numericOids[i], err = OidNames.Oid(oid); err != nil {
+			return nil, err
+		}
+		if log.IsLevelEnabled(log.DebugLevel) {
+			log.Debugf("[snmp] get %v", OidNames.Format(numericOids[i]))
+		}
+	}
+	oids = numericOids
+
+	transport, err := client.open()
+	if err != nil {
+		return nil, err
+	}
+
+	// A failure to close does not hide the result of the request.
+	defer func() {
+		if closeErr := transport.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	snmpPacket, err := transport.Get(oids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, data := range snmpPacket.Variables {
+
+		// If it looks like an ASCII string, try to translate it.
+		if data.Type == gosnmp.OctetString {
+			ascii, err := TranslatePrintableASCII(data.Value)
+			if err == nil {
+				data.Value = ascii
+			}
+			// err above is deliberately ignored here. SNMP does not differentiate
+			// between ASCII strings and byte array.
+		}
+
+		results = append(results, ReadResult{
+			Oid:  data.Name,
+			Data: data.Value,
+		})
+	}
+	return results, nil
 }
 
-// Walk performs an SNMP bulk walk on the given OID.
+// Walk performs an SNMP bulk walk on the given OID. The OID may be a
+// registered name, see OidNames.
 func (client *SnmpClient) Walk(rootOid string) (results []ReadResult, err error) {
 
-	goSnmp, err := client.createGoSNMP()
+	rootOid, err = OidNames.Oid(rootOid)
 	if err != nil {
 		return nil, err
 	}
+	if log.IsLevelEnabled(log.DebugLevel) {
+		log.Debugf("[snmp] walk %v", OidNames.Format(rootOid))
+	}
 
-	var resultSet []gosnmp.SnmpPDU
+	transport, err := client.open()
+	if err != nil {
+		return nil, err
+	}
+
+	// A failure to close does not hide the result of the request.
+	defer func() {
+		if closeErr := transport.Close(); err == nil {
+			err = closeErr
+		}
+	}()
 
+	// Agents which do not support GETBULK, e.g. SNMPv1 agents, are walked
+	// with GETNEXT from then on.
+	var resultSet []gosnmp.SnmpPDU
 	if client.SupportBulk {
-		resultSet, err = goSnmp.BulkWalkAll(rootOid)
+		resultSet, err = transport.BulkWalkAll(rootOid)
 		if err != nil {
 			client.SupportBulk = false
 		}
-	} else {
-		resultSet, err = goSnmp.WalkAll(rootOid)
+	}
+	if !client.SupportBulk {
+		resultSet, err = transport.WalkAll(rootOid)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	defer func() {
-		err = goSnmp.Conn.Close()
-	}()
-
 	// Package results.
 	for _, snmpPdu := range resultSet {
 
+		// A walk of a subtree the agent does not implement may end in the
+		// exception from a get of the root OID. It is an empty walk.
+		if snmpPdu.Type == gosnmp.NoSuchObject || snmpPdu.Type == gosnmp.NoSuchInstance ||
+			snmpPdu.Type == gosnmp.EndOfMibView {
+			continue
+		}
+
 		// If it looks like an ASCII string, try to translate it.
 		if snmpPdu.Type == gosnmp.OctetString {
 			ascii, err := TranslatePrintableASCII(snmpPdu.Value)
@@ -384,13 +496,88 @@ func (client *SnmpClient) Walk(rootOid string) (results []ReadResult, err error)
 			Data: snmpPdu.Value,
 		})
 	}
-	return results, err
+	return results, nil
+}
+
+// SetInteger performs an SNMP set of an INTEGER value on the given OID, e.g.
+// to control an outlet. The OID may be a registered name, see OidNames. An
+// error status from the agent, e.g. noAccess(6) when the community or user is
+// read only, is an error.
+func (client *SnmpClient) SetInteger(oid string, value int) (err error) {
+
+	oid, err = OidNames.Oid(oid)
+	if err != nil {
+		return err
+	}
+	if log.IsLevelEnabled(log.DebugLevel) {
+		log.Debugf("[snmp] set %v = %d", OidNames.Format(oid), value)
+	}
+
+	transport, err := client.open()
+	if err != nil {
+		return err
+	}
+
+	// A failure to close does not hide the result of the request.
+	defer func() {
+		if closeErr := transport.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	snmpPacket, err := transport.Set([]gosnmp.SnmpPDU{{
+		Name:  oid,
+		Type:  gosnmp.Integer,
+		Value: value,
+	}})
+	if err != nil {
+		return err
+	}
+	if snmpPacket.Error != gosnmp.NoError {
+		return fmt.Errorf("set %v failed: %v", OidNames.Format(oid), snmpPacket.Error)
+	}
+	return nil
+}
+
+// TrapParams creates the gosnmp parameters for a trap listener which receives
+// traps from the SNMP server. There is no connection.
+func (client *SnmpClient) TrapParams() (*gosnmp.GoSNMP, error) {
+	return client.newGoSNMP()
+}
+
+// open opens the Transport for a request.
+func (client *SnmpClient) open() (Transport, error) {
+	if client.Dial != nil {
+		return client.Dial()
+	}
+	goSnmp, err := client.createGoSNMP()
+	if err != nil {
+		return nil, err
+	}
+	return goSnmpTransport{goSnmp}, nil
 }
 
 // createGoSNMP is a helper to create gosnmp.GoSNMP from SnmpClient.
 // On success, the connection is open.
 func (client *SnmpClient) createGoSNMP() (*gosnmp.GoSNMP, error) {
 
+	goSnmp, err := client.newGoSNMP()
+	if err != nil {
+		return nil, err
+	}
+
+	// Connect
+	err = goSnmp.Connect()
+	if err != nil {
+		log.Error("gosnmp failed to connect")
+		return nil, fmt.Errorf("failed to connect gosnmp: %+v", err)
+	}
+	return goSnmp, err
+}
+
+// newGoSNMP is a helper to map SnmpClient parameters to gosnmp.GoSNMP.
+func (client *SnmpClient) newGoSNMP() (*gosnmp.GoSNMP, error) {
+
 	// Argument checks
 	if client == nil {
 		return nil, fmt.Errorf("client is nil")
@@ -438,12 +625,5 @@ func (client *SnmpClient) createGoSNMP() (*gosnmp.GoSNMP, error) {
 		ContextName: client.DeviceConfig.ContextName,
 		Retries:     client.DeviceConfig.Retries,
 	}
-
-	// Connect
-	err := goSnmp.Connect()
-	if err != nil {
-		log.Error("gosnmp failed to connect")
-		return nil, fmt.Errorf("failed to connect gosnmp: %+v", err)
-	}
-	return goSnmp, err
+	return goSnmp, nil
 }
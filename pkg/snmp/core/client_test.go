@@ -1,6 +1,7 @@
 package core
 
 import (
+	"fmt"
 	"github.com/gosnmp/gosnmp"
 	"testing"
 	"time"
@@ -349,3 +350,127 @@ func TestClientTrippliteUps(t *testing.T) {
 	// This will be different from the PxgmsUps because the data are different.
 	assert.Equal(t, 347, len(results))
 }
+
+// setTransport is a Transport which answers sets with an error status.
+type setTransport struct {
+	errorStatus gosnmp.SNMPError
+	closeErr    error
+}
+
+func (transport setTransport) Get(oids []string) (*gosnmp.SnmpPacket, error) {
+	return &gosnmp.SnmpPacket{}, nil
+}
+
+func (transport setTransport) BulkWalkAll(rootOid string) ([]gosnmp.SnmpPDU, error) {
+	return nil, nil
+}
+
+func (transport setTransport) WalkAll(rootOid string) ([]gosnmp.SnmpPDU, error) {
+	return nil, nil
+}
+
+func (transport setTransport) Set(pdus []gosnmp.SnmpPDU) (*gosnmp.SnmpPacket, error) {
+	return &gosnmp.SnmpPacket{Error: transport.errorStatus, Variables: pdus}, nil
+}
+
+func (transport setTransport) Close() error {
+	return transport.closeErr
+}
+
+// TestSetIntegerErrorStatus tests that sets the agent rejects are errors.
+func TestSetIntegerErrorStatus(t *testing.T) {
+	securityParameters, err := NewSecurityParameters("simulator", SHA, "auctoritas", AES, "privatus")
+	assert.NoError(t, err)
+	deviceConfig, err := NewDeviceConfig("v3", "127.0.0.1", 1024, securityParameters, "public", []string{})
+	assert.NoError(t, err)
+	client, err := NewSnmpClient(deviceConfig)
+	assert.NoError(t, err)
+
+	for _, test := range []struct {
+		transport setTransport
+		ok        bool
+	}{
+		{setTransport{errorStatus: gosnmp.NoError}, true},
+		{setTransport{errorStatus: gosnmp.NoAccess}, false},
+		{setTransport{errorStatus: gosnmp.WrongValue}, false},
+		{setTransport{errorStatus: gosnmp.NotWritable}, false},
+		// Failing to close after a good set is an error too.
+		{setTransport{errorStatus: gosnmp.NoError, closeErr: fmt.Errorf("closed")}, false},
+	} {
+		transport := test.transport
+		client.Dial = func() (Transport, error) { return transport, nil }
+		err = client.SetInteger(".1.3.6.1.4.1.534.1.12.2.1.3.1", 0)
+		if test.ok {
+			assert.NoError(t, err, test)
+		} else {
+			assert.Error(t, err, test)
+		}
+	}
+}
+
+// walkTransport is a Transport which answers walks with a result set, or
+// fails them.
+type walkTransport struct {
+	setTransport
+	bulkErr   error
+	walkErr   error
+	resultSet []gosnmp.SnmpPDU
+}
+
+func (transport walkTransport) BulkWalkAll(rootOid string) ([]gosnmp.SnmpPDU, error) {
+	if transport.bulkErr != nil {
+		return nil, transport.bulkErr
+	}
+	return transport.resultSet, nil
+}
+
+func (transport walkTransport) WalkAll(rootOid string) ([]gosnmp.SnmpPDU, error) {
+	if transport.walkErr != nil {
+		return nil, transport.walkErr
+	}
+	return transport.resultSet, nil
+}
+
+// TestWalkErrors tests that walk errors are not lost, and that a walk of a
+// subtree the agent does not implement is empty.
+func TestWalkErrors(t *testing.T) {
+	securityParameters, err := NewSecurityParameters("simulator", SHA, "auctoritas", AES, "privatus")
+	assert.NoError(t, err)
+	deviceConfig, err := NewDeviceConfig("v3", "127.0.0.1", 1024, securityParameters, "public", []string{})
+	assert.NoError(t, err)
+	client, err := NewSnmpClient(deviceConfig)
+	assert.NoError(t, err)
+
+	resultSet := []gosnmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.33.1.1.1.0", Type: gosnmp.OctetString, Value: []byte("Eaton")},
+	}
+	dial := func(transport walkTransport) func() (Transport, error) {
+		return func() (Transport, error) { return transport, nil }
+	}
+
+	// A failed bulk walk falls back to GETNEXT.
+	client.SupportBulk = true
+	client.Dial = dial(walkTransport{bulkErr: fmt.Errorf("bulk"), resultSet: resultSet})
+	results, err := client.Walk(".1.3.6.1.2.1.33.1.1")
+	assert.NoError(t, err)
+	assert.Equal(t, []ReadResult{{Oid: ".1.3.6.1.2.1.33.1.1.1.0", Data: "Eaton"}}, results)
+	assert.False(t, client.SupportBulk)
+
+	// Timeouts and the like are errors.
+	client.Dial = dial(walkTransport{walkErr: fmt.Errorf("request timeout")})
+	_, err = client.Walk(".1.3.6.1.2.1.33.1.1")
+	assert.Error(t, err)
+
+	// Failing to close after a good walk is an error too.
+	client.Dial = dial(walkTransport{setTransport: setTransport{closeErr: fmt.Errorf("closed")}, resultSet: resultSet})
+	_, err = client.Walk(".1.3.6.1.2.1.33.1.1")
+	assert.Error(t, err)
+
+	// A subtree the agent does not implement.
+	client.Dial = dial(walkTransport{resultSet: []gosnmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.33.1.1", Type: gosnmp.NoSuchObject},
+	}})
+	results, err = client.Walk(".1.3.6.1.2.1.33.1.1")
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+}
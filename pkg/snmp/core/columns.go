@@ -0,0 +1,249 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vapor-ware/synse-sdk/sdk/config"
+)
+
+// ColumnDevice declares the synse device for a column of a table, created
+// for each row by EnumerateColumns. This is the Go counterpart of
+// ColumnDeviceDefinition for hand-written MIBs.
+type ColumnDevice struct {
+	Column      int                     // 1 based column number.
+	DeviceType  string                  // Synse device type.
+	Multiplier  float32                 // Multiplier for the raw reading, if not 0.
+	Enumeration map[int]string          // Names of an enumerated INTEGER, if any.
+	Actions     map[string]ColumnAction // Write actions, if any.
+	// Value served when the row has no such object, e.g. -1 for the
+	// humidity of a temperature only probe, if any.
+	NotApplicable interface{}
+	LowLimit      int // 1 based column of the low limit, if any. See ColumnEnumeration.LimitsTable.
+	HighLimit     int // 1 based column of the high limit, if any.
+	// Other device data, e.g. the range a setpoint may be written with.
+	Data map[string]interface{}
+}
+
+// ColumnAction is a write action, an INTEGER value to set, e.g. to switch an
+// outlet. The value is set on Column of the same row or, when ColumnOid is
+// set, on the column of another table with the same index. See
+// devices.SnmpOutlet.
+type ColumnAction struct {
+	Column    int    // 1 based column number in the same row.
+	ColumnOid string // OID of the column of another table, without the index.
+	Value     int
+}
+
+// ColumnEnumeration declares the devices EnumerateColumns creates for a
+// table.
+type ColumnEnumeration struct {
+	// The device model, added to the context of each prototype if not empty.
+	Model string
+	// 1 based column with the name of the row, e.g. the outlet name, if any.
+	// The name is added to the context of the devices of the row.
+	NameColumn int
+	// Converts the data of the name column to the name. The default trims
+	// strings and ignores other data.
+	Name func(data interface{}) string
+	// The name of a joined table with the limits of each row. The default is
+	// the row itself. See SnmpTable.Join.
+	LimitsTable string
+	// Whether the agent serves an object. The default is any data which is
+	// not nil. Objects which are not served have no device.
+	Served func(data interface{}) bool
+	// The devices for the columns of each row.
+	Devices []ColumnDevice
+	// Gets the devices for the columns of a row, for tables where these
+	// differ by row, e.g. with the units of the row. Overrides Devices.
+	RowDevices func(row *SnmpRow) []ColumnDevice
+}
+
+// EnumerateColumns creates the devices for the columns of each row of a
+// table, with one prototype per device type in the order the types are first
+// found. Columns the agent does not serve have no device. Devices in
+// flattened tables are named by column, and devices in other tables by column
+// and row index, e.g. rPDU2PhaseStatusCurrent 1. Devices in other tables have
+// the index of the row in their context. The limits are added with AddLimits.
+func EnumerateColumns(table *SnmpTable, enumeration ColumnEnumeration) (devices []*config.DeviceProto, err error) {
+	if len(table.Rows) == 0 {
+		log.WithFields(log.Fields{
+			"table": table.Name,
+			"oid":   table.WalkOid,
+		}).Warn("[snmp] table has no rows, will not create any devices for it")
+		return
+	}
+
+	snmpDeviceConfigMap, err := table.SnmpServerBase.DeviceConfig.ToMap()
+	if err != nil {
+		return nil, err
+	}
+
+	served := enumeration.Served
+	if served == nil {
+		served = func(data interface{}) bool { return data != nil }
+	}
+	name := enumeration.Name
+	if name == nil {
+		name = trimmedName
+	}
+
+	// One prototype per device type, in the order they are first found.
+	protos := map[string]*config.DeviceProto{}
+
+	for i := 0; i < len(table.Rows); i++ {
+		row := &table.Rows[i]
+
+		var context map[string]string
+		if !table.FlattenedTable {
+			context = map[string]string{
+				"index": row.IndexString(),
+			}
+			for _, value := range row.Index {
+				context[value.Name] = value.String()
+			}
+			if enumeration.NameColumn != 0 {
+				if data := row.RowData[enumeration.NameColumn-1].Data; data != nil {
+					if rowName := name(data); rowName != "" {
+						context["name"] = rowName
+					}
+				}
+			}
+		}
+
+		limits := row
+		if enumeration.LimitsTable != "" {
+			limits = row.Joined(enumeration.LimitsTable)
+		}
+
+		columnDevices := enumeration.Devices
+		if enumeration.RowDevices != nil {
+			columnDevices = enumeration.RowDevices(row)
+		}
+		for _, columnDevice := range columnDevices {
+			data := row.RowData[columnDevice.Column-1].Data
+			if !served(data) || (columnDevice.NotApplicable != nil && data == columnDevice.NotApplicable) {
+				continue
+			}
+
+			deviceData := ColumnDeviceData(table, i, columnDevice.Column)
+			if columnDevice.Multiplier != 0 {
+				deviceData["multiplier"] = columnDevice.Multiplier
+			}
+			AddEnumeration(deviceData, columnDevice.Enumeration)
+			for action, columnAction := range columnDevice.Actions {
+				if columnAction.ColumnOid != "" {
+					deviceData["action_oid_"+action] = columnAction.ColumnOid + "." + row.IndexOid
+				} else {
+					deviceData["action_oid_"+action] = fmt.Sprintf(row.BaseOid, columnAction.Column)
+				}
+				deviceData["action_value_"+action] = fmt.Sprint(columnAction.Value)
+			}
+			if limits != nil {
+				AddLimits(deviceData, limits, columnDevice.LowLimit, columnDevice.HighLimit)
+			}
+			for key, value := range columnDevice.Data {
+				deviceData[key] = value
+			}
+			deviceData, err = MergeMapStringInterface(snmpDeviceConfigMap, deviceData)
+			if err != nil {
+				return nil, err
+			}
+
+			proto, ok := protos[columnDevice.DeviceType]
+			if !ok {
+				proto = &config.DeviceProto{
+					Type:      columnDevice.DeviceType,
+					Instances: []*config.DeviceInstance{},
+					Tags:      snmpDeviceConfigMap["deviceTags"].([]string),
+				}
+				if enumeration.Model != "" {
+					proto.Context = map[string]string{
+						"model": enumeration.Model,
+					}
+				}
+				protos[columnDevice.DeviceType] = proto
+				devices = append(devices, proto)
+			}
+
+			info := table.ColumnList[columnDevice.Column-1]
+			if !table.FlattenedTable {
+				info = fmt.Sprintf("%v %v", info, row.IndexString())
+			}
+			proto.Instances = append(proto.Instances, &config.DeviceInstance{
+				Info:    info,
+				Context: context,
+				Data:    deviceData,
+			})
+		}
+	}
+	return devices, nil
+}
+
+// NewColumnTable creates and loads the SnmpTable for a table definition of a
+// hand-written MIB, with a device enumerator which creates the devices with
+// EnumerateColumns. The device definitions of the columns are not used.
+// enumeration gets the devices when they are enumerated, once the MIB of the
+// table is set, e.g. to get the model from another table of the MIB.
+func NewColumnTable(definition *TableDefinition, snmpServerBase *SnmpServerBase,
+	enumeration func(table *SnmpTable) ColumnEnumeration) (*SnmpTable, error) {
+
+	snmpTable, err := NewTable(definition, snmpServerBase)
+	if err != nil {
+		return nil, err
+	}
+
+	snmpTable.DevEnumerator = ColumnTableDeviceEnumerator{
+		Table:       snmpTable,
+		Enumeration: enumeration,
+	}
+	return snmpTable, nil
+}
+
+// ColumnTableDeviceEnumerator is the device enumerator for tables created
+// with NewColumnTable.
+type ColumnTableDeviceEnumerator struct {
+	Table       *SnmpTable                               // Pointer back to the table.
+	Enumeration func(table *SnmpTable) ColumnEnumeration // Gets the devices of the table.
+}
+
+// DeviceEnumerator creates the devices of the enumeration with
+// EnumerateColumns.
+func (enumerator ColumnTableDeviceEnumerator) DeviceEnumerator(
+	data map[string]interface{}) (devices []*config.DeviceProto, err error) {
+	return EnumerateColumns(enumerator.Table, enumerator.Enumeration(enumerator.Table))
+}
+
+// ColumnDeviceData creates the device data for a column of a row of a table.
+// The data gets shimmed into the DeviceConfig for the synse device. row is the
+// 0 based row number and column the 1 based column number.
+func ColumnDeviceData(table *SnmpTable, row int, column int) map[string]interface{} {
+	baseOid := table.Rows[row].BaseOid
+	return map[string]interface{}{
+		"base_oid":   baseOid,
+		"table_name": table.Name,
+		"row":        fmt.Sprintf("%d", row),
+		"column":     fmt.Sprintf("%d", column),
+		"oid":        fmt.Sprintf(baseOid, column), // base_oid and integer column.
+	}
+}
+
+// AddEnumeration adds the names of an enumerated INTEGER to the device data,
+// if any. See devices.TranslateEnumeration.
+func AddEnumeration(deviceData map[string]interface{}, enumeration map[int]string) {
+	if len(enumeration) == 0 {
+		return
+	}
+	deviceData["enumeration"] = "true"
+	for value, name := range enumeration {
+		deviceData[fmt.Sprintf("enumeration%d", value)] = name
+	}
+}
+
+// trimmedName is the default name of a row, the trimmed string data of the
+// name column.
+func trimmedName(data interface{}) string {
+	name, _ := data.(string)
+	return strings.TrimSpace(name)
+}
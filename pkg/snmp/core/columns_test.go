@@ -0,0 +1,218 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newColumnsTestTable creates an outlet table with an INDEX clause, as served
+// by a PDU, for EnumerateColumns.
+func newColumnsTestTable(t *testing.T) *SnmpTable {
+	table := newJoinTestTable(t, "TEST-MIB-outletTable", ".1.3.6.1.4.1.99999.3",
+		[]string{"outletIndex", "outletName", "outletState", "outletCurrent", "outletHumidity"},
+		[]IndexComponent{{Name: "outletIndex", Type: IndexInteger}},
+		[]ReadResult{
+			{Oid: ".1.3.6.1.4.1.99999.3.1.1.1", Data: 1},
+			{Oid: ".1.3.6.1.4.1.99999.3.1.1.2", Data: 2},
+			{Oid: ".1.3.6.1.4.1.99999.3.1.2.1", Data: " rack fan "},
+			{Oid: ".1.3.6.1.4.1.99999.3.1.2.2", Data: ""},
+			{Oid: ".1.3.6.1.4.1.99999.3.1.3.1", Data: 1},
+			{Oid: ".1.3.6.1.4.1.99999.3.1.3.2", Data: 2},
+			{Oid: ".1.3.6.1.4.1.99999.3.1.4.1", Data: 12},
+			{Oid: ".1.3.6.1.4.1.99999.3.1.4.2", Data: 0},
+			{Oid: ".1.3.6.1.4.1.99999.3.1.5.1", Data: 40},
+			{Oid: ".1.3.6.1.4.1.99999.3.1.5.2", Data: -1},
+		})
+	table.SnmpServerBase = newOfflineServerBase(t)
+	return table
+}
+
+// TestEnumerateColumns tests creating the devices for the columns of a
+// table.
+func TestEnumerateColumns(t *testing.T) {
+	table := newColumnsTestTable(t)
+
+	protos, err := EnumerateColumns(table, ColumnEnumeration{
+		Model:      "test model",
+		NameColumn: 2,
+		Devices: []ColumnDevice{
+			{Column: 3, DeviceType: "status", Enumeration: map[int]string{1: "on", 2: "off"},
+				Actions: map[string]ColumnAction{
+					"off":    {Column: 3, Value: 2},
+					"reboot": {ColumnOid: ".1.3.6.1.4.1.99999.4.1.2", Value: 3},
+				}},
+			{Column: 4, DeviceType: "current", Multiplier: 0.1, Data: map[string]interface{}{"phase": "L1"}},
+			{Column: 5, DeviceType: "humidity", NotApplicable: -1},
+		},
+	})
+	assert.NoError(t, err)
+
+	// Prototypes are in the order the types are first found.
+	assert.Len(t, protos, 3)
+	assert.Equal(t, "status", protos[0].Type)
+	assert.Equal(t, "current", protos[1].Type)
+	assert.Equal(t, "humidity", protos[2].Type)
+	assert.Equal(t, "test model", protos[0].Context["model"])
+
+	byType := protosByType(protos)
+	assert.Len(t, byType["status"].Instances, 2)
+	assert.Len(t, byType["current"].Instances, 2)
+
+	// The humidity of the second outlet is not applicable.
+	assert.Len(t, byType["humidity"].Instances, 1)
+
+	// The row name is trimmed, and rows without a name have none.
+	status := byType["status"].Instances[0]
+	assert.Equal(t, "outletState 1", status.Info)
+	assert.Equal(t, "1", status.Context["index"])
+	assert.Equal(t, "1", status.Context["outletIndex"])
+	assert.Equal(t, "rack fan", status.Context["name"])
+	assert.NotContains(t, byType["status"].Instances[1].Context, "name")
+
+	assert.Equal(t, ".1.3.6.1.4.1.99999.3.1.3.1", status.Data["oid"])
+	assert.Equal(t, "TEST-MIB-outletTable", status.Data["table_name"])
+	assert.Equal(t, "true", status.Data["enumeration"])
+	assert.Equal(t, "off", status.Data["enumeration2"])
+	assert.Equal(t, "127.0.0.1", status.Data["endpoint"])
+
+	// Actions on the same row and on another table.
+	assert.Equal(t, ".1.3.6.1.4.1.99999.3.1.3.1", status.Data["action_oid_off"])
+	assert.Equal(t, "2", status.Data["action_value_off"])
+	assert.Equal(t, ".1.3.6.1.4.1.99999.4.1.2.1", status.Data["action_oid_reboot"])
+	assert.Equal(t, "3", status.Data["action_value_reboot"])
+
+	current := byType["current"].Instances[1]
+	assert.Equal(t, "outletCurrent 2", current.Info)
+	assert.Equal(t, float32(0.1), current.Data["multiplier"])
+	assert.Equal(t, "L1", current.Data["phase"])
+	assert.NotContains(t, current.Data, "enumeration")
+}
+
+// TestEnumerateColumnsServed tests that objects the agent does not serve
+// have no device.
+func TestEnumerateColumnsServed(t *testing.T) {
+	table := newColumnsTestTable(t)
+
+	protos, err := EnumerateColumns(table, ColumnEnumeration{
+		// Outlets which are off are not served.
+		Served: func(data interface{}) bool { return data != 2 },
+		Devices: []ColumnDevice{
+			{Column: 3, DeviceType: "status"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, protos, 1)
+	assert.Len(t, protos[0].Instances, 1)
+	assert.Equal(t, "outletState 1", protos[0].Instances[0].Info)
+
+	// No model.
+	assert.Nil(t, protos[0].Context)
+}
+
+// TestEnumerateColumnsRowDevices tests tables where the devices differ by
+// row, and limits from a joined table.
+func TestEnumerateColumnsRowDevices(t *testing.T) {
+	table := newColumnsTestTable(t)
+	limits := newJoinTestTable(t, "TEST-MIB-outletLimitsTable", ".1.3.6.1.4.1.99999.5",
+		[]string{"outletCurrentLow", "outletCurrentHigh"},
+		[]IndexComponent{{Name: "outletIndex", Type: IndexInteger}},
+		[]ReadResult{
+			{Oid: ".1.3.6.1.4.1.99999.5.1.1.2", Data: 1},
+			{Oid: ".1.3.6.1.4.1.99999.5.1.2.2", Data: 16},
+		})
+	assert.NoError(t, table.Join("", limits))
+
+	protos, err := EnumerateColumns(table, ColumnEnumeration{
+		NameColumn:  2,
+		Name:        func(data interface{}) string { return "outlet" },
+		LimitsTable: "TEST-MIB-outletLimitsTable",
+		RowDevices: func(row *SnmpRow) []ColumnDevice {
+			if row.IndexOid == "1" {
+				return []ColumnDevice{{Column: 5, DeviceType: "humidity"}}
+			}
+			return []ColumnDevice{{Column: 4, DeviceType: "current", LowLimit: 1, HighLimit: 2}}
+		},
+	})
+	assert.NoError(t, err)
+
+	byType := protosByType(protos)
+	assert.Len(t, protos, 2)
+	assert.Len(t, byType["humidity"].Instances, 1)
+	assert.Len(t, byType["current"].Instances, 1)
+	assert.Equal(t, "outlet", byType["current"].Instances[0].Context["name"])
+
+	// The limits of the first outlet are not joined.
+	assert.NotContains(t, byType["humidity"].Instances[0].Data, LimitLowKey)
+	current := byType["current"].Instances[0]
+	assert.Equal(t, 1, current.Data[LimitLowKey])
+	assert.Equal(t, 16, current.Data[LimitHighKey])
+}
+
+// TestEnumerateColumnsFlattened tests the devices of a flattened table, which
+// are named by column and have no index.
+func TestEnumerateColumnsFlattened(t *testing.T) {
+	table := &SnmpTable{
+		Name:           "TEST-MIB-identTable",
+		WalkOid:        ".1.3.6.1.4.1.99999.1",
+		ColumnList:     []string{"identModel", "identTemperature"},
+		SnmpServerBase: newOfflineServerBase(t),
+		FlattenedTable: true,
+	}
+	assert.NoError(t, table.translate([]ReadResult{
+		{Oid: ".1.3.6.1.4.1.99999.1.1.0", Data: "model"},
+		{Oid: ".1.3.6.1.4.1.99999.1.2.0", Data: 21},
+	}))
+
+	protos, err := EnumerateColumns(table, ColumnEnumeration{
+		NameColumn: 1,
+		Devices: []ColumnDevice{
+			{Column: 2, DeviceType: "temperature"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, protos, 1)
+	temperature := protos[0].Instances[0]
+	assert.Equal(t, "identTemperature", temperature.Info)
+	assert.Nil(t, temperature.Context)
+	assert.Equal(t, ".1.3.6.1.4.1.99999.1.2.0", temperature.Data["oid"])
+}
+
+// TestEnumerateColumnsEmpty tests that an empty table has no devices.
+func TestEnumerateColumnsEmpty(t *testing.T) {
+	table := &SnmpTable{
+		Name:           "TEST-MIB-emptyTable",
+		WalkOid:        ".1.3.6.1.4.1.99999.6",
+		SnmpServerBase: newOfflineServerBase(t),
+	}
+	protos, err := EnumerateColumns(table, ColumnEnumeration{
+		Devices: []ColumnDevice{{Column: 1, DeviceType: "status"}},
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, protos)
+}
+
+// TestColumnTableDeviceEnumerator tests that the devices of a table created
+// with NewColumnTable are got when they are enumerated.
+func TestColumnTableDeviceEnumerator(t *testing.T) {
+	table := newColumnsTestTable(t)
+
+	model := ""
+	table.DevEnumerator = ColumnTableDeviceEnumerator{
+		Table: table,
+		Enumeration: func(table *SnmpTable) ColumnEnumeration {
+			return ColumnEnumeration{
+				Model:   model,
+				Devices: []ColumnDevice{{Column: 4, DeviceType: "current"}},
+			}
+		},
+	}
+
+	model = "test model"
+	protos, err := table.EnumerateDevices(map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Len(t, protos, 1)
+	assert.Equal(t, "test model", protos[0].Context["model"])
+	assert.Len(t, protos[0].Instances, 2)
+	assert.Equal(t, "outletCurrent 2", protos[0].Instances[1].Info)
+}
@@ -0,0 +1,54 @@
+// Package coretest has the helpers the tests share to read and enumerate
+// devices from recorded walks, without an SNMP server.
+package coretest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-sdk/sdk/config"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// NewReplayServerBase creates an SnmpServerBase for a recorded walk. See
+// LoadWalkFile.
+func NewReplayServerBase(t *testing.T, path string) *core.SnmpServerBase {
+	replay, err := LoadWalkFile(path)
+	assert.NoError(t, err)
+	securityParameters, err := core.NewSecurityParameters("simulator", core.SHA, "auctoritas", core.AES, "privatus")
+	assert.NoError(t, err)
+	deviceConfig, err := core.NewDeviceConfig("v3", "127.0.0.1", 1024, securityParameters, "public", []string{})
+	assert.NoError(t, err)
+	client, err := core.NewSnmpClient(deviceConfig)
+	assert.NoError(t, err)
+	client.Dial = replay.Dial
+	base, err := core.NewSnmpServerBase(client, deviceConfig)
+	assert.NoError(t, err)
+	return base
+}
+
+// FindInstance finds an enumerated device of a type by info, or nil if not
+// found.
+func FindInstance(devices []*config.DeviceProto, deviceType string, info string) *config.DeviceInstance {
+	for _, proto := range devices {
+		if proto.Type != deviceType {
+			continue
+		}
+		for _, instance := range proto.Instances {
+			if instance.Info == info {
+				return instance
+			}
+		}
+	}
+	return nil
+}
+
+// CountInstances counts the enumerated devices of a type.
+func CountInstances(devices []*config.DeviceProto, deviceType string) (count int) {
+	for _, proto := range devices {
+		if proto.Type == deviceType {
+			count += len(proto.Instances)
+		}
+	}
+	return count
+}
@@ -0,0 +1,242 @@
+package coretest
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// WalkReplay serves the results of a recorded snmpwalk instead of an SNMP
+// agent, e.g. the walks in the emulator data. This is used to check MIB
+// implementations and vendor quirks against real agents without one. See
+// Dial.
+type WalkReplay struct {
+	// The recorded results in walk order.
+	Results []core.ReadResult
+}
+
+// enumerationValue matches an enumerated INTEGER as printed by snmpwalk with
+// MIBs loaded, e.g. up(1).
+var enumerationValue = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9-]*\((-?[0-9]+)\)$`)
+
+// LoadWalkFile loads a recorded snmpwalk from a file. See ParseWalk.
+func LoadWalkFile(path string) (*WalkReplay, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	replay, err := ParseWalk(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", path, err)
+	}
+	return replay, nil
+}
+
+// ParseWalk parses the output of snmpwalk -On, one OID per line, e.g.
+//
+//	.1.3.6.1.2.1.33.1.2.1.0 = INTEGER: 2
+//	.1.3.6.1.2.1.33.1.1.1.0 = STRING: "Eaton Corporation"
+//
+// Values are translated to the types gosnmp decodes them to, so that tables
+// translate them the same way they do a walk of the agent. Lines without a
+// value are skipped.
+func ParseWalk(content string) (*WalkReplay, error) {
+	replay := &WalkReplay{}
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		separator := strings.Index(line, " = ")
+		if separator < 0 {
+			if strings.HasSuffix(line, " =") {
+				continue // No value.
+			}
+			return nil, fmt.Errorf("line %d: expected oid = value, %v", lineNumber, line)
+		}
+		oid := line[:separator]
+		if !strings.HasPrefix(oid, ".") {
+			return nil, fmt.Errorf("line %d: oid must start with a period, %v", lineNumber, oid)
+		}
+		data, err := parseWalkValue(line[separator+3:])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+		}
+		replay.Results = append(replay.Results, core.ReadResult{Oid: oid, Data: data})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return replay, nil
+}
+
+// parseWalkValue parses the value of a walk line, e.g. INTEGER: 2.
+func parseWalkValue(value string) (interface{}, error) {
+	if value == `""` {
+		return "", nil
+	}
+	separator := strings.Index(value, ":")
+	if separator < 0 {
+		// A bare number, as printed for some types without MIBs loaded.
+		data, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported value %v", value)
+		}
+		return data, nil
+	}
+
+	valueType := value[:separator]
+	value = strings.TrimSpace(value[separator+1:])
+	switch valueType {
+	case "INTEGER":
+		if match := enumerationValue.FindStringSubmatch(value); match != nil {
+			value = match[1]
+		}
+		// Drop any UNITS, e.g. 5 seconds.
+		if fields := strings.Fields(value); len(fields) > 1 {
+			value = fields[0]
+		}
+		return strconv.Atoi(value)
+	case "Counter32", "Gauge32":
+		data, err := strconv.ParseUint(value, 10, 32)
+		return uint(data), err
+	case "Counter64":
+		return strconv.ParseUint(value, 10, 64)
+	case "Timeticks":
+		// e.g. (557779790) 64 days, 13:23:17.90
+		end := strings.Index(value, ")")
+		if !strings.HasPrefix(value, "(") || end < 0 {
+			return nil, fmt.Errorf("unsupported Timeticks %v", value)
+		}
+		data, err := strconv.ParseUint(value[1:end], 10, 32)
+		return uint32(data), err
+	case "STRING":
+		if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+			value = value[1 : len(value)-1]
+		}
+		return value, nil
+	case "Hex-STRING":
+		return hex.DecodeString(strings.Replace(value, " ", "", -1))
+	case "OID", "IpAddress":
+		return value, nil
+	}
+	return nil, fmt.Errorf("unsupported type %v", valueType)
+}
+
+// Get gets the recorded result for an OID. As with an agent, the data is nil
+// when the OID is not in the walk.
+func (replay *WalkReplay) Get(oid string) core.ReadResult {
+	for _, result := range replay.Results {
+		if result.Oid == oid {
+			return result
+		}
+	}
+	return core.ReadResult{Oid: oid}
+}
+
+// Set sets the recorded data for an OID, so that writes can be checked by
+// reading back. As with an agent, OIDs which are not in the walk cannot be
+// set.
+func (replay *WalkReplay) Set(oid string, data interface{}) error {
+	for i := range replay.Results {
+		if replay.Results[i].Oid == oid {
+			replay.Results[i].Data = data
+			return nil
+		}
+	}
+	return fmt.Errorf("%v is not in the walk", oid)
+}
+
+// Walk gets the recorded results under the root OID in walk order.
+func (replay *WalkReplay) Walk(rootOid string) (results []core.ReadResult) {
+	for _, result := range replay.Results {
+		if result.Oid == rootOid || strings.HasPrefix(result.Oid, rootOid+".") {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// Dial opens a core.Transport which serves the recorded walk, for
+// core.SnmpClient.Dial. Sets of OIDs which are not in the walk fail with
+// noCreation, as they would on an agent.
+func (replay *WalkReplay) Dial() (core.Transport, error) {
+	return replayTransport{replay}, nil
+}
+
+// replayTransport is the core.Transport of a WalkReplay.
+type replayTransport struct {
+	replay *WalkReplay
+}
+
+// Get gets the recorded results for the OIDs.
+func (transport replayTransport) Get(oids []string) (*gosnmp.SnmpPacket, error) {
+	packet := &gosnmp.SnmpPacket{}
+	for _, oid := range oids {
+		packet.Variables = append(packet.Variables, replayPdu(transport.replay.Get(oid)))
+	}
+	return packet, nil
+}
+
+// BulkWalkAll walks the recorded results under the root OID.
+func (transport replayTransport) BulkWalkAll(rootOid string) ([]gosnmp.SnmpPDU, error) {
+	return transport.WalkAll(rootOid)
+}
+
+// WalkAll walks the recorded results under the root OID.
+func (transport replayTransport) WalkAll(rootOid string) (pdus []gosnmp.SnmpPDU, err error) {
+	for _, result := range transport.replay.Walk(rootOid) {
+		pdus = append(pdus, replayPdu(result))
+	}
+	return pdus, nil
+}
+
+// Set sets the recorded data. The packet has the error status of the first
+// OID which is not in the walk, if any.
+func (transport replayTransport) Set(pdus []gosnmp.SnmpPDU) (*gosnmp.SnmpPacket, error) {
+	packet := &gosnmp.SnmpPacket{Variables: pdus}
+	for i, pdu := range pdus {
+		if err := transport.replay.Set(pdu.Name, pdu.Value); err != nil {
+			packet.Error = gosnmp.NoCreation
+			packet.ErrorIndex = uint8(i + 1)
+			break
+		}
+	}
+	return packet, nil
+}
+
+// Close does nothing.
+func (transport replayTransport) Close() error {
+	return nil
+}
+
+// replayPdu converts a recorded result to the PDU an agent would return for
+// it. Results without data are noSuchObject.
+func replayPdu(result core.ReadResult) gosnmp.SnmpPDU {
+	pdu := gosnmp.SnmpPDU{Name: result.Oid, Value: result.Data}
+	switch result.Data.(type) {
+	case nil:
+		pdu.Type = gosnmp.NoSuchObject
+	case int:
+		pdu.Type = gosnmp.Integer
+	case uint:
+		pdu.Type = gosnmp.Gauge32
+	case uint32:
+		pdu.Type = gosnmp.TimeTicks
+	case uint64:
+		pdu.Type = gosnmp.Counter64
+	case string, []byte:
+		pdu.Type = gosnmp.OctetString
+	}
+	return pdu
+}
@@ -0,0 +1,147 @@
+package coretest
+
+import (
+	"testing"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// TestParseWalk tests parsing the value types of a recorded snmpwalk.
+func TestParseWalk(t *testing.T) {
+	replay, err := ParseWalk(`
+.1.3.6.1.2.1.1.2.0 = OID: .1.3.6.1.4.1.850.100.1
+.1.3.6.1.2.1.1.3.0 = Timeticks: (557779790) 64 days, 13:23:17.90
+.1.3.6.1.2.1.1.4.0 = STRING:
+.1.3.6.1.2.1.2.2.1.2.2 = STRING: eth0
+.1.3.6.1.2.1.2.2.1.5.2 = Gauge32: 100000000
+.1.3.6.1.2.1.2.2.1.7.2 = INTEGER: up(1)
+.1.3.6.1.2.1.2.2.1.10.2 = Counter32: 336144
+.1.3.6.1.2.1.4.20.1.1.127.0.0.1 = IpAddress: 127.0.0.1
+.1.3.6.1.2.1.4.22.1.2.2.10.193.3.254 = Hex-STRING: 0C 8D DB B1 5D 84
+.1.3.6.1.2.1.6.1.0 = INTEGER: 5 seconds
+.1.3.6.1.2.1.31.1.1.1.6.2 = Counter64: 12020
+.1.3.6.1.2.1.33.1.1.1.0 = STRING: "Eaton Corporation"
+.1.3.6.1.2.1.33.1.1.2.0 = ""
+.1.3.6.1.2.1.33.1.2.2.0 = INTEGER: -1
+.1.3.6.1.2.1.33.1.7.5.0 = 0
+`)
+	assert.NoError(t, err)
+	assert.Len(t, replay.Results, 15)
+
+	for _, test := range []struct {
+		oid  string
+		data interface{}
+	}{
+		{".1.3.6.1.2.1.1.2.0", ".1.3.6.1.4.1.850.100.1"},
+		{".1.3.6.1.2.1.1.3.0", uint32(557779790)},
+		{".1.3.6.1.2.1.1.4.0", ""},
+		{".1.3.6.1.2.1.2.2.1.2.2", "eth0"},
+		{".1.3.6.1.2.1.2.2.1.5.2", uint(100000000)},
+		{".1.3.6.1.2.1.2.2.1.7.2", 1},
+		{".1.3.6.1.2.1.2.2.1.10.2", uint(336144)},
+		{".1.3.6.1.2.1.4.20.1.1.127.0.0.1", "127.0.0.1"},
+		{".1.3.6.1.2.1.4.22.1.2.2.10.193.3.254", []byte{0x0c, 0x8d, 0xdb, 0xb1, 0x5d, 0x84}},
+		{".1.3.6.1.2.1.6.1.0", 5},
+		{".1.3.6.1.2.1.31.1.1.1.6.2", uint64(12020)},
+		{".1.3.6.1.2.1.33.1.1.1.0", "Eaton Corporation"},
+		{".1.3.6.1.2.1.33.1.1.2.0", ""},
+		{".1.3.6.1.2.1.33.1.2.2.0", -1},
+		{".1.3.6.1.2.1.33.1.7.5.0", 0},
+		// Missing OIDs have nil data.
+		{".1.3.6.1.2.1.33.1.2.3.0", nil},
+	} {
+		assert.Equal(t, core.ReadResult{Oid: test.oid, Data: test.data}, replay.Get(test.oid), test.oid)
+	}
+
+	// Walks are by OID prefix in walk order.
+	results := replay.Walk(".1.3.6.1.2.1.33.1.1")
+	assert.Len(t, results, 2)
+	assert.Equal(t, ".1.3.6.1.2.1.33.1.1.1.0", results[0].Oid)
+	assert.Len(t, replay.Walk(".1.3.6.1.2.1.2.2.1.1"), 0)
+
+	for _, content := range []string{
+		".1.3.6.1.2.1.1.3.0 = Timeticks: 5",
+		".1.3.6.1.2.1.1.7.0 = INTEGER: many",
+		".1.3.6.1.2.1.1.7.0 = Opaque: 1",
+		".1.3.6.1.2.1.1.7.0 = unknown",
+		"1.3.6.1.2.1.1.7.0 = INTEGER: 1",
+		".1.3.6.1.2.1.1.7.0",
+	} {
+		_, err = ParseWalk(content)
+		assert.Error(t, err, content)
+	}
+}
+
+// TestLoadWalkFile tests loading the emulator walks.
+func TestLoadWalkFile(t *testing.T) {
+	replay, err := LoadWalkFile("../../../../emulator/ups/tripplite_ups/data/public.snmpwalk")
+	assert.NoError(t, err)
+	assert.Equal(t, "TrippLite", replay.Get(".1.3.6.1.2.1.33.1.1.1").Data)
+
+	_, err = LoadWalkFile("testdata/missing.snmpwalk")
+	assert.Error(t, err)
+}
+
+// TestClientReplay tests serving client reads from a recorded walk.
+func TestClientReplay(t *testing.T) {
+	replay, err := LoadWalkFile("../../../../emulator/ups/pxgms_ups/data/public.snmpwalk")
+	assert.NoError(t, err)
+	securityParameters, err := core.NewSecurityParameters("simulator", core.SHA, "auctoritas", core.AES, "privatus")
+	assert.NoError(t, err)
+	deviceConfig, err := core.NewDeviceConfig("v3", "127.0.0.1", 1024, securityParameters, "public", []string{})
+	assert.NoError(t, err)
+	client, err := core.NewSnmpClient(deviceConfig)
+	assert.NoError(t, err)
+	client.Dial = replay.Dial
+
+	result, err := client.Get(".1.3.6.1.2.1.33.1.2.5.0")
+	assert.NoError(t, err)
+	assert.Equal(t, 4972, result.Data)
+
+	results, err := client.GetMany([]string{".1.3.6.1.2.1.33.1.2.7.0", core.SysUpTimeOid})
+	assert.NoError(t, err)
+	assert.Equal(t, 24, results[0].Data)
+	assert.Equal(t, 6930266, results[1].Data)
+
+	results, err = client.Walk(".1.3.6.1.2.1.33.1.2")
+	assert.NoError(t, err)
+	assert.Len(t, results, 7)
+}
+
+// TestClientReplaySet tests that sets are read back from a recorded walk.
+func TestClientReplaySet(t *testing.T) {
+	replay, err := ParseWalk(`
+.1.3.6.1.4.1.534.1.12.2.1.3.1 = INTEGER: -1
+`)
+	assert.NoError(t, err)
+	securityParameters, err := core.NewSecurityParameters("simulator", core.SHA, "auctoritas", core.AES, "privatus")
+	assert.NoError(t, err)
+	deviceConfig, err := core.NewDeviceConfig("v3", "127.0.0.1", 1024, securityParameters, "public", []string{})
+	assert.NoError(t, err)
+	client, err := core.NewSnmpClient(deviceConfig)
+	assert.NoError(t, err)
+	client.Dial = replay.Dial
+
+	assert.NoError(t, client.SetInteger(".1.3.6.1.4.1.534.1.12.2.1.3.1", 0))
+	result, err := client.Get(".1.3.6.1.4.1.534.1.12.2.1.3.1")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.Data)
+
+	// OIDs not in the walk are not added, and the set is rejected.
+	assert.Error(t, client.SetInteger(".1.3.6.1.4.1.534.1.12.2.1.3.2", 0))
+	assert.Error(t, replay.Set(".1.3.6.1.4.1.534.1.12.2.1.3.2", 0))
+	transport, err := replay.Dial()
+	assert.NoError(t, err)
+	packet, err := transport.Set([]gosnmp.SnmpPDU{{
+		Name:  ".1.3.6.1.4.1.534.1.12.2.1.3.2",
+		Type:  gosnmp.Integer,
+		Value: 0,
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, gosnmp.NoCreation, packet.Error)
+	result, err = client.Get(".1.3.6.1.4.1.534.1.12.2.1.3.2")
+	assert.NoError(t, err)
+	assert.Nil(t, result.Data)
+}
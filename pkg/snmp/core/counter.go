@@ -0,0 +1,106 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// ToCounter converts Counter32 or Counter64 data to its value and width in
+// bits. gosnmp decodes Counter32 (and Gauge32) to uint and Counter64 to
+// uint64. Agents which report counters as INTEGER are treated as Counter32.
+func ToCounter(data interface{}) (value uint64, bits int, err error) {
+	switch c := data.(type) {
+	case uint:
+		return uint64(c), 32, nil
+	case uint32:
+		return uint64(c), 32, nil
+	case uint64:
+		return c, 64, nil
+	case int:
+		if c >= 0 && int64(c) <= math.MaxUint32 {
+			return uint64(c), 32, nil
+		}
+	case int64:
+		if c >= 0 && c <= math.MaxUint32 {
+			return uint64(c), 32, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("expected counter data, got type: %T, value: %v", data, data)
+}
+
+// DiscontinuityOidKey is the device data key for the OID of the
+// discontinuity time of a counter, e.g. ifCounterDiscontinuityTime of the
+// interface. Optional.
+const DiscontinuityOidKey = "discontinuity_oid"
+
+// CounterReading is a reading of a counter, with the objects read with it
+// which show whether the counter was reset between readings.
+type CounterReading struct {
+	Value uint64
+	Bits  int       // The width of the counter. See ToCounter.
+	Now   time.Time // The local time of the reading.
+	// sysUpTime read with the counter, nil if the agent did not serve it.
+	SysUpTime *uint32
+	// The discontinuity time of the counter read with it, e.g.
+	// ifCounterDiscontinuityTime, nil if it has none.
+	Discontinuity *uint32
+}
+
+// NewCounterReading creates the reading of counter data read at now, with the
+// sysUpTime and discontinuity time data read with it. Either may be nil.
+func NewCounterReading(data interface{}, sysUpTime interface{}, discontinuity interface{}, now time.Time) (
+	reading CounterReading, err error) {
+
+	reading.Now = now
+	reading.Value, reading.Bits, err = ToCounter(data)
+	if err != nil {
+		return reading, err
+	}
+	if reading.SysUpTime, err = optionalTicks(sysUpTime); err != nil {
+		return reading, fmt.Errorf("sysUpTime: %v", err)
+	}
+	if reading.Discontinuity, err = optionalTicks(discontinuity); err != nil {
+		return reading, fmt.Errorf("discontinuity time: %v", err)
+	}
+	return reading, nil
+}
+
+// optionalTicks converts TimeTicks data which may be nil. See ToTicks.
+func optionalTicks(data interface{}) (*uint32, error) {
+	if data == nil {
+		return nil, nil
+	}
+	ticks, err := ToTicks(data)
+	if err != nil {
+		return nil, err
+	}
+	return &ticks, nil
+}
+
+// CounterDelta is the increase of a counter between two readings. Counters
+// wrap to zero past their maximum, so a Counter32 which went down wrapped
+// once. ok is false when the delta is unknown: when sysUpTime shows that the
+// agent was re-initialized between the readings, or the discontinuity time
+// of the counter changed. A Counter64 does not wrap in practice, so one which
+// went down was reset too, as is one which changed width.
+func CounterDelta(previous CounterReading, current CounterReading) (delta uint64, ok bool) {
+	if previous.Bits != current.Bits {
+		return 0, false
+	}
+	if previous.SysUpTime != nil && current.SysUpTime != nil &&
+		Rebooted(*previous.SysUpTime, previous.Now, *current.SysUpTime, current.Now) {
+		return 0, false
+	}
+	if (previous.Discontinuity == nil) != (current.Discontinuity == nil) ||
+		previous.Discontinuity != nil && *previous.Discontinuity != *current.Discontinuity {
+		return 0, false
+	}
+	if current.Value >= previous.Value {
+		return current.Value - previous.Value, true
+	}
+	if current.Bits == 32 {
+		return current.Value + (1 << 32) - previous.Value, true
+	}
+	return 0, false
+}
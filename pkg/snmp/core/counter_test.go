@@ -0,0 +1,96 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestToCounter tests converting counter data.
+func TestToCounter(t *testing.T) {
+	for _, test := range []struct {
+		data  interface{}
+		value uint64
+		bits  int
+	}{
+		{uint(5353869), 5353869, 32},
+		{uint32(12), 12, 32},
+		{uint64(1 << 40), 1 << 40, 64},
+		{12020, 12020, 32},
+		{int64(7), 7, 32},
+	} {
+		value, bits, err := ToCounter(test.data)
+		assert.NoError(t, err, test.data)
+		assert.Equal(t, test.value, value, test.data)
+		assert.Equal(t, test.bits, bits, test.data)
+	}
+
+	for _, data := range []interface{}{nil, "12", -1, 1.5} {
+		_, _, err := ToCounter(data)
+		assert.Error(t, err, data)
+	}
+}
+
+// counterReading creates a counter reading for the counter tests, ten
+// seconds after the previous one.
+func counterReading(t *testing.T, data interface{}, sysUpTime interface{}, discontinuity interface{}, seconds int) CounterReading {
+	now := time.Date(2021, time.March, 4, 12, 0, seconds, 0, time.UTC)
+	reading, err := NewCounterReading(data, sysUpTime, discontinuity, now)
+	assert.NoError(t, err)
+	return reading
+}
+
+// TestCounterDelta tests counter deltas, with wraps and resets.
+func TestCounterDelta(t *testing.T) {
+	delta, ok := CounterDelta(counterReading(t, uint(100), nil, nil, 0), counterReading(t, uint(250), nil, nil, 10))
+	assert.True(t, ok)
+	assert.Equal(t, uint64(150), delta)
+
+	// Counter32 wrapped.
+	delta, ok = CounterDelta(counterReading(t, uint(4294967290), nil, nil, 0), counterReading(t, uint(10), nil, nil, 10))
+	assert.True(t, ok)
+	assert.Equal(t, uint64(16), delta)
+
+	// Counter64 reset.
+	_, ok = CounterDelta(counterReading(t, uint64(1<<40), nil, nil, 0), counterReading(t, uint64(10), nil, nil, 10))
+	assert.False(t, ok)
+
+	// Changed width, e.g. the agent stopped serving ifXTable.
+	_, ok = CounterDelta(counterReading(t, uint64(100), nil, nil, 0), counterReading(t, uint(250), nil, nil, 10))
+	assert.False(t, ok)
+}
+
+// TestCounterDeltaReset tests that counters reset with the agent or at a
+// discontinuity have no delta, even when they went up.
+func TestCounterDeltaReset(t *testing.T) {
+	// sysUpTime went up by the ten seconds between the readings.
+	delta, ok := CounterDelta(
+		counterReading(t, uint(100), uint32(500000), uint32(0), 0),
+		counterReading(t, uint(250), uint32(501000), uint32(0), 10))
+	assert.True(t, ok)
+	assert.Equal(t, uint64(150), delta)
+
+	// The agent rebooted, and counted past the previous reading since.
+	_, ok = CounterDelta(
+		counterReading(t, uint(100), uint32(500000), nil, 0),
+		counterReading(t, uint(250), uint32(500), nil, 10))
+	assert.False(t, ok)
+
+	// The counter had a discontinuity, e.g. the interface was re-created.
+	_, ok = CounterDelta(
+		counterReading(t, uint64(100), uint32(500000), uint32(0), 0),
+		counterReading(t, uint64(250), uint32(501000), uint32(500900), 10))
+	assert.False(t, ok)
+
+	// The agent started serving the discontinuity time.
+	_, ok = CounterDelta(
+		counterReading(t, uint64(100), uint32(500000), nil, 0),
+		counterReading(t, uint64(250), uint32(501000), uint32(0), 10))
+	assert.False(t, ok)
+
+	_, err := NewCounterReading(uint(100), "500000", nil, time.Now())
+	assert.Error(t, err)
+	_, err = NewCounterReading(uint(100), nil, "0", time.Now())
+	assert.Error(t, err)
+}
@@ -0,0 +1,583 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"text/template"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vapor-ware/synse-sdk/sdk/config"
+	"gopkg.in/yaml.v2"
+)
+
+// MibDefinition declares the tables of a MIB and the synse devices for their
+// columns, so that a MIB can be supported without writing Go. Definitions are
+// YAML or JSON.
+type MibDefinition struct {
+	// The name of the MIB.
+	Name string `yaml:"name" json:"name"`
+	// The MIB module which defines the tables, e.g. UPS-MIB. The column names
+	// are registered with it. Optional, since the name of a definition need
+	// not be a module name.
+	Module string `yaml:"module" json:"module"`
+	// Optional OID to get the device model from, e.g. upsIdentModel. The
+	// model is in the context of each device.
+	ModelOid string `yaml:"modelOid" json:"modelOid"`
+	// The tables in the MIB.
+	Tables []*TableDefinition `yaml:"tables" json:"tables"`
+}
+
+// TableDefinition declares an SNMP table. The fields match the parameters to
+// NewSnmpTable.
+type TableDefinition struct {
+	Name           string              `yaml:"name" json:"name"`
+	WalkOid        string              `yaml:"walkOid" json:"walkOid"`
+	RowBase        string              `yaml:"rowBase" json:"rowBase"`
+	IndexColumn    string              `yaml:"indexColumn" json:"indexColumn"`
+	ReadableColumn string              `yaml:"readableColumn" json:"readableColumn"`
+	Flattened      bool                `yaml:"flattened" json:"flattened"`
+	Columns        []*ColumnDefinition `yaml:"columns" json:"columns"`
+	// The INDEX clause of the table, to decode the row indexes. Optional.
+	Index []IndexComponent `yaml:"index" json:"index"`
+	// The name of a table in the MIB which this table AUGMENTS. The rows of
+	// each table are joined to the rows of the other. Optional.
+	Augments string `yaml:"augments" json:"augments"`
+	// Joins to other tables in the MIB with a shared index. Optional.
+	Joins []*JoinDefinition `yaml:"joins" json:"joins"`
+	// Templates for the context of each device, e.g. name: "{{.Values.ifName}}".
+	// See InfoTemplateData. Optional.
+	Context map[string]string `yaml:"context" json:"context"`
+}
+
+// JoinDefinition declares a join to another table. See TableJoin.
+type JoinDefinition struct {
+	// The name of the joined table in the MIB.
+	Table string `yaml:"table" json:"table"`
+	// The shared index components. When empty, the tables share the whole
+	// index.
+	On []string `yaml:"on" json:"on"`
+}
+
+// ColumnDefinition declares a table column. Columns are in OID order, so the
+// first column is column 1.
+type ColumnDefinition struct {
+	// The column name.
+	Name string `yaml:"name" json:"name"`
+	// The synse device for each row of the column. Columns without a device
+	// are read, but do not create devices, e.g. index columns.
+	Device *ColumnDeviceDefinition `yaml:"device" json:"device"`
+}
+
+// ColumnDeviceDefinition declares the synse device for a table column.
+type ColumnDeviceDefinition struct {
+	// The device type, which is the device handler name, e.g. voltage.
+	Type string `yaml:"type" json:"type"`
+	// Optional multiplier for the raw reading, e.g. 0.1 for units of 0.1 Volts.
+	Multiplier *float32 `yaml:"multiplier" json:"multiplier"`
+	// Optional enumeration of the raw reading to strings.
+	Enumeration map[int]string `yaml:"enumeration" json:"enumeration"`
+	// Template for the device info. See InfoTemplateData. The default is
+	// the column name for flattened tables and the column name followed by
+	// the row number for other tables.
+	Info string `yaml:"info" json:"info"`
+}
+
+// InfoTemplateData is the data for the device info and context templates of
+// a column.
+type InfoTemplateData struct {
+	Table    string                            // The table name.
+	Column   string                            // The column name.
+	Row      int                               // The zero based row number.
+	Index    string                            // The decoded row index, see SnmpRow.IndexString.
+	IndexOid string                            // The row index from the OID.
+	Indexes  map[string]string                 // The decoded index components by name.
+	Values   map[string]interface{}            // The row data by column name.
+	Joined   map[string]map[string]interface{} // The joined row data by table and column name.
+}
+
+// LoadMibDefinition reads a MIB definition from a YAML or JSON file.
+func LoadMibDefinition(path string) (*MibDefinition, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	definition, err := ParseMibDefinition(content)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", path, err)
+	}
+	return definition, nil
+}
+
+// ParseMibDefinition parses and validates a YAML or JSON MIB definition.
+// Content starting with a brace is JSON.
+func ParseMibDefinition(content []byte) (*MibDefinition, error) {
+	definition := &MibDefinition{}
+	if bytes.HasPrefix(bytes.TrimSpace(content), []byte("{")) {
+		// JSON object keys are strings, so enumerations need the json package.
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(definition); err != nil {
+			return nil, err
+		}
+	} else if err := yaml.UnmarshalStrict(content, definition); err != nil {
+		return nil, err
+	}
+	if err := definition.Validate(); err != nil {
+		return nil, err
+	}
+	return definition, nil
+}
+
+// Validate checks the MIB definition. The SNMP server is not consulted.
+func (definition *MibDefinition) Validate() error {
+	if definition.Name == "" {
+		return fmt.Errorf("mib name is empty")
+	}
+	if len(definition.Tables) == 0 {
+		return fmt.Errorf("mib %v has no tables", definition.Name)
+	}
+
+	names := map[string]bool{}
+	for i, table := range definition.Tables {
+		if table == nil {
+			return fmt.Errorf("mib %v table %d is empty", definition.Name, i)
+		}
+		if err := table.Validate(); err != nil {
+			return fmt.Errorf("mib %v: %v", definition.Name, err)
+		}
+		if names[table.Name] {
+			return fmt.Errorf("mib %v: duplicate table %v", definition.Name, table.Name)
+		}
+		names[table.Name] = true
+	}
+
+	// Joined tables are in the MIB.
+	for _, table := range definition.Tables {
+		if table.Augments != "" && !names[table.Augments] {
+			return fmt.Errorf("mib %v: table %v augments unknown table %v",
+				definition.Name, table.Name, table.Augments)
+		}
+		for _, join := range table.Joins {
+			if !names[join.Table] {
+				return fmt.Errorf("mib %v: table %v joins unknown table %v",
+					definition.Name, table.Name, join.Table)
+			}
+		}
+	}
+	return nil
+}
+
+// Validate checks the table definition.
+func (definition *TableDefinition) Validate() error {
+	if definition.Name == "" {
+		return fmt.Errorf("table name is empty")
+	}
+	// Symbolic names, e.g. UPS-MIB::upsInputTable, are resolved when the
+	// table is created.
+	if !strings.HasPrefix(definition.WalkOid, ".") && !isOidName(definition.WalkOid) {
+		return fmt.Errorf("table %v walkOid must start with a period or be an oid name, walkOid: %v",
+			definition.Name, definition.WalkOid)
+	}
+	if len(definition.Columns) == 0 {
+		return fmt.Errorf("table %v has no columns", definition.Name)
+	}
+	if err := ValidateIndex(definition.Index); err != nil {
+		return fmt.Errorf("table %v: %v", definition.Name, err)
+	}
+	if definition.Flattened && (definition.Augments != "" || len(definition.Joins) > 0) {
+		return fmt.Errorf("table %v is flattened and cannot be joined", definition.Name)
+	}
+	if definition.Augments == definition.Name {
+		return fmt.Errorf("table %v augments itself", definition.Name)
+	}
+	for i, join := range definition.Joins {
+		if join == nil || join.Table == "" {
+			return fmt.Errorf("table %v join %d has no table", definition.Name, i+1)
+		}
+		for _, component := range join.On {
+			if !hasIndexComponent(definition.Index, component) {
+				return fmt.Errorf("table %v joins %v on %v, which is not in the table index",
+					definition.Name, join.Table, component)
+			}
+		}
+	}
+	for key, value := range definition.Context {
+		if _, err := template.New(key).Parse(value); err != nil {
+			return fmt.Errorf("table %v context %v: %v", definition.Name, key, err)
+		}
+	}
+
+	for i, column := range definition.Columns {
+		if column == nil || column.Name == "" {
+			return fmt.Errorf("table %v column %d has no name", definition.Name, i+1)
+		}
+		if column.Device == nil {
+			continue
+		}
+		if column.Device.Type == "" {
+			return fmt.Errorf("table %v column %v device has no type", definition.Name, column.Name)
+		}
+		if _, err := template.New(column.Name).Parse(column.Device.Info); err != nil {
+			return fmt.Errorf("table %v column %v device info: %v", definition.Name, column.Name, err)
+		}
+	}
+	return nil
+}
+
+// ColumnNames are the names of the columns in OID order.
+func (definition *TableDefinition) ColumnNames() []string {
+	names := make([]string, len(definition.Columns))
+	for i, column := range definition.Columns {
+		names[i] = column.Name
+	}
+	return names
+}
+
+// NewDefinedTable creates and loads the SnmpTable for a table definition, with
+// a device enumerator for the definition.
+// model is the device model for the device context. It may be empty.
+func NewDefinedTable(
+	definition *TableDefinition, snmpServerBase *SnmpServerBase, model string) (*SnmpTable, error) {
+
+	snmpTable, err := NewTable(definition, snmpServerBase)
+	if err != nil {
+		return nil, err
+	}
+
+	snmpTable.DevEnumerator = DefinedTableDeviceEnumerator{
+		Table:      snmpTable,
+		Definition: definition,
+		Model:      model,
+	}
+	return snmpTable, nil
+}
+
+// NewTable creates and loads the SnmpTable for a table definition. The table
+// has the default device enumerator, which creates no devices, and the device
+// definitions of the columns are not used. See NewDefinedTable and
+// NewColumnTable for tables with devices.
+func NewTable(definition *TableDefinition, snmpServerBase *SnmpServerBase) (*SnmpTable, error) {
+	walkOid, err := OidNames.Oid(definition.WalkOid)
+	if err != nil {
+		return nil, fmt.Errorf("table %v: %v", definition.Name, err)
+	}
+
+	log.WithFields(log.Fields{
+		"name": definition.Name,
+		"oid":  OidNames.Format(walkOid),
+	}).Debug("[snmp] creating new defined table")
+
+	snmpTable, err := NewSnmpTable(
+		definition.Name,
+		walkOid,
+		definition.ColumnNames(),
+		snmpServerBase,
+		definition.RowBase,
+		definition.IndexColumn,
+		definition.ReadableColumn,
+		definition.Flattened,
+	)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error": err,
+			"table": definition.Name,
+		}).Error("[snmp] failed to create table")
+		return nil, err
+	}
+
+	if err = snmpTable.SetIndex(definition.Index); err != nil {
+		return nil, err
+	}
+	return snmpTable, nil
+}
+
+// NewDefinedMib creates and loads the SnmpMib for a MIB definition. As with
+// hand-written MIBs, a table the SNMP server does not implement has no rows,
+// and any error loading a table fails the whole MIB.
+func NewDefinedMib(definition *MibDefinition, snmpServerBase *SnmpServerBase) (*SnmpMib, error) {
+	if snmpServerBase == nil {
+		return nil, fmt.Errorf("NewDefinedMib. snmpServerBase is nil")
+	}
+
+	// Get the model, if any.
+	var model string
+	if definition.ModelOid != "" {
+		result, err := snmpServerBase.SnmpClient.Get(definition.ModelOid)
+		if err != nil {
+			return nil, err
+		}
+		if result.Data != nil {
+			model = fmt.Sprint(result.Data)
+		}
+	}
+
+	var tables []*SnmpTable
+	for _, tableDefinition := range definition.Tables {
+		table, err := NewDefinedTable(tableDefinition, snmpServerBase, model)
+		if err != nil {
+			return nil, fmt.Errorf("mib %v table %v: %v", definition.Name, tableDefinition.Name, err)
+		}
+		tables = append(tables, table)
+	}
+	joinDefinedTables(definition, tables)
+
+	mib, err := NewSnmpMib(definition.Name, tables)
+	if err != nil {
+		return nil, err
+	}
+	if definition.Module != "" {
+		mib.RegisterNames(definition.Module)
+	}
+	return mib, nil
+}
+
+// joinDefinedTables joins the loaded tables of a MIB definition. Joins to
+// tables which are not in the definition are logged and skipped, so the
+// devices of the table are still created, without the joined data.
+func joinDefinedTables(definition *MibDefinition, tables []*SnmpTable) {
+	loaded := map[string]*SnmpTable{}
+	for _, table := range tables {
+		loaded[table.Name] = table
+	}
+
+	for _, tableDefinition := range definition.Tables {
+		table, ok := loaded[tableDefinition.Name]
+		if !ok {
+			continue
+		}
+
+		var err error
+		if tableDefinition.Augments != "" {
+			if augmented, ok := loaded[tableDefinition.Augments]; ok {
+				err = table.Augment(augmented)
+			} else {
+				err = fmt.Errorf("augmented table %v is not loaded", tableDefinition.Augments)
+			}
+			if err != nil {
+				log.WithFields(log.Fields{
+					"error": err,
+					"table": table.Name,
+				}).Warn("[snmp] failed to augment table, skipping")
+			}
+		}
+
+		for _, join := range tableDefinition.Joins {
+			if joined, ok := loaded[join.Table]; ok {
+				err = table.Join(join.Table, joined, join.On...)
+			} else {
+				err = fmt.Errorf("joined table %v is not loaded", join.Table)
+			}
+			if err != nil {
+				log.WithFields(log.Fields{
+					"error": err,
+					"table": table.Name,
+					"join":  join.Table,
+				}).Warn("[snmp] failed to join table, skipping")
+			}
+		}
+	}
+}
+
+// DefinedTableDeviceEnumerator is the device enumerator for tables created
+// from a TableDefinition. There is a device for each row of each column with
+// a device definition.
+type DefinedTableDeviceEnumerator struct {
+	Table      *SnmpTable       // Pointer back to the table.
+	Definition *TableDefinition // The table definition.
+	Model      string           // The device model for the device context.
+}
+
+// DeviceEnumerator creates the devices for the table definition.
+func (enumerator DefinedTableDeviceEnumerator) DeviceEnumerator(
+	data map[string]interface{}) (devices []*config.DeviceProto, err error) {
+
+	table := enumerator.Table
+	definition := enumerator.Definition
+
+	if len(table.Rows) == 0 {
+		log.WithFields(log.Fields{
+			"table": table.Name,
+			"oid":   table.WalkOid,
+		}).Warn("[snmp] table has no rows, will not create any devices for it")
+		return
+	}
+
+	snmpDeviceConfigMap, err := table.SnmpServerBase.DeviceConfig.ToMap()
+	if err != nil {
+		return nil, err
+	}
+
+	// One prototype per device type, in column order.
+	protos := map[string]*config.DeviceProto{}
+	for _, column := range definition.Columns {
+		if column.Device == nil {
+			continue
+		}
+		if _, ok := protos[column.Device.Type]; ok {
+			continue
+		}
+		proto := &config.DeviceProto{
+			Type:      column.Device.Type,
+			Context:   map[string]string{},
+			Instances: []*config.DeviceInstance{},
+			Tags:      snmpDeviceConfigMap["deviceTags"].([]string),
+		}
+		if enumerator.Model != "" {
+			proto.Context["model"] = enumerator.Model
+		}
+		protos[column.Device.Type] = proto
+		devices = append(devices, proto)
+	}
+
+	for i := 0; i < len(table.Rows); i++ {
+		row := table.Rows[i]
+		for j, column := range definition.Columns {
+			if column.Device == nil {
+				continue
+			}
+			columnNumber := j + 1
+
+			deviceData := ColumnDeviceData(table, i, columnNumber)
+			if column.Device.Multiplier != nil {
+				deviceData["multiplier"] = *column.Device.Multiplier
+			}
+			AddEnumeration(deviceData, column.Device.Enumeration)
+			deviceData, err = MergeMapStringInterface(snmpDeviceConfigMap, deviceData)
+			if err != nil {
+				return nil, err
+			}
+
+			templateData := enumerator.templateData(column, i, &row)
+			info, err := enumerator.info(column, templateData)
+			if err != nil {
+				return nil, err
+			}
+			context, err := enumerator.context(templateData)
+			if err != nil {
+				return nil, err
+			}
+
+			proto := protos[column.Device.Type]
+			proto.Instances = append(proto.Instances, &config.DeviceInstance{
+				Info:    info,
+				Context: context,
+				Data:    deviceData,
+			})
+		}
+	}
+	return devices, nil
+}
+
+// templateData gets the template data for a column and row.
+func (enumerator DefinedTableDeviceEnumerator) templateData(
+	column *ColumnDefinition, rowNumber int, row *SnmpRow) *InfoTemplateData {
+
+	indexes := map[string]string{}
+	for _, value := range row.Index {
+		indexes[value.Name] = value.String()
+	}
+
+	joined := map[string]map[string]interface{}{}
+	for _, join := range enumerator.Table.Joins {
+		joined[join.Name] = templateValues(join.Table, row.Joined(join.Name))
+	}
+
+	return &InfoTemplateData{
+		Table:    enumerator.Table.Name,
+		Column:   column.Name,
+		Row:      rowNumber,
+		Index:    row.IndexString(),
+		IndexOid: row.IndexOid,
+		Indexes:  indexes,
+		Values:   templateValues(enumerator.Table, row),
+		Joined:   joined,
+	}
+}
+
+// templateValues gets the template values of a row by column name. Every
+// column of the table has a value, so that templates only fail on column names
+// which are not in the table. Columns without data, and the columns of a
+// missing joined row, are empty.
+func templateValues(table *SnmpTable, row *SnmpRow) map[string]interface{} {
+	values := map[string]interface{}{}
+	for _, column := range table.ColumnList {
+		values[column] = ""
+	}
+	if row == nil {
+		return values
+	}
+	for column, value := range row.Columns() {
+		if value != nil {
+			values[column] = value
+		}
+	}
+	return values
+}
+
+// info executes the device info template for a column and row.
+func (enumerator DefinedTableDeviceEnumerator) info(
+	column *ColumnDefinition, templateData *InfoTemplateData) (string, error) {
+
+	infoTemplate := column.Device.Info
+	if infoTemplate == "" {
+		infoTemplate = "{{.Column}}"
+		if !enumerator.Definition.Flattened {
+			infoTemplate = "{{.Column}}{{.Row}}"
+		}
+	}
+	return executeTemplate(column.Name, infoTemplate, templateData)
+}
+
+// context gets the device context for a column and row: the index context,
+// with the table context templates.
+func (enumerator DefinedTableDeviceEnumerator) context(
+	templateData *InfoTemplateData) (map[string]string, error) {
+
+	context := indexContext(templateData)
+	for key, contextTemplate := range enumerator.Definition.Context {
+		value, err := executeTemplate(key, contextTemplate, templateData)
+		if err != nil {
+			return nil, err
+		}
+		if context == nil {
+			context = map[string]string{}
+		}
+		context[key] = value
+	}
+	return context, nil
+}
+
+// executeTemplate executes a device info or context template. Values missing
+// from the template data, e.g. from a row without a joined row, are empty, so
+// a missing map key is a mistake in the template, e.g. a misspelled column.
+func executeTemplate(name string, text string, templateData *InfoTemplateData) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var result bytes.Buffer
+	if err = tmpl.Execute(&result, templateData); err != nil {
+		return "", err
+	}
+	return result.String(), nil
+}
+
+// indexContext is the device context for the index of a row: the index, and
+// each decoded index component by name. Flattened tables have no context.
+func indexContext(templateData *InfoTemplateData) map[string]string {
+	if templateData.IndexOid == "" {
+		return nil
+	}
+	context := map[string]string{
+		"index": templateData.Index,
+	}
+	for name, value := range templateData.Indexes {
+		context[name] = value
+	}
+	return context
+}
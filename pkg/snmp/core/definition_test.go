@@ -0,0 +1,346 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-sdk/sdk/config"
+)
+
+// newOfflineServerBase creates an SnmpServerBase which is never connected. It
+// is enough to enumerate devices from tables built in the test.
+func newOfflineServerBase(t *testing.T) *SnmpServerBase {
+	securityParameters, err := NewSecurityParameters("simulator", SHA, "auctoritas", AES, "privatus")
+	assert.NoError(t, err)
+	deviceConfig, err := NewDeviceConfig("v3", "127.0.0.1", 1024, securityParameters, "public", []string{})
+	assert.NoError(t, err)
+	client, err := NewSnmpClient(deviceConfig)
+	assert.NoError(t, err)
+	server, err := NewSnmpServerBase(client, deviceConfig)
+	assert.NoError(t, err)
+	return server
+}
+
+// newDefinedTestTable creates a table from a definition and translates the
+// walk results into rows, without an SNMP server.
+func newDefinedTestTable(t *testing.T, definition *TableDefinition, results []ReadResult) *SnmpTable {
+	table := &SnmpTable{
+		Name:           definition.Name,
+		WalkOid:        definition.WalkOid,
+		ColumnList:     definition.ColumnNames(),
+		SnmpServerBase: newOfflineServerBase(t),
+		RowBase:        definition.RowBase,
+		IndexColumn:    definition.IndexColumn,
+		ReadableColumn: definition.ReadableColumn,
+		FlattenedTable: definition.Flattened,
+	}
+	assert.NoError(t, table.SetIndex(definition.Index))
+	assert.NoError(t, table.translate(results))
+	table.DevEnumerator = DefinedTableDeviceEnumerator{
+		Table:      table,
+		Definition: definition,
+		Model:      "test model",
+	}
+	return table
+}
+
+// protosByType indexes device prototypes by type.
+func protosByType(protos []*config.DeviceProto) map[string]*config.DeviceProto {
+	byType := map[string]*config.DeviceProto{}
+	for _, proto := range protos {
+		byType[proto.Type] = proto
+	}
+	return byType
+}
+
+// TestLoadMibDefinitionYaml tests loading a YAML MIB definition.
+func TestLoadMibDefinitionYaml(t *testing.T) {
+	definition, err := LoadMibDefinition("testdata/ups_input_table.yaml")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "UPS-MIB-Input", definition.Name)
+	assert.Equal(t, "UPS-MIB", definition.Module)
+	assert.Equal(t, ".1.3.6.1.2.1.33.1.1.2.0", definition.ModelOid)
+	assert.Len(t, definition.Tables, 2)
+
+	headers := definition.Tables[0]
+	assert.True(t, headers.Flattened)
+	assert.Equal(t, []string{"upsInputLineBads", "upsInputNumLines"}, headers.ColumnNames())
+
+	input := definition.Tables[1]
+	assert.Equal(t, ".1.3.6.1.2.1.33.1.3.3", input.WalkOid)
+	assert.Equal(t, "1", input.RowBase)
+	assert.Equal(t, "2", input.ReadableColumn)
+	assert.False(t, input.Flattened)
+	assert.Len(t, input.Columns, 5)
+	assert.Nil(t, input.Columns[0].Device)
+	assert.Equal(t, "frequency", input.Columns[1].Device.Type)
+	assert.Equal(t, float32(0.1), *input.Columns[1].Device.Multiplier)
+	assert.Nil(t, input.Columns[2].Device.Multiplier)
+	assert.Equal(t, "upsInputVoltage{{.Index}}", input.Columns[2].Device.Info)
+}
+
+// TestLoadMibDefinitionJson tests loading a JSON MIB definition.
+func TestLoadMibDefinitionJson(t *testing.T) {
+	definition, err := LoadMibDefinition("testdata/ups_output_source.json")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "UPS-MIB-Output-Source", definition.Name)
+	assert.Len(t, definition.Tables, 1)
+	device := definition.Tables[0].Columns[0].Device
+	assert.Equal(t, "status", device.Type)
+	assert.Len(t, device.Enumeration, 7)
+	assert.Equal(t, "battery", device.Enumeration[5])
+}
+
+// TestLoadMibDefinitionMissing tests loading a missing file.
+func TestLoadMibDefinitionMissing(t *testing.T) {
+	_, err := LoadMibDefinition("testdata/missing.yaml")
+	assert.Error(t, err)
+}
+
+// TestParseMibDefinitionErrors tests invalid MIB definitions.
+func TestParseMibDefinitionErrors(t *testing.T) {
+	for _, content := range []string{
+		"not: [valid",
+		"name: TEST-MIB",
+		"tables: [{name: t, walkOid: .1.3, columns: [{name: c}]}]",
+		"name: TEST-MIB\nunknown: field\ntables: [{name: t, walkOid: .1.3, columns: [{name: c}]}]",
+		"name: TEST-MIB\ntables: [{walkOid: .1.3, columns: [{name: c}]}]",
+		"name: TEST-MIB\ntables: [{name: t, walkOid: 1.3, columns: [{name: c}]}]",
+		"name: TEST-MIB\ntables: [{name: t, walkOid: .1.3}]",
+		"name: TEST-MIB\ntables: [{name: t, walkOid: .1.3, columns: [{device: {type: status}}]}]",
+		"name: TEST-MIB\ntables: [{name: t, walkOid: .1.3, columns: [{name: c, device: {info: x}}]}]",
+		"name: TEST-MIB\ntables: [{name: t, walkOid: .1.3, columns: [{name: c, device: {type: status, info: '{{'}}]}]",
+		"name: TEST-MIB\ntables: [{name: t, walkOid: .1.3, columns: [{name: c}]}, {name: t, walkOid: .1.4, columns: [{name: c}]}]",
+	} {
+		_, err := ParseMibDefinition([]byte(content))
+		assert.Error(t, err, content)
+	}
+}
+
+// TestDefinedTableDeviceEnumerator tests enumerating devices for a table
+// with rows.
+func TestDefinedTableDeviceEnumerator(t *testing.T) {
+	definition, err := LoadMibDefinition("testdata/ups_input_table.yaml")
+	assert.NoError(t, err)
+
+	// Two input lines, with indexes 1 and 3.
+	table := newDefinedTestTable(t, definition.Tables[1], []ReadResult{
+		{Oid: ".1.3.6.1.2.1.33.1.3.3.1.1.1", Data: 1},
+		{Oid: ".1.3.6.1.2.1.33.1.3.3.1.1.3", Data: 3},
+		{Oid: ".1.3.6.1.2.1.33.1.3.3.1.2.1", Data: 600},
+		{Oid: ".1.3.6.1.2.1.33.1.3.3.1.2.3", Data: 599},
+		{Oid: ".1.3.6.1.2.1.33.1.3.3.1.3.1", Data: 230},
+		{Oid: ".1.3.6.1.2.1.33.1.3.3.1.3.3", Data: 231},
+	})
+	assert.Len(t, table.Rows, 2)
+
+	protos, err := table.DevEnumerator.DeviceEnumerator(map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Len(t, protos, 4)
+
+	// Prototypes are in column order.
+	assert.Equal(t, "frequency", protos[0].Type)
+	assert.Equal(t, "voltage", protos[1].Type)
+	assert.Equal(t, "current", protos[2].Type)
+	assert.Equal(t, "power", protos[3].Type)
+	assert.Equal(t, "test model", protos[0].Context["model"])
+
+	byType := protosByType(protos)
+	for _, proto := range byType {
+		assert.Len(t, proto.Instances, 2)
+	}
+
+	// Default info is the column and row number.
+	frequency := byType["frequency"].Instances[1]
+	assert.Equal(t, "upsInputFrequency1", frequency.Info)
+	assert.Equal(t, ".1.3.6.1.2.1.33.1.3.3.1.%d.3", frequency.Data["base_oid"])
+	assert.Equal(t, ".1.3.6.1.2.1.33.1.3.3.1.2.3", frequency.Data["oid"])
+	assert.Equal(t, "UPS-MIB-UPS-Input-Table", frequency.Data["table_name"])
+	assert.Equal(t, "1", frequency.Data["row"])
+	assert.Equal(t, "2", frequency.Data["column"])
+	assert.Equal(t, float32(0.1), frequency.Data["multiplier"])
+	assert.Equal(t, "127.0.0.1", frequency.Data["endpoint"])
+
+	// Templated info uses the row index.
+	voltage := byType["voltage"].Instances[1]
+	assert.Equal(t, "upsInputVoltage3", voltage.Info)
+	assert.Equal(t, ".1.3.6.1.2.1.33.1.3.3.1.3.3", voltage.Data["oid"])
+	assert.NotContains(t, voltage.Data, "multiplier")
+}
+
+// TestDefinedTableDeviceEnumeratorFlattened tests enumerating devices for a
+// flattened table with an enumeration.
+func TestDefinedTableDeviceEnumeratorFlattened(t *testing.T) {
+	definition, err := LoadMibDefinition("testdata/ups_output_source.json")
+	assert.NoError(t, err)
+
+	table := newDefinedTestTable(t, definition.Tables[0], []ReadResult{
+		{Oid: ".1.3.6.1.2.1.33.1.4.1.0", Data: 3},
+	})
+
+	protos, err := table.DevEnumerator.DeviceEnumerator(map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Len(t, protos, 1)
+	assert.Len(t, protos[0].Instances, 1)
+
+	device := protos[0].Instances[0]
+	assert.Equal(t, "upsOutputSource", device.Info)
+	assert.Equal(t, ".1.3.6.1.2.1.33.1.4.1.0", device.Data["oid"])
+	assert.Equal(t, "true", device.Data["enumeration"])
+	assert.Equal(t, "normal", device.Data["enumeration3"])
+	assert.Equal(t, "reducer", device.Data["enumeration7"])
+}
+
+// TestDefinedTableDeviceEnumeratorEmpty tests that an empty table has no
+// devices.
+func TestDefinedTableDeviceEnumeratorEmpty(t *testing.T) {
+	definition, err := LoadMibDefinition("testdata/ups_input_table.yaml")
+	assert.NoError(t, err)
+
+	table := newDefinedTestTable(t, definition.Tables[1], []ReadResult{})
+	protos, err := table.DevEnumerator.DeviceEnumerator(map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Len(t, protos, 0)
+}
+
+// TestDefinedTableDeviceEnumeratorIndex tests device context and info for a
+// table with a string index.
+func TestDefinedTableDeviceEnumeratorIndex(t *testing.T) {
+	definition, err := ParseMibDefinition([]byte(`
+name: TEST-MIB
+tables:
+  - name: TEST-MIB-Outlet-Table
+    walkOid: .1.3.6.1.4.1.99999.1
+    rowBase: "1"
+    readableColumn: "2"
+    index:
+      - name: outletBank
+        type: integer
+      - name: outletName
+        type: string
+    columns:
+      - name: outletName
+      - name: outletCurrent
+        device:
+          type: current
+          info: "outlet {{.Indexes.outletName}} bank {{.Indexes.outletBank}} ({{.Index}})"
+`))
+	assert.NoError(t, err)
+
+	table := newDefinedTestTable(t, definition.Tables[0], []ReadResult{
+		{Oid: ".1.3.6.1.4.1.99999.1.1.2.1.2.65.49", Data: 3},
+	})
+	protos, err := table.DevEnumerator.DeviceEnumerator(map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Len(t, protos, 1)
+	assert.Len(t, protos[0].Instances, 1)
+
+	device := protos[0].Instances[0]
+	assert.Equal(t, "outlet A1 bank 1 (1.A1)", device.Info)
+	assert.Equal(t, map[string]string{
+		"index":      "1.A1",
+		"outletBank": "1",
+		"outletName": "A1",
+	}, device.Context)
+	assert.Equal(t, ".1.3.6.1.4.1.99999.1.1.2.1.2.65.49", device.Data["oid"])
+}
+
+// TestDefinedTableDeviceEnumeratorJoin tests device info and context from
+// joined tables.
+func TestDefinedTableDeviceEnumeratorJoin(t *testing.T) {
+	definition, err := ParseMibDefinition([]byte(`
+name: TEST-ENTITY-MIB
+tables:
+  - name: entPhysicalTable
+    walkOid: .1.3.6.1.2.1.47.1.1.1
+    rowBase: "1"
+    readableColumn: "2"
+    index:
+      - name: entPhysicalIndex
+        type: integer
+    columns:
+      - name: entPhysicalIndex
+      - name: entPhysicalDescr
+      - name: entPhysicalVendorType
+      - name: entPhysicalContainedIn
+      - name: entPhysicalClass
+  - name: entPhySensorTable
+    walkOid: .1.3.6.1.2.1.99.1.1
+    rowBase: "1"
+    readableColumn: "4"
+    augments: entPhysicalTable
+    index:
+      - name: entPhysicalIndex
+        type: integer
+    context:
+      description: "{{.Joined.entPhysicalTable.entPhysicalDescr}}"
+      class: "{{.Joined.entPhysicalTable.entPhysicalClass}}"
+      vendorType: "{{.Joined.entPhysicalTable.entPhysicalVendorType}}"
+    columns:
+      - name: entPhySensorType
+      - name: entPhySensorScale
+      - name: entPhySensorPrecision
+      - name: entPhySensorValue
+        device:
+          type: temperature
+          info: "{{.Joined.entPhysicalTable.entPhysicalDescr}} sensor {{.Index}}"
+`))
+	assert.NoError(t, err)
+
+	entities := newDefinedTestTable(t, definition.Tables[0], []ReadResult{
+		{Oid: ".1.3.6.1.2.1.47.1.1.1.1.2.7", Data: "Inlet Temperature"},
+		{Oid: ".1.3.6.1.2.1.47.1.1.1.1.5.7", Data: 8},
+	})
+	sensors := newDefinedTestTable(t, definition.Tables[1], []ReadResult{
+		{Oid: ".1.3.6.1.2.1.99.1.1.1.4.7", Data: 24},
+		{Oid: ".1.3.6.1.2.1.99.1.1.1.4.8", Data: 25},
+	})
+	joinDefinedTables(definition, []*SnmpTable{entities, sensors})
+
+	protos, err := sensors.DevEnumerator.DeviceEnumerator(map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Len(t, protos, 1)
+	assert.Len(t, protos[0].Instances, 2)
+
+	device := protos[0].Instances[0]
+	assert.Equal(t, "Inlet Temperature sensor 7", device.Info)
+	assert.Equal(t, map[string]string{
+		"index":            "7",
+		"entPhysicalIndex": "7",
+		"description":      "Inlet Temperature",
+		"class":            "8",
+		"vendorType":       "", // Not served.
+	}, device.Context)
+
+	// A row without a joined row has empty joined values.
+	device = protos[0].Instances[1]
+	assert.Equal(t, " sensor 8", device.Info)
+	assert.Equal(t, "", device.Context["description"])
+
+	// A misspelled column is an error.
+	sensors.DevEnumerator.(DefinedTableDeviceEnumerator).Definition.Context["class"] =
+		"{{.Joined.entPhysicalTable.entPhysicalClas}}"
+	_, err = sensors.DevEnumerator.DeviceEnumerator(map[string]interface{}{})
+	assert.Error(t, err)
+}
+
+// TestMibDefinitionJoinErrors tests joins to tables not in the MIB.
+func TestMibDefinitionJoinErrors(t *testing.T) {
+	for _, content := range []string{
+		// Unknown augmented table.
+		`{"name": "M", "tables": [{"name": "T", "walkOid": ".1", "augments": "X", "columns": [{"name": "c"}]}]}`,
+		// Unknown joined table.
+		`{"name": "M", "tables": [{"name": "T", "walkOid": ".1", "joins": [{"table": "X"}], "columns": [{"name": "c"}]}]}`,
+		// Join on a component which is not in the index.
+		`{"name": "M", "tables": [{"name": "T", "walkOid": ".1", "joins": [{"table": "T", "on": ["i"]}], "columns": [{"name": "c"}]}]}`,
+		// Flattened tables do not join.
+		`{"name": "M", "tables": [{"name": "T", "walkOid": ".1", "flattened": true, "joins": [{"table": "T"}], "columns": [{"name": "c"}]}]}`,
+		// Invalid context template.
+		`{"name": "M", "tables": [{"name": "T", "walkOid": ".1", "context": {"name": "{{"}, "columns": [{"name": "c"}]}]}`,
+	} {
+		_, err := ParseMibDefinition([]byte(content))
+		assert.Error(t, err, content)
+	}
+}
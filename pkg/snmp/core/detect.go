@@ -0,0 +1,180 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// SysObjectIDOid is sysObjectID.0 from SNMPv2-MIB, the vendor
+	// identification of the agent, e.g. .1.3.6.1.4.1.534.2.12.
+	SysObjectIDOid = ".1.3.6.1.2.1.1.2.0"
+	// SysORTableOid is sysORTable from SNMPv2-MIB, which lists the MIB
+	// modules an agent implements.
+	SysORTableOid = ".1.3.6.1.2.1.1.9"
+	// sysORIDColumnOid is the sysORID column of the sysORTable.
+	sysORIDColumnOid = SysORTableOid + ".1.2."
+)
+
+// MibDetection declares how to detect that an SNMP agent implements a
+// registered MIB. The MIB is detected if any of these match.
+type MibDetection struct {
+	// MODULE-IDENTITY or compliance OIDs of the MIB. Agents list these in
+	// the sysORTable, e.g. .1.3.6.1.2.1.33 for UPS-MIB.
+	SysORIDs []string
+	// sysObjectID prefixes of agents known to implement the MIB, e.g. an
+	// enterprise OID.
+	SysObjectIDs []string
+	// OIDs to walk. The MIB is detected if the agent has any object under
+	// one of them. Keep these small, e.g. an identity group.
+	ProbeOids []string
+}
+
+var (
+	// mibDetections are the detections for registered MIBs by name.
+	mibDetections = map[string]MibDetection{}
+)
+
+// RegisterMibDetection registers how to detect a registered MIB. See
+// DetectMibs.
+func RegisterMibDetection(name string, detection MibDetection) error {
+	mibFactoriesLock.Lock()
+	defer mibFactoriesLock.Unlock()
+	if _, ok := mibFactories[name]; !ok {
+		return fmt.Errorf("RegisterMibDetection. mib %v is not registered", name)
+	}
+	if len(detection.SysORIDs)+len(detection.SysObjectIDs)+len(detection.ProbeOids) == 0 {
+		return fmt.Errorf("RegisterMibDetection. mib %v has nothing to detect", name)
+	}
+	mibDetections[name] = detection
+	return nil
+}
+
+// MibReader reads from an SNMP agent. SnmpClient implements it.
+type MibReader interface {
+	Get(oid string) (ReadResult, error)
+	Walk(rootOid string) ([]ReadResult, error)
+}
+
+// MibDetectionResult is the result of detecting the MIBs an agent implements.
+type MibDetectionResult struct {
+	// The sysObjectID of the agent.
+	SysObjectID string
+	// The sysORID values from the sysORTable of the agent.
+	SysORIDs []string
+	// The names of the registered MIBs detected, sorted.
+	Mibs []string
+	// Why each MIB was detected, e.g. "sysORID .1.3.6.1.2.1.33", keyed by name.
+	Reasons map[string]string
+}
+
+// Metadata is the detection result as agent metadata for device context.
+func (result *MibDetectionResult) Metadata() map[string]string {
+	return map[string]string{
+		"sysObjectID":  result.SysObjectID,
+		"detectedMibs": strings.Join(result.Mibs, ","),
+	}
+}
+
+// DetectMibs detects which registered MIBs an SNMP agent implements from
+// its sysObjectID, its sysORTable and the probe OIDs of each MIB. See
+// RegisterMibDetection. An error is returned if the sysObjectID cannot be
+// read, since the agent is most likely unreachable. The sysORTable is
+// optional.
+func DetectMibs(reader MibReader) (*MibDetectionResult, error) {
+	sysObjectID, err := reader.Get(SysObjectIDOid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sysObjectID: %v", err)
+	}
+	result := &MibDetectionResult{
+		Reasons: map[string]string{},
+	}
+	if sysObjectID.Data != nil {
+		result.SysObjectID = normalizeDetectionOid(fmt.Sprint(sysObjectID.Data))
+	}
+
+	sysORTable, err := reader.Walk(SysORTableOid)
+	if err != nil {
+		log.WithError(err).Warn("[snmp] failed to walk sysORTable, skipping")
+	}
+	for _, entry := range sysORTable {
+		if strings.HasPrefix(entry.Oid, sysORIDColumnOid) && entry.Data != nil {
+			result.SysORIDs = append(result.SysORIDs, normalizeDetectionOid(fmt.Sprint(entry.Data)))
+		}
+	}
+
+	mibFactoriesLock.RLock()
+	detections := map[string]MibDetection{}
+	for name, detection := range mibDetections {
+		detections[name] = detection
+	}
+	mibFactoriesLock.RUnlock()
+
+	for name, detection := range detections {
+		if reason, ok := detectMib(reader, result, detection); ok {
+			result.Mibs = append(result.Mibs, name)
+			result.Reasons[name] = reason
+		}
+	}
+	sort.Strings(result.Mibs)
+
+	log.WithFields(log.Fields{
+		"sysObjectID": result.SysObjectID,
+		"sysORIDs":    result.SysORIDs,
+		"mibs":        result.Mibs,
+		"reasons":     result.Reasons,
+	}).Info("[snmp] detected MIBs")
+	return result, nil
+}
+
+// detectMib checks one MIB detection, cheapest first. The reason is returned
+// when the MIB is detected.
+func detectMib(reader MibReader, result *MibDetectionResult, detection MibDetection) (reason string, ok bool) {
+	for _, sysORID := range detection.SysORIDs {
+		for _, agentORID := range result.SysORIDs {
+			if hasOidPrefix(agentORID, normalizeDetectionOid(sysORID)) {
+				return fmt.Sprintf("sysORID %v", agentORID), true
+			}
+		}
+	}
+
+	for _, sysObjectID := range detection.SysObjectIDs {
+		if result.SysObjectID != "" && hasOidPrefix(result.SysObjectID, normalizeDetectionOid(sysObjectID)) {
+			return fmt.Sprintf("sysObjectID %v", result.SysObjectID), true
+		}
+	}
+
+	for _, probeOid := range detection.ProbeOids {
+		results, err := reader.Walk(probeOid)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"error": err,
+				"oid":   probeOid,
+			}).Debug("[snmp] failed to probe oid")
+			continue
+		}
+		for _, probe := range results {
+			if probe.Data != nil {
+				return fmt.Sprintf("probe %v", probeOid), true
+			}
+		}
+	}
+	return "", false
+}
+
+// normalizeDetectionOid adds the leading period to an OID, since gosnmp
+// returns OID values with one, but configurations may not.
+func normalizeDetectionOid(oid string) string {
+	if oid == "" || strings.HasPrefix(oid, ".") {
+		return oid
+	}
+	return "." + oid
+}
+
+// hasOidPrefix is true if the OID is the prefix OID or under it.
+func hasOidPrefix(oid string, prefix string) bool {
+	return oid == prefix || strings.HasPrefix(oid, prefix+".")
+}
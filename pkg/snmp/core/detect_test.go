@@ -0,0 +1,108 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testMibReader reads from walk results instead of an SNMP agent.
+type testMibReader struct {
+	results []ReadResult
+	walks   []string
+	err     error
+}
+
+func (reader *testMibReader) Get(oid string) (ReadResult, error) {
+	if reader.err != nil {
+		return ReadResult{}, reader.err
+	}
+	for _, result := range reader.results {
+		if result.Oid == oid {
+			return result, nil
+		}
+	}
+	return ReadResult{Oid: oid}, nil
+}
+
+func (reader *testMibReader) Walk(rootOid string) (results []ReadResult, err error) {
+	reader.walks = append(reader.walks, rootOid)
+	for _, result := range reader.results {
+		if strings.HasPrefix(result.Oid, rootOid+".") {
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+func init() {
+	for name, detection := range map[string]MibDetection{
+		"TEST-DETECT-ORID-MIB":   {SysORIDs: []string{"1.3.6.1.2.1.33"}},
+		"TEST-DETECT-VENDOR-MIB": {SysObjectIDs: []string{".1.3.6.1.4.1.534"}},
+		"TEST-DETECT-PROBE-MIB":  {ProbeOids: []string{".1.3.6.1.4.1.99999.1"}},
+	} {
+		factory := func(server *SnmpServerBase) (Mib, error) { return nil, fmt.Errorf("not implemented") }
+		if err := RegisterMib(name, factory); err != nil {
+			panic(err)
+		}
+		if err := RegisterMibDetection(name, detection); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// TestDetectMibs tests detecting MIBs from the sysORTable, sysObjectID and
+// probe OIDs.
+func TestDetectMibs(t *testing.T) {
+	reader := &testMibReader{results: []ReadResult{
+		{Oid: SysObjectIDOid, Data: ".1.3.6.1.4.1.534.2.12"},
+		{Oid: ".1.3.6.1.2.1.1.9.1.2.1", Data: ".1.3.6.1.2.1.50"},
+		{Oid: ".1.3.6.1.2.1.1.9.1.2.2", Data: ".1.3.6.1.2.1.33.2.1"},
+		{Oid: ".1.3.6.1.2.1.1.9.1.3.1", Data: "The MIB module for managing UDP implementations"},
+		// Scalars without the .0 suffix are still found by the probe.
+		{Oid: ".1.3.6.1.4.1.99999.1.1", Data: "TestVendor"},
+	}}
+
+	result, err := DetectMibs(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, ".1.3.6.1.4.1.534.2.12", result.SysObjectID)
+	assert.Equal(t, []string{".1.3.6.1.2.1.50", ".1.3.6.1.2.1.33.2.1"}, result.SysORIDs)
+	assert.Equal(t, []string{"TEST-DETECT-ORID-MIB", "TEST-DETECT-PROBE-MIB", "TEST-DETECT-VENDOR-MIB"}, result.Mibs)
+	assert.Equal(t, "sysORID .1.3.6.1.2.1.33.2.1", result.Reasons["TEST-DETECT-ORID-MIB"])
+	assert.Equal(t, "sysObjectID .1.3.6.1.4.1.534.2.12", result.Reasons["TEST-DETECT-VENDOR-MIB"])
+	assert.Equal(t, "probe .1.3.6.1.4.1.99999.1", result.Reasons["TEST-DETECT-PROBE-MIB"])
+	assert.Equal(t, map[string]string{
+		"sysObjectID":  ".1.3.6.1.4.1.534.2.12",
+		"detectedMibs": "TEST-DETECT-ORID-MIB,TEST-DETECT-PROBE-MIB,TEST-DETECT-VENDOR-MIB",
+	}, result.Metadata())
+}
+
+// TestDetectMibsNone tests an agent which implements no registered MIB.
+func TestDetectMibsNone(t *testing.T) {
+	reader := &testMibReader{results: []ReadResult{
+		{Oid: SysObjectIDOid, Data: ".1.3.6.1.4.1.8072.3.2.10"},
+		// Not a sysORID.
+		{Oid: ".1.3.6.1.2.1.1.9.1.3.1", Data: ".1.3.6.1.2.1.33"},
+		// Not under the enterprise.
+		{Oid: ".1.3.6.1.4.1.5340.1", Data: 1},
+		// No such object.
+		{Oid: ".1.3.6.1.4.1.99999.1.1", Data: nil},
+	}}
+
+	result, err := DetectMibs(reader)
+	assert.NoError(t, err)
+	assert.Empty(t, result.Mibs)
+	assert.Contains(t, reader.walks, ".1.3.6.1.4.1.99999.1")
+
+	// The agent is unreachable.
+	_, err = DetectMibs(&testMibReader{err: fmt.Errorf("timeout")})
+	assert.Error(t, err)
+}
+
+// TestRegisterMibDetectionErrors tests invalid detections.
+func TestRegisterMibDetectionErrors(t *testing.T) {
+	assert.Error(t, RegisterMibDetection("TEST-UNREGISTERED-MIB", MibDetection{ProbeOids: []string{".1"}}))
+	assert.Error(t, RegisterMibDetection("TEST-DETECT-ORID-MIB", MibDetection{}))
+}
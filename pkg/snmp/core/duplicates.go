@@ -0,0 +1,78 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vapor-ware/synse-sdk/sdk/config"
+)
+
+// DuplicatesKey is the device data key for the OIDs of the objects in other
+// MIBs which the device duplicates, as a comma separated list, e.g. the
+// UPS-MIB upsIdentModel for the PowerNet-MIB upsBasicIdentModel. When the
+// devices of several MIBs are merged, the device of the MIB enabled first is
+// kept. See RemoveDuplicates.
+const DuplicatesKey = "duplicates"
+
+// duplicateOids gets the OIDs in the DuplicatesKey of the device data.
+func duplicateOids(data map[string]interface{}) []string {
+	duplicates, ok := data[DuplicatesKey].(string)
+	if !ok || duplicates == "" {
+		return nil
+	}
+	return strings.Split(duplicates, ",")
+}
+
+// RemoveDuplicates removes the device instances which duplicate the enabled
+// devices, in either direction, from the devices of a MIB being enabled.
+// Protos left without instances by it are removed, so the protos are returned.
+func RemoveDuplicates(enabled []*config.DeviceProto, devices []*config.DeviceProto) []*config.DeviceProto {
+	enabledOids := map[string]bool{}
+	enabledDuplicates := map[string]bool{}
+	for _, proto := range enabled {
+		for _, instance := range proto.Instances {
+			enabledOids[fmt.Sprint(instance.Data["oid"])] = true
+			for _, oid := range duplicateOids(instance.Data) {
+				enabledDuplicates[oid] = true
+			}
+		}
+	}
+
+	var kept []*config.DeviceProto
+	for _, proto := range devices {
+		var instances []*config.DeviceInstance
+		for _, instance := range proto.Instances {
+			if duplicate := isDuplicate(instance.Data, enabledOids, enabledDuplicates); duplicate != "" {
+				log.WithFields(log.Fields{
+					"device":    instance.Info,
+					"duplicate": OidNames.Format(duplicate),
+				}).Debug("[snmp] removed duplicate device")
+				continue
+			}
+			instances = append(instances, instance)
+		}
+		// Prototypes left without instances by the duplicates are dropped.
+		if len(instances) == 0 && len(proto.Instances) > 0 {
+			continue
+		}
+		proto.Instances = instances
+		kept = append(kept, proto)
+	}
+	return kept
+}
+
+// isDuplicate gets the OID of the enabled device the device duplicates, or
+// empty if it is not a duplicate.
+func isDuplicate(data map[string]interface{}, enabledOids map[string]bool, enabledDuplicates map[string]bool) string {
+	oid := fmt.Sprint(data["oid"])
+	if enabledDuplicates[oid] {
+		return oid
+	}
+	for _, duplicate := range duplicateOids(data) {
+		if enabledOids[duplicate] {
+			return duplicate
+		}
+	}
+	return ""
+}
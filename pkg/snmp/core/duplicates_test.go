@@ -0,0 +1,57 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-sdk/sdk/config"
+)
+
+// duplicatesTestProto creates a proto with an instance for each OID. The
+// duplicates are keyed by OID.
+func duplicatesTestProto(deviceType string, oids []string, duplicates map[string]string) *config.DeviceProto {
+	proto := &config.DeviceProto{Type: deviceType}
+	for _, oid := range oids {
+		data := map[string]interface{}{"oid": oid}
+		if duplicate, ok := duplicates[oid]; ok {
+			data[DuplicatesKey] = duplicate
+		}
+		proto.Instances = append(proto.Instances, &config.DeviceInstance{Info: oid, Data: data})
+	}
+	return proto
+}
+
+// TestRemoveDuplicates tests that the devices of the first MIB are kept.
+func TestRemoveDuplicates(t *testing.T) {
+	upsMib := []*config.DeviceProto{
+		duplicatesTestProto("identity", []string{".1.3.6.1.2.1.33.1.1.2.0", ".1.3.6.1.2.1.33.1.1.5.0"}, nil),
+		duplicatesTestProto("percentage", []string{".1.3.6.1.2.1.33.1.2.4.0"}, nil),
+	}
+	powerNet := func() []*config.DeviceProto {
+		return []*config.DeviceProto{
+			duplicatesTestProto("identity", []string{".1.3.6.1.4.1.318.1.1.1.1.1.1.0", ".1.3.6.1.4.1.318.1.1.1.2.1.3.0"},
+				map[string]string{".1.3.6.1.4.1.318.1.1.1.1.1.1.0": ".1.3.6.1.2.1.33.1.1.2.0"}),
+			duplicatesTestProto("percentage", []string{".1.3.6.1.4.1.318.1.1.1.4.1.1.0"},
+				map[string]string{".1.3.6.1.4.1.318.1.1.1.4.1.1.0": ".1.3.6.1.2.1.33.1.2.9.0,.1.3.6.1.2.1.33.1.2.4.0"}),
+		}
+	}
+
+	// UPS-MIB first. Only the PowerNet-MIB serial number is kept.
+	kept := RemoveDuplicates(upsMib, powerNet())
+	assert.Len(t, kept, 1)
+	assert.Equal(t, "identity", kept[0].Type)
+	assert.Len(t, kept[0].Instances, 1)
+	assert.Equal(t, ".1.3.6.1.4.1.318.1.1.1.2.1.3.0", kept[0].Instances[0].Info)
+
+	// PowerNet-MIB first. Only the UPS-MIB name is kept.
+	kept = RemoveDuplicates(powerNet(), upsMib)
+	assert.Len(t, kept, 1)
+	assert.Len(t, kept[0].Instances, 1)
+	assert.Equal(t, ".1.3.6.1.2.1.33.1.1.5.0", kept[0].Instances[0].Info)
+
+	// Nothing is enabled yet.
+	assert.Len(t, RemoveDuplicates(nil, powerNet()), 2)
+
+	// Protos which had no instances are kept.
+	assert.Len(t, RemoveDuplicates(upsMib, []*config.DeviceProto{{Type: "status"}}), 1)
+}
@@ -0,0 +1,213 @@
+package core
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// IndexType is the type of a component of a table INDEX clause. It decides
+// how the component is encoded in the OID of each row. See RFC 2578 7.7.
+type IndexType string
+
+const (
+	// IndexInteger is an INTEGER, Integer32, Unsigned32 or the like. It is a
+	// single OID component.
+	IndexInteger IndexType = "integer"
+	// IndexString is an OCTET STRING, e.g. an outlet name. It is prefixed with
+	// the length, unless it is fixed length or IMPLIED.
+	IndexString IndexType = "string"
+	// IndexIPAddress is an IpAddress. It is four OID components.
+	IndexIPAddress IndexType = "ipAddress"
+	// IndexOid is an OBJECT IDENTIFIER. It is prefixed with the length,
+	// unless it is IMPLIED.
+	IndexOid IndexType = "oid"
+)
+
+// IndexComponent declares one component of a table INDEX clause.
+type IndexComponent struct {
+	// The name of the index object, e.g. entPhysicalIndex.
+	Name string `yaml:"name" json:"name"`
+	// The type of the index object.
+	Type IndexType `yaml:"type" json:"type"`
+	// The length of a fixed length string, e.g. 6 for a MacAddress. Zero
+	// for length prefixed strings.
+	Length int `yaml:"length" json:"length"`
+	// True for an IMPLIED string or OID, which has no length prefix. Only
+	// the last component may be implied.
+	Implied bool `yaml:"implied" json:"implied"`
+	// True for a binary string, e.g. a MacAddress or PhysAddress, which is
+	// formatted as hex octets, e.g. 00:1a:2b:3c:4d:5e. Strings which are not
+	// printable are formatted as hex regardless.
+	Hex bool `yaml:"hex" json:"hex"`
+}
+
+// ValidateIndex checks the components of an INDEX clause.
+func ValidateIndex(index []IndexComponent) error {
+	for i, component := range index {
+		if component.Name == "" {
+			return fmt.Errorf("index component %d has no name", i+1)
+		}
+		switch component.Type {
+		case IndexInteger, IndexIPAddress:
+			if component.Implied || component.Length != 0 {
+				return fmt.Errorf("index %v of type %v cannot be implied or fixed length", component.Name, component.Type)
+			}
+		case IndexString, IndexOid:
+			if component.Length < 0 {
+				return fmt.Errorf("index %v length must not be negative", component.Name)
+			}
+			if component.Implied && i != len(index)-1 {
+				return fmt.Errorf("only the last index component may be implied, not %v", component.Name)
+			}
+		default:
+			return fmt.Errorf("index %v has unknown type %q", component.Name, component.Type)
+		}
+	}
+	return nil
+}
+
+// IndexValue is one decoded component of a row index.
+type IndexValue struct {
+	// The name of the index object.
+	Name string
+	// The type of the index object.
+	Type IndexType
+	// The value: uint64 for integers, string for strings, IP addresses and
+	// OIDs (with a leading dot).
+	Value interface{}
+	// True for a binary string. See IndexComponent.
+	Hex bool
+}
+
+// String formats the value for device info and context. Binary strings are
+// formatted as hex octets, e.g. 00:1a:2b:3c:4d:5e.
+func (value IndexValue) String() string {
+	if str, ok := value.Value.(string); ok && value.Type == IndexString && (value.Hex || !isPrintable(str)) {
+		octets := make([]string, len(str))
+		for i := 0; i < len(str); i++ {
+			octets[i] = fmt.Sprintf("%02x", str[i])
+		}
+		return strings.Join(octets, ":")
+	}
+	return fmt.Sprint(value.Value)
+}
+
+// isPrintable is true if every byte of a string is printable ASCII.
+func isPrintable(str string) bool {
+	for i := 0; i < len(str); i++ {
+		if str[i] < 0x20 || str[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// DecodeIndex decodes the index suffix of a row OID, e.g. "5.104.101.108.108.111",
+// with the INDEX clause of the table.
+func DecodeIndex(index []IndexComponent, suffix string) ([]IndexValue, error) {
+	oid, err := NewOid(suffix)
+	if err != nil {
+		return nil, fmt.Errorf("invalid index %q: %v", suffix, err)
+	}
+	arcs := oid.ToSlice
+
+	// take consumes n arcs.
+	take := func(component IndexComponent, n int) ([]uint64, error) {
+		if n > len(arcs) {
+			return nil, fmt.Errorf("index %q is too short for %v", suffix, component.Name)
+		}
+		taken := arcs[:n]
+		arcs = arcs[n:]
+		return taken, nil
+	}
+
+	// length gets the number of arcs in a variable length component.
+	length := func(component IndexComponent) (int, error) {
+		switch {
+		case component.Length > 0:
+			return component.Length, nil
+		case component.Implied:
+			return len(arcs), nil
+		default:
+			prefix, err := take(component, 1)
+			if err != nil {
+				return 0, err
+			}
+			if prefix[0] > uint64(len(arcs)) {
+				return 0, fmt.Errorf("index %q is too short for %v", suffix, component.Name)
+			}
+			return int(prefix[0]), nil
+		}
+	}
+
+	var values []IndexValue
+	for _, component := range index {
+		value := IndexValue{Name: component.Name, Type: component.Type, Hex: component.Hex}
+		switch component.Type {
+		case IndexInteger:
+			taken, err := take(component, 1)
+			if err != nil {
+				return nil, err
+			}
+			value.Value = taken[0]
+
+		case IndexIPAddress:
+			taken, err := take(component, 4)
+			if err != nil {
+				return nil, err
+			}
+			ip := make(net.IP, 4)
+			for i, arc := range taken {
+				if arc > 255 {
+					return nil, fmt.Errorf("index %q has an invalid IpAddress for %v", suffix, component.Name)
+				}
+				ip[i] = byte(arc)
+			}
+			value.Value = ip.String()
+
+		case IndexString:
+			n, err := length(component)
+			if err != nil {
+				return nil, err
+			}
+			taken, err := take(component, n)
+			if err != nil {
+				return nil, err
+			}
+			bytes := make([]byte, len(taken))
+			for i, arc := range taken {
+				if arc > 255 {
+					return nil, fmt.Errorf("index %q has an invalid string for %v", suffix, component.Name)
+				}
+				bytes[i] = byte(arc)
+			}
+			value.Value = string(bytes)
+
+		case IndexOid:
+			n, err := length(component)
+			if err != nil {
+				return nil, err
+			}
+			taken, err := take(component, n)
+			if err != nil {
+				return nil, err
+			}
+			parts := make([]string, len(taken))
+			for i, arc := range taken {
+				parts[i] = strconv.FormatUint(arc, 10)
+			}
+			value.Value = "." + strings.Join(parts, ".")
+
+		default:
+			return nil, fmt.Errorf("index %v has unknown type %q", component.Name, component.Type)
+		}
+		values = append(values, value)
+	}
+
+	if len(arcs) > 0 {
+		return nil, fmt.Errorf("index %q is too long for %d index components", suffix, len(index))
+	}
+	return values, nil
+}
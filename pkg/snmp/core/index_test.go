@@ -0,0 +1,193 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDecodeIndex tests decoding row indexes.
+func TestDecodeIndex(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		index  []IndexComponent
+		suffix string
+		values []interface{}
+	}{
+		{
+			"integer",
+			[]IndexComponent{{Name: "upsInputLineIndex", Type: IndexInteger}},
+			"3",
+			[]interface{}{uint64(3)},
+		},
+		{
+			"composite integers, e.g. ifStackTable",
+			[]IndexComponent{{Name: "ifStackHigherLayer", Type: IndexInteger}, {Name: "ifStackLowerLayer", Type: IndexInteger}},
+			"0.12",
+			[]interface{}{uint64(0), uint64(12)},
+		},
+		{
+			"length prefixed string",
+			[]IndexComponent{{Name: "outletName", Type: IndexString}},
+			"5.104.101.108.108.111",
+			[]interface{}{"hello"},
+		},
+		{
+			"integer and implied string",
+			[]IndexComponent{{Name: "sensorIndex", Type: IndexInteger}, {Name: "sensorName", Type: IndexString, Implied: true}},
+			"2.104.105",
+			[]interface{}{uint64(2), "hi"},
+		},
+		{
+			"fixed length string",
+			[]IndexComponent{{Name: "mac", Type: IndexString, Length: 6}, {Name: "port", Type: IndexInteger}},
+			"0.26.43.60.77.94.7",
+			[]interface{}{"\x00\x1a\x2b\x3c\x4d\x5e", uint64(7)},
+		},
+		{
+			"ip address",
+			[]IndexComponent{{Name: "ipAdEntAddr", Type: IndexIPAddress}},
+			"10.0.0.1",
+			[]interface{}{"10.0.0.1"},
+		},
+		{
+			"length prefixed and implied oids",
+			[]IndexComponent{{Name: "a", Type: IndexOid}, {Name: "b", Type: IndexOid, Implied: true}},
+			"2.1.3.1.3.6",
+			[]interface{}{".1.3", ".1.3.6"},
+		},
+	} {
+		values, err := DecodeIndex(test.index, test.suffix)
+		assert.NoError(t, err, test.name)
+		assert.Len(t, values, len(test.values), test.name)
+		for i, value := range values {
+			assert.Equal(t, test.index[i].Name, value.Name, test.name)
+			assert.Equal(t, test.index[i].Type, value.Type, test.name)
+			assert.Equal(t, test.values[i], value.Value, test.name)
+		}
+	}
+}
+
+// TestIndexValueString tests formatting index values for device info and
+// context.
+func TestIndexValueString(t *testing.T) {
+	for _, test := range []struct {
+		value    IndexValue
+		expected string
+	}{
+		{IndexValue{Type: IndexInteger, Value: uint64(3)}, "3"},
+		{IndexValue{Type: IndexString, Value: "hello"}, "hello"},
+		{IndexValue{Type: IndexIPAddress, Value: "10.0.0.1"}, "10.0.0.1"},
+		{IndexValue{Type: IndexOid, Value: ".1.3.6"}, ".1.3.6"},
+		// Not printable, e.g. a MacAddress.
+		{IndexValue{Type: IndexString, Value: "\x00\x1a\x2b\x3c\x4d\x5e"}, "00:1a:2b:3c:4d:5e"},
+		// A binary string which happens to be printable.
+		{IndexValue{Type: IndexString, Value: "ABCDEF", Hex: true}, "41:42:43:44:45:46"},
+		{IndexValue{Type: IndexString, Value: "", Hex: true}, ""},
+	} {
+		assert.Equal(t, test.expected, test.value.String(), test.value)
+	}
+
+	values, err := DecodeIndex([]IndexComponent{{Name: "mac", Type: IndexString, Length: 6, Hex: true}}, "65.66.67.68.69.70")
+	assert.NoError(t, err)
+	assert.Equal(t, "41:42:43:44:45:46", values[0].String())
+}
+
+// TestDecodeIndexErrors tests indexes which do not decode.
+func TestDecodeIndexErrors(t *testing.T) {
+	integer := []IndexComponent{{Name: "i", Type: IndexInteger}}
+	str := []IndexComponent{{Name: "s", Type: IndexString}}
+	for _, test := range []struct {
+		index  []IndexComponent
+		suffix string
+	}{
+		{integer, ""},
+		{integer, "1.2"},
+		{integer, "x"},
+		{str, "5.104.105"},
+		{str, "18446744073709551615.104"},
+		{str, "1.256"},
+		{[]IndexComponent{{Name: "ip", Type: IndexIPAddress}}, "10.0.0"},
+		{[]IndexComponent{{Name: "ip", Type: IndexIPAddress}}, "10.0.0.256"},
+		{[]IndexComponent{{Name: "x", Type: "float"}}, "1"},
+	} {
+		_, err := DecodeIndex(test.index, test.suffix)
+		assert.Error(t, err, test.suffix)
+	}
+}
+
+// TestValidateIndex tests checking INDEX clauses.
+func TestValidateIndex(t *testing.T) {
+	assert.NoError(t, ValidateIndex(nil))
+	assert.NoError(t, ValidateIndex([]IndexComponent{
+		{Name: "i", Type: IndexInteger},
+		{Name: "s", Type: IndexString, Implied: true},
+	}))
+
+	for _, index := range [][]IndexComponent{
+		{{Type: IndexInteger}},
+		{{Name: "i", Type: IndexInteger, Implied: true}},
+		{{Name: "ip", Type: IndexIPAddress, Length: 4}},
+		{{Name: "s", Type: IndexString, Length: -1}},
+		{{Name: "s", Type: IndexString, Implied: true}, {Name: "i", Type: IndexInteger}},
+		{{Name: "x", Type: "float"}},
+	} {
+		assert.Error(t, ValidateIndex(index), index)
+	}
+}
+
+// TestTableRowIndex tests that table rows have their decoded index.
+func TestTableRowIndex(t *testing.T) {
+	table := &SnmpTable{
+		Name:           "TEST-MIB-Outlet-Table",
+		WalkOid:        ".1.3.6.1.4.1.99999.1",
+		ColumnList:     []string{"outletName", "outletState"},
+		RowBase:        "1",
+		ReadableColumn: "2",
+	}
+	assert.NoError(t, table.translate([]ReadResult{
+		{Oid: ".1.3.6.1.4.1.99999.1.1.2.1.2.65.49", Data: 1},
+		{Oid: ".1.3.6.1.4.1.99999.1.1.2.2.2.65.50", Data: 2},
+	}))
+	assert.Len(t, table.Rows, 2)
+
+	// Without an INDEX clause there is only the OID.
+	assert.Equal(t, "1.2.65.49", table.Rows[0].IndexOid)
+	assert.Nil(t, table.Rows[0].Index)
+	assert.Equal(t, "1.2.65.49", table.Rows[0].IndexString())
+
+	assert.NoError(t, table.SetIndex([]IndexComponent{
+		{Name: "outletBank", Type: IndexInteger},
+		{Name: "outletName", Type: IndexString},
+	}))
+	assert.Equal(t, "2.A2", table.Rows[1].IndexString())
+	value, ok := table.Rows[1].IndexValue("outletName")
+	assert.True(t, ok)
+	assert.Equal(t, "A2", value.Value)
+	_, ok = table.Rows[1].IndexValue("missing")
+	assert.False(t, ok)
+
+	// Rows which do not decode keep the OID.
+	assert.Error(t, table.SetIndex([]IndexComponent{{Name: "x", Type: "float"}}))
+	assert.NoError(t, table.SetIndex([]IndexComponent{{Name: "outletBank", Type: IndexInteger}}))
+	assert.Nil(t, table.Rows[0].Index)
+	assert.Equal(t, "1.2.65.49", table.Rows[0].IndexString())
+}
+
+// TestTableRowIndexColumn tests row indexes from a readable index column.
+func TestTableRowIndexColumn(t *testing.T) {
+	table := &SnmpTable{
+		Name:        "UPS-MIB-UPS-Input-Table",
+		WalkOid:     ".1.3.6.1.2.1.33.1.3.3",
+		ColumnList:  []string{"upsInputLineIndex", "upsInputFrequency"},
+		RowBase:     "1",
+		IndexColumn: "1",
+	}
+	assert.NoError(t, table.translate([]ReadResult{
+		{Oid: ".1.3.6.1.2.1.33.1.3.3.1.1.1", Data: 1},
+		{Oid: ".1.3.6.1.2.1.33.1.3.3.1.2.1", Data: 600},
+	}))
+	assert.Len(t, table.Rows, 1)
+	assert.Equal(t, ".1.3.6.1.2.1.33.1.3.3.1.%d.1", table.Rows[0].BaseOid)
+	assert.Equal(t, 600, table.Rows[0].RowData[1].Data)
+}
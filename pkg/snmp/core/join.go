@@ -0,0 +1,173 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TableJoin joins the rows of a table to the rows of another table with a
+// shared index, e.g. ifTable to ifXTable, or entPhySensorTable to
+// entPhysicalTable. Enumerators use the joined rows to build device info and
+// context from both tables in one pass.
+type TableJoin struct {
+	// The name of the join, used to look up joined rows. This is the name
+	// of the joined table unless given otherwise.
+	Name string
+	// The joined table.
+	Table *SnmpTable
+	// The names of the shared index components, e.g. entPhysicalIndex. Both
+	// tables need an INDEX clause with these components. When empty, rows
+	// join on the whole index OID, as for a table which AUGMENTS another.
+	On []string
+}
+
+// Join joins the rows of the table to the rows of another table. See
+// TableJoin. on names the shared index components. Without any, the tables
+// share the whole index, as with AUGMENTS.
+func (snmpTable *SnmpTable) Join(name string, table *SnmpTable, on ...string) error {
+	if table == nil {
+		return fmt.Errorf("table %v: joined table is nil", snmpTable.Name)
+	}
+	if name == "" {
+		name = table.Name
+	}
+	if snmpTable.FlattenedTable || table.FlattenedTable {
+		return fmt.Errorf("table %v: cannot join flattened tables, join %v", snmpTable.Name, name)
+	}
+	for _, component := range on {
+		if !hasIndexComponent(snmpTable.Index, component) {
+			return fmt.Errorf("table %v: join %v on %v, which is not in the table index",
+				snmpTable.Name, name, component)
+		}
+		if !hasIndexComponent(table.Index, component) {
+			return fmt.Errorf("table %v: join %v on %v, which is not in the index of %v",
+				snmpTable.Name, name, component, table.Name)
+		}
+	}
+	for _, join := range snmpTable.Joins {
+		if join.Name == name {
+			return fmt.Errorf("table %v: duplicate join %v", snmpTable.Name, name)
+		}
+	}
+
+	snmpTable.Joins = append(snmpTable.Joins, &TableJoin{
+		Name:  name,
+		Table: table,
+		On:    on,
+	})
+	return nil
+}
+
+// Augment declares that the table AUGMENTS another table. The rows of each
+// table are joined to the rows of the other by the whole index, with the
+// names of the tables.
+func (snmpTable *SnmpTable) Augment(table *SnmpTable) error {
+	if table == nil {
+		return fmt.Errorf("table %v: augmented table is nil", snmpTable.Name)
+	}
+	if err := snmpTable.Join(table.Name, table); err != nil {
+		return err
+	}
+	return table.Join(snmpTable.Name, snmpTable)
+}
+
+// GetJoin gets a join of the table by name, or nil if not present.
+func (snmpTable *SnmpTable) GetJoin(name string) *TableJoin {
+	for _, join := range snmpTable.Joins {
+		if join.Name == name {
+			return join
+		}
+	}
+	return nil
+}
+
+// Joined gets the row of a joined table with the same shared index as the
+// row, or nil if the join or the row is not present. When more than one
+// row matches, e.g. a join from entPhysicalTable to a table with more than
+// one row per entity, the first is returned. See JoinedRows.
+func (snmpRow *SnmpRow) Joined(name string) *SnmpRow {
+	rows := snmpRow.JoinedRows(name)
+	if len(rows) == 0 {
+		return nil
+	}
+	return rows[0]
+}
+
+// JoinedRows gets the rows of a joined table with the same shared index as
+// the row. Joined rows are in the cache of the joined table. They are not
+// read from the SNMP server.
+func (snmpRow *SnmpRow) JoinedRows(name string) (rows []*SnmpRow) {
+	if snmpRow.Table == nil {
+		return nil
+	}
+	join := snmpRow.Table.GetJoin(name)
+	if join == nil {
+		return nil
+	}
+	key, ok := snmpRow.joinKey(join.On)
+	if !ok {
+		return nil
+	}
+	for i := range join.Table.Rows {
+		other := &join.Table.Rows[i]
+		if otherKey, ok := other.joinKey(join.On); ok && otherKey == key {
+			rows = append(rows, other)
+		}
+	}
+	return rows
+}
+
+// joinKey gets the values of the shared index components of the row, or the
+// index OID when there are none. ok is false if the row has no such index.
+func (snmpRow *SnmpRow) joinKey(on []string) (key string, ok bool) {
+	if len(on) == 0 {
+		return snmpRow.IndexOid, snmpRow.IndexOid != ""
+	}
+	parts := make([]string, len(on))
+	for i, name := range on {
+		value, ok := snmpRow.IndexValue(name)
+		if !ok {
+			return "", false
+		}
+		parts[i] = value.String()
+	}
+	return strings.Join(parts, "."), true
+}
+
+// Column gets the data of the row for a column by name. ok is false if the
+// table has no such column.
+func (snmpRow *SnmpRow) Column(name string) (data interface{}, ok bool) {
+	if snmpRow.Table == nil {
+		return nil, false
+	}
+	for i, column := range snmpRow.Table.ColumnList {
+		if column == name && i < len(snmpRow.RowData) {
+			return snmpRow.RowData[i].Data, true
+		}
+	}
+	return nil, false
+}
+
+// Columns gets the data of the row by column name.
+func (snmpRow *SnmpRow) Columns() map[string]interface{} {
+	columns := map[string]interface{}{}
+	if snmpRow.Table == nil {
+		return columns
+	}
+	for i, column := range snmpRow.Table.ColumnList {
+		if i < len(snmpRow.RowData) {
+			columns[column] = snmpRow.RowData[i].Data
+		}
+	}
+	return columns
+}
+
+// hasIndexComponent is true if the INDEX clause has a component with the name.
+func hasIndexComponent(index []IndexComponent, name string) bool {
+	for _, component := range index {
+		if component.Name == name {
+			return true
+		}
+	}
+	return false
+}
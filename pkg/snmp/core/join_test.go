@@ -0,0 +1,126 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newJoinTestTable creates a table with an INDEX clause from walk results.
+func newJoinTestTable(t *testing.T, name string, walkOid string, columns []string, index []IndexComponent, results []ReadResult) *SnmpTable {
+	table := &SnmpTable{
+		Name:           name,
+		WalkOid:        walkOid,
+		ColumnList:     columns,
+		RowBase:        "1",
+		ReadableColumn: "1",
+	}
+	assert.NoError(t, table.translate(results))
+	assert.NoError(t, table.SetIndex(index))
+	return table
+}
+
+// TestTableAugment tests joining a table which augments another, e.g.
+// ifXTable and ifTable.
+func TestTableAugment(t *testing.T) {
+	index := []IndexComponent{{Name: "ifIndex", Type: IndexInteger}}
+	ifTable := newJoinTestTable(t, "IF-MIB-ifTable", ".1.3.6.1.2.1.2.2",
+		[]string{"ifIndex", "ifDescr"}, index, []ReadResult{
+			{Oid: ".1.3.6.1.2.1.2.2.1.1.1", Data: 1},
+			{Oid: ".1.3.6.1.2.1.2.2.1.1.2", Data: 2},
+			{Oid: ".1.3.6.1.2.1.2.2.1.2.1", Data: "lo"},
+			{Oid: ".1.3.6.1.2.1.2.2.1.2.2", Data: "eth0"},
+		})
+	ifXTable := newJoinTestTable(t, "IF-MIB-ifXTable", ".1.3.6.1.2.1.31.1.1",
+		[]string{"ifName"}, index, []ReadResult{
+			{Oid: ".1.3.6.1.2.1.31.1.1.1.1.2", Data: "uplink"},
+		})
+
+	assert.NoError(t, ifXTable.Augment(ifTable))
+	assert.NotNil(t, ifTable.GetJoin("IF-MIB-ifXTable"))
+	assert.NotNil(t, ifXTable.GetJoin("IF-MIB-ifTable"))
+	assert.Nil(t, ifTable.GetJoin("missing"))
+
+	// Rows join in both directions.
+	joined := ifTable.Rows[1].Joined("IF-MIB-ifXTable")
+	assert.NotNil(t, joined)
+	name, ok := joined.Column("ifName")
+	assert.True(t, ok)
+	assert.Equal(t, "uplink", name)
+	descr, ok := ifXTable.Rows[0].Joined("IF-MIB-ifTable").Column("ifDescr")
+	assert.True(t, ok)
+	assert.Equal(t, "eth0", descr)
+
+	// No joined row.
+	assert.Nil(t, ifTable.Rows[0].Joined("IF-MIB-ifXTable"))
+	assert.Nil(t, ifTable.Rows[0].Joined("missing"))
+
+	// Duplicate joins.
+	assert.Error(t, ifXTable.Augment(ifTable))
+	assert.Error(t, ifXTable.Augment(nil))
+}
+
+// TestTableJoinOn tests joining tables on shared index components, e.g. a
+// table of sensors per entity to entPhysicalTable.
+func TestTableJoinOn(t *testing.T) {
+	entPhysicalTable := newJoinTestTable(t, "ENTITY-MIB-entPhysicalTable", ".1.3.6.1.2.1.47.1.1.1",
+		[]string{"entPhysicalDescr", "entPhysicalClass"},
+		[]IndexComponent{{Name: "entPhysicalIndex", Type: IndexInteger}},
+		[]ReadResult{
+			{Oid: ".1.3.6.1.2.1.47.1.1.1.1.1.10", Data: "PSU 1"},
+			{Oid: ".1.3.6.1.2.1.47.1.1.1.1.2.10", Data: 6},
+		})
+	sensorTable := newJoinTestTable(t, "TEST-MIB-sensorTable", ".1.3.6.1.4.1.99999.2",
+		[]string{"sensorValue"},
+		[]IndexComponent{{Name: "entPhysicalIndex", Type: IndexInteger}, {Name: "sensorNumber", Type: IndexInteger}},
+		[]ReadResult{
+			{Oid: ".1.3.6.1.4.1.99999.2.1.1.10.1", Data: 230},
+			{Oid: ".1.3.6.1.4.1.99999.2.1.1.10.2", Data: 231},
+			{Oid: ".1.3.6.1.4.1.99999.2.1.1.11.1", Data: 232},
+		})
+
+	assert.NoError(t, sensorTable.Join("entity", entPhysicalTable, "entPhysicalIndex"))
+	assert.NoError(t, entPhysicalTable.Join("", sensorTable, "entPhysicalIndex"))
+
+	entity := sensorTable.Rows[1].Joined("entity")
+	assert.NotNil(t, entity)
+	assert.Equal(t, map[string]interface{}{
+		"entPhysicalDescr": "PSU 1",
+		"entPhysicalClass": 6,
+	}, entity.Columns())
+	assert.Nil(t, sensorTable.Rows[2].Joined("entity"))
+
+	// An entity has more than one sensor.
+	sensors := entPhysicalTable.Rows[0].JoinedRows("TEST-MIB-sensorTable")
+	assert.Len(t, sensors, 2)
+	assert.Equal(t, "10.2", sensors[1].IndexString())
+
+	// Joins need the shared index components.
+	assert.Error(t, sensorTable.Join("bad", entPhysicalTable, "sensorNumber"))
+	assert.Error(t, sensorTable.Join("bad", entPhysicalTable, "missing"))
+	assert.Error(t, sensorTable.Join("entity", entPhysicalTable, "entPhysicalIndex"))
+	assert.Error(t, sensorTable.Join("bad", nil))
+	assert.Error(t, sensorTable.Join("bad", &SnmpTable{Name: "flat", FlattenedTable: true}))
+}
+
+// TestRowColumn tests getting row data by column name.
+func TestRowColumn(t *testing.T) {
+	table := newJoinTestTable(t, "IF-MIB-ifTable", ".1.3.6.1.2.1.2.2",
+		[]string{"ifIndex", "ifDescr"}, nil, []ReadResult{
+			{Oid: ".1.3.6.1.2.1.2.2.1.1.1", Data: 1},
+		})
+	row := table.Rows[0]
+
+	data, ok := row.Column("ifIndex")
+	assert.True(t, ok)
+	assert.Equal(t, 1, data)
+
+	// Unreadable columns have nil data.
+	data, ok = row.Column("ifDescr")
+	assert.True(t, ok)
+	assert.Nil(t, data)
+
+	_, ok = row.Column("missing")
+	assert.False(t, ok)
+	assert.Len(t, row.Columns(), 2)
+}
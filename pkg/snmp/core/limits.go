@@ -0,0 +1,75 @@
+package core
+
+import (
+	log "github.com/sirupsen/logrus"
+)
+
+// LimitLowKey and LimitHighKey are the device data keys for the thresholds
+// the agent has configured for a probe, e.g. the XUPS-MIB
+// xupsEnvRemoteTempLowerLimit and xupsEnvRemoteTempUpperLimit. They are in the
+// units of the reading. The device readings get a threshold context from them
+// unless the device has hysteresis settings of its own.
+const (
+	LimitLowKey  = "limit_low"
+	LimitHighKey = "limit_high"
+)
+
+// AddLimits adds the thresholds in the lowColumn and highColumn of a row to
+// the device data. Columns are 1 based and 0 is no column. Columns the agent
+// does not serve, or which are not numbers, are left out. Agents report unconfigured limits as the same
+// value, generally 0, so limits where the low is not below the high are left
+// out altogether.
+func AddLimits(deviceData map[string]interface{}, row *SnmpRow, lowColumn int, highColumn int) {
+	low, hasLow := limitColumn(row, lowColumn)
+	high, hasHigh := limitColumn(row, highColumn)
+
+	if hasLow && hasHigh {
+		lowValue, _ := limitValue(low)
+		highValue, _ := limitValue(high)
+		if lowValue >= highValue {
+			log.WithFields(log.Fields{
+				"table": row.Table.Name,
+				"low":   low,
+				"high":  high,
+			}).Debug("[snmp] ignoring probe limits which are not a range")
+			return
+		}
+	}
+
+	if hasLow {
+		deviceData[LimitLowKey] = low
+	}
+	if hasHigh {
+		deviceData[LimitHighKey] = high
+	}
+}
+
+// limitColumn gets the data of a limit column of a row. ok is false for no
+// column, and for data which is not a number.
+func limitColumn(row *SnmpRow, column int) (data interface{}, ok bool) {
+	if column <= 0 {
+		return nil, false
+	}
+	data = row.RowData[column-1].Data
+	_, ok = limitValue(data)
+	return data, ok
+}
+
+// limitValue converts the data of a limit column to float64 for comparison.
+// ok is false if the data is not a number.
+func limitValue(data interface{}) (value float64, ok bool) {
+	switch v := data.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,43 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAddLimits tests adding the probe limits of a row to device data.
+func TestAddLimits(t *testing.T) {
+	table := &SnmpTable{Name: "test-table"}
+	row := &SnmpRow{
+		Table: table,
+		RowData: []*ReadResult{
+			{Data: 25},     // Reading.
+			{Data: 10},     // Low limit.
+			{Data: 40},     // High limit.
+			{Data: nil},    // Not served.
+			{Data: "high"}, // Not a number.
+			{Data: 0},      // Unconfigured.
+			{Data: 0},      // Unconfigured.
+		},
+	}
+
+	for _, test := range []struct {
+		low      int
+		high     int
+		expected map[string]interface{}
+	}{
+		{2, 3, map[string]interface{}{LimitLowKey: 10, LimitHighKey: 40}},
+		{2, 0, map[string]interface{}{LimitLowKey: 10}},
+		{0, 3, map[string]interface{}{LimitHighKey: 40}},
+		{4, 3, map[string]interface{}{LimitHighKey: 40}},
+		{2, 5, map[string]interface{}{LimitLowKey: 10}},
+		{3, 2, map[string]interface{}{}},
+		{6, 7, map[string]interface{}{}},
+		{0, 0, map[string]interface{}{}},
+	} {
+		deviceData := map[string]interface{}{}
+		AddLimits(deviceData, row, test.low, test.high)
+		assert.Equal(t, test.expected, deviceData, test)
+	}
+}
@@ -16,7 +16,17 @@ type SnmpMib struct {
 	Tables []*SnmpTable
 }
 
-// NewSnmpMib creates the SnmpMib structure.
+// TableSupport summarizes which tables in a MIB an SNMP server supports. An
+// SNMP server is not required to implement every group in a MIB. The walk of
+// a group it does not implement is empty, so the table has no rows.
+type TableSupport struct {
+	Supported []string // Tables with at least one row.
+	Empty     []string // Tables without rows.
+}
+
+// NewSnmpMib creates the SnmpMib structure. name need not be the name of a
+// MIB module, e.g. PowerNet-MIB-uio, so column names are registered separately.
+// See RegisterNames.
 func NewSnmpMib(name string, snmpTables []*SnmpTable) (*SnmpMib, error) {
 	if name == "" {
 		return nil, fmt.Errorf("NewSnmpMib. name is empty")
@@ -39,6 +49,24 @@ func NewSnmpMib(name string, snmpTables []*SnmpTable) (*SnmpMib, error) {
 	return mib, nil
 }
 
+// RegisterNames registers the column names of tables in the MIB with OidNames
+// under module, the MIB module which defines them, e.g. PowerNet-MIB. Without
+// tables, the columns of every table in the MIB are registered. Names are a
+// convenience, so failures are logged rather than returned.
+func (snmpMib *SnmpMib) RegisterNames(module string, tables ...*SnmpTable) {
+	if len(tables) == 0 {
+		tables = snmpMib.Tables
+	}
+	for _, table := range tables {
+		if err := OidNames.RegisterTable(module, table); err != nil {
+			log.WithFields(log.Fields{
+				"error":  err,
+				"module": module,
+			}).Warn("[snmp] failed to register oid names")
+		}
+	}
+}
+
 // Dump all tables in the MIB to the log as CSV.
 func (snmpMib *SnmpMib) Dump() {
 	log.Debugf("Dumping SnmpMib %v. %d tables", snmpMib.Name, len(snmpMib.Tables))
@@ -51,6 +79,7 @@ func (snmpMib *SnmpMib) Dump() {
 }
 
 // EnumerateDevices enumerates all synse devices supported by the mib.
+// Devices with a registered name for their OID have it in the oid_name data.
 func (snmpMib *SnmpMib) EnumerateDevices(data map[string]interface{}) (devices []*config.DeviceProto, err error) {
 	for _, table := range snmpMib.Tables {
 		deviceSet, err := table.DevEnumerator.DeviceEnumerator(data)
@@ -59,6 +88,14 @@ func (snmpMib *SnmpMib) EnumerateDevices(data map[string]interface{}) (devices [
 		}
 		devices = append(devices, deviceSet...)
 	}
+
+	for _, proto := range devices {
+		for _, instance := range proto.Instances {
+			if name, ok := OidNames.Name(fmt.Sprint(instance.Data["oid"])); ok {
+				instance.Data["oid_name"] = name
+			}
+		}
+	}
 	return devices, nil
 }
 
@@ -73,6 +110,32 @@ func (snmpMib *SnmpMib) Load() error {
 	return nil
 }
 
+// Support summarizes which tables in the MIB the SNMP server implements.
+func (snmpMib *SnmpMib) Support() TableSupport {
+	support := TableSupport{}
+	for _, table := range snmpMib.Tables {
+		if len(table.Rows) == 0 {
+			support.Empty = append(support.Empty, table.Name)
+		} else {
+			support.Supported = append(support.Supported, table.Name)
+		}
+	}
+	return support
+}
+
+// LogSupport logs a summary of which tables in the MIB the SNMP server at the
+// given endpoint supports.
+func (snmpMib *SnmpMib) LogSupport(endpoint string) {
+	support := snmpMib.Support()
+	log.WithFields(log.Fields{
+		"endpoint":  endpoint,
+		"mib":       snmpMib.Name,
+		"supported": support.Supported,
+		"empty":     support.Empty,
+	}).Infof("[snmp] %s: %d of %d tables supported",
+		snmpMib.Name, len(support.Supported), len(snmpMib.Tables))
+}
+
 // Unload all tables defined for the MIB.
 func (snmpMib *SnmpMib) Unload() {
 	for i := 0; i < len(snmpMib.Tables); i++ {
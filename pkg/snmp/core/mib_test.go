@@ -0,0 +1,55 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSnmpMibSupport tests that the table support summary distinguishes
+// tables with rows and tables without rows.
+func TestSnmpMibSupport(t *testing.T) {
+	supported := &SnmpTable{
+		Name:    "supported",
+		Rows:    []SnmpRow{{BaseOid: ".1.3.6.1.2.1.33.1.2.%d.0"}},
+		WalkOid: ".1.3.6.1.2.1.33.1.2",
+	}
+	empty := &SnmpTable{
+		Name:    "empty",
+		WalkOid: ".1.3.6.1.2.1.33.1.5",
+	}
+
+	mib, err := NewSnmpMib("TEST-MIB", []*SnmpTable{supported, empty})
+	assert.NoError(t, err)
+
+	support := mib.Support()
+	assert.Equal(t, []string{"supported"}, support.Supported)
+	assert.Equal(t, []string{"empty"}, support.Empty)
+
+	// Each table points back to the mib.
+	for _, table := range mib.Tables {
+		assert.Equal(t, mib, table.Mib)
+	}
+}
+
+// TestSnmpMibRegisterNames tests that column names are registered with the
+// MIB module, rather than the name of the MIB.
+func TestSnmpMibRegisterNames(t *testing.T) {
+	sensors := &SnmpTable{
+		Name:       "sensors",
+		WalkOid:    ".1.3.6.1.4.1.99997.1",
+		RowBase:    "1",
+		ColumnList: []string{"testRegisterIndex", "testRegisterValue"},
+	}
+	mib, err := NewSnmpMib("TEST-REGISTER-MIB-sensors", []*SnmpTable{sensors})
+	assert.NoError(t, err)
+	_, ok := OidNames.Name(".1.3.6.1.4.1.99997.1.1.2.1")
+	assert.False(t, ok)
+
+	mib.RegisterNames("TEST-REGISTER-MIB")
+	name, ok := OidNames.Name(".1.3.6.1.4.1.99997.1.1.2.1")
+	assert.True(t, ok)
+	assert.Equal(t, "TEST-REGISTER-MIB::testRegisterValue.1", name)
+	_, err = OidNames.Oid("TEST-REGISTER-MIB-sensors::testRegisterValue")
+	assert.Error(t, err)
+}
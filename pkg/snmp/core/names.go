@@ -0,0 +1,190 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// OidNames is the registry of symbolic OID names for the plugin. It is filled
+// in from the column lists of each SnmpMib and from loaded MIB modules.
+var OidNames = NewNameRegistry()
+
+// NameRegistry translates between numeric OIDs, e.g.
+// .1.3.6.1.2.1.33.1.2.5.0, and symbolic names, e.g. UPS-MIB::upsBatteryVoltage.0.
+// Names are registered for object OIDs. Instance suffixes such as .0 or a row
+// index are carried over as is.
+type NameRegistry struct {
+	mutex   sync.RWMutex
+	byOid   map[string]string   // Qualified name by OID.
+	byName  map[string]string   // OID by qualified name, e.g. UPS-MIB::upsBatteryVoltage.
+	byShort map[string][]string // OIDs by unqualified name, e.g. upsBatteryVoltage.
+}
+
+// NewNameRegistry creates an empty NameRegistry.
+func NewNameRegistry() *NameRegistry {
+	return &NameRegistry{
+		byOid:   map[string]string{},
+		byName:  map[string]string{},
+		byShort: map[string][]string{},
+	}
+}
+
+// Register registers the name of an OID in a module. A later registration
+// of the same OID, or of the same name, replaces the earlier one.
+func (registry *NameRegistry) Register(module string, name string, oid string) error {
+	if module == "" || name == "" {
+		return fmt.Errorf("module and name are required for oid %v", oid)
+	}
+	parsed, err := NewOid(oid)
+	if err != nil {
+		return err
+	}
+	oid = "." + parsed.ToString
+	qualified := module + "::" + name
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	if previous, ok := registry.byOid[oid]; ok && previous != qualified {
+		delete(registry.byName, previous)
+		registry.removeShort(previous, oid)
+	}
+	if previous, ok := registry.byName[qualified]; ok && previous != oid {
+		delete(registry.byOid, previous)
+		registry.removeShort(qualified, previous)
+	}
+	registry.byOid[oid] = qualified
+	registry.byName[qualified] = oid
+	for _, existing := range registry.byShort[name] {
+		if existing == oid {
+			return nil
+		}
+	}
+	registry.byShort[name] = append(registry.byShort[name], oid)
+	return nil
+}
+
+// removeShort removes an OID from the unqualified names of a qualified name.
+func (registry *NameRegistry) removeShort(qualified string, oid string) {
+	short := qualified[strings.Index(qualified, "::")+2:]
+	oids := registry.byShort[short]
+	for i, existing := range oids {
+		if existing == oid {
+			registry.byShort[short] = append(oids[:i:i], oids[i+1:]...)
+			break
+		}
+	}
+	if len(registry.byShort[short]) == 0 {
+		delete(registry.byShort, short)
+	}
+}
+
+// RegisterTable registers the column names of a table in a module. Columns
+// are at WalkOid.RowBase.N for tables and WalkOid.N for flattened tables.
+func (registry *NameRegistry) RegisterTable(module string, table *SnmpTable) error {
+	base := table.WalkOid
+	if !table.FlattenedTable && table.RowBase != "" {
+		base += "." + table.RowBase
+	}
+	for i, column := range table.ColumnList {
+		if err := registry.Register(module, column, fmt.Sprintf("%s.%d", base, i+1)); err != nil {
+			return fmt.Errorf("table %v: %v", table.Name, err)
+		}
+	}
+	return nil
+}
+
+// Name translates a numeric OID to a symbolic name using the longest
+// registered prefix, e.g. UPS-MIB::upsInputVoltage.1. ok is false if no
+// prefix of the OID is registered.
+func (registry *NameRegistry) Name(oid string) (name string, ok bool) {
+	oid = "." + strings.TrimPrefix(oid, ".")
+
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	for prefix := oid; prefix != ""; {
+		if qualified, found := registry.byOid[prefix]; found {
+			return qualified + oid[len(prefix):], true
+		}
+		i := strings.LastIndex(prefix, ".")
+		if i <= 0 {
+			break
+		}
+		prefix = prefix[:i]
+	}
+	return "", false
+}
+
+// Oid translates a symbolic name to a numeric OID with a leading dot. The
+// name may be qualified with the module (UPS-MIB::upsBatteryVoltage.0) or
+// not (upsBatteryVoltage.0), in which case it must be unique. Numeric OIDs
+// are returned as is, so config may use either form.
+func (registry *NameRegistry) Oid(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("empty oid")
+	}
+	if !isOidName(name) {
+		oid, err := NewOid(name)
+		if err != nil {
+			return "", err
+		}
+		return "." + oid.ToString, nil
+	}
+
+	// Split off the instance suffix, e.g. .0 or .1.2.
+	symbol, suffix := name, ""
+	qualifier := strings.LastIndex(symbol, "::")
+	if i := strings.Index(symbol[qualifier+1:], "."); i >= 0 {
+		symbol, suffix = name[:qualifier+1+i], name[qualifier+1+i:]
+		if _, err := NewOid(suffix); err != nil {
+			return "", fmt.Errorf("invalid suffix in oid name %v: %v", name, err)
+		}
+	}
+
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	if qualifier >= 0 {
+		oid, ok := registry.byName[symbol]
+		if !ok {
+			return "", fmt.Errorf("unknown oid name %v", name)
+		}
+		return oid + suffix, nil
+	}
+
+	oids := registry.byShort[symbol]
+	switch len(oids) {
+	case 0:
+		return "", fmt.Errorf("unknown oid name %v", name)
+	case 1:
+		return oids[0] + suffix, nil
+	default:
+		var modules []string
+		for _, oid := range oids {
+			qualified := registry.byOid[oid]
+			modules = append(modules, qualified[:strings.Index(qualified, "::")])
+		}
+		sort.Strings(modules)
+		return "", fmt.Errorf("oid name %v is ambiguous, qualify it with one of %v", name, modules)
+	}
+}
+
+// isOidName returns true if s looks like a symbolic OID name rather than a
+// numeric OID.
+func isOidName(s string) bool {
+	return s != "" && (s[0] >= 'a' && s[0] <= 'z' || s[0] >= 'A' && s[0] <= 'Z')
+}
+
+// Format formats an OID for logs with both forms, e.g.
+// UPS-MIB::upsBatteryVoltage.0 (.1.3.6.1.2.1.33.1.2.5.0). OIDs without a
+// registered name are only numeric.
+func (registry *NameRegistry) Format(oid string) string {
+	if name, ok := registry.Name(oid); ok {
+		return fmt.Sprintf("%s (%s)", name, oid)
+	}
+	return oid
+}
@@ -0,0 +1,124 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNameRegistry tests translating between names and OIDs.
+func TestNameRegistry(t *testing.T) {
+	registry := NewNameRegistry()
+	assert.NoError(t, registry.Register("UPS-MIB", "upsBatteryVoltage", ".1.3.6.1.2.1.33.1.2.5"))
+	assert.NoError(t, registry.Register("UPS-MIB", "upsBattery", "1.3.6.1.2.1.33.1.2"))
+
+	name, ok := registry.Name(".1.3.6.1.2.1.33.1.2.5.0")
+	assert.True(t, ok)
+	assert.Equal(t, "UPS-MIB::upsBatteryVoltage.0", name)
+
+	// The longest prefix wins.
+	name, ok = registry.Name(".1.3.6.1.2.1.33.1.2.7.0")
+	assert.True(t, ok)
+	assert.Equal(t, "UPS-MIB::upsBattery.7.0", name)
+
+	name, ok = registry.Name("1.3.6.1.2.1.33.1.2")
+	assert.True(t, ok)
+	assert.Equal(t, "UPS-MIB::upsBattery", name)
+
+	_, ok = registry.Name(".1.3.6.1.2.1.33.1.3.1.0")
+	assert.False(t, ok)
+
+	for _, test := range []struct {
+		name string
+		oid  string
+	}{
+		{"UPS-MIB::upsBatteryVoltage.0", ".1.3.6.1.2.1.33.1.2.5.0"},
+		{"upsBatteryVoltage.0", ".1.3.6.1.2.1.33.1.2.5.0"},
+		{"upsBatteryVoltage", ".1.3.6.1.2.1.33.1.2.5"},
+		{"UPS-MIB::upsBattery", ".1.3.6.1.2.1.33.1.2"},
+		{".1.3.6.1.2.1.33.1.2.5.0", ".1.3.6.1.2.1.33.1.2.5.0"},
+		{"1.3.6.1.2.1.33.1.2.5.0", ".1.3.6.1.2.1.33.1.2.5.0"},
+	} {
+		oid, err := registry.Oid(test.name)
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.oid, oid, test.name)
+	}
+
+	assert.Equal(t, "UPS-MIB::upsBatteryVoltage.0 (.1.3.6.1.2.1.33.1.2.5.0)",
+		registry.Format(".1.3.6.1.2.1.33.1.2.5.0"))
+	assert.Equal(t, ".1.3.6.1.2.1.1.3.0", registry.Format(".1.3.6.1.2.1.1.3.0"))
+}
+
+// TestNameRegistryErrors tests names which do not translate.
+func TestNameRegistryErrors(t *testing.T) {
+	registry := NewNameRegistry()
+	assert.Error(t, registry.Register("", "upsBattery", ".1.3.6.1.2.1.33.1.2"))
+	assert.Error(t, registry.Register("UPS-MIB", "upsBattery", "not an oid"))
+
+	// The same name in two modules is ambiguous unless qualified.
+	assert.NoError(t, registry.Register("UPS-MIB", "upsIdentModel", ".1.3.6.1.2.1.33.1.1.2"))
+	assert.NoError(t, registry.Register("OTHER-MIB", "upsIdentModel", ".1.3.6.1.4.1.1.2"))
+	_, err := registry.Oid("upsIdentModel.0")
+	assert.Error(t, err)
+	oid, err := registry.Oid("OTHER-MIB::upsIdentModel.0")
+	assert.NoError(t, err)
+	assert.Equal(t, ".1.3.6.1.4.1.1.2.0", oid)
+
+	for _, name := range []string{
+		"",
+		"upsMissing",
+		"UPS-MIB::upsMissing.0",
+		"UPS-MIB::upsIdentModel.x",
+		".1.3.x",
+	} {
+		_, err := registry.Oid(name)
+		assert.Error(t, err, name)
+	}
+}
+
+// TestNameRegistryReplace tests that later registrations replace earlier
+// ones.
+func TestNameRegistryReplace(t *testing.T) {
+	registry := NewNameRegistry()
+	assert.NoError(t, registry.Register("UPS-MIB", "upsAlarmOnBypassBad", ".1.3.6.1.2.1.33.1.6.3.10"))
+	assert.NoError(t, registry.Register("UPS-MIB", "upsAlarmBypassBad", ".1.3.6.1.2.1.33.1.6.3.10"))
+
+	name, ok := registry.Name(".1.3.6.1.2.1.33.1.6.3.10")
+	assert.True(t, ok)
+	assert.Equal(t, "UPS-MIB::upsAlarmBypassBad", name)
+	_, err := registry.Oid("upsAlarmOnBypassBad")
+	assert.Error(t, err)
+
+	// A name moved to another OID.
+	assert.NoError(t, registry.Register("UPS-MIB", "upsAlarmBypassBad", ".1.3.6.1.2.1.33.1.6.3.11"))
+	_, ok = registry.Name(".1.3.6.1.2.1.33.1.6.3.10")
+	assert.False(t, ok)
+	oid, err := registry.Oid("upsAlarmBypassBad")
+	assert.NoError(t, err)
+	assert.Equal(t, ".1.3.6.1.2.1.33.1.6.3.11", oid)
+}
+
+// TestNameRegistryRegisterTable tests registering table column names.
+func TestNameRegistryRegisterTable(t *testing.T) {
+	registry := NewNameRegistry()
+	assert.NoError(t, registry.RegisterTable("UPS-MIB", &SnmpTable{
+		Name:       "UPS-MIB-UPS-Input-Table",
+		WalkOid:    ".1.3.6.1.2.1.33.1.3.3",
+		ColumnList: []string{"upsInputLineIndex", "upsInputFrequency", "upsInputVoltage"},
+		RowBase:    "1",
+	}))
+	assert.NoError(t, registry.RegisterTable("UPS-MIB", &SnmpTable{
+		Name:           "UPS-MIB-UPS-Battery-Table",
+		WalkOid:        ".1.3.6.1.2.1.33.1.2",
+		ColumnList:     []string{"upsBatteryStatus"},
+		FlattenedTable: true,
+	}))
+
+	name, ok := registry.Name(".1.3.6.1.2.1.33.1.3.3.1.3.2")
+	assert.True(t, ok)
+	assert.Equal(t, "UPS-MIB::upsInputVoltage.2", name)
+
+	oid, err := registry.Oid("upsBatteryStatus.0")
+	assert.NoError(t, err)
+	assert.Equal(t, ".1.3.6.1.2.1.33.1.2.1.0", oid)
+}
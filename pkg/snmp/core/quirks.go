@@ -0,0 +1,181 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vapor-ware/synse-sdk/sdk/config"
+)
+
+// UnsupportedKey is the device data key for the readings which mean the
+// agent does not support an object, as a comma separated list of integers,
+// e.g. "0,-1". These readings are treated as nil. See Quirks.Unsupported.
+const UnsupportedKey = "unsupported"
+
+// Quirks corrects the devices of an SNMP agent whose firmware does not follow
+// its MIBs. Objects are named as they are registered in OidNames, either
+// unqualified, e.g. upsBatteryVoltage, or qualified, e.g.
+// UPS-MIB::upsBatteryVoltage. Instances are not part of the name, so a quirk
+// applies to each row of a table.
+type Quirks struct {
+	// The name of the quirks, e.g. tripplite-ups.
+	Name string
+	// A human readable description of the firmware bugs.
+	Description string
+	// Multipliers replacing the multiplier of an object, e.g. for firmware
+	// which reports upsBatteryVoltage in Volts rather than 0.1 Volts.
+	Multipliers map[string]float32
+	// Objects the agent serves but does not implement. Their devices are
+	// removed.
+	Disabled []string
+	// Enumeration values of an object as reported by the agent, mapped to the
+	// values defined in the MIB.
+	Remap map[string]map[int]int
+	// Readings of an object which mean that the agent does not support it,
+	// e.g. 0 or -1. These read as nil.
+	Unsupported map[string][]int
+}
+
+// Validate checks that the quirks are named and correct something.
+func (quirks *Quirks) Validate() error {
+	if quirks.Name == "" {
+		return fmt.Errorf("quirks have no name")
+	}
+	if len(quirks.Multipliers)+len(quirks.Disabled)+len(quirks.Remap)+len(quirks.Unsupported) == 0 {
+		return fmt.Errorf("quirks %v have nothing to correct", quirks.Name)
+	}
+	return nil
+}
+
+// Apply corrects the device instances of the device protos in place. Protos
+// left without instances are removed, so the protos are returned.
+func (quirks *Quirks) Apply(devices []*config.DeviceProto) []*config.DeviceProto {
+	var kept []*config.DeviceProto
+	for _, proto := range devices {
+		var instances []*config.DeviceInstance
+		for _, instance := range proto.Instances {
+			qualified, name, ok := objectName(instance.Data)
+			if !ok {
+				instances = append(instances, instance)
+				continue
+			}
+			if quirks.disabled(qualified, name) {
+				log.WithFields(log.Fields{
+					"quirks": quirks.Name,
+					"device": instance.Info,
+					"object": qualified,
+				}).Debug("[snmp] quirks disabled device")
+				continue
+			}
+			quirks.apply(qualified, name, instance.Data)
+			instances = append(instances, instance)
+		}
+		proto.Instances = instances
+		if len(proto.Instances) > 0 {
+			kept = append(kept, proto)
+		}
+	}
+	return kept
+}
+
+// apply applies the quirks of an object to the data of its device.
+func (quirks *Quirks) apply(qualified string, name string, data map[string]interface{}) {
+	if multiplier, ok := quirks.lookupMultiplier(qualified, name); ok {
+		data["multiplier"] = multiplier
+	}
+
+	if remap, ok := quirks.Remap[qualified]; ok {
+		remapEnumeration(data, remap)
+	} else if remap, ok := quirks.Remap[name]; ok {
+		remapEnumeration(data, remap)
+	}
+
+	readings, ok := quirks.Unsupported[qualified]
+	if !ok {
+		readings, ok = quirks.Unsupported[name]
+	}
+	if ok {
+		values := make([]string, len(readings))
+		for i, reading := range readings {
+			values[i] = strconv.Itoa(reading)
+		}
+		data[UnsupportedKey] = strings.Join(values, ",")
+	}
+}
+
+// lookupMultiplier gets the multiplier for an object, qualified name first.
+func (quirks *Quirks) lookupMultiplier(qualified string, name string) (float32, bool) {
+	if multiplier, ok := quirks.Multipliers[qualified]; ok {
+		return multiplier, true
+	}
+	multiplier, ok := quirks.Multipliers[name]
+	return multiplier, ok
+}
+
+// disabled is true if the object is disabled by name.
+func (quirks *Quirks) disabled(qualified string, name string) bool {
+	for _, disabled := range quirks.Disabled {
+		if disabled == qualified || disabled == name {
+			return true
+		}
+	}
+	return false
+}
+
+// remapEnumeration translates the values the agent reports with the
+// enumeration of the values in the MIB. Values which are not remapped keep
+// their translation.
+func remapEnumeration(data map[string]interface{}, remap map[int]int) {
+	remapped := map[string]interface{}{}
+	for reported, defined := range remap {
+		if translation, ok := data[fmt.Sprintf("enumeration%d", defined)]; ok {
+			remapped[fmt.Sprintf("enumeration%d", reported)] = translation
+		}
+	}
+	for key, translation := range remapped {
+		data[key] = translation
+	}
+}
+
+// objectName gets the qualified and unqualified object name of the OID in the
+// device data, without the instance, e.g. UPS-MIB::upsBatteryVoltage and
+// upsBatteryVoltage. ok is false if the OID has no registered name.
+func objectName(data map[string]interface{}) (qualified string, name string, ok bool) {
+	oid, ok := data["oid"].(string)
+	if !ok {
+		return "", "", false
+	}
+	qualified, ok = OidNames.Name(oid)
+	if !ok {
+		return "", "", false
+	}
+	separator := strings.Index(qualified, "::")
+	if separator < 0 {
+		return "", "", false
+	}
+	if instance := strings.Index(qualified[separator:], "."); instance >= 0 {
+		qualified = qualified[:separator+instance]
+	}
+	return qualified, qualified[separator+2:], true
+}
+
+// IsUnsupportedReading is true if the reading is an integer the device data
+// marks as unsupported. See UnsupportedKey.
+func IsUnsupportedReading(result ReadResult, data map[string]interface{}) bool {
+	reading, ok := result.Data.(int)
+	if !ok {
+		return false
+	}
+	unsupported, ok := data[UnsupportedKey].(string)
+	if !ok || unsupported == "" {
+		return false
+	}
+	for _, value := range strings.Split(unsupported, ",") {
+		if strconv.Itoa(reading) == strings.TrimSpace(value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-sdk/sdk/config"
+)
+
+// newQuirksTestProto creates a device proto with an instance per OID.
+func newQuirksTestProto(deviceType string, oids ...string) *config.DeviceProto {
+	proto := &config.DeviceProto{Type: deviceType}
+	for _, oid := range oids {
+		proto.Instances = append(proto.Instances, &config.DeviceInstance{
+			Info: oid,
+			Data: map[string]interface{}{"oid": oid, "multiplier": float32(0.1)},
+		})
+	}
+	return proto
+}
+
+// TestQuirksApply tests correcting devices for firmware bugs.
+func TestQuirksApply(t *testing.T) {
+	assert.NoError(t, OidNames.Register("TEST-QUIRKS-MIB", "testVoltage", ".1.3.6.1.4.1.99999.7.1.2"))
+	assert.NoError(t, OidNames.Register("TEST-QUIRKS-MIB", "testCurrent", ".1.3.6.1.4.1.99999.7.1.3"))
+	assert.NoError(t, OidNames.Register("TEST-QUIRKS-MIB", "testStatus", ".1.3.6.1.4.1.99999.7.2.1"))
+	assert.NoError(t, OidNames.Register("TEST-QUIRKS-MIB", "testTemperature", ".1.3.6.1.4.1.99999.7.2.2"))
+
+	quirks := &Quirks{
+		Name:        "test-quirks",
+		Multipliers: map[string]float32{"TEST-QUIRKS-MIB::testVoltage": 1},
+		Disabled:    []string{"testCurrent", "testTemperature"},
+		Remap:       map[string]map[int]int{"testStatus": {0: 2, 2: 3}},
+		Unsupported: map[string][]int{"testVoltage": {0, -1}},
+	}
+	assert.NoError(t, quirks.Validate())
+
+	status := newQuirksTestProto("status", ".1.3.6.1.4.1.99999.7.2.1.0")
+	status.Instances[0].Data["enumeration2"] = "normal"
+	status.Instances[0].Data["enumeration3"] = "onBattery"
+	devices := quirks.Apply([]*config.DeviceProto{
+		newQuirksTestProto("voltage", ".1.3.6.1.4.1.99999.7.1.2.1", ".1.3.6.1.4.1.99999.7.1.2.2"),
+		newQuirksTestProto("current", ".1.3.6.1.4.1.99999.7.1.3.1", ".1.3.6.1.4.1.99999.8.1"),
+		newQuirksTestProto("temperature", ".1.3.6.1.4.1.99999.7.2.2.0"),
+		status,
+	})
+
+	// The temperature proto has no devices left.
+	assert.Len(t, devices, 3)
+
+	// Multipliers and unsupported readings apply to each row.
+	voltage := devices[0]
+	assert.Len(t, voltage.Instances, 2)
+	for _, instance := range voltage.Instances {
+		assert.Equal(t, float32(1), instance.Data["multiplier"])
+		assert.Equal(t, "0,-1", instance.Data[UnsupportedKey])
+	}
+
+	// Devices without a registered name are kept as is.
+	current := devices[1]
+	assert.Len(t, current.Instances, 1)
+	assert.Equal(t, ".1.3.6.1.4.1.99999.8.1", current.Instances[0].Info)
+	assert.Equal(t, float32(0.1), current.Instances[0].Data["multiplier"])
+
+	// Reported values translate as the values in the MIB.
+	data := devices[2].Instances[0].Data
+	assert.Equal(t, "normal", data["enumeration0"])
+	assert.Equal(t, "onBattery", data["enumeration2"])
+	assert.Equal(t, "onBattery", data["enumeration3"])
+	assert.Equal(t, float32(0.1), data["multiplier"])
+	assert.Nil(t, data[UnsupportedKey])
+
+	assert.Error(t, (&Quirks{}).Validate())
+	assert.Error(t, (&Quirks{Name: "test-nothing"}).Validate())
+}
+
+// TestIsUnsupportedReading tests checking readings marked as unsupported.
+func TestIsUnsupportedReading(t *testing.T) {
+	data := map[string]interface{}{UnsupportedKey: "0,-1"}
+	assert.True(t, IsUnsupportedReading(ReadResult{Data: 0}, data))
+	assert.True(t, IsUnsupportedReading(ReadResult{Data: -1}, data))
+	assert.False(t, IsUnsupportedReading(ReadResult{Data: 1}, data))
+	assert.False(t, IsUnsupportedReading(ReadResult{Data: "0"}, data))
+	assert.False(t, IsUnsupportedReading(ReadResult{Data: nil}, data))
+	assert.False(t, IsUnsupportedReading(ReadResult{Data: 0}, map[string]interface{}{}))
+}
@@ -0,0 +1,74 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/vapor-ware/synse-sdk/sdk/config"
+)
+
+// Mib is the interface for the MIB implementations an SNMP server serves.
+// SnmpMib implements it, so any MIB embedding SnmpMib does too.
+type Mib interface {
+	// Load the data for all tables in the MIB from the SNMP server.
+	Load() error
+	// EnumerateDevices enumerates all synse devices supported by the MIB.
+	EnumerateDevices(data map[string]interface{}) ([]*config.DeviceProto, error)
+	// Unload cached data once we're done with it.
+	Unload()
+}
+
+// MibFactory creates and loads a MIB for an SNMP server.
+type MibFactory func(server *SnmpServerBase) (Mib, error)
+
+var (
+	// mibFactories are the registered MIB implementations by name.
+	mibFactories = map[string]MibFactory{}
+	// mibFactoriesLock protects mibFactories.
+	mibFactoriesLock sync.RWMutex
+)
+
+// RegisterMib registers a MIB implementation by name, e.g. UPS-MIB, so that it
+// can be enabled in the configuration of an SNMP agent. MIB packages register
+// themselves in init. Names must be unique.
+func RegisterMib(name string, factory MibFactory) error {
+	if name == "" {
+		return fmt.Errorf("RegisterMib. name is empty")
+	}
+	if factory == nil {
+		return fmt.Errorf("RegisterMib. factory for %v is nil", name)
+	}
+
+	mibFactoriesLock.Lock()
+	defer mibFactoriesLock.Unlock()
+	if _, exists := mibFactories[name]; exists {
+		return fmt.Errorf("mib %v is already registered", name)
+	}
+	mibFactories[name] = factory
+	return nil
+}
+
+// NewRegisteredMib creates and loads the registered MIB implementation with the
+// given name for an SNMP server.
+func NewRegisteredMib(name string, server *SnmpServerBase) (Mib, error) {
+	mibFactoriesLock.RLock()
+	factory, ok := mibFactories[name]
+	mibFactoriesLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown mib %v, registered mibs are %v", name, RegisteredMibs())
+	}
+	return factory(server)
+}
+
+// RegisteredMibs are the names of the registered MIB implementations, sorted.
+func RegisteredMibs() []string {
+	mibFactoriesLock.RLock()
+	defer mibFactoriesLock.RUnlock()
+	var names []string
+	for name := range mibFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,27 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRegisterMib tests registering MIB implementations.
+func TestRegisterMib(t *testing.T) {
+	factory := func(server *SnmpServerBase) (Mib, error) {
+		return NewSnmpMib("TEST-REGISTRY-MIB", []*SnmpTable{{Name: "test"}})
+	}
+	assert.NoError(t, RegisterMib("TEST-REGISTRY-MIB", factory))
+	assert.Contains(t, RegisteredMibs(), "TEST-REGISTRY-MIB")
+
+	mib, err := NewRegisteredMib("TEST-REGISTRY-MIB", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "TEST-REGISTRY-MIB", mib.(*SnmpMib).Name)
+
+	// Errors.
+	assert.Error(t, RegisterMib("TEST-REGISTRY-MIB", factory))
+	assert.Error(t, RegisterMib("", factory))
+	assert.Error(t, RegisterMib("TEST-NIL-MIB", nil))
+	_, err = NewRegisteredMib("TEST-MISSING-MIB", nil)
+	assert.Error(t, err)
+}
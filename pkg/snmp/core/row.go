@@ -2,6 +2,7 @@ package core
 
 import (
 	"fmt"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -17,6 +18,12 @@ type SnmpRow struct {
 	Table *SnmpTable
 	// SNMP data for the walked row.
 	RowData []*ReadResult
+	// The index portion of the row OIDs, e.g. 1 or 5.104.101.108.108.111.
+	// Empty for flattened tables.
+	IndexOid string
+	// The index decoded with the INDEX clause of the table. nil when the
+	// table has no INDEX clause.
+	Index []IndexValue
 }
 
 // NewSnmpRow creates the SnmpRow structure.
@@ -44,11 +51,37 @@ func NewSnmpRow(baseOid string, table *SnmpTable, rowData []*ReadResult) (*SnmpR
 	}, nil
 }
 
+// IndexString formats the row index for device info and context. The decoded
+// components are joined with a period, e.g. 1.hello. Rows without a decoded
+// index use the IndexOid.
+func (snmpRow *SnmpRow) IndexString() string {
+	if len(snmpRow.Index) == 0 {
+		return snmpRow.IndexOid
+	}
+	parts := make([]string, len(snmpRow.Index))
+	for i, value := range snmpRow.Index {
+		parts[i] = value.String()
+	}
+	return strings.Join(parts, ".")
+}
+
+// IndexValue gets a decoded index component by name.
+func (snmpRow *SnmpRow) IndexValue(name string) (IndexValue, bool) {
+	for _, value := range snmpRow.Index {
+		if value.Name == name {
+			return value, true
+		}
+	}
+	return IndexValue{}, false
+}
+
 // Dump the SnmpRow to the debug log.
 func (snmpRow *SnmpRow) Dump() {
 	log.Debugf("Dumping row for SNMP table %v", snmpRow.Table.Name)
 	log.Debugf("baseOid: %v", snmpRow.BaseOid)
+	log.Debugf("index: %v", snmpRow.IndexString())
 	for i := 0; i < len(snmpRow.Table.ColumnList); i++ {
-		log.Debugf("row[%v] = %v", snmpRow.Table.ColumnList[i], snmpRow.RowData[i])
+		cell := snmpRow.RowData[i]
+		log.Debugf("row[%v] = %v: %v", snmpRow.Table.ColumnList[i], OidNames.Format(cell.Oid), cell.Data)
 	}
 }
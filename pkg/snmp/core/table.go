@@ -38,6 +38,11 @@ type SnmpTable struct {
 	// and not truly an SNMP table. It's just simpler to read it in as a
 	// single row table.
 	FlattenedTable bool
+	// The INDEX clause of the table, used to decode the index of each row.
+	// Optional. See SetIndex.
+	Index []IndexComponent
+	// Joins to other tables with a shared index. Optional. See Join.
+	Joins []*TableJoin
 
 	// The row data in the table.
 	Rows []SnmpRow
@@ -118,10 +123,11 @@ func NewSnmpTable(
 // Dump to the log as CSV. Also to console.
 func (snmpTable *SnmpTable) Dump() {
 	// Header
+	walkOid := OidNames.Format(snmpTable.WalkOid)
 	log.Debugf("Dumping %v table. %d rows. walk oid: %v",
-		snmpTable.Name, len(snmpTable.Rows), snmpTable.WalkOid)
+		snmpTable.Name, len(snmpTable.Rows), walkOid)
 	fmt.Printf("Dumping %v table. %d rows. walk oid: %v\n",
-		snmpTable.Name, len(snmpTable.Rows), snmpTable.WalkOid)
+		snmpTable.Name, len(snmpTable.Rows), walkOid)
 	// Column list
 	log.Debugf("%v", strings.Join(snmpTable.ColumnList, ","))
 	fmt.Printf("%v\n", strings.Join(snmpTable.ColumnList, ","))
@@ -210,6 +216,39 @@ func (snmpTable *SnmpTable) UpdateCell(
 		snmpTable.Name, baseOid, index)
 }
 
+// SetIndex sets the INDEX clause of the table and decodes the index of each
+// row with it. Tables are loaded when they are created, so this decodes the
+// rows already loaded too.
+func (snmpTable *SnmpTable) SetIndex(index []IndexComponent) error {
+	if err := ValidateIndex(index); err != nil {
+		return fmt.Errorf("table %v: %v", snmpTable.Name, err)
+	}
+	snmpTable.Index = index
+	for i := range snmpTable.Rows {
+		snmpTable.decodeRowIndex(&snmpTable.Rows[i])
+	}
+	return nil
+}
+
+// decodeRowIndex decodes the index of a row with the INDEX clause of the
+// table. Rows which do not decode are logged and keep only the IndexOid.
+func (snmpTable *SnmpTable) decodeRowIndex(row *SnmpRow) {
+	row.Index = nil
+	if len(snmpTable.Index) == 0 || row.IndexOid == "" {
+		return
+	}
+	index, err := DecodeIndex(snmpTable.Index, row.IndexOid)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error": err,
+			"table": snmpTable.Name,
+			"index": row.IndexOid,
+		}).Warn("[snmp] failed to decode row index")
+		return
+	}
+	row.Index = index
+}
+
 // getRowIndexes gets the index portion of the OID so that we can line up the rows.
 func (snmpTable *SnmpTable) getRowIndexes(tableData []ReadResult) []string {
 	var rowIndexes []string
@@ -225,7 +264,7 @@ func (snmpTable *SnmpTable) getRowIndexes(tableData []ReadResult) []string {
 		//  Get all values where the key starts with the prefix.
 		for i := 0; i < len(tableData); i++ {
 			if strings.HasPrefix(tableData[i].Oid, prefix) {
-				rowIndexes = append(rowIndexes, tableData[i].Oid)
+				rowIndexes = append(rowIndexes, tableData[i].Oid[len(prefix):])
 			}
 		}
 	} else {
@@ -263,6 +302,22 @@ func getData(oid string, results []ReadResult) *ReadResult {
 	}
 }
 
+// scalarsWithoutInstance is true when the walk of a flattened table has some
+// of its columns without the .0 instance suffix and none with it.
+func (snmpTable *SnmpTable) scalarsWithoutInstance(tableData []ReadResult) bool {
+	found := false
+	for i := range snmpTable.ColumnList {
+		column := fmt.Sprintf("%v.%d", snmpTable.WalkOid, i+1)
+		if getData(column+".0", tableData).Data != nil {
+			return false
+		}
+		if getData(column, tableData).Data != nil {
+			found = true
+		}
+	}
+	return found
+}
+
 // Translate into a structure of SnmpRow.
 func (snmpTable *SnmpTable) translate(tableData []ReadResult) error {
 	snmpTable.Rows = *new([]SnmpRow)
@@ -294,10 +349,18 @@ func (snmpTable *SnmpTable) translate(tableData []ReadResult) error {
 			if err != nil {
 				return err
 			}
+			row.IndexOid = rowIndexes[i]
+			snmpTable.decodeRowIndex(row)
 			snmpTable.Rows = append(snmpTable.Rows, *row)
 		}
 	} else {
 		baseOid := snmpTable.WalkOid + ".%d.0"
+		if snmpTable.scalarsWithoutInstance(tableData) {
+			// Some firmware, e.g. Tripp Lite, serves scalars without the .0
+			// instance. Read them where they are.
+			log.WithField("table", snmpTable.Name).Debug("[snmp] scalars have no .0 instance")
+			baseOid = snmpTable.WalkOid + ".%d"
+		}
 		columnIndex := 1
 		var rowData []*ReadResult
 		for i := 0; i < len(snmpTable.ColumnList); i++ {
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestTable
@@ -63,7 +64,8 @@ func TestTable(t *testing.T) {
 		"2",        // readableColumn
 		false,      // flattened table
 	)
-	assert.NoError(t, err)
+	// The table is nil when the emulator is not running.
+	require.NoError(t, err)
 
 	testUpsInputTable.Dump()
 
@@ -74,3 +76,32 @@ func TestTable(t *testing.T) {
 	assert.NotNil(t, devices)
 	assert.Len(t, devices, 0)
 }
+
+// TestTableScalarsWithoutInstance tests reading a flattened table from an
+// agent which serves scalars without the .0 instance, as Tripp Lite does.
+func TestTableScalarsWithoutInstance(t *testing.T) {
+	table := &SnmpTable{
+		Name:           "UPS-MIB-upsBattery-Table",
+		WalkOid:        ".1.3.6.1.2.1.33.1.2",
+		ColumnList:     []string{"upsBatteryStatus", "upsSecondsOnBattery", "upsEstimatedMinutesRemaining"},
+		FlattenedTable: true,
+	}
+	assert.NoError(t, table.translate([]ReadResult{
+		{Oid: ".1.3.6.1.2.1.33.1.2.1", Data: 2},
+		{Oid: ".1.3.6.1.2.1.33.1.2.2", Data: 0},
+	}))
+	assert.Len(t, table.Rows, 1)
+	assert.Equal(t, ".1.3.6.1.2.1.33.1.2.%d", table.Rows[0].BaseOid)
+	assert.Equal(t, 2, table.Rows[0].RowData[0].Data)
+	assert.Equal(t, 0, table.Rows[0].RowData[1].Data)
+	assert.Nil(t, table.Rows[0].RowData[2].Data)
+
+	// The .0 instance is used when any scalar has it.
+	assert.NoError(t, table.translate([]ReadResult{
+		{Oid: ".1.3.6.1.2.1.33.1.2.1", Data: 2},
+		{Oid: ".1.3.6.1.2.1.33.1.2.2.0", Data: 0},
+	}))
+	assert.Equal(t, ".1.3.6.1.2.1.33.1.2.%d.0", table.Rows[0].BaseOid)
+	assert.Nil(t, table.Rows[0].RowData[0].Data)
+	assert.Equal(t, 0, table.Rows[0].RowData[1].Data)
+}
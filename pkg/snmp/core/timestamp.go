@@ -0,0 +1,127 @@
+package core
+
+import (
+	"fmt"
+	"time"
+)
+
+// SysUpTimeOid is the OID for SNMPv2-MIB sysUpTime.0. This is the time in
+// hundredths of a second since the network management portion of the agent was
+// last re-initialized.
+const SysUpTimeOid = ".1.3.6.1.2.1.1.3.0"
+
+// tickDuration is the duration of one TimeTicks tick.
+const tickDuration = 10 * time.Millisecond
+
+// TimeStamp is an SNMPv2-TC TimeStamp. It is the value of sysUpTime at the
+// time the event occurred.
+type TimeStamp uint32
+
+// ToTicks converts raw SNMP TimeTicks / TimeStamp data to uint32 ticks.
+// gosnmp decodes TimeTicks to uint32, but the emulator and some agents serve
+// these as other integer types.
+func ToTicks(data interface{}) (ticks uint32, err error) {
+	switch t := data.(type) {
+	case uint32:
+		return t, nil
+	case uint:
+		return uint32(t), nil
+	case uint64:
+		return uint32(t), nil
+	case int:
+		return uint32(t), nil
+	case int32:
+		return uint32(t), nil
+	case int64:
+		return uint32(t), nil
+	default:
+		return 0, fmt.Errorf("unable to convert %T, %+v to ticks", data, data)
+	}
+}
+
+// TimeStampToTime converts a TimeStamp to wall-clock time.
+//
+// sysUpTime must be read from the agent together with the TimeStamp so that
+// both are relative to the same agent re-initialization. now is the local time
+// at which sysUpTime was read.
+//
+// Both values are TimeTicks which wrap at 2^32 (about 497 days). A TimeStamp
+// greater than sysUpTime is either an event before sysUpTime wrapped, or
+// garbage, e.g. from an agent that does not reset its TimeStamps when it
+// re-initializes. wrapped is whether sysUpTime is known to have wrapped since
+// the agent was re-initialized, see Wrapped. When it has, the elapsed time is
+// computed modulo 2^32, so an event before the wrap still gives the correct
+// time, provided the event happened within the last 497 days. When it has not,
+// ok is false.
+//
+// A TimeStamp of zero means the event happened before the agent was last
+// re-initialized, so there is no way to know when it was. In this case ok is
+// also false.
+func TimeStampToTime(timeStamp TimeStamp, sysUpTime uint32, now time.Time, wrapped bool) (t time.Time, ok bool) {
+	if timeStamp == 0 {
+		return t, false
+	}
+	if uint32(timeStamp) > sysUpTime && !wrapped {
+		return t, false
+	}
+
+	elapsed := sysUpTime - uint32(timeStamp) // uint32 arithmetic handles the wrap.
+	return now.Add(-time.Duration(elapsed) * tickDuration), true
+}
+
+// FormatTimeStamp converts raw TimeStamp and sysUpTime data to an RFC3339
+// string. The string is empty if the time of the event is unknown. See
+// TimeStampToTime for wrapped.
+func FormatTimeStamp(timeStamp interface{}, sysUpTime interface{}, now time.Time, wrapped bool) (string, error) {
+	ts, err := ToTicks(timeStamp)
+	if err != nil {
+		return "", err
+	}
+	upTime, err := ToTicks(sysUpTime)
+	if err != nil {
+		return "", err
+	}
+
+	t, ok := TimeStampToTime(TimeStamp(ts), upTime, now, wrapped)
+	if !ok {
+		return "", nil
+	}
+	return t.UTC().Format(time.RFC3339), nil
+}
+
+// rebootSlack is how far the boot time of an agent may move between readings
+// of sysUpTime without it being a reboot, e.g. from the time it took to read.
+const rebootSlack = time.Minute
+
+// wrapDuration is the duration after which TimeTicks wrap, about 497 days.
+const wrapDuration = time.Duration(1<<32) * tickDuration
+
+// UpTimeDuration converts sysUpTime TimeTicks to a duration.
+func UpTimeDuration(sysUpTime uint32) time.Duration {
+	return time.Duration(sysUpTime) * tickDuration
+}
+
+// BootTime is the wall-clock time the agent was last re-initialized, given
+// sysUpTime read at now.
+func BootTime(sysUpTime uint32, now time.Time) time.Time {
+	return now.Add(-UpTimeDuration(sysUpTime))
+}
+
+// Rebooted is true if the agent was re-initialized between two readings of
+// sysUpTime, each read at the given local time. The boot time of the agent
+// only moves when it reboots, or when sysUpTime wraps, in which case it moves
+// by the wrap duration.
+func Rebooted(previous uint32, previousNow time.Time, sysUpTime uint32, now time.Time) bool {
+	moved := BootTime(sysUpTime, now).Sub(BootTime(previous, previousNow))
+	if moved <= rebootSlack {
+		return false
+	}
+	wrap := moved - wrapDuration
+	return wrap > rebootSlack || wrap < -rebootSlack
+}
+
+// Wrapped is true if sysUpTime wrapped between two readings, each read at the
+// given local time, without the agent being re-initialized.
+func Wrapped(previous uint32, previousNow time.Time, sysUpTime uint32, now time.Time) bool {
+	return sysUpTime < previous && !Rebooted(previous, previousNow, sysUpTime, now)
+}
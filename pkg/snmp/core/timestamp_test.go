@@ -0,0 +1,120 @@
+package core
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testNow is a fixed local time for the TimeStamp tests.
+var testNow = time.Date(2021, time.March, 4, 12, 0, 0, 0, time.UTC)
+
+// TestTimeStampToTime tests the simple case where sysUpTime has not wrapped.
+func TestTimeStampToTime(t *testing.T) {
+	// Event 60 seconds ago.
+	actual, ok := TimeStampToTime(TimeStamp(1000), 7000, testNow, false)
+	assert.True(t, ok)
+	assert.Equal(t, testNow.Add(-60*time.Second), actual)
+
+	// Event now.
+	actual, ok = TimeStampToTime(TimeStamp(7000), 7000, testNow, false)
+	assert.True(t, ok)
+	assert.Equal(t, testNow, actual)
+}
+
+// TestTimeStampToTimeWrap tests the case where sysUpTime has wrapped since the
+// event.
+func TestTimeStampToTimeWrap(t *testing.T) {
+	// Event 100 ticks before the wrap, sysUpTime 500 ticks after the wrap.
+	actual, ok := TimeStampToTime(TimeStamp(math.MaxUint32-99), 500, testNow, true)
+	assert.True(t, ok)
+	assert.Equal(t, testNow.Add(-6*time.Second), actual)
+
+	// Without knowing sysUpTime wrapped, the TimeStamp may be garbage.
+	actual, ok = TimeStampToTime(TimeStamp(math.MaxUint32-99), 500, testNow, false)
+	assert.False(t, ok)
+	assert.True(t, actual.IsZero())
+	actual, ok = TimeStampToTime(TimeStamp(7001), 7000, testNow, false)
+	assert.False(t, ok)
+	assert.True(t, actual.IsZero())
+}
+
+// TestTimeStampToTimeBeforeReinit tests that a TimeStamp of zero is unknown.
+func TestTimeStampToTimeBeforeReinit(t *testing.T) {
+	actual, ok := TimeStampToTime(TimeStamp(0), 7000, testNow, false)
+	assert.False(t, ok)
+	assert.True(t, actual.IsZero())
+}
+
+// TestFormatTimeStamp tests formatting raw TimeStamp data.
+func TestFormatTimeStamp(t *testing.T) {
+	// gosnmp decodes TimeTicks to uint32.
+	actual, err := FormatTimeStamp(uint32(1000), uint32(7000), testNow, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "2021-03-04T11:59:00Z", actual)
+
+	// Other integer types.
+	actual, err = FormatTimeStamp(1000, int64(7000), testNow, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "2021-03-04T11:59:00Z", actual)
+
+	// Non-UTC times are formatted in UTC.
+	actual, err = FormatTimeStamp(uint32(1000), uint32(7000), testNow.In(time.FixedZone("test", 3600)), false)
+	assert.NoError(t, err)
+	assert.Equal(t, "2021-03-04T11:59:00Z", actual)
+
+	// Unknown time.
+	actual, err = FormatTimeStamp(uint32(0), uint32(7000), testNow, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "", actual)
+}
+
+// TestFormatTimeStampError tests formatting non-integer TimeStamp data.
+func TestFormatTimeStampError(t *testing.T) {
+	_, err := FormatTimeStamp("1000", uint32(7000), testNow, false)
+	assert.Error(t, err)
+
+	_, err = FormatTimeStamp(uint32(1000), nil, testNow, false)
+	assert.Error(t, err)
+}
+
+// TestBootTime tests the wall-clock boot time from sysUpTime.
+func TestBootTime(t *testing.T) {
+	assert.Equal(t, 90*time.Second, UpTimeDuration(9000))
+	assert.Equal(t, testNow.Add(-90*time.Second), BootTime(9000, testNow))
+	assert.Equal(t, testNow, BootTime(0, testNow))
+}
+
+// TestRebooted tests detecting agent reboots between readings of sysUpTime.
+func TestRebooted(t *testing.T) {
+	later := testNow.Add(5 * time.Minute)
+
+	// Up for five more minutes.
+	assert.False(t, Rebooted(100000, testNow, 130000, later))
+	// Reading delays move the boot time a little.
+	assert.False(t, Rebooted(100000, testNow, 127000, later))
+	assert.False(t, Rebooted(100000, testNow, 133000, later))
+	// Up for less time than has passed.
+	assert.True(t, Rebooted(100000, testNow, 6000, later))
+	assert.True(t, Rebooted(100000, testNow, 100000, later))
+
+	// Wrapped, up for five more minutes.
+	assert.False(t, Rebooted(math.MaxUint32-10000, testNow, 19999, later))
+	// Rebooted around the wrap.
+	assert.True(t, Rebooted(math.MaxUint32-10000, testNow, 100, later))
+}
+
+// TestWrapped tests detecting sysUpTime wraps between readings.
+func TestWrapped(t *testing.T) {
+	later := testNow.Add(5 * time.Minute)
+
+	// Up for five more minutes.
+	assert.False(t, Wrapped(100000, testNow, 130000, later))
+	// Wrapped, up for five more minutes.
+	assert.True(t, Wrapped(math.MaxUint32-10000, testNow, 19999, later))
+	// Rebooted.
+	assert.False(t, Wrapped(100000, testNow, 6000, later))
+	assert.False(t, Wrapped(math.MaxUint32-10000, testNow, 100, later))
+}
@@ -100,6 +100,23 @@ func MergeMapStringInterface(a map[string]interface{}, b map[string]interface{})
 	return merged, nil
 }
 
+// ToStringMap converts a decoded YAML or JSON map to map[string]interface{}.
+// Maps decoded from YAML may be map[interface{}]interface{}.
+func ToStringMap(raw interface{}) (map[string]interface{}, error) {
+	switch m := raw.(type) {
+	case map[string]interface{}:
+		return m, nil
+	case map[interface{}]interface{}:
+		result := map[string]interface{}{}
+		for k, v := range m {
+			result[fmt.Sprint(k)] = v
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("expected a map, got type: %T, value: %v", raw, raw)
+	}
+}
+
 // TranslatePrintableASCII translates byte arrays from gosnmp to a printable
 // string if possible. If this call fails, the caller should normally just keep
 // the raw byte array. This call makes no attempt to support extended (8bit)
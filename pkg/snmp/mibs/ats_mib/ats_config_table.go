@@ -0,0 +1,51 @@
+package atsmib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// configTable is the definition of SNMP OID .1.3.6.1.4.1.318.1.1.8.4, the
+// configuration of the transfer switch.
+var configTable = &core.TableDefinition{
+	Name:      "PowerNet-MIB-atsConfig",
+	WalkOid:   atsOid + ".4",
+	Flattened: true,
+	Columns: []*core.ColumnDefinition{
+		{Name: "atsConfigProductName"},          // User assigned name of the switch.
+		{Name: "atsConfigPreferredSource"},      // e.g. sourceA(1). Read-write.
+		{Name: "atsConfigFrontPanelLockout"},    // Whether the preferred source may be set on the front panel.
+		{Name: "atsConfigVoltageSensitivity"},   // Sensitivity to input voltage distortion.
+		{Name: "atsConfigTransferVoltageRange"}, // Input voltage range before a transfer.
+		{Name: "atsConfigCurrentLimit"},         // Tenths of amps.
+		{Name: "atsConfigResetValues"},          // Write only.
+		{Name: "atsConfigLineVRMS"},             // Nominal input voltage.
+		{Name: "atsConfigNominalFrequency"},     // Nominal input frequency.
+	},
+}
+
+// preferredSource enumerates atsConfigPreferredSource. The switch feeds the
+// load from the preferred source whenever that source is good.
+var preferredSource = map[int]string{
+	1: "sourceA",
+	2: "sourceB",
+	3: "none",
+}
+
+// preferredSourceActions are the write actions of the preferred source, the
+// values to set.
+var preferredSourceActions = map[string]core.ColumnAction{
+	"sourceA": {Column: 2, Value: 1},
+	"sourceB": {Column: 2, Value: 2},
+	"none":    {Column: 2, Value: 3},
+}
+
+// configDevices are the devices of the configuration. The preferred
+// source may be changed. See devices.SnmpTransferSource for how writes are
+// guarded.
+var configDevices = []core.ColumnDevice{
+	{Column: 1, DeviceType: "identity"}, // atsConfigProductName
+	{
+		Column:      2, // atsConfigPreferredSource
+		DeviceType:  "transfer-source",
+		Enumeration: preferredSource,
+		Actions:     preferredSourceActions,
+	},
+}
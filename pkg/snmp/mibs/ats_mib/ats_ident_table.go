@@ -0,0 +1,28 @@
+package atsmib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// identTable is the definition of SNMP OID .1.3.6.1.4.1.318.1.1.8.1, the
+// identification of the transfer switch.
+var identTable = &core.TableDefinition{
+	Name:      "PowerNet-MIB-atsIdent",
+	WalkOid:   atsOid + ".1",
+	Flattened: true,
+	Columns: []*core.ColumnDefinition{
+		{Name: "atsIdentHardwareRev"},       // Hardware revision.
+		{Name: "atsIdentFirmwareRev"},       // Firmware revision.
+		{Name: "atsIdentFirmwareDate"},      // Date of the firmware release.
+		{Name: "atsIdentDateOfManufacture"}, // mm/dd/yy
+		{Name: "atsIdentModelNumber"},       // e.g. AP4423.
+		{Name: "atsIdentSerialNumber"},      // Serial number.
+	},
+}
+
+// identDevices are the identity devices of the switch.
+var identDevices = []core.ColumnDevice{
+	{Column: 1, DeviceType: "identity"}, // atsIdentHardwareRev
+	{Column: 2, DeviceType: "identity"}, // atsIdentFirmwareRev
+	{Column: 4, DeviceType: "identity"}, // atsIdentDateOfManufacture
+	{Column: 5, DeviceType: "identity"}, // atsIdentModelNumber
+	{Column: 6, DeviceType: "identity"}, // atsIdentSerialNumber
+}
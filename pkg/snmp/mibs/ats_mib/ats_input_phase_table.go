@@ -0,0 +1,36 @@
+package atsmib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// inputPhaseTable is the definition of SNMP OID .1.3.6.1.4.1.318.1.1.8.5.3.3,
+// the phases of each source of the transfer switch.
+var inputPhaseTable = &core.TableDefinition{
+	Name:        "PowerNet-MIB-atsInputPhaseTable",
+	WalkOid:     atsOid + ".5.3.3",
+	RowBase:     "1",
+	IndexColumn: "1",
+	Index: []core.IndexComponent{
+		{Name: "atsInputPhaseTableIndex", Type: core.IndexInteger},
+		{Name: "atsInputPhaseIndex", Type: core.IndexInteger},
+	},
+	Columns: []*core.ColumnDefinition{
+		{Name: "atsInputPhaseTableIndex"}, // Index of the source.
+		{Name: "atsInputPhaseIndex"},      // Index of the phase.
+		{Name: "atsInputVoltage"},         // Volts
+		{Name: "atsInputMaxVoltage"},      // Volts, since the last reset.
+		{Name: "atsInputMinVoltage"},      // Volts, since the last reset.
+		{Name: "atsInputCurrent"},         // Tenths of amps.
+		{Name: "atsInputMaxCurrent"},      // Tenths of amps, since the last reset.
+		{Name: "atsInputMinCurrent"},      // Tenths of amps, since the last reset.
+		{Name: "atsInputPower"},           // Watts
+		{Name: "atsInputMaxPower"},        // Watts, since the last reset.
+		{Name: "atsInputMinPower"},        // Watts, since the last reset.
+	},
+}
+
+// inputPhaseDevices are the devices of each phase of each source.
+var inputPhaseDevices = []core.ColumnDevice{
+	{Column: 3, DeviceType: "voltage"},                     // atsInputVoltage
+	{Column: 6, DeviceType: "current", Multiplier: tenths}, // atsInputCurrent
+	{Column: 9, DeviceType: "power"},                       // atsInputPower
+}
@@ -0,0 +1,26 @@
+package atsmib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// inputTable is the definition of SNMP OID .1.3.6.1.4.1.318.1.1.8.5.3.2, the
+// sources of the transfer switch, source A and source B.
+var inputTable = &core.TableDefinition{
+	Name:        "PowerNet-MIB-atsInputTable",
+	WalkOid:     atsOid + ".5.3.2",
+	RowBase:     "1",
+	IndexColumn: "1",
+	Index:       []core.IndexComponent{{Name: "atsInputTableIndex", Type: core.IndexInteger}},
+	Columns: []*core.ColumnDefinition{
+		{Name: "atsInputTableIndex"},         // Index of the source.
+		{Name: "atsNumInputPhases"},          // Number of phases of the source.
+		{Name: "atsInputVoltageOrientation"}, // e.g. singlePhase(2).
+		{Name: "atsInputFrequency"},          // Hertz
+		{Name: "atsInputType"},               // e.g. main(2).
+		{Name: "atsInputName"},               // Name of the source, e.g. Source A.
+	},
+}
+
+// inputDevices are the devices of each source.
+var inputDevices = []core.ColumnDevice{
+	{Column: 4, DeviceType: "frequency"}, // atsInputFrequency
+}
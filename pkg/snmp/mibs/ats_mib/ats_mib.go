@@ -0,0 +1,160 @@
+package atsmib
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// MibName is the name the ats subtree of PowerNet-MIB is registered with.
+// See core.RegisterMib.
+const MibName = "PowerNet-MIB-ats"
+
+// powerNetModule is the MIB module which defines the subtree. The column
+// names are registered with it.
+const powerNetModule = "PowerNet-MIB"
+
+// atsOid is the ats subtree of PowerNet-MIB.
+const atsOid = ".1.3.6.1.4.1.318.1.1.8"
+
+func init() {
+	err := core.RegisterMib(MibName, func(server *core.SnmpServerBase) (core.Mib, error) {
+		atsMib, err := NewAtsMib(server)
+		if err != nil {
+			return nil, err
+		}
+		return atsMib, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	// APC agents do not fill in the sysORTable. The sysObjectID of APC
+	// transfer switches is under ats, and every one serves atsIdent.
+	err = core.RegisterMibDetection(MibName, core.MibDetection{
+		SysObjectIDs: []string{".1.3.6.1.4.1.318.1.3.11"},
+		ProbeOids:    []string{atsOid + ".1"},
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// AtsMib is the class for the ats subtree of the APC (Schneider)
+// PowerNet-MIB, served by the rack automatic transfer switches (ATS), e.g.
+// the AP4400 series. The switch feeds the load from source A or source B,
+// and transfers to the other source when the selected one fails.
+//
+// The status group has the selected source, the state of each source and
+// whether the load is still redundant. The input tables have the frequency
+// and voltage of each source, and the output tables the load per phase. The
+// preferred source may be changed. See devices.SnmpTransferSource.
+type AtsMib struct {
+	*core.SnmpMib // base class
+
+	// Tables defined in this MIB
+	AtsIdentTable       *core.SnmpTable
+	AtsConfigTable      *core.SnmpTable
+	AtsStatusTable      *core.SnmpTable
+	AtsInputTable       *core.SnmpTable
+	AtsInputPhaseTable  *core.SnmpTable
+	AtsOutputTable      *core.SnmpTable
+	AtsOutputPhaseTable *core.SnmpTable
+}
+
+// NewAtsMib constructs the AtsMib.
+func NewAtsMib(server *core.SnmpServerBase) (atsMib *AtsMib, err error) {
+	log.Debugf("[snmp] initializing AtsMib")
+
+	// Arg checks.
+	if server == nil {
+		return nil, fmt.Errorf("unable to create new AtsMib: server is nil")
+	}
+
+	// Initialize Tables.
+	atsMib = &AtsMib{}
+	for _, table := range []struct {
+		table      **core.SnmpTable
+		definition *core.TableDefinition
+		nameColumn int
+		devices    []core.ColumnDevice
+	}{
+		{&atsMib.AtsIdentTable, identTable, 0, identDevices},
+		{&atsMib.AtsConfigTable, configTable, 0, configDevices},
+		{&atsMib.AtsStatusTable, statusTable, 0, statusDevices},
+		{&atsMib.AtsInputTable, inputTable, 6, inputDevices}, // atsInputName
+		{&atsMib.AtsInputPhaseTable, inputPhaseTable, 0, inputPhaseDevices},
+		{&atsMib.AtsOutputTable, outputTable, 0, outputDevices},
+		{&atsMib.AtsOutputPhaseTable, outputPhaseTable, 0, outputPhaseDevices},
+	} {
+		*table.table, err = core.NewColumnTable(table.definition, server, enumeration(table.nameColumn, table.devices))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Initialize the base class.
+	snmpMib, err := core.NewSnmpMib(MibName, []*core.SnmpTable{
+		atsMib.AtsIdentTable,
+		atsMib.AtsConfigTable,
+		atsMib.AtsStatusTable,
+		atsMib.AtsInputTable,
+		atsMib.AtsInputPhaseTable,
+		atsMib.AtsOutputTable,
+		atsMib.AtsOutputPhaseTable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	snmpMib.RegisterNames(powerNetModule)
+	atsMib.SnmpMib = snmpMib
+
+	// Update mib pointer for each table.
+	for _, table := range atsMib.Tables {
+		table.Mib = atsMib
+	}
+
+	log.Debugf("Initialized AtsMib")
+	return atsMib, nil
+}
+
+// Model gets the model of the switch, atsIdentModelNumber, or the empty
+// string if the agent does not serve it.
+func (atsMib *AtsMib) Model() string {
+	table := atsMib.AtsIdentTable
+	if len(table.Rows) == 0 {
+		return ""
+	}
+	model, _ := table.Rows[0].RowData[4].Data.(string)
+	return strings.TrimSpace(model)
+}
+
+// tenths is the multiplier of objects in tenths, e.g. of amps.
+const tenths = float32(0.1)
+
+// notSupported is served by APC transfer switches for the measures the model
+// does not have, e.g. the output power of a switch without metering.
+const notSupported = -1
+
+// served is false for objects the agent does not serve, or serves as not
+// supported.
+func served(data interface{}) bool {
+	return data != nil && data != notSupported
+}
+
+// enumeration gets the devices for the columns of a table, with the model of
+// the switch in their context. The name column of other tables, if not 0, is
+// added to the context of the devices as the name, e.g. the name of the
+// source. See core.NewColumnTable.
+func enumeration(nameColumn int, columnDevices []core.ColumnDevice) func(table *core.SnmpTable) core.ColumnEnumeration {
+	return func(table *core.SnmpTable) core.ColumnEnumeration {
+		return core.ColumnEnumeration{
+			Model:      table.Mib.(*AtsMib).Model(),
+			NameColumn: nameColumn,
+			Served:     served,
+			Devices:    columnDevices,
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package atsmib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core/coretest"
+)
+
+// TestAtsMib tests the devices of a transfer switch which lost source B.
+func TestAtsMib(t *testing.T) {
+	atsMib, err := NewAtsMib(coretest.NewReplayServerBase(t, "testdata/ats.snmpwalk"))
+	assert.NoError(t, err)
+	assert.Equal(t, MibName, atsMib.Name)
+
+	devices, err := atsMib.EnumerateDevices(map[string]interface{}{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "AP4423", atsMib.Model())
+	for _, proto := range devices {
+		assert.Equal(t, "AP4423", proto.Context["model"])
+	}
+
+	// Identity.
+	assert.NotNil(t, coretest.FindInstance(devices, "identity", "atsIdentModelNumber"))
+	assert.NotNil(t, coretest.FindInstance(devices, "identity", "atsIdentSerialNumber"))
+	assert.NotNil(t, coretest.FindInstance(devices, "identity", "atsConfigProductName"))
+
+	// Status.
+	selected := coretest.FindInstance(devices, "status", "atsStatusSelectedSource")
+	assert.NotNil(t, selected)
+	assert.Equal(t, ".1.3.6.1.4.1.318.1.1.8.5.2.0", selected.Data["oid"])
+	assert.Equal(t, "sourceB", selected.Data["enumeration2"])
+	redundancy := coretest.FindInstance(devices, "status", "atsStatusRedundancyState")
+	assert.NotNil(t, redundancy)
+	assert.Equal(t, "atsRedundancyLost", redundancy.Data["enumeration1"])
+	sourceB := coretest.FindInstance(devices, "status", "atsStatusSourceBStatus")
+	assert.NotNil(t, sourceB)
+	assert.Equal(t, "fail", sourceB.Data["enumeration1"])
+	assert.NotNil(t, coretest.FindInstance(devices, "status", "atsStatusPhaseSyncStatus"))
+	assert.Equal(t, 10, coretest.CountInstances(devices, "status"))
+
+	// Sources.
+	assert.Equal(t, 3, coretest.CountInstances(devices, "frequency"))
+	frequency := coretest.FindInstance(devices, "frequency", "atsInputFrequency 2")
+	assert.NotNil(t, frequency)
+	assert.Equal(t, ".1.3.6.1.4.1.318.1.1.8.5.3.2.1.4.2", frequency.Data["oid"])
+	assert.Equal(t, map[string]string{"index": "2", "atsInputTableIndex": "2", "name": "Source B"}, frequency.Context)
+	voltage := coretest.FindInstance(devices, "voltage", "atsInputVoltage 1.1")
+	assert.NotNil(t, voltage)
+	assert.Equal(t, map[string]string{
+		"index":                   "1.1",
+		"atsInputPhaseTableIndex": "1",
+		"atsInputPhaseIndex":      "1",
+	}, voltage.Context)
+	assert.NotNil(t, coretest.FindInstance(devices, "voltage", "atsInputVoltage 2.1"))
+	// The input current and power are not supported.
+	assert.Nil(t, coretest.FindInstance(devices, "current", "atsInputCurrent 1.1"))
+	assert.Nil(t, coretest.FindInstance(devices, "power", "atsInputPower 1.1"))
+
+	// Output.
+	current := coretest.FindInstance(devices, "current", "atsOutputCurrent 1.1")
+	assert.NotNil(t, current)
+	assert.Equal(t, ".1.3.6.1.4.1.318.1.1.8.5.4.3.1.4.1.1", current.Data["oid"])
+	assert.Equal(t, float32(0.1), current.Data["multiplier"])
+	assert.NotNil(t, coretest.FindInstance(devices, "voltage", "atsOutputVoltage 1.1"))
+	assert.NotNil(t, coretest.FindInstance(devices, "apparent-power", "atsOutputLoad 1.1"))
+	assert.NotNil(t, coretest.FindInstance(devices, "percentage", "atsOutputPercentLoad 1.1"))
+	assert.NotNil(t, coretest.FindInstance(devices, "power", "atsOutputPower 1.1"))
+
+	// Preferred source.
+	preferred := coretest.FindInstance(devices, "transfer-source", "atsConfigPreferredSource")
+	assert.NotNil(t, preferred)
+	assert.Equal(t, ".1.3.6.1.4.1.318.1.1.8.4.2.0", preferred.Data["oid"])
+	assert.Equal(t, "sourceA", preferred.Data["enumeration1"])
+	assert.Equal(t, ".1.3.6.1.4.1.318.1.1.8.4.2.0", preferred.Data["action_oid_sourceB"])
+	assert.Equal(t, "2", preferred.Data["action_value_sourceB"])
+	assert.Equal(t, "3", preferred.Data["action_value_none"])
+	// Control is not enabled by the MIB.
+	assert.NotContains(t, preferred.Data, "transfer_source_control")
+}
@@ -0,0 +1,43 @@
+package atsmib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// outputPhaseTable is the definition of SNMP OID .1.3.6.1.4.1.318.1.1.8.5.4.3,
+// the load on each phase of the output of the transfer switch.
+var outputPhaseTable = &core.TableDefinition{
+	Name:        "PowerNet-MIB-atsOutputPhaseTable",
+	WalkOid:     atsOid + ".5.4.3",
+	RowBase:     "1",
+	IndexColumn: "1",
+	Index: []core.IndexComponent{
+		{Name: "atsOutputPhaseTableIndex", Type: core.IndexInteger},
+		{Name: "atsOutputPhaseIndex", Type: core.IndexInteger},
+	},
+	Columns: []*core.ColumnDefinition{
+		{Name: "atsOutputPhaseTableIndex"}, // Index of the output.
+		{Name: "atsOutputPhaseIndex"},      // Index of the phase.
+		{Name: "atsOutputVoltage"},         // Volts
+		{Name: "atsOutputCurrent"},         // Tenths of amps.
+		{Name: "atsOutputMaxCurrent"},      // Tenths of amps, since the last reset.
+		{Name: "atsOutputMinCurrent"},      // Tenths of amps, since the last reset.
+		{Name: "atsOutputLoad"},            // Volt-amperes
+		{Name: "atsOutputMaxLoad"},         // Volt-amperes, since the last reset.
+		{Name: "atsOutputMinLoad"},         // Volt-amperes, since the last reset.
+		{Name: "atsOutputPercentLoad"},     // Percent of the capacity in volt-amperes.
+		{Name: "atsOutputMaxPercentLoad"},  // Percent, since the last reset.
+		{Name: "atsOutputMinPercentLoad"},  // Percent, since the last reset.
+		{Name: "atsOutputPower"},           // Watts
+		{Name: "atsOutputMaxPower"},        // Watts, since the last reset.
+		{Name: "atsOutputMinPower"},        // Watts, since the last reset.
+		{Name: "atsOutputPercentPower"},    // Percent of the capacity in watts.
+	},
+}
+
+// outputPhaseDevices are the devices of each phase of the output.
+var outputPhaseDevices = []core.ColumnDevice{
+	{Column: 3, DeviceType: "voltage"},                     // atsOutputVoltage
+	{Column: 4, DeviceType: "current", Multiplier: tenths}, // atsOutputCurrent
+	{Column: 7, DeviceType: "apparent-power"},              // atsOutputLoad
+	{Column: 10, DeviceType: "percentage"},                 // atsOutputPercentLoad
+	{Column: 13, DeviceType: "power"},                      // atsOutputPower
+}
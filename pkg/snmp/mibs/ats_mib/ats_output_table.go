@@ -0,0 +1,24 @@
+package atsmib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// outputTable is the definition of SNMP OID .1.3.6.1.4.1.318.1.1.8.5.4.2, the
+// output of the transfer switch.
+var outputTable = &core.TableDefinition{
+	Name:        "PowerNet-MIB-atsOutputTable",
+	WalkOid:     atsOid + ".5.4.2",
+	RowBase:     "1",
+	IndexColumn: "1",
+	Index:       []core.IndexComponent{{Name: "atsOutputTableIndex", Type: core.IndexInteger}},
+	Columns: []*core.ColumnDefinition{
+		{Name: "atsOutputTableIndex"},         // Index of the output.
+		{Name: "atsNumOutputPhases"},          // Number of phases of the output.
+		{Name: "atsOutputVoltageOrientation"}, // e.g. singlePhase(2).
+		{Name: "atsOutputFrequency"},          // Hertz
+	},
+}
+
+// outputDevices are the devices of each output.
+var outputDevices = []core.ColumnDevice{
+	{Column: 4, DeviceType: "frequency"}, // atsOutputFrequency
+}
@@ -0,0 +1,85 @@
+package atsmib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// statusTable is the definition of SNMP OID .1.3.6.1.4.1.318.1.1.8.5, the
+// status of the transfer switch and its sources.
+var statusTable = &core.TableDefinition{
+	Name:      "PowerNet-MIB-atsStatus",
+	WalkOid:   atsOid + ".5",
+	Flattened: true,
+	Columns: []*core.ColumnDefinition{
+		{Name: "atsStatusCommStatus"},       // e.g. atsCommEstablished(2).
+		{Name: "atsStatusSelectedSource"},   // The source feeding the load, e.g. sourceA(1).
+		{Name: "atsStatusRedundancyState"},  // e.g. atsFullyRedundant(2).
+		{Name: "atsStatusOverCurrentState"}, // e.g. atsCurrentOK(2).
+		{Name: "atsStatus5VPowerSupply"},    // Internal power supplies.
+		{Name: "atsStatus24VPowerSupply"},
+		{Name: "atsStatus24VSourceBPowerSupply"},
+		{Name: "atsStatusPlus12VPowerSupply"},
+		{Name: "atsStatusMinus12VPowerSupply"},
+		{Name: "atsStatusSwitchStatus"},      // e.g. ok(2).
+		{Name: "atsStatusFrontPanel"},        // Whether the front panel is locked.
+		{Name: "atsStatusSourceAStatus"},     // e.g. ok(2).
+		{Name: "atsStatusSourceBStatus"},     // e.g. ok(2).
+		{Name: "atsStatusPhaseSyncStatus"},   // Whether the sources are in phase, e.g. inSync(1).
+		{Name: "atsStatusVoltageOutStatus"},  // e.g. ok(2).
+		{Name: "atsStatusHardwareStatus"},    // e.g. ok(2).
+		{Name: "atsStatusResetMaxMinValues"}, // Write only.
+		{Name: "atsStatusInput"},             // Not a scalar. Placeholder for the column number.
+		{Name: "atsStatusOutput"},            // Not a scalar. Placeholder for the column number.
+	},
+}
+
+// commStatus enumerates atsStatusCommStatus, the communication between the
+// network card and the switch.
+var commStatus = map[int]string{
+	1: "atsNeverDiscovered",
+	2: "atsCommEstablished",
+	3: "atsCommLost",
+}
+
+// selectedSource enumerates atsStatusSelectedSource.
+var selectedSource = map[int]string{
+	1: "sourceA",
+	2: "sourceB",
+}
+
+// redundancyState enumerates atsStatusRedundancyState. Redundancy is lost
+// when the source which is not selected fails.
+var redundancyState = map[int]string{
+	1: "atsRedundancyLost",
+	2: "atsFullyRedundant",
+}
+
+// overCurrentState enumerates atsStatusOverCurrentState.
+var overCurrentState = map[int]string{
+	1: "atsOverCurrent",
+	2: "atsCurrentOK",
+}
+
+// failOK enumerates the status objects which are fail(1) or ok(2).
+var failOK = map[int]string{
+	1: "fail",
+	2: "ok",
+}
+
+// phaseSyncStatus enumerates atsStatusPhaseSyncStatus.
+var phaseSyncStatus = map[int]string{
+	1: "inSync",
+	2: "outOfSync",
+}
+
+// statusDevices are the status devices of the switch and its sources.
+var statusDevices = []core.ColumnDevice{
+	{Column: 1, DeviceType: "status", Enumeration: commStatus},       // atsStatusCommStatus
+	{Column: 2, DeviceType: "status", Enumeration: selectedSource},   // atsStatusSelectedSource
+	{Column: 3, DeviceType: "status", Enumeration: redundancyState},  // atsStatusRedundancyState
+	{Column: 4, DeviceType: "status", Enumeration: overCurrentState}, // atsStatusOverCurrentState
+	{Column: 10, DeviceType: "status", Enumeration: failOK},          // atsStatusSwitchStatus
+	{Column: 12, DeviceType: "status", Enumeration: failOK},          // atsStatusSourceAStatus
+	{Column: 13, DeviceType: "status", Enumeration: failOK},          // atsStatusSourceBStatus
+	{Column: 14, DeviceType: "status", Enumeration: phaseSyncStatus}, // atsStatusPhaseSyncStatus
+	{Column: 15, DeviceType: "status", Enumeration: failOK},          // atsStatusVoltageOutStatus
+	{Column: 16, DeviceType: "status", Enumeration: failOK},          // atsStatusHardwareStatus
+}
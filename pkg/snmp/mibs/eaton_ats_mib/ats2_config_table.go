@@ -0,0 +1,36 @@
+package eatonatsmib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// configTable is the definition of SNMP OID .1.3.6.1.4.1.534.10.2.4, the
+// configuration of the transfer switch.
+var configTable = &core.TableDefinition{
+	Name:      "EATON-ATS2-MIB-ats2Config",
+	WalkOid:   ats2Oid + ".4",
+	Flattened: true,
+	Columns: []*core.ColumnDefinition{
+		{Name: "ats2ConfigTimeRTC"},            // Seconds since the epoch.
+		{Name: "ats2ConfigTimeTextDate"},       // mm/dd/yyyy
+		{Name: "ats2ConfigTimeTextTime"},       // hh:mm:ss
+		{Name: "ats2ConfigInputVoltageRating"}, // Nominal input voltage.
+		{Name: "ats2ConfigPreferred"},          // e.g. source1(1). Read-write.
+	},
+}
+
+// preferredSourceActions are the write actions of the preferred source, the
+// values to set.
+var preferredSourceActions = map[string]core.ColumnAction{
+	"source1": {Column: 5, Value: 1},
+	"source2": {Column: 5, Value: 2},
+}
+
+// configDevices are the devices of the configuration. The preferred source
+// may be changed. See devices.SnmpTransferSource for how writes are guarded.
+var configDevices = []core.ColumnDevice{
+	{
+		Column:      5, // ats2ConfigPreferred
+		DeviceType:  "transfer-source",
+		Enumeration: sources,
+		Actions:     preferredSourceActions,
+	},
+}
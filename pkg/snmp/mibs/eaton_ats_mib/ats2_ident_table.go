@@ -0,0 +1,30 @@
+package eatonatsmib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// identTable is the definition of SNMP OID .1.3.6.1.4.1.534.10.2.1, the
+// identification of the transfer switch.
+var identTable = &core.TableDefinition{
+	Name:      "EATON-ATS2-MIB-ats2Ident",
+	WalkOid:   ats2Oid + ".1",
+	Flattened: true,
+	Columns: []*core.ColumnDefinition{
+		{Name: "ats2IdentManufacturer"}, // e.g. EATON.
+		{Name: "ats2IdentModel"},        // e.g. EATS16N.
+		{Name: "ats2IdentFWVersion"},    // Firmware version of the switch.
+		{Name: "ats2IdentRelease"},      // Release date of the firmware.
+		{Name: "ats2IdentSerialNumber"}, // Serial number.
+		{Name: "ats2IdentPartNumber"},   // Part number.
+		{Name: "ats2IdentAgentVersion"}, // Firmware version of the network card.
+	},
+}
+
+// identDevices are the identity devices of the switch.
+var identDevices = []core.ColumnDevice{
+	{Column: 1, DeviceType: "identity"}, // ats2IdentManufacturer
+	{Column: 2, DeviceType: "identity"}, // ats2IdentModel
+	{Column: 3, DeviceType: "identity"}, // ats2IdentFWVersion
+	{Column: 5, DeviceType: "identity"}, // ats2IdentSerialNumber
+	{Column: 6, DeviceType: "identity"}, // ats2IdentPartNumber
+	{Column: 7, DeviceType: "identity"}, // ats2IdentAgentVersion
+}
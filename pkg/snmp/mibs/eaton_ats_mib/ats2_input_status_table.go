@@ -0,0 +1,70 @@
+package eatonatsmib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// inputStatusTable is the definition of SNMP OID .1.3.6.1.4.1.534.10.2.3.2,
+// the status of the sources of the transfer switch.
+var inputStatusTable = &core.TableDefinition{
+	Name:        "EATON-ATS2-MIB-ats2InputStatusTable",
+	WalkOid:     ats2Oid + ".3.2",
+	RowBase:     "1",
+	IndexColumn: "1",
+	Index:       []core.IndexComponent{{Name: "ats2InputStatusIndex", Type: core.IndexInteger}},
+	Columns: []*core.ColumnDefinition{
+		{Name: "ats2InputStatusIndex"},           // The source, e.g. source1(1).
+		{Name: "ats2InputStatusDephasing"},       // Phase difference to the other source, e.g. normal(1).
+		{Name: "ats2InputStatusFrequency"},       // e.g. good(1).
+		{Name: "ats2InputStatusGood"},            // Whether the source could feed the load.
+		{Name: "ats2InputStatusInternalFailure"}, // e.g. good(1).
+		{Name: "ats2InputStatusVoltage"},         // e.g. normalRange(1).
+		{Name: "ats2InputStatusUsed"},            // Whether the source feeds the load.
+	},
+}
+
+// dephasing enumerates ats2InputStatusDephasing.
+var dephasing = map[int]string{
+	1: "normal",
+	2: "outOfRange",
+}
+
+// frequencyStatus enumerates ats2InputStatusFrequency.
+var frequencyStatus = map[int]string{
+	1: "good",
+	2: "outOfRange",
+}
+
+// sourceGood enumerates ats2InputStatusGood. Redundancy is lost when the
+// source which is not used is not good.
+var sourceGood = map[int]string{
+	1: "voltageOrFrequencyNotGood",
+	2: "voltageAndFrequencyGood",
+}
+
+// internalFailure enumerates ats2InputStatusInternalFailure.
+var internalFailure = map[int]string{
+	1: "good",
+	2: "internalFailure",
+}
+
+// voltageStatus enumerates ats2InputStatusVoltage.
+var voltageStatus = map[int]string{
+	1: "normalRange",
+	2: "underVoltage",
+	3: "overVoltage",
+}
+
+// sourceUsed enumerates ats2InputStatusUsed.
+var sourceUsed = map[int]string{
+	1: "notPowering",
+	2: "powering",
+}
+
+// inputStatusDevices are the status devices of each source.
+var inputStatusDevices = []core.ColumnDevice{
+	{Column: 2, DeviceType: "status", Enumeration: dephasing},       // ats2InputStatusDephasing
+	{Column: 3, DeviceType: "status", Enumeration: frequencyStatus}, // ats2InputStatusFrequency
+	{Column: 4, DeviceType: "status", Enumeration: sourceGood},      // ats2InputStatusGood
+	{Column: 5, DeviceType: "status", Enumeration: internalFailure}, // ats2InputStatusInternalFailure
+	{Column: 6, DeviceType: "status", Enumeration: voltageStatus},   // ats2InputStatusVoltage
+	{Column: 7, DeviceType: "status", Enumeration: sourceUsed},      // ats2InputStatusUsed
+}
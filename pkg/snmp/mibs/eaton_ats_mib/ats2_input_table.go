@@ -0,0 +1,24 @@
+package eatonatsmib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// inputTable is the definition of SNMP OID .1.3.6.1.4.1.534.10.2.2.2, the
+// measures of the sources of the transfer switch, source 1 and source 2.
+var inputTable = &core.TableDefinition{
+	Name:        "EATON-ATS2-MIB-ats2InputTable",
+	WalkOid:     ats2Oid + ".2.2",
+	RowBase:     "1",
+	IndexColumn: "1",
+	Index:       []core.IndexComponent{{Name: "ats2InputIndex", Type: core.IndexInteger}},
+	Columns: []*core.ColumnDefinition{
+		{Name: "ats2InputIndex"},     // The source, e.g. source1(1).
+		{Name: "ats2InputVoltage"},   // Tenths of volts.
+		{Name: "ats2InputFrequency"}, // Tenths of Hertz.
+	},
+}
+
+// inputDevices are the devices of each source.
+var inputDevices = []core.ColumnDevice{
+	{Column: 2, DeviceType: "voltage", Multiplier: tenths},   // ats2InputVoltage
+	{Column: 3, DeviceType: "frequency", Multiplier: tenths}, // ats2InputFrequency
+}
@@ -0,0 +1,21 @@
+package eatonatsmib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// outputTable is the definition of SNMP OID .1.3.6.1.4.1.534.10.2.2.3, the
+// measures of the single phase output of the transfer switch.
+var outputTable = &core.TableDefinition{
+	Name:      "EATON-ATS2-MIB-ats2Output",
+	WalkOid:   ats2Oid + ".2.3",
+	Flattened: true,
+	Columns: []*core.ColumnDefinition{
+		{Name: "ats2OutputVoltage"}, // Tenths of volts.
+		{Name: "ats2OutputCurrent"}, // Tenths of amps.
+	},
+}
+
+// outputDevices are the devices of the output.
+var outputDevices = []core.ColumnDevice{
+	{Column: 1, DeviceType: "voltage", Multiplier: tenths}, // ats2OutputVoltage
+	{Column: 2, DeviceType: "current", Multiplier: tenths}, // ats2OutputCurrent
+}
@@ -0,0 +1,32 @@
+package eatonatsmib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// statusTable is the definition of SNMP OID .1.3.6.1.4.1.534.10.2.3, the
+// status of the transfer switch.
+var statusTable = &core.TableDefinition{
+	Name:      "EATON-ATS2-MIB-ats2Status",
+	WalkOid:   ats2Oid + ".3",
+	Flattened: true,
+	Columns: []*core.ColumnDefinition{
+		{Name: "ats2OperationMode"},    // The source feeding the load, e.g. source1(4).
+		{Name: "ats2InputStatusTable"}, // Not a scalar. Placeholder for the column number.
+	},
+}
+
+// operationMode enumerates ats2OperationMode. The switch is in source1 or
+// source2 mode while the source feeds the load.
+var operationMode = map[int]string{
+	1: "initialization",
+	2: "diagnosis",
+	3: "off",
+	4: "source1",
+	5: "source2",
+	6: "safe",
+	7: "fault",
+}
+
+// statusDevices are the status devices of the switch.
+var statusDevices = []core.ColumnDevice{
+	{Column: 1, DeviceType: "status", Enumeration: operationMode}, // ats2OperationMode
+}
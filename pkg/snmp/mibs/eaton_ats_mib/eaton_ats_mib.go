@@ -0,0 +1,160 @@
+package eatonatsmib
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// MibName is the name EATON-ATS2-MIB is registered with. See
+// core.RegisterMib.
+const MibName = "EATON-ATS2-MIB"
+
+// ats2Oid is the root of EATON-ATS2-MIB, under the Eaton enterprise.
+const ats2Oid = ".1.3.6.1.4.1.534.10.2"
+
+func init() {
+	err := core.RegisterMib(MibName, func(server *core.SnmpServerBase) (core.Mib, error) {
+		eatonAtsMib, err := NewEatonAtsMib(server)
+		if err != nil {
+			return nil, err
+		}
+		return eatonAtsMib, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	// The sysObjectID of the Network-M2 cards of Eaton transfer switches is
+	// the root of the MIB, and every one serves ats2Ident.
+	err = core.RegisterMibDetection(MibName, core.MibDetection{
+		SysObjectIDs: []string{ats2Oid},
+		ProbeOids:    []string{ats2Oid + ".1"},
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// EatonAtsMib is the class for EATON-ATS2-MIB, served by the Network-M2 cards
+// of the Eaton rack automatic transfer switches (ATS), e.g. the EATS16 and
+// EATS30. The switch feeds the load from source 1 or source 2, and transfers
+// to the other source when the one in use fails.
+//
+// The status group has the operation mode, which is the source in use. The
+// input status table has whether each source is good and powering the load,
+// so redundancy is lost when the source which is not in use is not good. The
+// measures have the voltage and frequency of each source, and the output
+// voltage and current of the single phase output. The preferred source may be
+// changed. See devices.SnmpTransferSource.
+type EatonAtsMib struct {
+	*core.SnmpMib // base class
+
+	// Tables defined in this MIB
+	Ats2IdentTable       *core.SnmpTable
+	Ats2InputTable       *core.SnmpTable
+	Ats2OutputTable      *core.SnmpTable
+	Ats2StatusTable      *core.SnmpTable
+	Ats2InputStatusTable *core.SnmpTable
+	Ats2ConfigTable      *core.SnmpTable
+}
+
+// NewEatonAtsMib constructs the EatonAtsMib.
+func NewEatonAtsMib(server *core.SnmpServerBase) (eatonAtsMib *EatonAtsMib, err error) {
+	log.Debugf("[snmp] initializing EatonAtsMib")
+
+	// Arg checks.
+	if server == nil {
+		return nil, fmt.Errorf("unable to create new EatonAtsMib: server is nil")
+	}
+
+	// Initialize Tables.
+	eatonAtsMib = &EatonAtsMib{}
+	for _, table := range []struct {
+		table      **core.SnmpTable
+		definition *core.TableDefinition
+		devices    []core.ColumnDevice
+	}{
+		{&eatonAtsMib.Ats2IdentTable, identTable, identDevices},
+		{&eatonAtsMib.Ats2InputTable, inputTable, inputDevices},
+		{&eatonAtsMib.Ats2OutputTable, outputTable, outputDevices},
+		{&eatonAtsMib.Ats2StatusTable, statusTable, statusDevices},
+		{&eatonAtsMib.Ats2InputStatusTable, inputStatusTable, inputStatusDevices},
+		{&eatonAtsMib.Ats2ConfigTable, configTable, configDevices},
+	} {
+		*table.table, err = core.NewColumnTable(table.definition, server, enumeration(table.devices))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Initialize the base class.
+	snmpMib, err := core.NewSnmpMib(MibName, []*core.SnmpTable{
+		eatonAtsMib.Ats2IdentTable,
+		eatonAtsMib.Ats2InputTable,
+		eatonAtsMib.Ats2OutputTable,
+		eatonAtsMib.Ats2StatusTable,
+		eatonAtsMib.Ats2InputStatusTable,
+		eatonAtsMib.Ats2ConfigTable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	snmpMib.RegisterNames(MibName)
+	eatonAtsMib.SnmpMib = snmpMib
+
+	// Update mib pointer for each table.
+	for _, table := range eatonAtsMib.Tables {
+		table.Mib = eatonAtsMib
+	}
+
+	log.Debugf("Initialized EatonAtsMib")
+	return eatonAtsMib, nil
+}
+
+// Model gets the model of the switch, ats2IdentModel, or the empty string if
+// the agent does not serve it.
+func (eatonAtsMib *EatonAtsMib) Model() string {
+	table := eatonAtsMib.Ats2IdentTable
+	if len(table.Rows) == 0 {
+		return ""
+	}
+	model, _ := table.Rows[0].RowData[1].Data.(string)
+	return strings.TrimSpace(model)
+}
+
+// tenths is the multiplier of objects in tenths, e.g. of volts.
+const tenths = float32(0.1)
+
+// sources enumerates the index of the input tables, which is the source.
+var sources = map[int]string{
+	1: "source1",
+	2: "source2",
+}
+
+// sourceName gets the name of the source of an input table row from its
+// index column, e.g. source1.
+func sourceName(index interface{}) string {
+	source, _ := index.(int)
+	return sources[source]
+}
+
+// enumeration gets the devices for the columns of a table, with the model of
+// the switch in their context. Devices of the input tables have the name of
+// the source in their context. See core.NewColumnTable.
+func enumeration(columnDevices []core.ColumnDevice) func(table *core.SnmpTable) core.ColumnEnumeration {
+	return func(table *core.SnmpTable) core.ColumnEnumeration {
+		enumeration := core.ColumnEnumeration{
+			Model:   table.Mib.(*EatonAtsMib).Model(),
+			Devices: columnDevices,
+		}
+		if !table.FlattenedTable {
+			// The index column is the source.
+			enumeration.NameColumn = 1
+			enumeration.Name = sourceName
+		}
+		return enumeration
+	}
+}
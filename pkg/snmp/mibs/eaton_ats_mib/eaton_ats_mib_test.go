@@ -0,0 +1,67 @@
+package eatonatsmib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core/coretest"
+)
+
+// TestEatonAtsMib tests the devices of a transfer switch which lost source 2.
+func TestEatonAtsMib(t *testing.T) {
+	eatonAtsMib, err := NewEatonAtsMib(coretest.NewReplayServerBase(t, "testdata/eaton_ats.snmpwalk"))
+	assert.NoError(t, err)
+	assert.Equal(t, MibName, eatonAtsMib.Name)
+
+	devices, err := eatonAtsMib.EnumerateDevices(map[string]interface{}{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "EATS16N", eatonAtsMib.Model())
+	for _, proto := range devices {
+		assert.Equal(t, "EATS16N", proto.Context["model"])
+	}
+
+	// Identity.
+	assert.NotNil(t, coretest.FindInstance(devices, "identity", "ats2IdentModel"))
+	assert.NotNil(t, coretest.FindInstance(devices, "identity", "ats2IdentSerialNumber"))
+	assert.Equal(t, 6, coretest.CountInstances(devices, "identity"))
+
+	// Status.
+	mode := coretest.FindInstance(devices, "status", "ats2OperationMode")
+	assert.NotNil(t, mode)
+	assert.Equal(t, ".1.3.6.1.4.1.534.10.2.3.1.0", mode.Data["oid"])
+	assert.Equal(t, "source1", mode.Data["enumeration4"])
+	good := coretest.FindInstance(devices, "status", "ats2InputStatusGood 2")
+	assert.NotNil(t, good)
+	assert.Equal(t, ".1.3.6.1.4.1.534.10.2.3.2.1.4.2", good.Data["oid"])
+	assert.Equal(t, "voltageOrFrequencyNotGood", good.Data["enumeration1"])
+	assert.Equal(t, map[string]string{"index": "2", "ats2InputStatusIndex": "2", "name": "source2"}, good.Context)
+	assert.NotNil(t, coretest.FindInstance(devices, "status", "ats2InputStatusUsed 1"))
+	assert.Equal(t, 13, coretest.CountInstances(devices, "status"))
+
+	// Sources.
+	voltage := coretest.FindInstance(devices, "voltage", "ats2InputVoltage 1")
+	assert.NotNil(t, voltage)
+	assert.Equal(t, ".1.3.6.1.4.1.534.10.2.2.2.1.2.1", voltage.Data["oid"])
+	assert.Equal(t, float32(0.1), voltage.Data["multiplier"])
+	assert.Equal(t, map[string]string{"index": "1", "ats2InputIndex": "1", "name": "source1"}, voltage.Context)
+	assert.NotNil(t, coretest.FindInstance(devices, "voltage", "ats2InputVoltage 2"))
+	assert.Equal(t, 2, coretest.CountInstances(devices, "frequency"))
+
+	// Output.
+	current := coretest.FindInstance(devices, "current", "ats2OutputCurrent")
+	assert.NotNil(t, current)
+	assert.Equal(t, ".1.3.6.1.4.1.534.10.2.2.3.2.0", current.Data["oid"])
+	assert.Equal(t, float32(0.1), current.Data["multiplier"])
+	assert.NotNil(t, coretest.FindInstance(devices, "voltage", "ats2OutputVoltage"))
+
+	// Preferred source.
+	preferred := coretest.FindInstance(devices, "transfer-source", "ats2ConfigPreferred")
+	assert.NotNil(t, preferred)
+	assert.Equal(t, ".1.3.6.1.4.1.534.10.2.4.5.0", preferred.Data["oid"])
+	assert.Equal(t, "source1", preferred.Data["enumeration1"])
+	assert.Equal(t, ".1.3.6.1.4.1.534.10.2.4.5.0", preferred.Data["action_oid_source2"])
+	assert.Equal(t, "2", preferred.Data["action_value_source2"])
+	// Control is not enabled by the MIB.
+	assert.NotContains(t, preferred.Data, "transfer_source_control")
+}
@@ -0,0 +1,273 @@
+package entitysensormib
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vapor-ware/synse-sdk/sdk/config"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// EntPhySensorTable represents SNMP OID .1.3.6.1.2.1.99.1.1, the sensors of
+// ENTITY-SENSOR-MIB.
+type EntPhySensorTable struct {
+	*core.SnmpTable // base class
+}
+
+// NewEntPhySensorTable constructs the EntPhySensorTable.
+func NewEntPhySensorTable(snmpServerBase *core.SnmpServerBase) (table *EntPhySensorTable, err error) {
+	var tableName = "ENTITY-SENSOR-MIB-entPhySensorTable"
+	var walkOid = ".1.3.6.1.2.1.99.1.1"
+
+	log.WithFields(log.Fields{
+		"name": tableName,
+		"oid":  walkOid,
+	}).Debug("[snmp] creating new table")
+
+	// Initialize the base.
+	snmpTable, err := core.NewSnmpTable(
+		tableName,
+		walkOid,
+		[]string{ // Column Names
+			"entPhySensorType",            // EntitySensorDataType
+			"entPhySensorScale",           // EntitySensorDataScale, e.g. milli(8).
+			"entPhySensorPrecision",       // Decimal places of the value.
+			"entPhySensorValue",           // The reading, in units of type, scale and precision.
+			"entPhySensorOperStatus",      // ok(1), unavailable(2), nonoperational(3)
+			"entPhySensorUnitsDisplay",    // Units for display, e.g. degrees C.
+			"entPhySensorValueTimeStamp",  // TimeStamp
+			"entPhySensorValueUpdateRate", // Milliseconds
+		},
+		snmpServerBase, // snmpServer
+		"1",            // rowBase
+		"",             // indexColumn
+		"1",            // readableColumn
+		false,          // flattened table
+	)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error": err,
+			"table": tableName,
+		}).Error("[snmp] failed to create table")
+		return nil, err
+	}
+
+	if err = snmpTable.SetIndex(entPhysicalIndex); err != nil {
+		return nil, err
+	}
+
+	table = &EntPhySensorTable{SnmpTable: snmpTable}
+	table.DevEnumerator = EntPhySensorTableDeviceEnumerator{table}
+	return table, nil
+}
+
+// sensorTypeNames enumerates EntitySensorDataType.
+var sensorTypeNames = map[int]string{
+	1:  "other",
+	2:  "unknown",
+	3:  "voltsAC",
+	4:  "voltsDC",
+	5:  "amperes",
+	6:  "watts",
+	7:  "hertz",
+	8:  "celsius",
+	9:  "percentRH",
+	10: "rpm",
+	11: "cmm",
+	12: "truthvalue",
+	13: "specialEnum",
+	14: "dBm",
+}
+
+// sensorDeviceTypes are the synse device types for each EntitySensorDataType
+// with one. Sensors of other types do not create devices.
+var sensorDeviceTypes = map[int]string{
+	3:  "voltage",
+	4:  "voltage",
+	5:  "current",
+	6:  "power",
+	7:  "frequency",
+	8:  "temperature",
+	9:  "humidity",
+	10: "rpm",
+	12: "status",
+}
+
+// truthValue enumerates TruthValue, for truthvalue sensors.
+var truthValue = map[int]string{
+	1: "true",
+	2: "false",
+}
+
+// sensorOperStatus enumerates entPhySensorOperStatus, which is the validity
+// of the sensor readings. Only ok(1) readings are valid.
+var sensorOperStatus = map[int]string{
+	1: "ok",
+	2: "unavailable",
+	3: "nonoperational",
+}
+
+// unitsScale is the EntitySensorDataScale of units(9), with an exponent of 0.
+// Each step is a factor of 1000.
+const unitsScale = 9
+
+// sensorMultiplier gets the multiplier for the raw value of a sensor from its
+// entPhySensorScale and entPhySensorPrecision, e.g. 0.1 for units(9) with a
+// precision of 1, or 0.001 for milli(8) with a precision of 0.
+func sensorMultiplier(scale int, precision int) float32 {
+	return float32(math.Pow10((scale-unitsScale)*3 - precision))
+}
+
+// EntPhySensorTableDeviceEnumerator overrides the default SnmpTable device
+// enumerator for the sensor table. Each sensor row is joined to its physical
+// entity for the device info.
+type EntPhySensorTableDeviceEnumerator struct {
+	Table *EntPhySensorTable // Pointer back to the table.
+}
+
+// DeviceEnumerator overrides the default SnmpTable device enumerator.
+func (enumerator EntPhySensorTableDeviceEnumerator) DeviceEnumerator(
+	data map[string]interface{}) (devices []*config.DeviceProto, err error) {
+
+	// Pull out the table and SNMP DeviceConfig.
+	table := enumerator.Table
+
+	if len(table.Rows) == 0 {
+		log.WithFields(log.Fields{
+			"table": table.Name,
+			"oid":   table.WalkOid,
+		}).Warn("[snmp] table has no rows, will not create any devices for it")
+		return
+	}
+
+	snmpDeviceConfigMap, err := table.SnmpServerBase.DeviceConfig.ToMap()
+	if err != nil {
+		return nil, err
+	}
+
+	// One prototype per device type, in the order they are first found.
+	protos := map[string]*config.DeviceProto{}
+
+	for i := 0; i < len(table.Rows); i++ {
+		row := &table.Rows[i]
+
+		sensorType, _ := row.Column("entPhySensorType")
+		sensorTypeInt, _ := sensorType.(int)
+		deviceType, ok := sensorDeviceTypes[sensorTypeInt]
+		if !ok {
+			log.WithFields(log.Fields{
+				"table": table.Name,
+				"index": row.IndexOid,
+				"type":  sensorType,
+			}).Debug("[snmp] no device type for entity sensor, skipping")
+			continue
+		}
+		if value, _ := row.Column("entPhySensorValue"); value == nil {
+			continue
+		}
+
+		// deviceData gets shimmed into the DeviceConfig for each synse device.
+		// The value is in column 4 and the oper status in column 5.
+		deviceData := map[string]interface{}{
+			"base_oid":     row.BaseOid,
+			"table_name":   table.Name,
+			"row":          fmt.Sprintf("%d", i),
+			"column":       "4",
+			"oid":          fmt.Sprintf(row.BaseOid, 4), // base_oid and integer column.
+			"validity_oid": fmt.Sprintf(row.BaseOid, 5),
+			"validity_ok":  "1",
+		}
+		for value, name := range sensorOperStatus {
+			deviceData[fmt.Sprintf("validity%d", value)] = name
+		}
+		if deviceType == "status" {
+			deviceData["enumeration"] = "true"
+			for value, name := range truthValue {
+				deviceData[fmt.Sprintf("enumeration%d", value)] = name
+			}
+		} else {
+			scale, _ := row.Column("entPhySensorScale")
+			scaleInt, ok := scale.(int)
+			if !ok {
+				scaleInt = unitsScale
+			}
+			precision, _ := row.Column("entPhySensorPrecision")
+			precisionInt, _ := precision.(int)
+			if multiplier := sensorMultiplier(scaleInt, precisionInt); multiplier != 1 {
+				deviceData["multiplier"] = multiplier
+			}
+		}
+		deviceData, err = core.MergeMapStringInterface(snmpDeviceConfigMap, deviceData)
+		if err != nil {
+			return nil, err
+		}
+
+		proto, ok := protos[deviceType]
+		if !ok {
+			proto = &config.DeviceProto{
+				Type:      deviceType,
+				Instances: []*config.DeviceInstance{},
+				Tags:      snmpDeviceConfigMap["deviceTags"].([]string),
+			}
+			protos[deviceType] = proto
+			devices = append(devices, proto)
+		}
+		name, context := sensorContext(row, sensorTypeNames[sensorTypeInt])
+		proto.Instances = append(proto.Instances, &config.DeviceInstance{
+			Info:    name,
+			Context: context,
+			Data:    deviceData,
+		})
+	}
+	return devices, nil
+}
+
+// sensorContext gets the name of a sensor for device info, and the device
+// context: the index, the sensor type and units, and the name and description
+// of the physical entity. The name is entPhysicalName, or entPhysicalDescr
+// without one.
+func sensorContext(row *core.SnmpRow, sensorType string) (name string, context map[string]string) {
+	context = map[string]string{
+		"index": row.IndexString(),
+	}
+	for _, value := range row.Index {
+		context[value.Name] = value.String()
+	}
+	if sensorType != "" {
+		context["entPhySensorType"] = sensorType
+	}
+	units, _ := row.Column("entPhySensorUnitsDisplay")
+	if s := displayString(units); s != "" {
+		context["entPhySensorUnitsDisplay"] = s
+	}
+
+	if entity := row.Joined("ENTITY-MIB-entPhysicalTable"); entity != nil {
+		descr, _ := entity.Column("entPhysicalDescr")
+		if s := displayString(descr); s != "" {
+			context["entPhysicalDescr"] = s
+			name = s
+		}
+		entityName, _ := entity.Column("entPhysicalName")
+		if s := displayString(entityName); s != "" {
+			context["entPhysicalName"] = s
+			name = s
+		}
+	}
+	if name == "" {
+		name = fmt.Sprintf("entPhySensorValue%v", row.IndexOid)
+	}
+	return name, context
+}
+
+// displayString gets a SnmpAdminString column as a string.
+func displayString(data interface{}) string {
+	switch value := data.(type) {
+	case string:
+		return strings.TrimSpace(value)
+	case []byte:
+		return strings.TrimSpace(string(value))
+	}
+	return ""
+}
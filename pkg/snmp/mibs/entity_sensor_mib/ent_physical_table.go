@@ -0,0 +1,73 @@
+package entitysensormib
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// entPhysicalIndex is the INDEX clause of entPhysicalTable, and of
+// entPhySensorTable, which shares it.
+var entPhysicalIndex = []core.IndexComponent{{Name: "entPhysicalIndex", Type: core.IndexInteger}}
+
+// EntPhysicalTable represents SNMP OID .1.3.6.1.2.1.47.1.1.1, the physical
+// entities of ENTITY-MIB. It has no devices of its own. It is joined to the
+// entPhySensorTable for the names of the sensors.
+type EntPhysicalTable struct {
+	*core.SnmpTable // base class
+}
+
+// NewEntPhysicalTable constructs the EntPhysicalTable.
+func NewEntPhysicalTable(snmpServerBase *core.SnmpServerBase) (table *EntPhysicalTable, err error) {
+	var tableName = "ENTITY-MIB-entPhysicalTable"
+	var walkOid = ".1.3.6.1.2.1.47.1.1.1"
+
+	log.WithFields(log.Fields{
+		"name": tableName,
+		"oid":  walkOid,
+	}).Debug("[snmp] creating new table")
+
+	// Initialize the base.
+	snmpTable, err := core.NewSnmpTable(
+		tableName,
+		walkOid,
+		[]string{ // Column Names
+			"entPhysicalIndex",       // MIB says not accessible.
+			"entPhysicalDescr",       // Description of the entity.
+			"entPhysicalVendorType",  // Vendor OID of the entity type.
+			"entPhysicalContainedIn", // entPhysicalIndex of the container, or 0.
+			"entPhysicalClass",       // PhysicalClass, e.g. sensor(8).
+			"entPhysicalParentRelPos",
+			"entPhysicalName", // Name of the entity, e.g. the label on the hardware.
+			"entPhysicalHardwareRev",
+			"entPhysicalFirmwareRev",
+			"entPhysicalSoftwareRev",
+			"entPhysicalSerialNum",
+			"entPhysicalMfgName",
+			"entPhysicalModelName",
+			"entPhysicalAlias", // Administratively assigned alias.
+			"entPhysicalAssetID",
+			"entPhysicalIsFRU",
+			"entPhysicalMfgDate",
+			"entPhysicalUris",
+		},
+		snmpServerBase, // snmpServer
+		"1",            // rowBase
+		"",             // indexColumn
+		"2",            // readableColumn
+		false,          // flattened table
+	)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error": err,
+			"table": tableName,
+		}).Error("[snmp] failed to create table")
+		return nil, err
+	}
+
+	if err = snmpTable.SetIndex(entPhysicalIndex); err != nil {
+		return nil, err
+	}
+
+	table = &EntPhysicalTable{SnmpTable: snmpTable}
+	return table, nil
+}
@@ -0,0 +1,99 @@
+package entitysensormib
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// MibName is the name ENTITY-SENSOR-MIB is registered with. See
+// core.RegisterMib.
+const MibName = "ENTITY-SENSOR-MIB"
+
+// entityMibName is the module of entPhysicalTable, which is walked along
+// with the sensors for their names.
+const entityMibName = "ENTITY-MIB"
+
+func init() {
+	err := core.RegisterMib(MibName, func(server *core.SnmpServerBase) (core.Mib, error) {
+		entitySensorMib, err := NewEntitySensorMib(server)
+		if err != nil {
+			return nil, err
+		}
+		return entitySensorMib, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	err = core.RegisterMibDetection(MibName, core.MibDetection{
+		SysORIDs:  []string{".1.3.6.1.2.1.99"},
+		ProbeOids: []string{".1.3.6.1.2.1.99.1.1.1.1"},
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// EntitySensorMib is the class for the sensors of ENTITY-SENSOR-MIB, rfc 3433,
+// with the physical entities of ENTITY-MIB, rfc 6933, they belong to.
+type EntitySensorMib struct {
+	*core.SnmpMib // base class
+
+	// Tables defined in this MIB
+	EntPhySensorTable *EntPhySensorTable
+	// Tables defined in ENTITY-MIB
+	EntPhysicalTable *EntPhysicalTable
+}
+
+// NewEntitySensorMib constructs the EntitySensorMib.
+func NewEntitySensorMib(server *core.SnmpServerBase) (entitySensorMib *EntitySensorMib, err error) {
+	log.Debugf("[snmp] initializing EntitySensorMib")
+
+	// Arg checks.
+	if server == nil {
+		return nil, fmt.Errorf("unable to create new EntitySensorMib: server is nil")
+	}
+
+	// Initialize Tables.
+	entPhysicalTable, err := NewEntPhysicalTable(server)
+	if err != nil {
+		return nil, err
+	}
+	entPhySensorTable, err := NewEntPhySensorTable(server)
+	if err != nil {
+		return nil, err
+	}
+	// Each sensor is a physical entity.
+	err = entPhySensorTable.Join(entPhysicalTable.Name, entPhysicalTable.SnmpTable, "entPhysicalIndex")
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize the base class.
+	snmpMib, err := core.NewSnmpMib(MibName, []*core.SnmpTable{
+		entPhySensorTable.SnmpTable,
+		entPhysicalTable.SnmpTable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	snmpMib.RegisterNames(MibName, entPhySensorTable.SnmpTable)
+	// The entPhysicalTable columns are named in their own module.
+	snmpMib.RegisterNames(entityMibName, entPhysicalTable.SnmpTable)
+
+	entitySensorMib = &EntitySensorMib{
+		SnmpMib:           snmpMib,
+		EntPhySensorTable: entPhySensorTable,
+		EntPhysicalTable:  entPhysicalTable,
+	}
+
+	// Update mib pointer for each table.
+	for _, table := range entitySensorMib.Tables {
+		table.Mib = entitySensorMib
+	}
+
+	log.Debugf("Initialized EntitySensorMib")
+	return entitySensorMib, nil
+}
@@ -0,0 +1,100 @@
+package entitysensormib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core/coretest"
+)
+
+// TestEntitySensorMib tests the sensor devices of a recorded walk with a
+// sensor of each type.
+func TestEntitySensorMib(t *testing.T) {
+	entitySensorMib, err := NewEntitySensorMib(coretest.NewReplayServerBase(t, "testdata/entity_sensor.snmpwalk"))
+	assert.NoError(t, err)
+	assert.Equal(t, MibName, entitySensorMib.Name)
+	assert.Len(t, entitySensorMib.EntPhysicalTable.Rows, 8)
+	assert.Len(t, entitySensorMib.EntPhySensorTable.Rows, 7)
+
+	devices, err := entitySensorMib.EnumerateDevices(map[string]interface{}{})
+	assert.NoError(t, err)
+	var types []string
+	for _, proto := range devices {
+		types = append(types, proto.Type)
+		assert.Len(t, proto.Instances, 1, proto.Type)
+	}
+	// The dBm sensor has no device type.
+	assert.Equal(t, []string{"temperature", "humidity", "voltage", "rpm", "current", "status"}, types)
+
+	temperature := coretest.FindInstance(devices, "temperature", "Inlet Temperature")
+	assert.NotNil(t, temperature)
+	assert.Equal(t, ".1.3.6.1.2.1.99.1.1.1.4.10", temperature.Data["oid"])
+	assert.Equal(t, float32(0.1), temperature.Data["multiplier"])
+	assert.Equal(t, ".1.3.6.1.2.1.99.1.1.1.5.10", temperature.Data["validity_oid"])
+	assert.Equal(t, "1", temperature.Data["validity_ok"])
+	assert.Equal(t, "nonoperational", temperature.Data["validity3"])
+	assert.Equal(t, map[string]string{
+		"index":                    "10",
+		"entPhysicalIndex":         "10",
+		"entPhySensorType":         "celsius",
+		"entPhySensorUnitsDisplay": "degrees C",
+		"entPhysicalDescr":         "Inlet Temperature Sensor",
+		"entPhysicalName":          "Inlet Temperature",
+	}, temperature.Context)
+
+	// Units need no multiplier.
+	voltage := coretest.FindInstance(devices, "voltage", "Input Voltage")
+	assert.NotNil(t, voltage)
+	assert.NotContains(t, voltage.Data, "multiplier")
+	assert.Equal(t, "voltsAC", voltage.Context["entPhySensorType"])
+
+	// Milliamperes.
+	current := coretest.FindInstance(devices, "current", "Output Current")
+	assert.NotNil(t, current)
+	assert.Equal(t, float32(0.001), current.Data["multiplier"])
+
+	// Truth values are enumerated.
+	door := coretest.FindInstance(devices, "status", "Door")
+	assert.NotNil(t, door)
+	assert.Equal(t, "true", door.Data["enumeration"])
+	assert.Equal(t, "false", door.Data["enumeration2"])
+
+	assert.NotNil(t, coretest.FindInstance(devices, "rpm", "Fan 1"))
+	assert.NotNil(t, coretest.FindInstance(devices, "humidity", "Inlet Humidity"))
+
+	// The entPhysicalTable columns are named in ENTITY-MIB.
+	name, ok := core.OidNames.Name(".1.3.6.1.2.1.47.1.1.1.1.7.10")
+	assert.True(t, ok)
+	assert.Equal(t, "ENTITY-MIB::entPhysicalName.10", name)
+}
+
+// TestEntitySensorMibWithoutSensors tests an agent with physical entities but
+// no sensors.
+func TestEntitySensorMibWithoutSensors(t *testing.T) {
+	entitySensorMib, err := NewEntitySensorMib(
+		coretest.NewReplayServerBase(t, "../../../../emulator/ups/pxgms_ups/data/public.snmpwalk"))
+	assert.NoError(t, err)
+	assert.Len(t, entitySensorMib.EntPhysicalTable.Rows, 6)
+	assert.Empty(t, entitySensorMib.EntPhySensorTable.Rows)
+
+	devices, err := entitySensorMib.EnumerateDevices(map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Empty(t, devices)
+}
+
+// TestSensorMultiplier tests multipliers from sensor scale and precision.
+func TestSensorMultiplier(t *testing.T) {
+	assert.Equal(t, float32(1), sensorMultiplier(9, 0))
+	assert.Equal(t, float32(0.1), sensorMultiplier(9, 1))
+	assert.Equal(t, float32(0.001), sensorMultiplier(8, 0))
+	assert.Equal(t, float32(1000), sensorMultiplier(10, 0))
+	assert.Equal(t, float32(100), sensorMultiplier(10, 1))
+	assert.Equal(t, float32(10), sensorMultiplier(9, -1))
+}
+
+// TestNewEntitySensorMibNilServer tests that the server is required.
+func TestNewEntitySensorMibNilServer(t *testing.T) {
+	_, err := NewEntitySensorMib(nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,90 @@
+package ifmib
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// MibName is the name IF-MIB is registered with. See core.RegisterMib.
+const MibName = "IF-MIB"
+
+func init() {
+	err := core.RegisterMib(MibName, func(server *core.SnmpServerBase) (core.Mib, error) {
+		ifMib, err := NewIfMib(server)
+		if err != nil {
+			return nil, err
+		}
+		return ifMib, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	// Agents list IF-MIB in the sysORTable inconsistently, so probe the
+	// interfaces group too.
+	err = core.RegisterMibDetection(MibName, core.MibDetection{
+		SysORIDs:  []string{".1.3.6.1.2.1.31"},
+		ProbeOids: []string{".1.3.6.1.2.1.2.2.1.1"},
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// IfMib is the class for the interface tables of IF-MIB, rfc 2863.
+type IfMib struct {
+	*core.SnmpMib // base class
+
+	// Tables defined in this MIB
+	IfTable  *IfTable
+	IfXTable *IfXTable // Has no rows when the agent does not serve it.
+}
+
+// NewIfMib constructs the IfMib. The ifXTable is optional. Without rows in
+// it, devices come from the ifTable alone, with 32-bit counters.
+func NewIfMib(server *core.SnmpServerBase) (ifMib *IfMib, err error) {
+	log.Debugf("[snmp] initializing IfMib")
+
+	// Arg checks.
+	if server == nil {
+		return nil, fmt.Errorf("unable to create new IfMib: server is nil")
+	}
+
+	// Initialize Tables.
+	ifTable, err := NewIfTable(server)
+	if err != nil {
+		return nil, err
+	}
+	ifXTable, err := NewIfXTable(server)
+	if err != nil {
+		return nil, err
+	}
+	// ifXTable AUGMENTS ifTable.
+	if err = ifXTable.Augment(ifTable.SnmpTable); err != nil {
+		return nil, err
+	}
+	snmpTables := []*core.SnmpTable{ifTable.SnmpTable, ifXTable.SnmpTable}
+
+	// Initialize the base class.
+	snmpMib, err := core.NewSnmpMib(MibName, snmpTables)
+	if err != nil {
+		return nil, err
+	}
+	snmpMib.RegisterNames(MibName)
+
+	ifMib = &IfMib{
+		SnmpMib:  snmpMib,
+		IfTable:  ifTable,
+		IfXTable: ifXTable,
+	}
+
+	// Update mib pointer for each table.
+	for _, table := range ifMib.Tables {
+		table.Mib = ifMib
+	}
+
+	log.Debugf("Initialized IfMib")
+	return ifMib, nil
+}
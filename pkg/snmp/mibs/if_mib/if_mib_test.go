@@ -0,0 +1,127 @@
+package ifmib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core/coretest"
+)
+
+// TestIfMib tests the interface devices of the PXGMS UPS walk, which has
+// both ifTable and ifXTable.
+func TestIfMib(t *testing.T) {
+	ifMib, err := NewIfMib(coretest.NewReplayServerBase(t, "../../../../emulator/ups/pxgms_ups/data/public.snmpwalk"))
+	assert.NoError(t, err)
+	assert.Equal(t, MibName, ifMib.Name)
+	assert.Len(t, ifMib.IfTable.Rows, 4)
+	assert.NotNil(t, ifMib.IfXTable)
+	assert.Len(t, ifMib.IfXTable.Rows, 4)
+
+	devices, err := ifMib.EnumerateDevices(map[string]interface{}{})
+	assert.NoError(t, err)
+	counts := map[string]int{}
+	for _, proto := range devices {
+		counts[proto.Type] = len(proto.Instances)
+	}
+	assert.Equal(t, map[string]int{
+		"status":     8,
+		"speed":      4,
+		"throughput": 8,
+		"error-rate": 8,
+	}, counts)
+
+	// Status enumerations.
+	operStatus := coretest.FindInstance(devices, "status", "ifOperStatus eth0")
+	assert.NotNil(t, operStatus)
+	assert.Equal(t, ".1.3.6.1.2.1.2.2.1.8.2", operStatus.Data["oid"])
+	assert.Equal(t, "true", operStatus.Data["enumeration"])
+	assert.Equal(t, "up", operStatus.Data["enumeration1"])
+	assert.Equal(t, "lowerLayerDown", operStatus.Data["enumeration7"])
+	assert.Equal(t, map[string]string{
+		"index":   "2",
+		"ifIndex": "2",
+		"ifDescr": "eth0",
+		"ifName":  "eth0",
+	}, operStatus.Context)
+	adminStatus := coretest.FindInstance(devices, "status", "ifAdminStatus sit0")
+	assert.NotNil(t, adminStatus)
+	assert.Equal(t, "down", adminStatus.Data["enumeration2"])
+
+	// Speed and octets come from ifXTable.
+	speed := coretest.FindInstance(devices, "speed", "ifHighSpeed eth0")
+	assert.NotNil(t, speed)
+	assert.Equal(t, ".1.3.6.1.2.1.31.1.1.1.15.2", speed.Data["oid"])
+	assert.Equal(t, "IF-MIB-ifXTable", speed.Data["table_name"])
+	assert.Equal(t, float32(1000000), speed.Data["multiplier"])
+	inOctets := coretest.FindInstance(devices, "throughput", "ifHCInOctets eth0")
+	assert.NotNil(t, inOctets)
+	assert.Equal(t, ".1.3.6.1.2.1.31.1.1.1.6.2", inOctets.Data["oid"])
+	assert.NotNil(t, coretest.FindInstance(devices, "throughput", "ifHCOutOctets eth0"))
+	result, err := ifMib.IfTable.SnmpServerBase.SnmpClient.Get(inOctets.Data["oid"].(string))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5353869), result.Data)
+	assert.Equal(t, ".1.3.6.1.2.1.31.1.1.1.19.2", inOctets.Data[core.DiscontinuityOidKey])
+
+	// Errors only come from ifTable.
+	inErrors := coretest.FindInstance(devices, "error-rate", "ifInErrors eth0")
+	assert.NotNil(t, inErrors)
+	assert.Equal(t, ".1.3.6.1.2.1.2.2.1.14.2", inErrors.Data["oid"])
+	assert.Equal(t, "IF-MIB-ifTable", inErrors.Data["table_name"])
+	assert.Equal(t, ".1.3.6.1.2.1.31.1.1.1.19.2", inErrors.Data[core.DiscontinuityOidKey])
+	assert.NotContains(t, speed.Data, core.DiscontinuityOidKey)
+}
+
+// TestIfMibWithoutIfXTable tests the interface devices of the Tripp Lite UPS
+// walk, which has no ifXTable, so octets come from 32-bit counters.
+func TestIfMibWithoutIfXTable(t *testing.T) {
+	ifMib, err := NewIfMib(coretest.NewReplayServerBase(t, "../../../../emulator/ups/tripplite_ups/data/public.snmpwalk"))
+	assert.NoError(t, err)
+	assert.Len(t, ifMib.IfTable.Rows, 5)
+	assert.Empty(t, ifMib.IfXTable.Rows)
+
+	devices, err := ifMib.EnumerateDevices(map[string]interface{}{})
+	assert.NoError(t, err)
+
+	// The walk shows the NUL padding of ifDescr as dots.
+	inOctets := coretest.FindInstance(devices, "throughput", "ifInOctets eth0............")
+	assert.NotNil(t, inOctets)
+	assert.Equal(t, ".1.3.6.1.2.1.2.2.1.10.2", inOctets.Data["oid"])
+	assert.NotContains(t, inOctets.Data, core.DiscontinuityOidKey)
+	speed := coretest.FindInstance(devices, "speed", "ifSpeed eth0............")
+	assert.NotNil(t, speed)
+	assert.Nil(t, speed.Data["multiplier"])
+	operStatus := coretest.FindInstance(devices, "status", "ifOperStatus tunl0...........")
+	assert.NotNil(t, operStatus)
+	assert.Equal(t, "3", operStatus.Context["ifIndex"])
+	assert.NotContains(t, operStatus.Context, "ifName")
+}
+
+// TestInterfaceContext tests the interface name and context with an alias.
+func TestInterfaceContext(t *testing.T) {
+	ifTable := &core.SnmpTable{Name: "IF-MIB-ifTable", ColumnList: []string{"ifIndex", "ifDescr"}}
+	ifXTable := &core.SnmpTable{Name: "IF-MIB-ifXTable", ColumnList: []string{"ifName", "ifAlias"}}
+	row := &core.SnmpRow{Table: ifTable, IndexOid: "7", RowData: []*core.ReadResult{
+		{Data: 7}, {Data: []byte("ge-0/0/1\x00\x00")},
+	}}
+	xRow := &core.SnmpRow{Table: ifXTable, IndexOid: "7", RowData: []*core.ReadResult{
+		{Data: ""}, {Data: "uplink"},
+	}}
+
+	name, context := interfaceContext(row, xRow)
+	assert.Equal(t, "ge-0/0/1 (uplink)", name)
+	assert.Equal(t, "ge-0/0/1", context["ifDescr"])
+	assert.Equal(t, "uplink", context["ifAlias"])
+	assert.NotContains(t, context, "ifName")
+
+	// Nothing to name the interface by.
+	row.RowData[1].Data = nil
+	name, _ = interfaceContext(row, nil)
+	assert.Equal(t, "7", name)
+}
+
+// TestNewIfMibNilServer tests that the server is required.
+func TestNewIfMibNilServer(t *testing.T) {
+	_, err := NewIfMib(nil)
+	assert.Error(t, err)
+}
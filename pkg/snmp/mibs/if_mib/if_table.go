@@ -0,0 +1,297 @@
+package ifmib
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vapor-ware/synse-sdk/sdk/config"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// ifIndex is the INDEX clause of ifTable, and of ifXTable which AUGMENTS it.
+var ifIndex = []core.IndexComponent{{Name: "ifIndex", Type: core.IndexInteger}}
+
+// IfTable represents SNMP OID .1.3.6.1.2.1.2.2, the interfaces table.
+type IfTable struct {
+	*core.SnmpTable // base class
+}
+
+// NewIfTable constructs the IfTable.
+func NewIfTable(snmpServerBase *core.SnmpServerBase) (table *IfTable, err error) {
+	var tableName = "IF-MIB-ifTable"
+	var walkOid = ".1.3.6.1.2.1.2.2"
+
+	log.WithFields(log.Fields{
+		"name": tableName,
+		"oid":  walkOid,
+	}).Debug("[snmp] creating new table")
+
+	// Initialize the base.
+	snmpTable, err := core.NewSnmpTable(
+		tableName,
+		walkOid,
+		[]string{ // Column Names
+			"ifIndex",           // Index of the interface.
+			"ifDescr",           // Description of the interface, e.g. eth0.
+			"ifType",            // IANAifType, e.g. ethernetCsmacd(6).
+			"ifMtu",             // Octets
+			"ifSpeed",           // Bits per second. Saturates at 4,294,967,295.
+			"ifPhysAddress",     // MAC address.
+			"ifAdminStatus",     // Desired state.
+			"ifOperStatus",      // Current state.
+			"ifLastChange",      // TimeStamp
+			"ifInOctets",        // Counter32
+			"ifInUcastPkts",     // Counter32
+			"ifInNUcastPkts",    // Counter32, deprecated.
+			"ifInDiscards",      // Counter32
+			"ifInErrors",        // Counter32
+			"ifInUnknownProtos", // Counter32
+			"ifOutOctets",       // Counter32
+			"ifOutUcastPkts",    // Counter32
+			"ifOutNUcastPkts",   // Counter32, deprecated.
+			"ifOutDiscards",     // Counter32
+			"ifOutErrors",       // Counter32
+			"ifOutQLen",         // Gauge32, deprecated.
+			"ifSpecific",        // OID, deprecated.
+		},
+		snmpServerBase, // snmpServer
+		"1",            // rowBase
+		"1",            // indexColumn
+		"",             // readableColumn
+		false,          // flattened table
+	)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error": err,
+			"table": tableName,
+		}).Error("[snmp] failed to create table")
+		return nil, err
+	}
+
+	if err = snmpTable.SetIndex(ifIndex); err != nil {
+		return nil, err
+	}
+
+	table = &IfTable{SnmpTable: snmpTable}
+	table.DevEnumerator = IfTableDeviceEnumerator{table}
+	return table, nil
+}
+
+// ifAdminStatus enumerates ifAdminStatus.
+var ifAdminStatus = map[int]string{
+	1: "up",
+	2: "down",
+	3: "testing",
+}
+
+// ifOperStatus enumerates ifOperStatus.
+var ifOperStatus = map[int]string{
+	1: "up",
+	2: "down",
+	3: "testing",
+	4: "unknown",
+	5: "dormant",
+	6: "notPresent",
+	7: "lowerLayerDown",
+}
+
+// ifDevice is a synse device for each interface.
+type ifDevice struct {
+	deviceType string
+	// The ifTable column.
+	column string
+	// The ifXTable column which replaces the ifTable column when the agent
+	// serves it, e.g. the 64-bit ifHCInOctets for ifInOctets. Optional.
+	xColumn string
+	// Multiplier for the ifXTable column, if any.
+	xMultiplier float32
+	// Enumeration of the raw reading, if any.
+	enumeration map[int]string
+	// Whether the column is a counter, which is reset at the
+	// ifCounterDiscontinuityTime of the interface.
+	counter bool
+}
+
+// ifDevices are the synse devices for each interface, in order.
+var ifDevices = []ifDevice{
+	{deviceType: "status", column: "ifOperStatus", enumeration: ifOperStatus},
+	{deviceType: "status", column: "ifAdminStatus", enumeration: ifAdminStatus},
+	{deviceType: "speed", column: "ifSpeed", xColumn: "ifHighSpeed", xMultiplier: 1000000},
+	{deviceType: "throughput", column: "ifInOctets", xColumn: "ifHCInOctets", counter: true},
+	{deviceType: "throughput", column: "ifOutOctets", xColumn: "ifHCOutOctets", counter: true},
+	{deviceType: "error-rate", column: "ifInErrors", counter: true},
+	{deviceType: "error-rate", column: "ifOutErrors", counter: true},
+}
+
+// IfTableDeviceEnumerator overrides the default SnmpTable device enumerator
+// for the interfaces table. Devices are enumerated from each ifTable row
+// joined to its ifXTable row, if any.
+type IfTableDeviceEnumerator struct {
+	Table *IfTable // Pointer back to the table.
+}
+
+// DeviceEnumerator overrides the default SnmpTable device enumerator.
+func (enumerator IfTableDeviceEnumerator) DeviceEnumerator(
+	data map[string]interface{}) (devices []*config.DeviceProto, err error) {
+
+	// Pull out the table and SNMP DeviceConfig.
+	table := enumerator.Table
+
+	if len(table.Rows) == 0 {
+		log.WithFields(log.Fields{
+			"table": table.Name,
+			"oid":   table.WalkOid,
+		}).Warn("[snmp] table has no rows, will not create any devices for it")
+		return
+	}
+
+	snmpDeviceConfigMap, err := table.SnmpServerBase.DeviceConfig.ToMap()
+	if err != nil {
+		return nil, err
+	}
+
+	// One prototype per device type, in the order of ifDevices.
+	protos := map[string]*config.DeviceProto{}
+	for _, device := range ifDevices {
+		if _, ok := protos[device.deviceType]; ok {
+			continue
+		}
+		proto := &config.DeviceProto{
+			Type:      device.deviceType,
+			Instances: []*config.DeviceInstance{},
+			Tags:      snmpDeviceConfigMap["deviceTags"].([]string),
+		}
+		protos[device.deviceType] = proto
+		devices = append(devices, proto)
+	}
+
+	for i := 0; i < len(table.Rows); i++ {
+		row := &table.Rows[i]
+		xRow := row.Joined("IF-MIB-ifXTable")
+		name, context := interfaceContext(row, xRow)
+		discontinuityOid := discontinuityTimeOid(xRow)
+
+		for _, device := range ifDevices {
+			// Use the ifXTable column when the agent serves it.
+			source, column := row, device.column
+			var multiplier float32
+			if device.xColumn != "" && xRow != nil {
+				if value, _ := xRow.Column(device.xColumn); value != nil {
+					source, column, multiplier = xRow, device.xColumn, device.xMultiplier
+				}
+			}
+			columnNumber := columnNumber(source.Table, column)
+			if columnNumber == 0 || source.RowData[columnNumber-1].Data == nil {
+				continue
+			}
+
+			// deviceData gets shimmed into the DeviceConfig for each synse device.
+			deviceData := map[string]interface{}{
+				"base_oid":   source.BaseOid,
+				"table_name": source.Table.Name,
+				"row":        fmt.Sprintf("%d", i),
+				"column":     fmt.Sprintf("%d", columnNumber),
+				"oid":        fmt.Sprintf(source.BaseOid, columnNumber), // base_oid and integer column.
+			}
+			if multiplier != 0 {
+				deviceData["multiplier"] = multiplier
+			}
+			if device.counter && discontinuityOid != "" {
+				deviceData[core.DiscontinuityOidKey] = discontinuityOid
+			}
+			if len(device.enumeration) > 0 {
+				deviceData["enumeration"] = "true"
+				for value, enumeration := range device.enumeration {
+					deviceData[fmt.Sprintf("enumeration%d", value)] = enumeration
+				}
+			}
+			deviceData, err = core.MergeMapStringInterface(snmpDeviceConfigMap, deviceData)
+			if err != nil {
+				return nil, err
+			}
+
+			proto := protos[device.deviceType]
+			proto.Instances = append(proto.Instances, &config.DeviceInstance{
+				Info:    fmt.Sprintf("%v %v", column, name),
+				Context: context,
+				Data:    deviceData,
+			})
+		}
+	}
+	return devices, nil
+}
+
+// discontinuityTimeOid gets the OID of the ifCounterDiscontinuityTime of the
+// ifXTable row, or "" when the agent does not serve it.
+func discontinuityTimeOid(xRow *core.SnmpRow) string {
+	if xRow == nil {
+		return ""
+	}
+	column := columnNumber(xRow.Table, "ifCounterDiscontinuityTime")
+	if column == 0 || xRow.RowData[column-1].Data == nil {
+		return ""
+	}
+	return fmt.Sprintf(xRow.BaseOid, column)
+}
+
+// interfaceContext gets the name of an interface for device info, and the
+// device context: the index, ifDescr, and ifName and ifAlias from the
+// ifXTable row, if any. The name is ifName, or ifDescr without one, followed
+// by the alias, e.g. eth0 (uplink).
+func interfaceContext(row *core.SnmpRow, xRow *core.SnmpRow) (name string, context map[string]string) {
+	context = map[string]string{
+		"index": row.IndexString(),
+	}
+	for _, value := range row.Index {
+		context[value.Name] = value.String()
+	}
+
+	descr, _ := row.Column("ifDescr")
+	if s := displayString(descr); s != "" {
+		context["ifDescr"] = s
+		name = s
+	}
+	if xRow != nil {
+		ifName, _ := xRow.Column("ifName")
+		if s := displayString(ifName); s != "" {
+			context["ifName"] = s
+			name = s
+		}
+		ifAlias, _ := xRow.Column("ifAlias")
+		if s := displayString(ifAlias); s != "" {
+			context["ifAlias"] = s
+			name = fmt.Sprintf("%v (%v)", name, s)
+		}
+	}
+	if name == "" {
+		name = row.IndexOid
+	}
+	return name, context
+}
+
+// displayString gets a DisplayString column as a string. Some agents pad the
+// string with NUL characters.
+func displayString(data interface{}) string {
+	var s string
+	switch value := data.(type) {
+	case string:
+		s = value
+	case []byte:
+		s = string(value)
+	default:
+		return ""
+	}
+	return strings.TrimSpace(strings.Trim(s, "\x00"))
+}
+
+// columnNumber gets the one based number of a column of a table by name, or
+// zero if the table has no such column.
+func columnNumber(table *core.SnmpTable, name string) int {
+	for i, column := range table.ColumnList {
+		if column == name {
+			return i + 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,70 @@
+package ifmib
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// IfXTable represents SNMP OID .1.3.6.1.2.1.31.1.1, the extension to the
+// ifTable with names, aliases and 64-bit counters. It has no devices of its
+// own. It is joined to the ifTable, which enumerates the devices for both.
+type IfXTable struct {
+	*core.SnmpTable // base class
+}
+
+// NewIfXTable constructs the IfXTable.
+func NewIfXTable(snmpServerBase *core.SnmpServerBase) (table *IfXTable, err error) {
+	var tableName = "IF-MIB-ifXTable"
+	var walkOid = ".1.3.6.1.2.1.31.1.1"
+
+	log.WithFields(log.Fields{
+		"name": tableName,
+		"oid":  walkOid,
+	}).Debug("[snmp] creating new table")
+
+	// Initialize the base.
+	snmpTable, err := core.NewSnmpTable(
+		tableName,
+		walkOid,
+		[]string{ // Column Names
+			"ifName",                     // Name of the interface, e.g. eth0.
+			"ifInMulticastPkts",          // Counter32
+			"ifInBroadcastPkts",          // Counter32
+			"ifOutMulticastPkts",         // Counter32
+			"ifOutBroadcastPkts",         // Counter32
+			"ifHCInOctets",               // Counter64
+			"ifHCInUcastPkts",            // Counter64
+			"ifHCInMulticastPkts",        // Counter64
+			"ifHCInBroadcastPkts",        // Counter64
+			"ifHCOutOctets",              // Counter64
+			"ifHCOutUcastPkts",           // Counter64
+			"ifHCOutMulticastPkts",       // Counter64
+			"ifHCOutBroadcastPkts",       // Counter64
+			"ifLinkUpDownTrapEnable",     // enabled(1), disabled(2)
+			"ifHighSpeed",                // Units of 1,000,000 bits per second.
+			"ifPromiscuousMode",          // TruthValue
+			"ifConnectorPresent",         // TruthValue
+			"ifAlias",                    // Administratively assigned alias.
+			"ifCounterDiscontinuityTime", // TimeStamp
+		},
+		snmpServerBase, // snmpServer
+		"1",            // rowBase
+		"",             // indexColumn
+		"1",            // readableColumn
+		false,          // flattened table
+	)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error": err,
+			"table": tableName,
+		}).Error("[snmp] failed to create table")
+		return nil, err
+	}
+
+	if err = snmpTable.SetIndex(ifIndex); err != nil {
+		return nil, err
+	}
+
+	table = &IfXTable{SnmpTable: snmpTable}
+	return table, nil
+}
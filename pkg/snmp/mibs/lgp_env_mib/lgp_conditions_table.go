@@ -0,0 +1,124 @@
+package lgpenvmib
+
+import (
+	"fmt"
+
+	"github.com/vapor-ware/synse-sdk/sdk/config"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// LgpConditionsTable represents SNMP OID .1.3.6.1.4.1.476.1.42.3.2.3, the
+// active conditions (alarms and warnings) of the unit from
+// LIEBERT-GP-CONDITIONS-MIB. There are no rows in this table when no
+// conditions are present.
+type LgpConditionsTable struct {
+	*core.SnmpTable // base class
+}
+
+// conditionsTable is the definition of the LgpConditionsTable.
+var conditionsTable = &core.TableDefinition{
+	Name:           "LIEBERT-GP-CONDITIONS-MIB-lgpConditionsTable",
+	WalkOid:        lgpConditionsOid + ".3",
+	RowBase:        "1",
+	ReadableColumn: "2",
+	Columns: []*core.ColumnDefinition{
+		{Name: "lgpConditionsIndex"}, // Not accessible.
+		{Name: "lgpConditionDescr"},  // OID of the well-known condition.
+		{Name: "lgpConditionTime"},   // sysUpTime when the condition was raised.
+	},
+}
+
+// NewLgpConditionsTable constructs the LgpConditionsTable.
+func NewLgpConditionsTable(snmpServerBase *core.SnmpServerBase) (table *LgpConditionsTable, err error) {
+	snmpTable, err := core.NewTable(conditionsTable, snmpServerBase)
+	if err != nil {
+		return nil, err
+	}
+
+	table = &LgpConditionsTable{SnmpTable: snmpTable}
+	table.DevEnumerator = LgpConditionsTableDeviceEnumerator{table}
+	return table, nil
+}
+
+// LgpConditionsTableDeviceEnumerator overrides the default SnmpTable device
+// enumerator for the conditions table.
+type LgpConditionsTableDeviceEnumerator struct {
+	Table *LgpConditionsTable // Pointer back to the table.
+}
+
+// DeviceEnumerator overrides the default SnmpTable device enumerator.
+// Conditions come and go, so as for the UPS-MIB alarms there is a single
+// active alarms device which walks the table on each read, and an alarm
+// history device for the lifecycle of each condition. The conditions are
+// named by their lgpConditionDescr OID, since the well-known conditions
+// differ between the cards.
+func (enumerator LgpConditionsTableDeviceEnumerator) DeviceEnumerator(
+	data map[string]interface{}) (devices []*config.DeviceProto, err error) {
+
+	table := enumerator.Table
+	model := table.Mib.(*LgpEnvMib).Model()
+
+	snmpDeviceConfigMap, err := table.SnmpServerBase.DeviceConfig.ToMap()
+	if err != nil {
+		return
+	}
+
+	alarmsProto := &config.DeviceProto{
+		Type: "alarms",
+		Context: map[string]string{
+			"model": model,
+		},
+		Instances: []*config.DeviceInstance{},
+		Tags:      snmpDeviceConfigMap["deviceTags"].([]string),
+	}
+
+	alarmHistoryProto := &config.DeviceProto{
+		Type: "alarm-history",
+		Context: map[string]string{
+			"model": model,
+		},
+		Instances: []*config.DeviceInstance{},
+		Tags:      snmpDeviceConfigMap["deviceTags"].([]string),
+	}
+
+	devices = []*config.DeviceProto{
+		alarmsProto,
+		alarmHistoryProto,
+	}
+
+	// Both devices poll the conditions table the same way.
+	alarmData := map[string]interface{}{
+		"table_name":  table.Name,
+		"walk_oid":    table.WalkOid,                                        // Walked on each read.
+		"present_oid": lgpConditionsOid + ".2.0",                            // lgpConditionsPresent
+		"descr_oid":   fmt.Sprintf("%s.%s.2", table.WalkOid, table.RowBase), // lgpConditionDescr
+		"time_oid":    fmt.Sprintf("%s.%s.3", table.WalkOid, table.RowBase), // lgpConditionTime
+	}
+	alarmData, err = core.MergeMapStringInterface(snmpDeviceConfigMap, alarmData)
+	if err != nil {
+		return nil, err
+	}
+
+	// lgpActiveConditions -----------------------------------------------------
+	deviceData := core.CopyMapStringInterface(alarmData)
+	deviceData["oid"] = table.WalkOid
+
+	device := &config.DeviceInstance{
+		Info: "lgpActiveConditions",
+		Data: deviceData,
+	}
+	alarmsProto.Instances = append(alarmsProto.Instances, device)
+
+	// lgpConditionHistory -----------------------------------------------------
+	// The history of the conditions tracked from polling.
+	deviceData = core.CopyMapStringInterface(alarmData)
+	deviceData["oid"] = fmt.Sprintf("%s.%s", table.WalkOid, table.RowBase) // lgpConditionsEntry
+
+	device = &config.DeviceInstance{
+		Info: "lgpConditionHistory",
+		Data: deviceData,
+	}
+	alarmHistoryProto.Instances = append(alarmHistoryProto.Instances, device)
+
+	return
+}
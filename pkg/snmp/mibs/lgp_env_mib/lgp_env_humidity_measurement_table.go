@@ -0,0 +1,34 @@
+package lgpenvmib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// humidityMeasurementTable is the definition of SNMP OID
+// .1.3.6.1.4.1.476.1.42.3.4.2.3.3, the relative humidity measured by the unit.
+var humidityMeasurementTable = &core.TableDefinition{
+	Name:        "LIEBERT-GP-ENVIRONMENTAL-MIB-lgpEnvHumidityMeasurementTableRel",
+	WalkOid:     lgpEnvironmentalOid + ".2.3.3",
+	RowBase:     "1",
+	IndexColumn: "1",
+	Index: []core.IndexComponent{
+		{Name: "lgpEnvHumidityMeasurementIndexRel", Type: core.IndexInteger},
+	},
+	Columns: []*core.ColumnDefinition{
+		{Name: "lgpEnvHumidityMeasurementIndexRel"}, // Index of the sensor.
+		{Name: "lgpEnvHumidityDescrRel"},            // Well-known sensor, e.g. lgpEnvReturnAirHumidity.
+		{Name: "lgpEnvHumidityMeasurementRel"},      // Percent relative humidity.
+		{Name: "lgpEnvHumidityHighThresholdRel"},    // Percent relative humidity.
+		{Name: "lgpEnvHumidityLowThresholdRel"},     // Percent relative humidity.
+	},
+}
+
+// humidityMeasurementDevices are the devices of each humidity sensor. The
+// high and low thresholds of the sensor are the thresholds of the humidity
+// device.
+var humidityMeasurementDevices = []core.ColumnDevice{
+	{
+		Column:     3, // lgpEnvHumidityMeasurementRel
+		DeviceType: "humidity",
+		LowLimit:   5, // lgpEnvHumidityLowThresholdRel
+		HighLimit:  4, // lgpEnvHumidityHighThresholdRel
+	},
+}
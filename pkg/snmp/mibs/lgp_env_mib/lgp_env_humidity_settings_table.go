@@ -0,0 +1,46 @@
+package lgpenvmib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// humiditySettingsTable is the definition of SNMP OID
+// .1.3.6.1.4.1.476.1.42.3.4.2.3.2, the relative humidity setpoints and
+// thresholds of the unit.
+var humiditySettingsTable = &core.TableDefinition{
+	Name:        "LIEBERT-GP-ENVIRONMENTAL-MIB-lgpEnvHumiditySettingsTableRel",
+	WalkOid:     lgpEnvironmentalOid + ".2.3.2",
+	RowBase:     "1",
+	IndexColumn: "1",
+	Index: []core.IndexComponent{
+		{Name: "lgpEnvHumiditySettingsIndexRel", Type: core.IndexInteger},
+	},
+	Columns: []*core.ColumnDefinition{
+		{Name: "lgpEnvHumiditySettingsIndexRel"}, // Index of the sensor.
+		{Name: "lgpEnvHumidityDescrRel"},         // Well-known sensor, e.g. lgpEnvReturnAirHumidity.
+		{Name: "lgpEnvHumiditySetPointRel"},      // Percent relative humidity. Read-write.
+		{Name: "lgpEnvHumidityHighThresholdRel"}, // Percent relative humidity. Read-write.
+		{Name: "lgpEnvHumidityLowThresholdRel"},  // Percent relative humidity. Read-write.
+	},
+}
+
+// humiditySensors are the well-known humidity sensors of
+// lgpEnvHumidityDescrRel.
+var humiditySensors = wellKnown{
+	oid: lgpEnvironmentalOid + ".2.1",
+	names: map[int]string{
+		1: "lgpEnvReturnAirHumidity",
+		2: "lgpEnvSupplyAirHumidity",
+		3: "lgpEnvAmbientHumidity",
+	},
+}
+
+// humiditySetpointRange is the range the humidity setpoints may be written
+// with. Below it there is a risk of static discharge, and above it of
+// condensation.
+var humiditySetpointRange = setpointRange(20, 80)
+
+// humiditySettingsDevices are the devices of each humidity setting. The
+// setpoint may be changed. See devices.SnmpHumiditySetpoint for how writes
+// are guarded.
+var humiditySettingsDevices = []core.ColumnDevice{
+	{Column: 3, DeviceType: "humidity-setpoint", Data: humiditySetpointRange}, // lgpEnvHumiditySetPointRel
+}
@@ -0,0 +1,204 @@
+package lgpenvmib
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// MibName is the name LIEBERT-GP-ENVIRONMENTAL-MIB is registered with.
+// See core.RegisterMib.
+const MibName = "LIEBERT-GP-ENVIRONMENTAL-MIB"
+
+// The Liebert (Vertiv) global products subtrees.
+const (
+	lgpAgentOid         = ".1.3.6.1.4.1.476.1.42.2"   // LIEBERT-GP-AGENT-MIB
+	lgpConditionsOid    = ".1.3.6.1.4.1.476.1.42.3.2" // LIEBERT-GP-CONDITIONS-MIB
+	lgpEnvironmentalOid = ".1.3.6.1.4.1.476.1.42.3.4" // LIEBERT-GP-ENVIRONMENTAL-MIB
+)
+
+func init() {
+	err := core.RegisterMib(MibName, func(server *core.SnmpServerBase) (core.Mib, error) {
+		lgpEnvMib, err := NewLgpEnvMib(server)
+		if err != nil {
+			return nil, err
+		}
+		return lgpEnvMib, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	// Liebert agents do not fill in the sysORTable, and the sysObjectID of
+	// the IntelliSlot cards is the same for UPSs and cooling units. Cooling
+	// units serve the temperature measurement table.
+	err = core.RegisterMibDetection(MibName, core.MibDetection{
+		ProbeOids: []string{lgpEnvironmentalOid + ".1.3.3"},
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// LgpEnvMib is the class for LIEBERT-GP-ENVIRONMENTAL-MIB, served by the
+// IntelliSlot cards of Liebert (Vertiv) cooling units, e.g. the CRV, DS and
+// PDX computer room air conditioners (CRAC) and the CW air handlers (CRAH).
+//
+// The temperature and humidity tables have a row per sensor, e.g. the supply
+// and return air, with the measurements and the setpoints the unit controls
+// to. The setpoints may be changed. See devices.SnmpTemperatureSetpoint. The
+// state group has whether the unit is cooling, heating, humidifying or
+// dehumidifying and the fan state.
+//
+// The model of the unit is from LIEBERT-GP-AGENT-MIB, and the active alarms
+// are the conditions of LIEBERT-GP-CONDITIONS-MIB, which every card serving
+// this MIB also serves.
+type LgpEnvMib struct {
+	*core.SnmpMib // base class
+
+	// Tables defined in this MIB
+	LgpAgentIdentTable                *core.SnmpTable
+	LgpEnvTemperatureSettingsTable    *core.SnmpTable
+	LgpEnvTemperatureMeasurementTable *core.SnmpTable
+	LgpEnvHumiditySettingsTable       *core.SnmpTable
+	LgpEnvHumidityMeasurementTable    *core.SnmpTable
+	LgpEnvStateTable                  *core.SnmpTable
+	LgpConditionsTable                *LgpConditionsTable
+}
+
+// NewLgpEnvMib constructs the LgpEnvMib.
+func NewLgpEnvMib(server *core.SnmpServerBase) (lgpEnvMib *LgpEnvMib, err error) {
+	log.Debugf("[snmp] initializing LgpEnvMib")
+
+	// Arg checks.
+	if server == nil {
+		return nil, fmt.Errorf("unable to create new LgpEnvMib: server is nil")
+	}
+
+	// Initialize Tables.
+	lgpEnvMib = &LgpEnvMib{}
+	for _, table := range []struct {
+		table       **core.SnmpTable
+		definition  *core.TableDefinition
+		descrColumn int
+		sensors     wellKnown
+		devices     []core.ColumnDevice
+	}{
+		{&lgpEnvMib.LgpAgentIdentTable, identTable, 0, wellKnown{}, identDevices},
+		{&lgpEnvMib.LgpEnvTemperatureSettingsTable, temperatureSettingsTable, 2, temperatureSensors,
+			temperatureSettingsDevices},
+		{&lgpEnvMib.LgpEnvTemperatureMeasurementTable, temperatureMeasurementTable, 2, temperatureSensors,
+			temperatureMeasurementDevices},
+		{&lgpEnvMib.LgpEnvHumiditySettingsTable, humiditySettingsTable, 2, humiditySensors,
+			humiditySettingsDevices},
+		{&lgpEnvMib.LgpEnvHumidityMeasurementTable, humidityMeasurementTable, 2, humiditySensors,
+			humidityMeasurementDevices},
+		{&lgpEnvMib.LgpEnvStateTable, stateTable, 0, wellKnown{}, stateDevices},
+	} {
+		*table.table, err = core.NewColumnTable(table.definition, server,
+			enumeration(table.descrColumn, table.sensors, table.devices))
+		if err != nil {
+			return nil, err
+		}
+	}
+	lgpEnvMib.LgpConditionsTable, err = NewLgpConditionsTable(server)
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize the base class.
+	snmpMib, err := core.NewSnmpMib(MibName, []*core.SnmpTable{
+		lgpEnvMib.LgpAgentIdentTable,
+		lgpEnvMib.LgpEnvTemperatureSettingsTable,
+		lgpEnvMib.LgpEnvTemperatureMeasurementTable,
+		lgpEnvMib.LgpEnvHumiditySettingsTable,
+		lgpEnvMib.LgpEnvHumidityMeasurementTable,
+		lgpEnvMib.LgpEnvStateTable,
+		lgpEnvMib.LgpConditionsTable.SnmpTable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	snmpMib.RegisterNames(MibName)
+	lgpEnvMib.SnmpMib = snmpMib
+
+	// Update mib pointer for each table.
+	for _, table := range lgpEnvMib.Tables {
+		table.Mib = lgpEnvMib
+	}
+
+	log.Debugf("Initialized LgpEnvMib")
+	return lgpEnvMib, nil
+}
+
+// Model gets the model of the unit, lgpAgentIdentModel, or the empty string
+// if the agent does not serve it.
+func (lgpEnvMib *LgpEnvMib) Model() string {
+	table := lgpEnvMib.LgpAgentIdentTable
+	if len(table.Rows) == 0 {
+		return ""
+	}
+	model, _ := table.Rows[0].RowData[1].Data.(string)
+	return strings.TrimSpace(model)
+}
+
+// onOff enumerates the states of the state group.
+var onOff = map[int]string{
+	1: "on",
+	2: "off",
+}
+
+// setpointRange is the device data for the range of values a setpoint may be
+// written with, in the units of the reading. See devices.SnmpSetpointWrite.
+func setpointRange(min float32, max float32) map[string]interface{} {
+	return map[string]interface{}{
+		"setpoint_min": min,
+		"setpoint_max": max,
+	}
+}
+
+// wellKnown names the well-known sensors in a description column. The
+// descriptions are OIDs under oid, e.g. lgpEnvSupplyAirTemperature.
+type wellKnown struct {
+	oid   string
+	names map[int]string
+}
+
+// name gets the name of the well-known sensor in a description, or the
+// description as is when it is not well-known.
+func (known wellKnown) name(descr interface{}) string {
+	descrString := strings.TrimSpace(fmt.Sprint(descr))
+	oid := "." + strings.TrimPrefix(descrString, ".")
+	if !strings.HasPrefix(oid, known.oid+".") {
+		return descrString
+	}
+	index, err := strconv.Atoi(oid[len(known.oid)+1:])
+	if err != nil {
+		return descrString
+	}
+	if name, ok := known.names[index]; ok {
+		return name
+	}
+	return descrString
+}
+
+// enumeration gets the devices for the columns of a table, with the model of
+// the unit in their context. The description column of other tables, if not
+// 0, is the well-known sensor of the row, e.g. lgpEnvReturnAirTemperature, and
+// is added to the context of the devices as the name. The limits are from the
+// same row. See core.NewColumnTable.
+func enumeration(descrColumn int, sensors wellKnown, columnDevices []core.ColumnDevice) func(
+	table *core.SnmpTable) core.ColumnEnumeration {
+
+	return func(table *core.SnmpTable) core.ColumnEnumeration {
+		return core.ColumnEnumeration{
+			Model:      table.Mib.(*LgpEnvMib).Model(),
+			NameColumn: descrColumn,
+			Name:       sensors.name,
+			Devices:    columnDevices,
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package lgpenvmib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core/coretest"
+)
+
+// TestLgpEnvMib tests the devices of a cooling unit with supply and return
+// air sensors and an extra sensor it has no well-known name for.
+func TestLgpEnvMib(t *testing.T) {
+	lgpEnvMib, err := NewLgpEnvMib(coretest.NewReplayServerBase(t, "testdata/lgp_env.snmpwalk"))
+	assert.NoError(t, err)
+	assert.Equal(t, MibName, lgpEnvMib.Name)
+
+	devices, err := lgpEnvMib.EnumerateDevices(map[string]interface{}{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Liebert CRV", lgpEnvMib.Model())
+	for _, proto := range devices {
+		assert.Equal(t, "Liebert CRV", proto.Context["model"])
+	}
+	assert.Equal(t, 4, coretest.CountInstances(devices, "identity"))
+
+	// Temperatures. The sensor is named by its well-known OID.
+	assert.Equal(t, 3, coretest.CountInstances(devices, "temperature"))
+	supply := coretest.FindInstance(devices, "temperature", "lgpEnvTemperatureMeasurementDegC 1")
+	assert.NotNil(t, supply)
+	assert.Equal(t, ".1.3.6.1.4.1.476.1.42.3.4.1.3.3.1.3.1", supply.Data["oid"])
+	assert.Equal(t, map[string]string{
+		"index":                                 "1",
+		"lgpEnvTemperatureMeasurementIndexDegC": "1",
+		"name":                                  "lgpEnvSupplyAirTemperature",
+	}, supply.Context)
+	assert.Equal(t, 15, supply.Data[core.LimitLowKey])
+	assert.Equal(t, 27, supply.Data[core.LimitHighKey])
+	returnAir := coretest.FindInstance(devices, "temperature", "lgpEnvTemperatureMeasurementDegC 2")
+	assert.NotNil(t, returnAir)
+	assert.Equal(t, "lgpEnvReturnAirTemperature", returnAir.Context["name"])
+	// Unknown sensors keep the OID, and unconfigured thresholds are left out.
+	other := coretest.FindInstance(devices, "temperature", "lgpEnvTemperatureMeasurementDegC 3")
+	assert.NotNil(t, other)
+	assert.Equal(t, ".1.3.6.1.4.1.476.1.42.3.4.1.1.9", other.Context["name"])
+	assert.NotContains(t, other.Data, core.LimitLowKey)
+	assert.NotContains(t, other.Data, core.LimitHighKey)
+
+	// Humidity.
+	humidity := coretest.FindInstance(devices, "humidity", "lgpEnvHumidityMeasurementRel 1")
+	assert.NotNil(t, humidity)
+	assert.Equal(t, "lgpEnvReturnAirHumidity", humidity.Context["name"])
+	assert.Equal(t, 30, humidity.Data[core.LimitLowKey])
+	assert.Equal(t, 60, humidity.Data[core.LimitHighKey])
+
+	// Setpoints.
+	assert.Equal(t, 2, coretest.CountInstances(devices, "temperature-setpoint"))
+	setpoint := coretest.FindInstance(devices, "temperature-setpoint", "lgpEnvTemperatureSetPointDegC 1")
+	assert.NotNil(t, setpoint)
+	assert.Equal(t, ".1.3.6.1.4.1.476.1.42.3.4.1.3.2.1.3.1", setpoint.Data["oid"])
+	assert.Equal(t, "lgpEnvSupplyAirTemperature", setpoint.Context["name"])
+	assert.Equal(t, float32(15), setpoint.Data["setpoint_min"])
+	assert.Equal(t, float32(32), setpoint.Data["setpoint_max"])
+	// Control is not enabled by the MIB.
+	assert.NotContains(t, setpoint.Data, "setpoint_control")
+	humiditySetpoint := coretest.FindInstance(devices, "humidity-setpoint", "lgpEnvHumiditySetPointRel 1")
+	assert.NotNil(t, humiditySetpoint)
+	assert.Equal(t, float32(20), humiditySetpoint.Data["setpoint_min"])
+	assert.Equal(t, float32(80), humiditySetpoint.Data["setpoint_max"])
+
+	// State. The unit has no variable speed fans.
+	assert.Equal(t, 9, coretest.CountInstances(devices, "status"))
+	cooling := coretest.FindInstance(devices, "status", "lgpEnvStateCooling")
+	assert.NotNil(t, cooling)
+	assert.Equal(t, ".1.3.6.1.4.1.476.1.42.3.4.3.2.0", cooling.Data["oid"])
+	assert.Equal(t, "on", cooling.Data["enumeration1"])
+	assert.NotNil(t, coretest.FindInstance(devices, "status", "lgpEnvStateHumidifying"))
+	assert.NotNil(t, coretest.FindInstance(devices, "percentage", "lgpEnvStateCoolingCapacity"))
+	assert.Nil(t, coretest.FindInstance(devices, "percentage", "lgpEnvStateFanCapacity"))
+
+	// Conditions.
+	conditions := coretest.FindInstance(devices, "alarms", "lgpActiveConditions")
+	assert.NotNil(t, conditions)
+	assert.Equal(t, ".1.3.6.1.4.1.476.1.42.3.2.2.0", conditions.Data["present_oid"])
+	assert.Equal(t, ".1.3.6.1.4.1.476.1.42.3.2.3.1.2", conditions.Data["descr_oid"])
+	assert.Equal(t, ".1.3.6.1.4.1.476.1.42.3.2.3.1.3", conditions.Data["time_oid"])
+	assert.NotNil(t, coretest.FindInstance(devices, "alarm-history", "lgpConditionHistory"))
+}
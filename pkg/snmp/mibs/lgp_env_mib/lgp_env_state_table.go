@@ -0,0 +1,44 @@
+package lgpenvmib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// stateTable is the definition of SNMP OID .1.3.6.1.4.1.476.1.42.3.4.3, the
+// operating state of the unit.
+var stateTable = &core.TableDefinition{
+	Name:      "LIEBERT-GP-ENVIRONMENTAL-MIB-lgpEnvState",
+	WalkOid:   lgpEnvironmentalOid + ".3",
+	Flattened: true,
+	Columns: []*core.ColumnDefinition{
+		{Name: "lgpEnvStateSystem"},             // Whether the unit is on.
+		{Name: "lgpEnvStateCooling"},            // Whether the compressors or chilled water valve are cooling.
+		{Name: "lgpEnvStateHeating"},            // Whether the reheat is on.
+		{Name: "lgpEnvStateHumidifying"},        // Whether the humidifier is on.
+		{Name: "lgpEnvStateDehumidifying"},      // Whether the unit is dehumidifying.
+		{Name: "lgpEnvStateEconoCycle"},         // Whether free cooling is on.
+		{Name: "lgpEnvStateFan"},                // Whether the fans are on.
+		{Name: "lgpEnvStateGeneralAlarmOutput"}, // Whether the general alarm relay is energized.
+		{Name: "lgpEnvStateCoolingCapacity"},    // Percent of the cooling capacity in use.
+		{Name: "lgpEnvStateHeatingCapacity"},    // Percent of the heating capacity in use.
+		{Name: "lgpEnvStateAudibleAlarm"},       // Whether the audible alarm is sounding.
+		{Name: "lgpEnvStateFanCapacity"},        // Percent of the fan speed.
+	},
+}
+
+// stateDevices are the devices of the state group. The compressors are
+// cooling on direct expansion (DX) units, and the chilled water valve is on
+// air handlers. Units without variable speed fans do not serve the fan
+// capacity.
+var stateDevices = []core.ColumnDevice{
+	{Column: 1, DeviceType: "status", Enumeration: onOff},  // lgpEnvStateSystem
+	{Column: 2, DeviceType: "status", Enumeration: onOff},  // lgpEnvStateCooling
+	{Column: 3, DeviceType: "status", Enumeration: onOff},  // lgpEnvStateHeating
+	{Column: 4, DeviceType: "status", Enumeration: onOff},  // lgpEnvStateHumidifying
+	{Column: 5, DeviceType: "status", Enumeration: onOff},  // lgpEnvStateDehumidifying
+	{Column: 6, DeviceType: "status", Enumeration: onOff},  // lgpEnvStateEconoCycle
+	{Column: 7, DeviceType: "status", Enumeration: onOff},  // lgpEnvStateFan
+	{Column: 8, DeviceType: "status", Enumeration: onOff},  // lgpEnvStateGeneralAlarmOutput
+	{Column: 9, DeviceType: "percentage"},                  // lgpEnvStateCoolingCapacity
+	{Column: 10, DeviceType: "percentage"},                 // lgpEnvStateHeatingCapacity
+	{Column: 11, DeviceType: "status", Enumeration: onOff}, // lgpEnvStateAudibleAlarm
+	{Column: 12, DeviceType: "percentage"},                 // lgpEnvStateFanCapacity
+}
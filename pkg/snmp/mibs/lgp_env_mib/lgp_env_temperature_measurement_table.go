@@ -0,0 +1,35 @@
+package lgpenvmib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// temperatureMeasurementTable is the definition of SNMP OID
+// .1.3.6.1.4.1.476.1.42.3.4.1.3.3, the temperatures measured by the unit in
+// degrees C.
+var temperatureMeasurementTable = &core.TableDefinition{
+	Name:        "LIEBERT-GP-ENVIRONMENTAL-MIB-lgpEnvTemperatureMeasurementTableDegC",
+	WalkOid:     lgpEnvironmentalOid + ".1.3.3",
+	RowBase:     "1",
+	IndexColumn: "1",
+	Index: []core.IndexComponent{
+		{Name: "lgpEnvTemperatureMeasurementIndexDegC", Type: core.IndexInteger},
+	},
+	Columns: []*core.ColumnDefinition{
+		{Name: "lgpEnvTemperatureMeasurementIndexDegC"}, // Index of the sensor.
+		{Name: "lgpEnvTemperatureDescrDegC"},            // Well-known sensor, e.g. lgpEnvSupplyAirTemperature.
+		{Name: "lgpEnvTemperatureMeasurementDegC"},      // Degrees C.
+		{Name: "lgpEnvTemperatureHighThresholdDegC"},    // Degrees C.
+		{Name: "lgpEnvTemperatureLowThresholdDegC"},     // Degrees C.
+	},
+}
+
+// temperatureMeasurementDevices are the devices of each temperature sensor.
+// The high and low thresholds of the sensor are the thresholds of the
+// temperature device.
+var temperatureMeasurementDevices = []core.ColumnDevice{
+	{
+		Column:     3, // lgpEnvTemperatureMeasurementDegC
+		DeviceType: "temperature",
+		LowLimit:   5, // lgpEnvTemperatureLowThresholdDegC
+		HighLimit:  4, // lgpEnvTemperatureHighThresholdDegC
+	},
+}
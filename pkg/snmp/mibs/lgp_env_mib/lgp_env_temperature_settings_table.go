@@ -0,0 +1,46 @@
+package lgpenvmib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// temperatureSettingsTable is the definition of SNMP OID
+// .1.3.6.1.4.1.476.1.42.3.4.1.3.2, the temperature setpoints and thresholds of
+// the unit in degrees C.
+var temperatureSettingsTable = &core.TableDefinition{
+	Name:        "LIEBERT-GP-ENVIRONMENTAL-MIB-lgpEnvTemperatureSettingsTableDegC",
+	WalkOid:     lgpEnvironmentalOid + ".1.3.2",
+	RowBase:     "1",
+	IndexColumn: "1",
+	Index: []core.IndexComponent{
+		{Name: "lgpEnvTemperatureSettingsIndexDegC", Type: core.IndexInteger},
+	},
+	Columns: []*core.ColumnDefinition{
+		{Name: "lgpEnvTemperatureSettingsIndexDegC"}, // Index of the sensor.
+		{Name: "lgpEnvTemperatureDescrDegC"},         // Well-known sensor, e.g. lgpEnvSupplyAirTemperature.
+		{Name: "lgpEnvTemperatureSetPointDegC"},      // Degrees C. Read-write.
+		{Name: "lgpEnvTemperatureHighThresholdDegC"}, // Degrees C. Read-write.
+		{Name: "lgpEnvTemperatureLowThresholdDegC"},  // Degrees C. Read-write.
+	},
+}
+
+// temperatureSensors are the well-known temperature sensors of
+// lgpEnvTemperatureDescrDegC.
+var temperatureSensors = wellKnown{
+	oid: lgpEnvironmentalOid + ".1.1",
+	names: map[int]string{
+		1: "lgpEnvReturnAirTemperature",
+		2: "lgpEnvSupplyAirTemperature",
+		3: "lgpEnvAmbientTemperature",
+	},
+}
+
+// temperatureSetpointRange is the range the temperature setpoints may be
+// written with, the ASHRAE allowable range for class A1 equipment, so that a
+// mistyped setpoint can neither overheat nor overcool the room.
+var temperatureSetpointRange = setpointRange(15, 32)
+
+// temperatureSettingsDevices are the devices of each temperature setting.
+// The setpoint may be changed. See devices.SnmpTemperatureSetpoint for how
+// writes are guarded.
+var temperatureSettingsDevices = []core.ColumnDevice{
+	{Column: 3, DeviceType: "temperature-setpoint", Data: temperatureSetpointRange}, // lgpEnvTemperatureSetPointDegC
+}
@@ -0,0 +1,34 @@
+package lgpflexiblemib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// identTable is the definition of SNMP OID .1.3.6.1.4.1.476.1.42.2.1, the
+// identification of the unit from LIEBERT-GP-AGENT-MIB.
+var identTable = &core.TableDefinition{
+	Name:      "LIEBERT-GP-AGENT-MIB-lgpAgentIdent",
+	WalkOid:   lgpAgentOid + ".1",
+	Flattened: true,
+	Columns: []*core.ColumnDefinition{
+		{Name: "lgpAgentIdentManufacturer"},    // e.g. Liebert Corporation.
+		{Name: "lgpAgentIdentModel"},           // e.g. Liebert CRV.
+		{Name: "lgpAgentIdentFirmwareVersion"}, // Firmware of the card.
+		{Name: "lgpAgentIdentSerialNumber"},    // Serial number of the card.
+	},
+}
+
+// identDevices are the identity devices of the unit.
+var identDevices = []core.ColumnDevice{
+	{Column: 1, DeviceType: "identity"}, // lgpAgentIdentManufacturer
+	{Column: 2, DeviceType: "identity"}, // lgpAgentIdentModel
+	{Column: 3, DeviceType: "identity"}, // lgpAgentIdentFirmwareVersion
+	{Column: 4, DeviceType: "identity"}, // lgpAgentIdentSerialNumber
+}
+
+// identEnumeration gets the identity devices, with the model of the unit in
+// their context. See core.NewColumnTable.
+func identEnumeration(table *core.SnmpTable) core.ColumnEnumeration {
+	return core.ColumnEnumeration{
+		Model:   table.Mib.(*LgpFlexibleMib).Model(),
+		Devices: identDevices,
+	}
+}
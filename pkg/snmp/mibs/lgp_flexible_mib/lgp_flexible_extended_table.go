@@ -0,0 +1,106 @@
+package lgpflexiblemib
+
+import (
+	"math"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// The columns of lgpFlexibleExtendedEntry used here. The columns are numbered
+// in tens.
+const (
+	dataLabelColumn      = 10 // lgpFlexibleEntryDataLabel
+	valueColumn          = 20 // lgpFlexibleEntryValue
+	unitsOfMeasureColumn = 30 // lgpFlexibleEntryUnitsOfMeasure
+	integerValueColumn   = 40 // lgpFlexibleEntryIntegerValue
+)
+
+// extendedTable is the definition of SNMP OID .1.3.6.1.4.1.476.1.42.3.9.30,
+// the data points of the unit. The rows are indexed by the data point, which
+// is not accessible, and every data point has a label.
+var extendedTable = &core.TableDefinition{
+	Name:           "LIEBERT-GP-FLEXIBLE-MIB-lgpFlexibleExtendedTable",
+	WalkOid:        lgpFlexibleOid + ".30",
+	RowBase:        "1",
+	ReadableColumn: "10",
+	Columns:        extendedColumns(),
+}
+
+// extendedColumns are the columns of lgpFlexibleExtendedEntry, with the
+// unused columns between the tens left unnamed.
+func extendedColumns() []*core.ColumnDefinition {
+	columns := make([]*core.ColumnDefinition, integerValueColumn)
+	for i := range columns {
+		columns[i] = &core.ColumnDefinition{}
+	}
+	columns[dataLabelColumn-1].Name = "lgpFlexibleEntryDataLabel"           // e.g. Supply Air Temperature.
+	columns[valueColumn-1].Name = "lgpFlexibleEntryValue"                   // The value as text, e.g. 17.5 or On.
+	columns[unitsOfMeasureColumn-1].Name = "lgpFlexibleEntryUnitsOfMeasure" // e.g. deg C, or empty.
+	columns[integerValueColumn-1].Name = "lgpFlexibleEntryIntegerValue"     // The value without the decimal point, e.g. 175.
+	return columns
+}
+
+// unitDeviceTypes are the device types of the data points by their units of
+// measure, in lower case without spaces. Temperatures in degrees F have no
+// device, so the cards should be set to metric units.
+var unitDeviceTypes = map[string]string{
+	"degc": "temperature",
+	"%rh":  "humidity",
+	"%":    "percentage",
+	"rpm":  "rpm",
+}
+
+// extendedEnumeration gets the devices of the extended table. Data points
+// with units of measure are read from the integer value, with the multiplier
+// from the decimal places of the text value. Data points without units are
+// states, e.g. Compressor 1 State, and are read from the text value as status
+// devices. Other data points have no device. Devices are named by column and
+// data point, and the label is added to the context of the devices as the
+// name. See core.NewColumnTable.
+func extendedEnumeration(table *core.SnmpTable) core.ColumnEnumeration {
+	return core.ColumnEnumeration{
+		Model:      table.Mib.(*LgpFlexibleMib).Model(),
+		NameColumn: dataLabelColumn,
+		RowDevices: func(row *core.SnmpRow) []core.ColumnDevice {
+			return extendedDevices(table, row)
+		},
+	}
+}
+
+// extendedDevices gets the device for a data point, if any. The device type
+// is from the units, with the integer value scaled by the decimals of the
+// text value. Data points without units are a status with the text value.
+func extendedDevices(table *core.SnmpTable, row *core.SnmpRow) []core.ColumnDevice {
+	value := strings.TrimSpace(stringColumn(row, valueColumn))
+	units := strings.TrimSpace(stringColumn(row, unitsOfMeasureColumn))
+
+	device := core.ColumnDevice{}
+	switch {
+	case units == "" && value != "":
+		device.DeviceType, device.Column = "status", valueColumn
+	case row.RowData[integerValueColumn-1].Data != nil:
+		device.DeviceType = unitDeviceTypes[strings.ToLower(strings.Replace(units, " ", "", -1))]
+		device.Column = integerValueColumn
+		if point := strings.Index(value, "."); point >= 0 {
+			device.Multiplier = float32(math.Pow10(point + 1 - len(value)))
+		}
+	}
+	if device.DeviceType == "" {
+		log.WithFields(log.Fields{
+			"table": table.Name,
+			"label": strings.TrimSpace(stringColumn(row, dataLabelColumn)),
+			"units": units,
+		}).Debug("[snmp] no device for flexible data point")
+		return nil
+	}
+	return []core.ColumnDevice{device}
+}
+
+// stringColumn gets a column of a row as a string, or the empty string if
+// it is not one.
+func stringColumn(row *core.SnmpRow, column int) string {
+	value, _ := row.RowData[column-1].Data.(string)
+	return value
+}
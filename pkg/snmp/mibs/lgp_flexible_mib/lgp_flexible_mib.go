@@ -0,0 +1,108 @@
+package lgpflexiblemib
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// MibName is the name LIEBERT-GP-FLEXIBLE-MIB is registered with.
+// See core.RegisterMib.
+const MibName = "LIEBERT-GP-FLEXIBLE-MIB"
+
+// The Liebert (Vertiv) global products subtrees.
+const (
+	lgpAgentOid    = ".1.3.6.1.4.1.476.1.42.2"   // LIEBERT-GP-AGENT-MIB
+	lgpFlexibleOid = ".1.3.6.1.4.1.476.1.42.3.9" // LIEBERT-GP-FLEXIBLE-MIB
+)
+
+func init() {
+	err := core.RegisterMib(MibName, func(server *core.SnmpServerBase) (core.Mib, error) {
+		lgpFlexibleMib, err := NewLgpFlexibleMib(server)
+		if err != nil {
+			return nil, err
+		}
+		return lgpFlexibleMib, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	// Liebert agents do not fill in the sysORTable. Cards with the flexible
+	// MIB serve the extended table.
+	err = core.RegisterMibDetection(MibName, core.MibDetection{
+		ProbeOids: []string{lgpFlexibleOid + ".30"},
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// LgpFlexibleMib is the class for LIEBERT-GP-FLEXIBLE-MIB, served by the
+// IntelliSlot Unity cards of Liebert (Vertiv) cooling units.
+//
+// Where LIEBERT-GP-ENVIRONMENTAL-MIB has fixed objects, the flexible MIB has
+// a table with a row per data point of the unit, labelled and with its units
+// of measure, e.g. "Fan Speed" in "%". It has the data points the fixed
+// objects do not, e.g. the state of each compressor of a unit with several.
+// The device type of each data point is from its units of measure.
+type LgpFlexibleMib struct {
+	*core.SnmpMib // base class
+
+	// Tables defined in this MIB
+	LgpAgentIdentTable       *core.SnmpTable
+	LgpFlexibleExtendedTable *core.SnmpTable
+}
+
+// NewLgpFlexibleMib constructs the LgpFlexibleMib.
+func NewLgpFlexibleMib(server *core.SnmpServerBase) (lgpFlexibleMib *LgpFlexibleMib, err error) {
+	log.Debugf("[snmp] initializing LgpFlexibleMib")
+
+	// Arg checks.
+	if server == nil {
+		return nil, fmt.Errorf("unable to create new LgpFlexibleMib: server is nil")
+	}
+
+	// Initialize Tables.
+	lgpFlexibleMib = &LgpFlexibleMib{}
+	lgpFlexibleMib.LgpAgentIdentTable, err = core.NewColumnTable(identTable, server, identEnumeration)
+	if err != nil {
+		return nil, err
+	}
+	lgpFlexibleMib.LgpFlexibleExtendedTable, err = core.NewColumnTable(extendedTable, server, extendedEnumeration)
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize the base class.
+	snmpMib, err := core.NewSnmpMib(MibName, []*core.SnmpTable{
+		lgpFlexibleMib.LgpAgentIdentTable,
+		lgpFlexibleMib.LgpFlexibleExtendedTable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	snmpMib.RegisterNames(MibName)
+	lgpFlexibleMib.SnmpMib = snmpMib
+
+	// Update mib pointer for each table.
+	for _, table := range lgpFlexibleMib.Tables {
+		table.Mib = lgpFlexibleMib
+	}
+
+	log.Debugf("Initialized LgpFlexibleMib")
+	return lgpFlexibleMib, nil
+}
+
+// Model gets the model of the unit, lgpAgentIdentModel, or the empty string
+// if the agent does not serve it.
+func (lgpFlexibleMib *LgpFlexibleMib) Model() string {
+	table := lgpFlexibleMib.LgpAgentIdentTable
+	if len(table.Rows) == 0 {
+		return ""
+	}
+	model, _ := table.Rows[0].RowData[1].Data.(string)
+	return strings.TrimSpace(model)
+}
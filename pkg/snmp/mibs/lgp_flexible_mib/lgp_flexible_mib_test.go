@@ -0,0 +1,57 @@
+package lgpflexiblemib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core/coretest"
+)
+
+// TestLgpFlexibleMib tests the devices of the data points of a cooling unit.
+func TestLgpFlexibleMib(t *testing.T) {
+	lgpFlexibleMib, err := NewLgpFlexibleMib(coretest.NewReplayServerBase(t, "testdata/lgp_flexible.snmpwalk"))
+	assert.NoError(t, err)
+	assert.Equal(t, MibName, lgpFlexibleMib.Name)
+
+	devices, err := lgpFlexibleMib.EnumerateDevices(map[string]interface{}{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Liebert DS", lgpFlexibleMib.Model())
+	for _, proto := range devices {
+		assert.Equal(t, "Liebert DS", proto.Context["model"])
+	}
+	model := coretest.FindInstance(devices, "identity", "lgpAgentIdentModel")
+	assert.NotNil(t, model)
+	assert.Equal(t, ".1.3.6.1.4.1.476.1.42.2.1.2.0", model.Data["oid"])
+
+	// Data points with units are read from the integer value. The degrees F
+	// duplicate of the supply air temperature has no device.
+	assert.Equal(t, 2, coretest.CountInstances(devices, "temperature"))
+	supply := coretest.FindInstance(devices, "temperature", "lgpFlexibleEntryIntegerValue 1.4291")
+	assert.NotNil(t, supply)
+	assert.Equal(t, ".1.3.6.1.4.1.476.1.42.3.9.30.1.40.1.4291", supply.Data["oid"])
+	assert.Equal(t, float32(0.1), supply.Data["multiplier"])
+	assert.Equal(t, map[string]string{"index": "1.4291", "name": "Supply Air Temperature"}, supply.Context)
+
+	humidity := coretest.FindInstance(devices, "humidity", "lgpFlexibleEntryIntegerValue 1.5028")
+	assert.NotNil(t, humidity)
+	assert.NotContains(t, humidity.Data, "multiplier")
+	fan := coretest.FindInstance(devices, "percentage", "lgpFlexibleEntryIntegerValue 1.5077")
+	assert.NotNil(t, fan)
+	assert.Equal(t, "Fan Speed", fan.Context["name"])
+
+	// Data points without units are states, read from the text value.
+	assert.Equal(t, 4, coretest.CountInstances(devices, "status"))
+	compressor := coretest.FindInstance(devices, "status", "lgpFlexibleEntryValue 1.5110")
+	assert.NotNil(t, compressor)
+	assert.Equal(t, ".1.3.6.1.4.1.476.1.42.3.9.30.1.20.1.5110", compressor.Data["oid"])
+	assert.Equal(t, "Compressor 2 State", compressor.Context["name"])
+
+	// Units without a device type.
+	assert.Nil(t, coretest.FindInstance(devices, "status", "lgpFlexibleEntryValue 1.5500"))
+	for _, proto := range devices {
+		for _, instance := range proto.Instances {
+			assert.NotEqual(t, "Unit Run Hours", instance.Context["name"])
+		}
+	}
+}
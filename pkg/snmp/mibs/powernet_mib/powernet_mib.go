@@ -0,0 +1,410 @@
+package powernetmib
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// MibName is the name PowerNet-MIB is registered with. See core.RegisterMib.
+const MibName = "PowerNet-MIB"
+
+// upsOid is the ups subtree of PowerNet-MIB.
+const upsOid = ".1.3.6.1.4.1.318.1.1.1"
+
+func init() {
+	err := core.RegisterMib(MibName, func(server *core.SnmpServerBase) (core.Mib, error) {
+		powerNetMib, err := NewPowerNetMib(server)
+		if err != nil {
+			return nil, err
+		}
+		return powerNetMib, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	// APC agents do not fill in the sysORTable. upsBasicIdentModel is served
+	// by every APC UPS.
+	err = core.RegisterMibDetection(MibName, core.MibDetection{
+		ProbeOids: []string{upsOid + ".1.1.1.0"},
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// PowerNetMib is the class for the ups subtree of the APC (Schneider) PowerNet-MIB,
+// served by the Galaxy and Smart-UPS network management cards. APC implements
+// UPS-MIB sparsely, so this fills in the battery replacement, the internal
+// temperature, the output state including bypass, and the state flags.
+//
+// The upsHighPrec objects are read in place of the upsAdv objects for the same
+// measure when the agent serves them. Devices for measures UPS-MIB also has
+// name the UPS-MIB object they duplicate, so only one of them is kept when
+// both MIBs are enabled. See core.DuplicatesKey.
+type PowerNetMib struct {
+	*core.SnmpMib // base class
+
+	// Tables defined in this MIB
+	UpsBasicIdent      *UpsGroupTable
+	UpsBasicBattery    *UpsGroupTable
+	UpsBasicOutput     *UpsGroupTable
+	UpsBasicState      *UpsGroupTable
+	UpsAdvIdent        *UpsGroupTable
+	UpsAdvBattery      *UpsGroupTable
+	UpsAdvInput        *UpsGroupTable
+	UpsAdvOutput       *UpsGroupTable
+	UpsHighPrecBattery *UpsGroupTable
+	UpsHighPrecInput   *UpsGroupTable
+	UpsHighPrecOutput  *UpsGroupTable
+}
+
+// NewPowerNetMib constructs the PowerNetMib.
+func NewPowerNetMib(server *core.SnmpServerBase) (powerNetMib *PowerNetMib, err error) { // nolint: gocyclo
+	log.Debugf("[snmp] initializing PowerNetMib")
+
+	// Arg checks.
+	if server == nil {
+		return nil, fmt.Errorf("unable to create new PowerNetMib: server is nil")
+	}
+
+	// Initialize Tables.
+	powerNetMib = &PowerNetMib{}
+	for _, group := range []struct {
+		table   **UpsGroupTable
+		name    string
+		oid     string
+		columns []string
+	}{
+		{&powerNetMib.UpsBasicIdent, "upsBasicIdent", upsOid + ".1.1", []string{
+			"upsBasicIdentModel", // Model name, e.g. Galaxy VM 180 kVA.
+			"upsBasicIdentName",  // Name assigned by the user.
+		}},
+		{&powerNetMib.UpsBasicBattery, "upsBasicBattery", upsOid + ".1.2", []string{
+			"upsBasicBatteryStatus",          // e.g. batteryNormal(2).
+			"upsBasicBatteryTimeOnBattery",   // TimeTicks since the UPS switched to battery.
+			"upsBasicBatteryLastReplaceDate", // Date the batteries were last replaced, mm/dd/yy.
+		}},
+		{&powerNetMib.UpsBasicOutput, "upsBasicOutput", upsOid + ".1.4", []string{
+			"upsBasicOutputStatus", // e.g. onLine(2), switchedBypass(9).
+			"upsBasicOutputPhase",  // Number of output phases.
+		}},
+		{&powerNetMib.UpsBasicState, "upsBasicState", upsOid + ".11.1", []string{
+			"upsBasicStateOutputState", // 64 flags, each 0 or 1.
+		}},
+		{&powerNetMib.UpsAdvIdent, "upsAdvIdent", upsOid + ".2.1", []string{
+			"upsAdvIdentFirmwareRevision",  // UPS firmware revision.
+			"upsAdvIdentDateOfManufacture", // mm/dd/yy
+			"upsAdvIdentSerialNumber",      // UPS serial number.
+		}},
+		{&powerNetMib.UpsAdvBattery, "upsAdvBattery", upsOid + ".2.2", []string{
+			"upsAdvBatteryCapacity",          // Percent of full charge.
+			"upsAdvBatteryTemperature",       // Degrees C, internal to the UPS.
+			"upsAdvBatteryRunTimeRemaining",  // TimeTicks
+			"upsAdvBatteryReplaceIndicator",  // noBatteryNeedsReplacing(1), batteryNeedsReplacing(2)
+			"upsAdvBatteryNumOfBattPacks",    // External battery packs.
+			"upsAdvBatteryNumOfBadBattPacks", // External battery packs which are defective.
+			"upsAdvBatteryNominalVoltage",    // Volts DC
+			"upsAdvBatteryActualVoltage",     // Volts DC
+			"upsAdvBatteryCurrent",           // Amps DC
+			"upsAdvTotalDCCurrent",           // Amps DC
+		}},
+		{&powerNetMib.UpsAdvInput, "upsAdvInput", upsOid + ".2.3", []string{
+			"upsAdvInputLineVoltage",    // Volts AC
+			"upsAdvInputMaxLineVoltage", // Volts AC, over the last minute.
+			"upsAdvInputMinLineVoltage", // Volts AC, over the last minute.
+			"upsAdvInputFrequency",      // Hertz
+			"upsAdvInputLineFailCause",  // Reason for the last transfer to battery.
+		}},
+		{&powerNetMib.UpsAdvOutput, "upsAdvOutput", upsOid + ".2.4", []string{
+			"upsAdvOutputVoltage",   // Volts AC
+			"upsAdvOutputFrequency", // Hertz
+			"upsAdvOutputLoad",      // Percent of capacity.
+			"upsAdvOutputCurrent",   // Amps AC
+		}},
+		{&powerNetMib.UpsHighPrecBattery, "upsHighPrecBattery", upsOid + ".4.1", []string{
+			"upsHighPrecBatteryCapacity",       // Tenths of percent.
+			"upsHighPrecBatteryTemperature",    // Tenths of degrees C.
+			"upsHighPrecBatteryNominalVoltage", // Tenths of Volts DC.
+			"upsHighPrecBatteryActualVoltage",  // Tenths of Volts DC.
+			"upsHighPrecBatteryCurrent",        // Tenths of Amps DC.
+			"upsHighPrecTotalDCCurrent",        // Tenths of Amps DC.
+		}},
+		{&powerNetMib.UpsHighPrecInput, "upsHighPrecInput", upsOid + ".4.2", []string{
+			"upsHighPrecInputLineVoltage",    // Tenths of Volts AC.
+			"upsHighPrecInputMaxLineVoltage", // Tenths of Volts AC.
+			"upsHighPrecInputMinLineVoltage", // Tenths of Volts AC.
+			"upsHighPrecInputFrequency",      // Tenths of Hertz.
+		}},
+		{&powerNetMib.UpsHighPrecOutput, "upsHighPrecOutput", upsOid + ".4.3", []string{
+			"upsHighPrecOutputVoltage",   // Tenths of Volts AC.
+			"upsHighPrecOutputFrequency", // Tenths of Hertz.
+			"upsHighPrecOutputLoad",      // Tenths of percent.
+			"upsHighPrecOutputCurrent",   // Tenths of Amps AC.
+		}},
+	} {
+		*group.table, err = NewUpsGroupTable(server, group.name, group.oid, group.columns)
+		if err != nil {
+			return nil, err
+		}
+	}
+	powerNetMib.setDevices()
+
+	// Initialize the base class.
+	snmpMib, err := core.NewSnmpMib(MibName, []*core.SnmpTable{
+		powerNetMib.UpsBasicIdent.SnmpTable,
+		powerNetMib.UpsBasicBattery.SnmpTable,
+		powerNetMib.UpsBasicOutput.SnmpTable,
+		powerNetMib.UpsBasicState.SnmpTable,
+		powerNetMib.UpsAdvIdent.SnmpTable,
+		powerNetMib.UpsAdvBattery.SnmpTable,
+		powerNetMib.UpsAdvInput.SnmpTable,
+		powerNetMib.UpsAdvOutput.SnmpTable,
+		powerNetMib.UpsHighPrecBattery.SnmpTable,
+		powerNetMib.UpsHighPrecInput.SnmpTable,
+		powerNetMib.UpsHighPrecOutput.SnmpTable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	snmpMib.RegisterNames(MibName)
+	powerNetMib.SnmpMib = snmpMib
+
+	// Update mib pointer for each table.
+	for _, table := range powerNetMib.Tables {
+		table.Mib = powerNetMib
+	}
+
+	log.Debugf("Initialized PowerNetMib")
+	return powerNetMib, nil
+}
+
+// Model gets the UPS model, upsBasicIdentModel, or the empty string if the
+// agent does not serve it.
+func (powerNetMib *PowerNetMib) Model() string {
+	table := powerNetMib.UpsBasicIdent
+	if !served(table.SnmpTable, 1) {
+		return ""
+	}
+	model, _ := table.Rows[0].RowData[0].Data.(string)
+	return model
+}
+
+// The UPS-MIB objects the PowerNet-MIB devices duplicate. Per line objects
+// are those of the first line.
+const (
+	upsIdentModel                = ".1.3.6.1.2.1.33.1.1.2.0"
+	upsIdentUPSSoftwareVersion   = ".1.3.6.1.2.1.33.1.1.3.0"
+	upsIdentName                 = ".1.3.6.1.2.1.33.1.1.5.0"
+	upsBatteryStatus             = ".1.3.6.1.2.1.33.1.2.1.0"
+	upsSecondsOnBattery          = ".1.3.6.1.2.1.33.1.2.2.0"
+	upsEstimatedMinutesRemaining = ".1.3.6.1.2.1.33.1.2.3.0"
+	upsEstimatedChargeRemaining  = ".1.3.6.1.2.1.33.1.2.4.0"
+	upsBatteryVoltage            = ".1.3.6.1.2.1.33.1.2.5.0"
+	upsBatteryCurrent            = ".1.3.6.1.2.1.33.1.2.6.0"
+	upsBatteryTemperature        = ".1.3.6.1.2.1.33.1.2.7.0"
+	upsInputFrequency1           = ".1.3.6.1.2.1.33.1.3.3.1.2.1"
+	upsInputVoltage1             = ".1.3.6.1.2.1.33.1.3.3.1.3.1"
+	upsOutputFrequency           = ".1.3.6.1.2.1.33.1.4.2.0"
+	upsOutputVoltage1            = ".1.3.6.1.2.1.33.1.4.4.1.2.1"
+	upsOutputCurrent1            = ".1.3.6.1.2.1.33.1.4.4.1.3.1"
+	upsOutputPercentLoad1        = ".1.3.6.1.2.1.33.1.4.4.1.5.1"
+)
+
+// timeTicks is the multiplier of TimeTicks to seconds.
+const timeTicks = float32(0.01)
+
+// tenths is the multiplier of the upsHighPrec objects.
+const tenths = float32(0.1)
+
+// basicBatteryStatus enumerates upsBasicBatteryStatus.
+var basicBatteryStatus = map[int]string{
+	1: "unknown",
+	2: "batteryNormal",
+	3: "batteryLow",
+	4: "batteryInFaultCondition",
+	5: "noBatteryPresent",
+}
+
+// basicOutputStatus enumerates upsBasicOutputStatus, including the bypass and
+// static switch states.
+var basicOutputStatus = map[int]string{
+	1:  "unknown",
+	2:  "onLine",
+	3:  "onBattery",
+	4:  "onSmartBoost",
+	5:  "timedSleeping",
+	6:  "softwareBypass",
+	7:  "off",
+	8:  "rebooting",
+	9:  "switchedBypass",
+	10: "hardwareFailureBypass",
+	11: "sleepingUntilPowerReturn",
+	12: "onSmartTrim",
+	13: "ecoMode",
+	14: "hotStandby",
+	15: "onBatteryTest",
+	16: "emergencyStaticBypass",
+	17: "staticBypassStandby",
+	18: "powerSavingMode",
+	19: "spotMode",
+	20: "eConversion",
+	21: "chargerSpotmode",
+	22: "inverterSpotmode",
+	23: "activeLoad",
+	24: "batteryDischargeSpotmode",
+	25: "inverterStandby",
+	26: "chargerOnly",
+}
+
+// batteryReplaceIndicator enumerates upsAdvBatteryReplaceIndicator.
+var batteryReplaceIndicator = map[int]string{
+	1: "noBatteryNeedsReplacing",
+	2: "batteryNeedsReplacing",
+}
+
+// inputLineFailCause enumerates upsAdvInputLineFailCause.
+var inputLineFailCause = map[int]string{
+	1:  "noTransfer",
+	2:  "highLineVoltage",
+	3:  "brownout",
+	4:  "blackout",
+	5:  "smallMomentarySag",
+	6:  "deepMomentarySag",
+	7:  "smallMomentarySpike",
+	8:  "largeMomentarySpike",
+	9:  "selfTest",
+	10: "rateOfVoltageChange",
+}
+
+// outputStateFlags names the flags of upsBasicStateOutputState by position.
+var outputStateFlags = map[int]string{
+	1:  "Abnormal Condition Present",
+	2:  "On Battery",
+	3:  "Low Battery",
+	4:  "On Line",
+	5:  "Replace Battery",
+	6:  "Serial Communication Established",
+	7:  "AVR Boost Active",
+	8:  "AVR Trim Active",
+	9:  "Overload",
+	10: "Runtime Calibration",
+	11: "Batteries Discharged",
+	12: "Manual Bypass",
+	13: "Software Bypass",
+	14: "In Bypass due to Internal Fault",
+	15: "In Bypass due to Supply Failure",
+	16: "In Bypass due to Fan Failure",
+	17: "Sleeping on a Timer",
+	18: "Sleeping until Utility Power Returns",
+	19: "On",
+	20: "Rebooting",
+	21: "Battery Communication Lost",
+	22: "Graceful Shutdown Initiated",
+	23: "Smart Boost or Smart Trim Fault",
+	24: "Bad Output Voltage",
+	25: "Battery Charger Failure",
+	26: "High Battery Temperature",
+	27: "Warning Battery Temperature",
+	28: "Critical Battery Temperature",
+	29: "Self Test In Progress",
+	30: "Low Battery / On Battery",
+	31: "Graceful Shutdown Issued by Upstream Device",
+	32: "Graceful Shutdown Issued by Downstream Device",
+	33: "No Batteries Attached",
+	34: "Synchronized Command is in Progress",
+	35: "Synchronized Sleeping Command is in Progress",
+	36: "Synchronized Rebooting Command is in Progress",
+	37: "Inverter DC Imbalance",
+	38: "Transfer Relay Failure",
+	39: "Shutdown or Unable to Transfer",
+	40: "Low Battery Shutdown",
+	41: "Electronic Unit Fan Failure",
+	42: "Main Relay Failure",
+	43: "Bypass Relay Failure",
+	44: "Temporary Bypass",
+	45: "High Internal Temperature",
+	46: "Battery Temperature Sensor Fault",
+	47: "Input Out of Range for Bypass",
+	48: "DC Bus Overvoltage",
+	49: "PFC Failure",
+	50: "Critical Hardware Fault",
+	51: "Green Mode/ECO Mode",
+	52: "Hot Standby",
+	53: "Emergency Power Off (EPO) Activated",
+	54: "Load Alarm Violation",
+	55: "Bypass Phase Fault",
+	56: "UPS Internal Communication Failure",
+	57: "Efficiency Booster Mode",
+	58: "Off",
+	59: "Standby",
+	60: "Minor or Environment Alarm",
+}
+
+// setDevices sets the devices of each group. The upsHighPrec groups have no
+// devices of their own, they are read in place of the upsAdv objects.
+func (powerNetMib *PowerNetMib) setDevices() {
+	highPrec := func(table *UpsGroupTable, column int) *highPrecColumn {
+		return &highPrecColumn{table: table, column: column, multiplier: tenths}
+	}
+
+	for _, group := range []struct {
+		table   *UpsGroupTable
+		devices []groupDevice
+	}{
+		{powerNetMib.UpsBasicIdent, []groupDevice{
+			{column: 1, deviceType: "identity", duplicates: upsIdentModel},
+			{column: 2, deviceType: "identity", duplicates: upsIdentName},
+		}},
+		{powerNetMib.UpsBasicBattery, []groupDevice{
+			{column: 1, deviceType: "status", enumeration: basicBatteryStatus, duplicates: upsBatteryStatus},
+			{column: 2, deviceType: "seconds", multiplier: timeTicks, duplicates: upsSecondsOnBattery},
+			{column: 3, deviceType: "identity"},
+		}},
+		{powerNetMib.UpsBasicOutput, []groupDevice{
+			{column: 1, deviceType: "status", enumeration: basicOutputStatus},
+		}},
+		{powerNetMib.UpsBasicState, []groupDevice{
+			{column: 1, deviceType: "status", flags: outputStateFlags},
+		}},
+		{powerNetMib.UpsAdvIdent, []groupDevice{
+			{column: 1, deviceType: "identity", duplicates: upsIdentUPSSoftwareVersion},
+			{column: 2, deviceType: "identity"},
+			{column: 3, deviceType: "identity"},
+		}},
+		{powerNetMib.UpsAdvBattery, []groupDevice{
+			{column: 1, deviceType: "percentage", duplicates: upsEstimatedChargeRemaining,
+				highPrec: highPrec(powerNetMib.UpsHighPrecBattery, 1)},
+			{column: 2, deviceType: "temperature", duplicates: upsBatteryTemperature,
+				highPrec: highPrec(powerNetMib.UpsHighPrecBattery, 2)},
+			{column: 3, deviceType: "seconds", multiplier: timeTicks, duplicates: upsEstimatedMinutesRemaining},
+			{column: 4, deviceType: "status", enumeration: batteryReplaceIndicator},
+			{column: 6, deviceType: "status"},
+			{column: 8, deviceType: "voltage", duplicates: upsBatteryVoltage,
+				highPrec: highPrec(powerNetMib.UpsHighPrecBattery, 4)},
+			{column: 9, deviceType: "current", duplicates: upsBatteryCurrent,
+				highPrec: highPrec(powerNetMib.UpsHighPrecBattery, 5)},
+		}},
+		{powerNetMib.UpsAdvInput, []groupDevice{
+			{column: 1, deviceType: "voltage", duplicates: upsInputVoltage1,
+				highPrec: highPrec(powerNetMib.UpsHighPrecInput, 1)},
+			{column: 4, deviceType: "frequency", duplicates: upsInputFrequency1,
+				highPrec: highPrec(powerNetMib.UpsHighPrecInput, 4)},
+			{column: 5, deviceType: "status", enumeration: inputLineFailCause},
+		}},
+		{powerNetMib.UpsAdvOutput, []groupDevice{
+			{column: 1, deviceType: "voltage", duplicates: upsOutputVoltage1,
+				highPrec: highPrec(powerNetMib.UpsHighPrecOutput, 1)},
+			{column: 2, deviceType: "frequency", duplicates: upsOutputFrequency,
+				highPrec: highPrec(powerNetMib.UpsHighPrecOutput, 2)},
+			{column: 3, deviceType: "percentage", duplicates: upsOutputPercentLoad1,
+				highPrec: highPrec(powerNetMib.UpsHighPrecOutput, 3)},
+			{column: 4, deviceType: "current", duplicates: upsOutputCurrent1,
+				highPrec: highPrec(powerNetMib.UpsHighPrecOutput, 4)},
+		}},
+	} {
+		group.table.DevEnumerator = UpsGroupTableDeviceEnumerator{Table: group.table, Devices: group.devices}
+	}
+}
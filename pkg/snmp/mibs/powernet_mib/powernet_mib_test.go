@@ -0,0 +1,73 @@
+package powernetmib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core/coretest"
+)
+
+// TestPowerNetMib tests the PowerNet-MIB devices of a Galaxy VM walk.
+func TestPowerNetMib(t *testing.T) {
+	powerNetMib, err := NewPowerNetMib(coretest.NewReplayServerBase(t, "testdata/powernet.snmpwalk"))
+	assert.NoError(t, err)
+	assert.Equal(t, MibName, powerNetMib.Name)
+
+	devices, err := powerNetMib.EnumerateDevices(map[string]interface{}{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Galaxy VM 180 kVA", powerNetMib.Model())
+	for _, proto := range devices {
+		assert.Equal(t, "Galaxy VM 180 kVA", proto.Context["model"])
+	}
+
+	// Identities. The Galaxy serves an empty upsBasicIdentName.
+	model := coretest.FindInstance(devices, "identity", "upsBasicIdentModel")
+	assert.NotNil(t, model)
+	assert.Equal(t, ".1.3.6.1.4.1.318.1.1.1.1.1.1.0", model.Data["oid"])
+	assert.Equal(t, ".1.3.6.1.2.1.33.1.1.2.0", model.Data[core.DuplicatesKey])
+	assert.Nil(t, coretest.FindInstance(devices, "identity", "upsBasicIdentName"))
+	assert.NotNil(t, coretest.FindInstance(devices, "identity", "upsAdvIdentSerialNumber"))
+	replaceDate := coretest.FindInstance(devices, "identity", "upsBasicBatteryLastReplaceDate")
+	assert.NotNil(t, replaceDate)
+	assert.NotContains(t, replaceDate.Data, core.DuplicatesKey)
+
+	// High precision objects are preferred.
+	temperature := coretest.FindInstance(devices, "temperature", "upsHighPrecBatteryTemperature")
+	assert.NotNil(t, temperature)
+	assert.Equal(t, ".1.3.6.1.4.1.318.1.1.1.4.1.2.0", temperature.Data["oid"])
+	assert.Equal(t, "PowerNet-MIB-upsHighPrecBattery", temperature.Data["table_name"])
+	assert.Equal(t, float32(0.1), temperature.Data["multiplier"])
+	assert.Equal(t, ".1.3.6.1.2.1.33.1.2.7.0", temperature.Data[core.DuplicatesKey])
+	assert.Nil(t, coretest.FindInstance(devices, "temperature", "upsAdvBatteryTemperature"))
+	assert.NotNil(t, coretest.FindInstance(devices, "percentage", "upsHighPrecOutputLoad"))
+
+	// The Galaxy does not serve upsHighPrecInput, so upsAdvInput is read.
+	lineVoltage := coretest.FindInstance(devices, "voltage", "upsAdvInputLineVoltage")
+	assert.NotNil(t, lineVoltage)
+	assert.Equal(t, ".1.3.6.1.4.1.318.1.1.1.2.3.1.0", lineVoltage.Data["oid"])
+	assert.NotContains(t, lineVoltage.Data, "multiplier")
+	assert.Len(t, powerNetMib.UpsHighPrecInput.Rows, 0)
+
+	// TimeTicks are read as seconds.
+	runTime := coretest.FindInstance(devices, "seconds", "upsAdvBatteryRunTimeRemaining")
+	assert.NotNil(t, runTime)
+	assert.Equal(t, float32(0.01), runTime.Data["multiplier"])
+
+	// Bypass and the state flags.
+	outputStatus := coretest.FindInstance(devices, "status", "upsBasicOutputStatus")
+	assert.NotNil(t, outputStatus)
+	assert.Equal(t, "true", outputStatus.Data["enumeration"])
+	assert.Equal(t, "emergencyStaticBypass", outputStatus.Data["enumeration16"])
+	outputState := coretest.FindInstance(devices, "status", "upsBasicStateOutputState")
+	assert.NotNil(t, outputState)
+	assert.Equal(t, "true", outputState.Data["flags"])
+	assert.Equal(t, "High Internal Temperature", outputState.Data["flag45"])
+	assert.NotContains(t, outputState.Data, "enumeration")
+
+	// The high precision groups have no devices of their own.
+	highPrec, err := powerNetMib.UpsHighPrecBattery.DevEnumerator.DeviceEnumerator(map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Len(t, highPrec, 0)
+}
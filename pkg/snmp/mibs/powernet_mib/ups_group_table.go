@@ -0,0 +1,173 @@
+package powernetmib
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vapor-ware/synse-sdk/sdk/config"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// UpsGroupTable represents a group of scalars in the ups subtree of
+// PowerNet-MIB, .1.3.6.1.4.1.318.1.1.1, e.g. upsAdvBattery. Each group is a
+// flattened table.
+type UpsGroupTable struct {
+	*core.SnmpTable // base class
+}
+
+// NewUpsGroupTable constructs the UpsGroupTable for a group. The table is
+// named after the group, e.g. PowerNet-MIB-upsAdvBattery.
+func NewUpsGroupTable(snmpServerBase *core.SnmpServerBase, group string, walkOid string, columns []string) (
+	table *UpsGroupTable, err error) {
+
+	definition := &core.TableDefinition{
+		Name:      "PowerNet-MIB-" + group,
+		WalkOid:   walkOid,
+		Flattened: true,
+	}
+	for _, column := range columns {
+		definition.Columns = append(definition.Columns, &core.ColumnDefinition{Name: column})
+	}
+
+	snmpTable, err := core.NewTable(definition, snmpServerBase)
+	if err != nil {
+		return nil, err
+	}
+
+	table = &UpsGroupTable{SnmpTable: snmpTable}
+	table.DevEnumerator = UpsGroupTableDeviceEnumerator{Table: table}
+	return table, nil
+}
+
+// groupDevice is a device for a scalar of a group.
+type groupDevice struct {
+	column      int            // 1 based column number.
+	deviceType  string         // Synse device type.
+	multiplier  float32        // Multiplier for the raw reading, if not 0.
+	enumeration map[int]string // Names of an enumerated INTEGER, if any.
+	flags       map[int]string // Names of the flags of a flag string, if any.
+	duplicates  string         // OID of the UPS-MIB object this duplicates, if any.
+	highPrec    *highPrecColumn
+}
+
+// highPrecColumn is the upsHighPrec scalar for the same measure as an upsAdv
+// scalar, with more precision. It is used instead when the agent serves it.
+type highPrecColumn struct {
+	table      *UpsGroupTable
+	column     int
+	multiplier float32
+}
+
+// UpsGroupTableDeviceEnumerator overrides the default SnmpTable device
+// enumerator for a group.
+type UpsGroupTableDeviceEnumerator struct {
+	Table   *UpsGroupTable // Pointer back to the table.
+	Devices []groupDevice  // The devices of the group. The upsHighPrec groups have none.
+}
+
+// DeviceEnumerator overrides the default SnmpTable device enumerator.
+func (enumerator UpsGroupTableDeviceEnumerator) DeviceEnumerator(
+	data map[string]interface{}) (devices []*config.DeviceProto, err error) {
+
+	// Pull out the table, device model and SNMP DeviceConfig.
+	table := enumerator.Table
+	if len(enumerator.Devices) == 0 {
+		return nil, nil
+	}
+
+	snmpDeviceConfigMap, err := table.SnmpServerBase.DeviceConfig.ToMap()
+	if err != nil {
+		return nil, err
+	}
+
+	// One prototype per device type, in the order they are first found.
+	protos := map[string]*config.DeviceProto{}
+	model := table.Mib.(*PowerNetMib).Model()
+
+	for _, groupDevice := range enumerator.Devices {
+		deviceTable, column, multiplier := groupDevice.source(table)
+		if deviceTable == nil {
+			continue // Not served.
+		}
+		row := deviceTable.Rows[0]
+
+		// deviceData gets shimmed into the DeviceConfig for each synse device.
+		deviceData := map[string]interface{}{
+			"base_oid":   row.BaseOid,
+			"table_name": deviceTable.Name,
+			"row":        "0",
+			"column":     fmt.Sprint(column),
+			"oid":        fmt.Sprintf(row.BaseOid, column), // base_oid and integer column.
+		}
+		if multiplier != 0 {
+			deviceData["multiplier"] = multiplier
+		}
+		if groupDevice.enumeration != nil {
+			deviceData["enumeration"] = "true"
+			for value, name := range groupDevice.enumeration {
+				deviceData[fmt.Sprintf("enumeration%d", value)] = name
+			}
+		}
+		if groupDevice.flags != nil {
+			deviceData["flags"] = "true"
+			for position, name := range groupDevice.flags {
+				deviceData[fmt.Sprintf("flag%d", position)] = name
+			}
+		}
+		if groupDevice.duplicates != "" {
+			deviceData[core.DuplicatesKey] = groupDevice.duplicates
+		}
+		deviceData, err = core.MergeMapStringInterface(snmpDeviceConfigMap, deviceData)
+		if err != nil {
+			return nil, err
+		}
+
+		proto, ok := protos[groupDevice.deviceType]
+		if !ok {
+			proto = &config.DeviceProto{
+				Type: groupDevice.deviceType,
+				Context: map[string]string{
+					"model": model,
+				},
+				Instances: []*config.DeviceInstance{},
+				Tags:      snmpDeviceConfigMap["deviceTags"].([]string),
+			}
+			protos[groupDevice.deviceType] = proto
+			devices = append(devices, proto)
+		}
+		proto.Instances = append(proto.Instances, &config.DeviceInstance{
+			Info: deviceTable.ColumnList[column-1],
+			Data: deviceData,
+		})
+	}
+	return devices, nil
+}
+
+// source gets the table, column and multiplier to read a device from: the
+// upsHighPrec scalar when the agent serves it, otherwise the scalar of the
+// group. The table is nil when the agent serves neither.
+func (device groupDevice) source(table *UpsGroupTable) (*core.SnmpTable, int, float32) {
+	if device.highPrec != nil && served(device.highPrec.table.SnmpTable, device.highPrec.column) {
+		return device.highPrec.table.SnmpTable, device.highPrec.column, device.highPrec.multiplier
+	}
+	if served(table.SnmpTable, device.column) {
+		return table.SnmpTable, device.column, device.multiplier
+	}
+	return nil, 0, 0
+}
+
+// served is true if the agent serves the scalar of a group. APC agents serve
+// empty strings for some identities they do not know, which are not served
+// either.
+func served(table *core.SnmpTable, column int) bool {
+	if len(table.Rows) == 0 {
+		return false
+	}
+	switch data := table.Rows[0].RowData[column-1].Data.(type) {
+	case nil:
+		return false
+	case string:
+		return strings.TrimSpace(data) != ""
+	}
+	return true
+}
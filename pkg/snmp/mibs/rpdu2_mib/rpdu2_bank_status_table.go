@@ -0,0 +1,27 @@
+package rpdu2mib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// bankStatusTable is the definition of SNMP OID .1.3.6.1.4.1.318.1.1.26.8.3,
+// the load of each outlet bank. Each bank is protected by a breaker, so a bank
+// in overload is at risk of tripping it.
+var bankStatusTable = &core.TableDefinition{
+	Name:        "PowerNet-MIB-rPDU2BankStatusTable",
+	WalkOid:     rPDU2Oid + ".8.3",
+	RowBase:     "1",
+	IndexColumn: "1",
+	Index:       []core.IndexComponent{{Name: "rPDU2BankStatusIndex", Type: core.IndexInteger}},
+	Columns: []*core.ColumnDefinition{
+		{Name: "rPDU2BankStatusIndex"},     // Index of the bank.
+		{Name: "rPDU2BankStatusModule"},    // User assigned number of the PDU when PDUs are linked.
+		{Name: "rPDU2BankStatusNumber"},    // Number of the bank.
+		{Name: "rPDU2BankStatusLoadState"}, // e.g. normal(2).
+		{Name: "rPDU2BankStatusCurrent"},   // Tenths of amps.
+	},
+}
+
+// bankStatusDevices are the devices of each bank.
+var bankStatusDevices = []core.ColumnDevice{
+	{Column: 4, DeviceType: "status", Enumeration: loadState}, // rPDU2BankStatusLoadState
+	{Column: 5, DeviceType: "current", Multiplier: tenths},    // rPDU2BankStatusCurrent
+}
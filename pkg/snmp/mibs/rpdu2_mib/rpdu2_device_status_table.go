@@ -0,0 +1,47 @@
+package rpdu2mib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// deviceStatusTable is the definition of SNMP OID .1.3.6.1.4.1.318.1.1.26.4.3,
+// the total load of each PDU, as drawn through its inlet.
+var deviceStatusTable = &core.TableDefinition{
+	Name:        "PowerNet-MIB-rPDU2DeviceStatusTable",
+	WalkOid:     rPDU2Oid + ".4.3",
+	RowBase:     "1",
+	IndexColumn: "1",
+	Index:       []core.IndexComponent{{Name: "rPDU2DeviceStatusIndex", Type: core.IndexInteger}},
+	Columns: []*core.ColumnDefinition{
+		{Name: "rPDU2DeviceStatusIndex"},                  // Index of the PDU.
+		{Name: "rPDU2DeviceStatusModule"},                 // User assigned number of the PDU when PDUs are linked.
+		{Name: "rPDU2DeviceStatusName"},                   // User assigned name of the PDU.
+		{Name: "rPDU2DeviceStatusLoadState"},              // e.g. normal(2).
+		{Name: "rPDU2DeviceStatusPower"},                  // Hundredths of kilowatts.
+		{Name: "rPDU2DeviceStatusPeakPower"},              // Hundredths of kilowatts.
+		{Name: "rPDU2DeviceStatusPeakPowerTimestamp"},     // Date and time of the peak power.
+		{Name: "rPDU2DeviceStatusPeakPowerStartTime"},     // Date and time the peak power was reset.
+		{Name: "rPDU2DeviceStatusEnergy"},                 // Tenths of kilowatt-hours since the energy was reset.
+		{Name: "rPDU2DeviceStatusEnergyStartTime"},        // Date and time the energy was reset.
+		{Name: "rPDU2DeviceStatusCommandPending"},         // Whether a device command is pending.
+		{Name: "rPDU2DeviceStatusPowerSupplyAlarm"},       // normal(1), alarm(2)
+		{Name: "rPDU2DeviceStatusPowerSupply1Status"},     // normal(1), alarm(2), notInstalled(3)
+		{Name: "rPDU2DeviceStatusPowerSupply2Status"},     // normal(1), alarm(2), notInstalled(3)
+		{Name: "rPDU2DeviceStatusOutletsEnergyStartTime"}, // Date and time the outlet energy was reset.
+		{Name: "rPDU2DeviceStatusApparentPower"},          // Hundredths of kilovolt-amperes.
+		{Name: "rPDU2DeviceStatusPowerFactor"},            // Hundredths.
+	},
+}
+
+// powerSupplyAlarm enumerates rPDU2DeviceStatusPowerSupplyAlarm.
+var powerSupplyAlarm = map[int]string{
+	1: "normal",
+	2: "alarm",
+}
+
+// deviceStatusDevices are the devices of each PDU.
+var deviceStatusDevices = []core.ColumnDevice{
+	{Column: 4, DeviceType: "status", Enumeration: loadState},                // rPDU2DeviceStatusLoadState
+	{Column: 5, DeviceType: "power", Multiplier: hundredthsOfKilo},           // rPDU2DeviceStatusPower
+	{Column: 9, DeviceType: "energy", Multiplier: tenths},                    // rPDU2DeviceStatusEnergy
+	{Column: 12, DeviceType: "status", Enumeration: powerSupplyAlarm},        // rPDU2DeviceStatusPowerSupplyAlarm
+	{Column: 16, DeviceType: "apparent-power", Multiplier: hundredthsOfKilo}, // rPDU2DeviceStatusApparentPower
+}
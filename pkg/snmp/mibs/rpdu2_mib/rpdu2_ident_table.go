@@ -0,0 +1,32 @@
+package rpdu2mib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// identTable is the definition of SNMP OID .1.3.6.1.4.1.318.1.1.26.2.1, the
+// identity of each PDU.
+var identTable = &core.TableDefinition{
+	Name:        "PowerNet-MIB-rPDU2IdentTable",
+	WalkOid:     rPDU2Oid + ".2.1",
+	RowBase:     "1",
+	IndexColumn: "1",
+	Index:       []core.IndexComponent{{Name: "rPDU2IdentIndex", Type: core.IndexInteger}},
+	Columns: []*core.ColumnDefinition{
+		{Name: "rPDU2IdentIndex"},             // Index of the PDU.
+		{Name: "rPDU2IdentModule"},            // User assigned number of the PDU when PDUs are linked.
+		{Name: "rPDU2IdentName"},              // User assigned name of the PDU.
+		{Name: "rPDU2IdentLocation"},          // User assigned location of the PDU.
+		{Name: "rPDU2IdentHardwareRev"},       // Hardware revision.
+		{Name: "rPDU2IdentFirmwareRev"},       // Firmware revision.
+		{Name: "rPDU2IdentDateOfManufacture"}, // mm/dd/yyyy
+		{Name: "rPDU2IdentModelNumber"},       // e.g. AP8853
+		{Name: "rPDU2IdentSerialNumber"},      // PDU serial number.
+	},
+}
+
+// identDevices are the devices of each PDU.
+var identDevices = []core.ColumnDevice{
+	{Column: 3, DeviceType: "identity"}, // rPDU2IdentName
+	{Column: 6, DeviceType: "identity"}, // rPDU2IdentFirmwareRev
+	{Column: 8, DeviceType: "identity"}, // rPDU2IdentModelNumber
+	{Column: 9, DeviceType: "identity"}, // rPDU2IdentSerialNumber
+}
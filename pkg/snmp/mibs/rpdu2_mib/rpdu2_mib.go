@@ -0,0 +1,145 @@
+package rpdu2mib
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// MibName is the name the rPDU2 subtree of PowerNet-MIB is registered with.
+// See core.RegisterMib.
+const MibName = "PowerNet-MIB-rPDU2"
+
+// powerNetModule is the MIB module which defines the subtree. The column
+// names are registered with it.
+const powerNetModule = "PowerNet-MIB"
+
+// rPDU2Oid is the rPDU2 subtree of PowerNet-MIB.
+const rPDU2Oid = ".1.3.6.1.4.1.318.1.1.26"
+
+func init() {
+	err := core.RegisterMib(MibName, func(server *core.SnmpServerBase) (core.Mib, error) {
+		rPDU2Mib, err := NewRPDU2Mib(server)
+		if err != nil {
+			return nil, err
+		}
+		return rPDU2Mib, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	// APC agents do not fill in the sysORTable. The sysObjectID of APC rack
+	// PDUs is under masterSwitch, and every rack PDU has a row in
+	// rPDU2IdentTable.
+	err = core.RegisterMibDetection(MibName, core.MibDetection{
+		SysObjectIDs: []string{".1.3.6.1.4.1.318.1.3.4"},
+		ProbeOids:    []string{rPDU2Oid + ".2.1.1"},
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// RPDU2Mib is the class for the rPDU2 subtree of the APC (Schneider)
+// PowerNet-MIB, served by the metered and switched rack PDUs, e.g. the AP8000
+// series. Each table has a row per PDU, phase, bank or outlet, with the PDU
+// given by the module column when PDUs are linked.
+//
+// The inlet is covered by the device and phase tables, the breakers by the
+// bank table, and the outlets by the metered and switched outlet tables.
+// Switched outlets may be switched on, off, or cycled.
+type RPDU2Mib struct {
+	*core.SnmpMib // base class
+
+	// Tables defined in this MIB
+	RPDU2IdentTable                *core.SnmpTable
+	RPDU2DeviceStatusTable         *core.SnmpTable
+	RPDU2PhaseStatusTable          *core.SnmpTable
+	RPDU2BankStatusTable           *core.SnmpTable
+	RPDU2OutletSwitchedStatusTable *core.SnmpTable
+	RPDU2OutletMeteredStatusTable  *core.SnmpTable
+}
+
+// NewRPDU2Mib constructs the RPDU2Mib.
+func NewRPDU2Mib(server *core.SnmpServerBase) (rPDU2Mib *RPDU2Mib, err error) {
+	log.Debugf("[snmp] initializing RPDU2Mib")
+
+	// Arg checks.
+	if server == nil {
+		return nil, fmt.Errorf("unable to create new RPDU2Mib: server is nil")
+	}
+
+	// Initialize Tables.
+	rPDU2Mib = &RPDU2Mib{}
+	for _, table := range []struct {
+		table      **core.SnmpTable
+		definition *core.TableDefinition
+		nameColumn int
+		devices    []core.ColumnDevice
+	}{
+		{&rPDU2Mib.RPDU2IdentTable, identTable, 3, identDevices},
+		{&rPDU2Mib.RPDU2DeviceStatusTable, deviceStatusTable, 3, deviceStatusDevices},
+		{&rPDU2Mib.RPDU2PhaseStatusTable, phaseStatusTable, 0, phaseStatusDevices},
+		{&rPDU2Mib.RPDU2BankStatusTable, bankStatusTable, 0, bankStatusDevices},
+		{&rPDU2Mib.RPDU2OutletSwitchedStatusTable, outletSwitchedStatusTable, 3, outletSwitchedDevices},
+		{&rPDU2Mib.RPDU2OutletMeteredStatusTable, outletMeteredStatusTable, 3, outletMeteredDevices},
+	} {
+		*table.table, err = core.NewColumnTable(table.definition, server, enumeration(table.nameColumn, table.devices))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Initialize the base class.
+	snmpMib, err := core.NewSnmpMib(MibName, []*core.SnmpTable{
+		rPDU2Mib.RPDU2IdentTable,
+		rPDU2Mib.RPDU2DeviceStatusTable,
+		rPDU2Mib.RPDU2PhaseStatusTable,
+		rPDU2Mib.RPDU2BankStatusTable,
+		rPDU2Mib.RPDU2OutletSwitchedStatusTable,
+		rPDU2Mib.RPDU2OutletMeteredStatusTable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	snmpMib.RegisterNames(powerNetModule)
+	rPDU2Mib.SnmpMib = snmpMib
+
+	// Update mib pointer for each table.
+	for _, table := range rPDU2Mib.Tables {
+		table.Mib = rPDU2Mib
+	}
+
+	log.Debugf("Initialized RPDU2Mib")
+	return rPDU2Mib, nil
+}
+
+// loadState enumerates the load state of a PDU, phase, bank or outlet against
+// its configured thresholds.
+var loadState = map[int]string{
+	1: "lowLoad",
+	2: "normal",
+	3: "nearOverload",
+	4: "overload",
+}
+
+// tenths is the multiplier of objects in tenths, e.g. of amps.
+const tenths = float32(0.1)
+
+// hundredthsOfKilo is the multiplier of objects in hundredths of kilowatts or
+// kilovolt-amperes, to watts or volt-amperes.
+const hundredthsOfKilo = float32(10)
+
+// enumeration gets the devices for the columns of a table. The name column,
+// if not 0, is added to the context of the devices as the name, e.g. the
+// outlet name. See core.NewColumnTable.
+func enumeration(nameColumn int, columnDevices []core.ColumnDevice) func(table *core.SnmpTable) core.ColumnEnumeration {
+	return func(table *core.SnmpTable) core.ColumnEnumeration {
+		return core.ColumnEnumeration{
+			NameColumn: nameColumn,
+			Devices:    columnDevices,
+		}
+	}
+}
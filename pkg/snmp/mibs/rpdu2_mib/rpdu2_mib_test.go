@@ -0,0 +1,78 @@
+package rpdu2mib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core/coretest"
+)
+
+// TestRPDU2Mib tests the rPDU2 devices of a switched and metered rack PDU.
+func TestRPDU2Mib(t *testing.T) {
+	rPDU2Mib, err := NewRPDU2Mib(coretest.NewReplayServerBase(t, "testdata/rpdu2.snmpwalk"))
+	assert.NoError(t, err)
+	assert.Equal(t, MibName, rPDU2Mib.Name)
+
+	devices, err := rPDU2Mib.EnumerateDevices(map[string]interface{}{})
+	assert.NoError(t, err)
+
+	// Identity.
+	model := coretest.FindInstance(devices, "identity", "rPDU2IdentModelNumber 1")
+	assert.NotNil(t, model)
+	assert.Equal(t, ".1.3.6.1.4.1.318.1.1.26.2.1.1.8.1", model.Data["oid"])
+	assert.Equal(t, map[string]string{"index": "1", "rPDU2IdentIndex": "1", "name": "rack-a12-pdu-a"}, model.Context)
+	assert.NotNil(t, coretest.FindInstance(devices, "identity", "rPDU2IdentSerialNumber 1"))
+
+	// Inlet.
+	power := coretest.FindInstance(devices, "power", "rPDU2DeviceStatusPower 1")
+	assert.NotNil(t, power)
+	assert.Equal(t, float32(10), power.Data["multiplier"])
+	energy := coretest.FindInstance(devices, "energy", "rPDU2DeviceStatusEnergy 1")
+	assert.NotNil(t, energy)
+	assert.Equal(t, ".1.3.6.1.4.1.318.1.1.26.4.3.1.9.1", energy.Data["oid"])
+	assert.Equal(t, float32(0.1), energy.Data["multiplier"])
+	assert.NotNil(t, coretest.FindInstance(devices, "apparent-power", "rPDU2DeviceStatusApparentPower 1"))
+	assert.NotNil(t, coretest.FindInstance(devices, "voltage", "rPDU2PhaseStatusVoltage 1"))
+	phaseCurrent := coretest.FindInstance(devices, "current", "rPDU2PhaseStatusCurrent 1")
+	assert.NotNil(t, phaseCurrent)
+	assert.Equal(t, float32(0.1), phaseCurrent.Data["multiplier"])
+	// Older firmware does not serve the phase power.
+	assert.Nil(t, coretest.FindInstance(devices, "power", "rPDU2PhaseStatusPower 1"))
+
+	// Banks.
+	bankState := coretest.FindInstance(devices, "status", "rPDU2BankStatusLoadState 2")
+	assert.NotNil(t, bankState)
+	assert.Equal(t, "nearOverload", bankState.Data["enumeration3"])
+	assert.NotNil(t, coretest.FindInstance(devices, "current", "rPDU2BankStatusCurrent 2"))
+
+	// Outlets.
+	assert.Equal(t, 4, coretest.CountInstances(devices, "outlet"))
+	outlet := coretest.FindInstance(devices, "outlet", "rPDU2OutletSwitchedStatusState 3")
+	assert.NotNil(t, outlet)
+	assert.Equal(t, ".1.3.6.1.4.1.318.1.1.26.9.2.3.1.5.3", outlet.Data["oid"])
+	assert.Equal(t, "on", outlet.Data["enumeration2"])
+	assert.Equal(t, ".1.3.6.1.4.1.318.1.1.26.9.2.4.1.5.3", outlet.Data["action_oid_off"])
+	assert.Equal(t, "2", outlet.Data["action_value_off"])
+	assert.Equal(t, "1", outlet.Data["action_value_on"])
+	assert.Equal(t, "3", outlet.Data["action_value_cycle"])
+	assert.Equal(t, "db-1", outlet.Context["name"])
+	// Outlet control is not enabled by the MIB.
+	assert.NotContains(t, outlet.Data, "outlet_control")
+	// Unnamed outlets have no name.
+	assert.NotContains(t, coretest.FindInstance(devices, "outlet", "rPDU2OutletSwitchedStatusState 4").Context, "name")
+
+	outletCurrent := coretest.FindInstance(devices, "current", "rPDU2OutletMeteredStatusCurrent 1")
+	assert.NotNil(t, outletCurrent)
+	assert.Equal(t, ".1.3.6.1.4.1.318.1.1.26.9.4.3.1.6.1", outletCurrent.Data["oid"])
+	outletPower := coretest.FindInstance(devices, "power", "rPDU2OutletMeteredStatusPower 1")
+	assert.NotNil(t, outletPower)
+	assert.NotContains(t, outletPower.Data, "multiplier")
+	assert.Equal(t, 5, coretest.CountInstances(devices, "power"))
+
+	// Switching an outlet off is read back.
+	client := rPDU2Mib.RPDU2OutletSwitchedStatusTable.SnmpServerBase.SnmpClient
+	assert.NoError(t, client.SetInteger(outlet.Data["action_oid_off"].(string), 2))
+	result, err := client.Get(".1.3.6.1.4.1.318.1.1.26.9.2.4.1.5.3")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Data)
+}
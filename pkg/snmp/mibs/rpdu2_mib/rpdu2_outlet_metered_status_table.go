@@ -0,0 +1,29 @@
+package rpdu2mib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// outletMeteredStatusTable is the definition of SNMP OID
+// .1.3.6.1.4.1.318.1.1.26.9.4.3, the load of each metered outlet.
+var outletMeteredStatusTable = &core.TableDefinition{
+	Name:        "PowerNet-MIB-rPDU2OutletMeteredStatusTable",
+	WalkOid:     rPDU2Oid + ".9.4.3",
+	RowBase:     "1",
+	IndexColumn: "1",
+	Index:       []core.IndexComponent{{Name: "rPDU2OutletMeteredStatusIndex", Type: core.IndexInteger}},
+	Columns: []*core.ColumnDefinition{
+		{Name: "rPDU2OutletMeteredStatusIndex"},   // Index of the outlet.
+		{Name: "rPDU2OutletMeteredStatusModule"},  // User assigned number of the PDU when PDUs are linked.
+		{Name: "rPDU2OutletMeteredStatusName"},    // User assigned name of the outlet.
+		{Name: "rPDU2OutletMeteredStatusNumber"},  // Number of the outlet.
+		{Name: "rPDU2OutletMeteredStatusState"},   // e.g. normal(2).
+		{Name: "rPDU2OutletMeteredStatusCurrent"}, // Tenths of amps.
+		{Name: "rPDU2OutletMeteredStatusPower"},   // Watts
+	},
+}
+
+// outletMeteredDevices are the devices of each metered outlet.
+var outletMeteredDevices = []core.ColumnDevice{
+	{Column: 5, DeviceType: "status", Enumeration: loadState}, // rPDU2OutletMeteredStatusState
+	{Column: 6, DeviceType: "current", Multiplier: tenths},    // rPDU2OutletMeteredStatusCurrent
+	{Column: 7, DeviceType: "power"},                          // rPDU2OutletMeteredStatusPower
+}
@@ -0,0 +1,48 @@
+package rpdu2mib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// outletSwitchedStatusTable is the definition of SNMP OID
+// .1.3.6.1.4.1.318.1.1.26.9.2.3, the state of each switched outlet. Outlets
+// are switched with rPDU2OutletSwitchedControlCommand, in
+// rPDU2OutletSwitchedControlTable with the same index.
+var outletSwitchedStatusTable = &core.TableDefinition{
+	Name:        "PowerNet-MIB-rPDU2OutletSwitchedStatusTable",
+	WalkOid:     rPDU2Oid + ".9.2.3",
+	RowBase:     "1",
+	IndexColumn: "1",
+	Index:       []core.IndexComponent{{Name: "rPDU2OutletSwitchedStatusIndex", Type: core.IndexInteger}},
+	Columns: []*core.ColumnDefinition{
+		{Name: "rPDU2OutletSwitchedStatusIndex"},          // Index of the outlet.
+		{Name: "rPDU2OutletSwitchedStatusModule"},         // User assigned number of the PDU when PDUs are linked.
+		{Name: "rPDU2OutletSwitchedStatusName"},           // User assigned name of the outlet.
+		{Name: "rPDU2OutletSwitchedStatusNumber"},         // Number of the outlet.
+		{Name: "rPDU2OutletSwitchedStatusState"},          // off(1), on(2)
+		{Name: "rPDU2OutletSwitchedStatusCommandPending"}, // Whether an outlet command is pending.
+		{Name: "rPDU2OutletSwitchedStatusExternalLink"},   // Whether the outlet is linked to another PDU.
+	},
+}
+
+// outletState enumerates rPDU2OutletSwitchedStatusState.
+var outletState = map[int]string{
+	1: "off",
+	2: "on",
+}
+
+// outletControlCommandOid is rPDU2OutletSwitchedControlCommand, without the
+// outlet index. See devices.SnmpOutlet for how writes are guarded.
+const outletControlCommandOid = rPDU2Oid + ".9.2.4.1.5"
+
+// outletSwitchedDevices are the devices of each switched outlet.
+var outletSwitchedDevices = []core.ColumnDevice{
+	{
+		Column:      5, // rPDU2OutletSwitchedStatusState
+		DeviceType:  "outlet",
+		Enumeration: outletState,
+		Actions: map[string]core.ColumnAction{
+			"on":    {ColumnOid: outletControlCommandOid, Value: 1}, // immediateOn
+			"off":   {ColumnOid: outletControlCommandOid, Value: 2}, // immediateOff
+			"cycle": {ColumnOid: outletControlCommandOid, Value: 3}, // immediateReboot
+		},
+	},
+}
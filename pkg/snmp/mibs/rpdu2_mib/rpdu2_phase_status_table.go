@@ -0,0 +1,33 @@
+package rpdu2mib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// phaseStatusTable is the definition of SNMP OID .1.3.6.1.4.1.318.1.1.26.6.3,
+// the load of each inlet phase.
+var phaseStatusTable = &core.TableDefinition{
+	Name:        "PowerNet-MIB-rPDU2PhaseStatusTable",
+	WalkOid:     rPDU2Oid + ".6.3",
+	RowBase:     "1",
+	IndexColumn: "1",
+	Index:       []core.IndexComponent{{Name: "rPDU2PhaseStatusIndex", Type: core.IndexInteger}},
+	Columns: []*core.ColumnDefinition{
+		{Name: "rPDU2PhaseStatusIndex"},         // Index of the phase.
+		{Name: "rPDU2PhaseStatusModule"},        // User assigned number of the PDU when PDUs are linked.
+		{Name: "rPDU2PhaseStatusNumber"},        // Number of the phase.
+		{Name: "rPDU2PhaseStatusLoadState"},     // e.g. normal(2).
+		{Name: "rPDU2PhaseStatusCurrent"},       // Tenths of amps.
+		{Name: "rPDU2PhaseStatusVoltage"},       // Volts
+		{Name: "rPDU2PhaseStatusPower"},         // Hundredths of kilowatts.
+		{Name: "rPDU2PhaseStatusApparentPower"}, // Hundredths of kilovolt-amperes.
+		{Name: "rPDU2PhaseStatusPowerFactor"},   // Hundredths.
+	},
+}
+
+// phaseStatusDevices are the devices of each phase.
+var phaseStatusDevices = []core.ColumnDevice{
+	{Column: 4, DeviceType: "status", Enumeration: loadState},               // rPDU2PhaseStatusLoadState
+	{Column: 5, DeviceType: "current", Multiplier: tenths},                  // rPDU2PhaseStatusCurrent
+	{Column: 6, DeviceType: "voltage"},                                      // rPDU2PhaseStatusVoltage
+	{Column: 7, DeviceType: "power", Multiplier: hundredthsOfKilo},          // rPDU2PhaseStatusPower
+	{Column: 8, DeviceType: "apparent-power", Multiplier: hundredthsOfKilo}, // rPDU2PhaseStatusApparentPower
+}
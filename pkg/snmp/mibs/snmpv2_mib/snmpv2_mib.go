@@ -0,0 +1,78 @@
+package snmpv2mib
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// MibName is the name SNMPv2-MIB is registered with. See core.RegisterMib.
+const MibName = "SNMPv2-MIB"
+
+func init() {
+	err := core.RegisterMib(MibName, func(server *core.SnmpServerBase) (core.Mib, error) {
+		snmpv2Mib, err := NewSnmpV2Mib(server)
+		if err != nil {
+			return nil, err
+		}
+		return snmpv2Mib, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	// Every SNMP agent implements the system group.
+	err = core.RegisterMibDetection(MibName, core.MibDetection{
+		SysORIDs:  []string{".1.3.6.1.6.3.1"},
+		ProbeOids: []string{".1.3.6.1.2.1.1.1"},
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// SnmpV2Mib is the class for the system group of SNMPv2-MIB, rfc 3418. Every
+// SNMP agent implements it, whatever else it implements.
+type SnmpV2Mib struct {
+	*core.SnmpMib // base class
+
+	// Tables defined in this MIB
+	SystemTable *SystemTable
+}
+
+// NewSnmpV2Mib constructs the SnmpV2Mib.
+func NewSnmpV2Mib(server *core.SnmpServerBase) (snmpv2Mib *SnmpV2Mib, err error) {
+	log.Debugf("[snmp] initializing SnmpV2Mib")
+
+	// Arg checks.
+	if server == nil {
+		return nil, fmt.Errorf("unable to create new SnmpV2Mib: server is nil")
+	}
+
+	// Initialize Tables.
+	systemTable, err := NewSystemTable(server)
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize the base class.
+	snmpMib, err := core.NewSnmpMib(MibName, []*core.SnmpTable{systemTable.SnmpTable})
+	if err != nil {
+		return nil, err
+	}
+	snmpMib.RegisterNames(MibName)
+
+	snmpv2Mib = &SnmpV2Mib{
+		SnmpMib:     snmpMib,
+		SystemTable: systemTable,
+	}
+
+	// Update mib pointer for each table.
+	for _, table := range snmpv2Mib.Tables {
+		table.Mib = snmpv2Mib
+	}
+
+	log.Debugf("Initialized SnmpV2Mib")
+	return snmpv2Mib, nil
+}
@@ -0,0 +1,62 @@
+package snmpv2mib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core/coretest"
+)
+
+// TestSnmpV2Mib tests the system group devices of the PXGMS UPS walk.
+func TestSnmpV2Mib(t *testing.T) {
+	snmpv2Mib, err := NewSnmpV2Mib(coretest.NewReplayServerBase(t, "../../../../emulator/ups/pxgms_ups/data/public.snmpwalk"))
+	assert.NoError(t, err)
+	assert.Equal(t, MibName, snmpv2Mib.Name)
+
+	devices, err := snmpv2Mib.EnumerateDevices(map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Len(t, devices, 2)
+
+	for _, info := range []string{"sysDescr", "sysObjectID", "sysContact", "sysName", "sysLocation"} {
+		assert.NotNil(t, coretest.FindInstance(devices, "identity", info), info)
+	}
+	sysName := coretest.FindInstance(devices, "identity", "sysName")
+	assert.Equal(t, ".1.3.6.1.2.1.1.5.0", sysName.Data["oid"])
+	assert.Equal(t, "SNMPv2-MIB-System-Table", sysName.Data["table_name"])
+
+	sysUpTime := coretest.FindInstance(devices, "uptime", "sysUpTime")
+	assert.NotNil(t, sysUpTime)
+	assert.Equal(t, ".1.3.6.1.2.1.1.3.0", sysUpTime.Data["oid"])
+	result, err := snmpv2Mib.SystemTable.SnmpServerBase.SnmpClient.Get(".1.3.6.1.2.1.1.3.0")
+	assert.NoError(t, err)
+	ticks, err := core.ToTicks(result.Data)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(6930266), ticks)
+
+	oid, err := core.OidNames.Oid("SNMPv2-MIB::sysUpTime")
+	assert.NoError(t, err)
+	assert.Equal(t, ".1.3.6.1.2.1.1.3", oid)
+}
+
+// TestSnmpV2MibTripplite tests the system group devices of the Tripp Lite
+// UPS walk, which reports sysUpTime as TimeTicks.
+func TestSnmpV2MibTripplite(t *testing.T) {
+	snmpv2Mib, err := NewSnmpV2Mib(coretest.NewReplayServerBase(t, "../../../../emulator/ups/tripplite_ups/data/public.snmpwalk"))
+	assert.NoError(t, err)
+
+	devices, err := snmpv2Mib.EnumerateDevices(map[string]interface{}{})
+	assert.NoError(t, err)
+	sysUpTime := coretest.FindInstance(devices, "uptime", "sysUpTime")
+	assert.NotNil(t, sysUpTime)
+	result, err := snmpv2Mib.SystemTable.SnmpServerBase.SnmpClient.Get(sysUpTime.Data["oid"].(string))
+	assert.NoError(t, err)
+	assert.IsType(t, uint32(0), result.Data)
+	assert.NotNil(t, coretest.FindInstance(devices, "identity", "sysObjectID"))
+}
+
+// TestNewSnmpV2MibNilServer tests that the server is required.
+func TestNewSnmpV2MibNilServer(t *testing.T) {
+	_, err := NewSnmpV2Mib(nil)
+	assert.Error(t, err)
+}
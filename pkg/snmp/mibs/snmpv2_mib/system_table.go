@@ -0,0 +1,153 @@
+package snmpv2mib
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vapor-ware/synse-sdk/sdk/config"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// SystemTable represents SNMP OID .1.3.6.1.2.1.1, the system group.
+type SystemTable struct {
+	*core.SnmpTable // base class
+}
+
+// NewSystemTable constructs the SystemTable.
+func NewSystemTable(snmpServerBase *core.SnmpServerBase) (table *SystemTable, err error) {
+	var tableName = "SNMPv2-MIB-System-Table"
+	var walkOid = ".1.3.6.1.2.1.1"
+
+	log.WithFields(log.Fields{
+		"name": tableName,
+		"oid":  walkOid,
+	}).Debug("[snmp] creating new table")
+
+	// Initialize the base.
+	snmpTable, err := core.NewSnmpTable(
+		tableName,
+		walkOid,
+		[]string{ // Column Names
+			"sysDescr",        // Description of the agent, e.g. hardware and software versions.
+			"sysObjectID",     // Vendor identification of the agent.
+			"sysUpTime",       // TimeTicks since the agent was last re-initialized.
+			"sysContact",      // Contact person for the agent.
+			"sysName",         // Administratively assigned name, usually the FQDN.
+			"sysLocation",     // Physical location of the agent.
+			"sysServices",     // Bit field of the services offered.
+			"sysORLastChange", // sysUpTime at the last change to the sysORTable.
+		},
+		snmpServerBase, // snmpServer
+		"",             // rowBase
+		"",             // indexColumn
+		"",             // readableColumn
+		true,           // flattened table
+	)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error": err,
+			"table": tableName,
+		}).Error("[snmp] failed to create table")
+		return nil, err
+	}
+
+	table = &SystemTable{SnmpTable: snmpTable}
+	table.DevEnumerator = SystemTableDeviceEnumerator{table}
+	return table, nil
+}
+
+// systemDevices are the device type for each column of the system group
+// which is a device, by column number.
+var systemDevices = []struct {
+	column     int
+	deviceType string
+}{
+	{1, "identity"}, // sysDescr
+	{2, "identity"}, // sysObjectID
+	{3, "uptime"},   // sysUpTime
+	{4, "identity"}, // sysContact
+	{5, "identity"}, // sysName
+	{6, "identity"}, // sysLocation
+}
+
+// SystemTableDeviceEnumerator overrides the default SnmpTable device
+// enumerator for the system table.
+type SystemTableDeviceEnumerator struct {
+	Table *SystemTable // Pointer back to the table.
+}
+
+// DeviceEnumerator overrides the default SnmpTable device enumerator.
+func (enumerator SystemTableDeviceEnumerator) DeviceEnumerator(
+	data map[string]interface{}) (devices []*config.DeviceProto, err error) {
+
+	// Pull out the table and SNMP DeviceConfig.
+	table := enumerator.Table
+
+	// This is a single row table. If there are no rows, the agent is not
+	// serving the system group and there are no devices to create.
+	if len(table.Rows) == 0 {
+		log.WithFields(log.Fields{
+			"table": table.Name,
+			"oid":   table.WalkOid,
+		}).Warn("[snmp] table has no rows, will not create any devices for it")
+		return
+	}
+
+	snmpDeviceConfigMap, err := table.SnmpServerBase.DeviceConfig.ToMap()
+	if err != nil {
+		return nil, err
+	}
+
+	// We will have "identity" and "uptime" device kinds.
+	identityProto := &config.DeviceProto{
+		Type:      "identity",
+		Instances: []*config.DeviceInstance{},
+		Tags:      snmpDeviceConfigMap["deviceTags"].([]string),
+	}
+
+	uptimeProto := &config.DeviceProto{
+		Type:      "uptime",
+		Instances: []*config.DeviceInstance{},
+		Tags:      snmpDeviceConfigMap["deviceTags"].([]string),
+	}
+
+	protos := map[string]*config.DeviceProto{
+		"identity": identityProto,
+		"uptime":   uptimeProto,
+	}
+
+	// This is always a single row table.
+	row := table.Rows[0]
+	for _, systemDevice := range systemDevices {
+		// Agents may leave out objects. There is nothing to read for these.
+		if row.RowData[systemDevice.column-1].Data == nil {
+			continue
+		}
+
+		// deviceData gets shimmed into the DeviceConfig for each synse device.
+		deviceData := map[string]interface{}{
+			"base_oid":   row.BaseOid,
+			"table_name": table.Name,
+			"row":        "0",
+			"column":     fmt.Sprint(systemDevice.column),
+			"oid":        fmt.Sprintf(row.BaseOid, systemDevice.column), // base_oid and integer column.
+		}
+		deviceData, err = core.MergeMapStringInterface(snmpDeviceConfigMap, deviceData)
+		if err != nil {
+			return nil, err
+		}
+
+		proto := protos[systemDevice.deviceType]
+		proto.Instances = append(proto.Instances, &config.DeviceInstance{
+			Info: table.ColumnList[systemDevice.column-1],
+			Data: deviceData,
+		})
+	}
+
+	for _, proto := range []*config.DeviceProto{identityProto, uptimeProto} {
+		if len(proto.Instances) > 0 {
+			devices = append(devices, proto)
+		}
+	}
+	return devices, nil
+}
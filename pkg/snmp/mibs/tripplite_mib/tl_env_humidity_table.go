@@ -0,0 +1,22 @@
+package tripplitemib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// humidityTable is the definition of SNMP OID .1.3.6.1.4.1.850.101.1.2, the
+// humidity of the EnviroSense probe.
+var humidityTable = &core.TableDefinition{
+	Name:      "TRIPPLITE-PRODUCTS-EnvHumidity-Table",
+	WalkOid:   ".1.3.6.1.4.1.850.101.1.2",
+	Flattened: true,
+	Columns: []*core.ColumnDefinition{
+		{Name: "tlEnvHumidity"},          // Percent relative humidity.
+		{Name: "tlEnvHumidityLowLimit"},  // Percent relative humidity.
+		{Name: "tlEnvHumidityHighLimit"}, // Percent relative humidity.
+	},
+}
+
+// humidityDevices are the devices of the probe humidity. The limits are the
+// thresholds of the device. See core.AddLimits.
+var humidityDevices = []core.ColumnDevice{
+	{Column: 1, DeviceType: "humidity", LowLimit: 2, HighLimit: 3}, // tlEnvHumidity
+}
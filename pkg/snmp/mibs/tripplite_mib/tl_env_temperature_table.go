@@ -0,0 +1,23 @@
+package tripplitemib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// temperatureTable is the definition of SNMP OID .1.3.6.1.4.1.850.101.1.1, the
+// temperature of the EnviroSense probe.
+var temperatureTable = &core.TableDefinition{
+	Name:      "TRIPPLITE-PRODUCTS-EnvTemperature-Table",
+	WalkOid:   ".1.3.6.1.4.1.850.101.1.1",
+	Flattened: true,
+	Columns: []*core.ColumnDefinition{
+		{Name: "tlEnvTemperatureF"},         // Degrees F
+		{Name: "tlEnvTemperatureC"},         // Degrees C
+		{Name: "tlEnvTemperatureLowLimit"},  // Degrees F
+		{Name: "tlEnvTemperatureHighLimit"}, // Degrees F
+	},
+}
+
+// temperatureDevices are the devices of the probe temperature. The limits
+// are in degrees F, so they are not the thresholds of the device.
+var temperatureDevices = []core.ColumnDevice{
+	{Column: 2, DeviceType: "temperature"}, // tlEnvTemperatureC
+}
@@ -0,0 +1,20 @@
+package tripplitemib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// batteryTable is the definition of SNMP OID .1.3.6.1.4.1.850.100.1.2, the
+// battery group.
+var batteryTable = &core.TableDefinition{
+	Name:      "TRIPPLITE-PRODUCTS-Battery-Table",
+	WalkOid:   ".1.3.6.1.4.1.850.100.1.2",
+	Flattened: true,
+	Columns: []*core.ColumnDefinition{
+		{Name: "tlUpsBatteryAge"},   // Months since the batteries were installed.
+		{Name: "tlUpsTemperatureF"}, // Degrees F. UPS-MIB has the temperature in degrees C.
+	},
+}
+
+// batteryDevices are the devices of the battery group.
+var batteryDevices = []core.ColumnDevice{
+	{Column: 1, DeviceType: "status"}, // tlUpsBatteryAge
+}
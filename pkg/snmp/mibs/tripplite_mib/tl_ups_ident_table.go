@@ -0,0 +1,24 @@
+package tripplitemib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// identTable is the definition of SNMP OID .1.3.6.1.4.1.850.100.1.1, the
+// identity group of the UPS and its SNMPWEBCARD.
+var identTable = &core.TableDefinition{
+	Name:      "TRIPPLITE-PRODUCTS-Ident-Table",
+	WalkOid:   ".1.3.6.1.4.1.850.100.1.1",
+	Flattened: true,
+	Columns: []*core.ColumnDefinition{
+		{Name: "tlUpsIdentUpsSoftwareChecksum"}, // Checksum of the UPS firmware.
+		{Name: "tlUpsIdentSerialNum"},           // UPS serial number, empty when not set.
+		{Name: "tlUpsIdentID"},                  // User assigned UPS identifier.
+		{Name: "tlUpsSnmpCardSerialNum"},        // SNMPWEBCARD serial number.
+	},
+}
+
+// identDevices are the devices of the identity group.
+var identDevices = []core.ColumnDevice{
+	{Column: 2, DeviceType: "identity"}, // tlUpsIdentSerialNum
+	{Column: 3, DeviceType: "identity"}, // tlUpsIdentID
+	{Column: 4, DeviceType: "identity"}, // tlUpsSnmpCardSerialNum
+}
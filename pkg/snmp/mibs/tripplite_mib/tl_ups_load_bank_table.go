@@ -0,0 +1,28 @@
+package tripplitemib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// loadBankTable is the definition of SNMP OID .1.3.6.1.4.1.850.100.1.4.7, the
+// load banks, which are switched as a whole.
+var loadBankTable = &core.TableDefinition{
+	Name:        "TRIPPLITE-PRODUCTS-tlUpsLoadBankTable",
+	WalkOid:     ".1.3.6.1.4.1.850.100.1.4.7",
+	RowBase:     "1",
+	IndexColumn: "1",
+	Index:       []core.IndexComponent{{Name: "tlUpsLoadBankIndex", Type: core.IndexInteger}},
+	Columns: []*core.ColumnDefinition{
+		{Name: "tlUpsLoadBankIndex"},   // Index of the load bank.
+		{Name: "tlUpsLoadBankState"},   // State of the load bank, e.g. on(1).
+		{Name: "tlUpsLoadBankCommand"}, // Command to switch the load bank, e.g. turnOff(2).
+	},
+}
+
+// loadBankDevices are the devices of each load bank.
+var loadBankDevices = []core.ColumnDevice{
+	{
+		Column:      2, // tlUpsLoadBankState
+		DeviceType:  "outlet",
+		Enumeration: outletState,
+		Actions:     outletActions(3), // tlUpsLoadBankCommand
+	},
+}
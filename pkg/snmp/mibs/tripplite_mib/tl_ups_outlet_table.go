@@ -0,0 +1,31 @@
+package tripplitemib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// outletTable is the definition of SNMP OID .1.3.6.1.4.1.850.100.1.10.2, the
+// outlets. The number of rows is tlUpsOutletNumOutlets,
+// .1.3.6.1.4.1.850.100.1.10.1.
+var outletTable = &core.TableDefinition{
+	Name:        "TRIPPLITE-PRODUCTS-tlUpsOutletTable",
+	WalkOid:     ".1.3.6.1.4.1.850.100.1.10.2",
+	RowBase:     "1",
+	IndexColumn: "1",
+	Index:       []core.IndexComponent{{Name: "tlUpsOutletIndex", Type: core.IndexInteger}},
+	Columns: []*core.ColumnDefinition{
+		{Name: "tlUpsOutletIndex"},    // Index of the outlet.
+		{Name: "tlUpsOutletName"},     // Name of the outlet.
+		{Name: "tlUpsOutletState"},    // State of the outlet, e.g. on(1).
+		{Name: "tlUpsOutletCommand"},  // Command to switch the outlet, e.g. turnOff(2).
+		{Name: "tlUpsOutletLoadBank"}, // Index of the load bank of the outlet.
+	},
+}
+
+// outletDevices are the devices of each outlet.
+var outletDevices = []core.ColumnDevice{
+	{
+		Column:      3, // tlUpsOutletState
+		DeviceType:  "outlet",
+		Enumeration: outletState,
+		Actions:     outletActions(4), // tlUpsOutletCommand
+	},
+}
@@ -0,0 +1,147 @@
+package tripplitemib
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// MibName is the name TRIPPLITE-PRODUCTS is registered with. See
+// core.RegisterMib.
+const MibName = "TRIPPLITE-PRODUCTS"
+
+func init() {
+	err := core.RegisterMib(MibName, func(server *core.SnmpServerBase) (core.Mib, error) {
+		trippliteMib, err := NewTrippliteMib(server)
+		if err != nil {
+			return nil, err
+		}
+		return trippliteMib, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	// Tripp Lite agents do not fill in the sysORTable. Their sysObjectID is
+	// tlUpsObjects, and the tlUpsIdent group is served without the .0
+	// instance, so it is walked.
+	err = core.RegisterMibDetection(MibName, core.MibDetection{
+		SysObjectIDs: []string{".1.3.6.1.4.1.850"},
+		ProbeOids:    []string{".1.3.6.1.4.1.850.100.1.1"},
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// TrippliteMib is the class for the Tripp Lite TRIPPLITE-PRODUCTS MIB, served
+// by the SNMPWEBCARD. It covers what the generic UPS-MIB does not: the UPS and
+// card serial numbers, the battery age, the load banks and outlets, which may
+// be switched, and the EnviroSense probe.
+type TrippliteMib struct {
+	*core.SnmpMib // base class
+
+	// Tables defined in this MIB
+	TlUpsIdentTable       *core.SnmpTable
+	TlUpsBatteryTable     *core.SnmpTable
+	TlUpsLoadBankTable    *core.SnmpTable
+	TlUpsOutletTable      *core.SnmpTable
+	TlEnvTemperatureTable *core.SnmpTable
+	TlEnvHumidityTable    *core.SnmpTable
+}
+
+// NewTrippliteMib constructs the TrippliteMib.
+func NewTrippliteMib(server *core.SnmpServerBase) (trippliteMib *TrippliteMib, err error) {
+	log.Debugf("[snmp] initializing TrippliteMib")
+
+	// Arg checks.
+	if server == nil {
+		return nil, fmt.Errorf("unable to create new TrippliteMib: server is nil")
+	}
+
+	// Initialize Tables.
+	trippliteMib = &TrippliteMib{}
+	for _, table := range []struct {
+		table      **core.SnmpTable
+		definition *core.TableDefinition
+		devices    []core.ColumnDevice
+	}{
+		{&trippliteMib.TlUpsIdentTable, identTable, identDevices},
+		{&trippliteMib.TlUpsBatteryTable, batteryTable, batteryDevices},
+		{&trippliteMib.TlUpsLoadBankTable, loadBankTable, loadBankDevices},
+		{&trippliteMib.TlUpsOutletTable, outletTable, outletDevices},
+		{&trippliteMib.TlEnvTemperatureTable, temperatureTable, temperatureDevices},
+		{&trippliteMib.TlEnvHumidityTable, humidityTable, humidityDevices},
+	} {
+		*table.table, err = core.NewColumnTable(table.definition, server, enumeration(table.devices))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Initialize the base class.
+	snmpMib, err := core.NewSnmpMib(MibName, []*core.SnmpTable{
+		trippliteMib.TlUpsIdentTable,
+		trippliteMib.TlUpsBatteryTable,
+		trippliteMib.TlUpsLoadBankTable,
+		trippliteMib.TlUpsOutletTable,
+		trippliteMib.TlEnvTemperatureTable,
+		trippliteMib.TlEnvHumidityTable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	snmpMib.RegisterNames(MibName)
+	trippliteMib.SnmpMib = snmpMib
+
+	// Update mib pointer for each table.
+	for _, table := range trippliteMib.Tables {
+		table.Mib = trippliteMib
+	}
+
+	log.Debugf("Initialized TrippliteMib")
+	return trippliteMib, nil
+}
+
+// outletState enumerates the state of load banks and outlets.
+var outletState = map[int]string{
+	0: "unknown",
+	1: "on",
+	2: "off",
+}
+
+// outletActions are the commands which switch load banks and outlets, set on
+// the command column. See devices.SnmpOutlet for how writes are guarded.
+func outletActions(commandColumn int) map[string]core.ColumnAction {
+	return map[string]core.ColumnAction{
+		"on":    {Column: commandColumn, Value: 1}, // turnOn
+		"off":   {Column: commandColumn, Value: 2}, // turnOff
+		"cycle": {Column: commandColumn, Value: 3}, // cycle
+	}
+}
+
+// served is false for objects the agent does not serve. Tripp Lite agents
+// serve empty strings for objects they do not know, e.g. an unset serial
+// number, which are not served either.
+func served(data interface{}) bool {
+	switch value := data.(type) {
+	case nil:
+		return false
+	case string:
+		return strings.TrimSpace(value) != ""
+	}
+	return true
+}
+
+// enumeration gets the devices for the columns of a table. See
+// core.NewColumnTable.
+func enumeration(columnDevices []core.ColumnDevice) func(table *core.SnmpTable) core.ColumnEnumeration {
+	return func(table *core.SnmpTable) core.ColumnEnumeration {
+		return core.ColumnEnumeration{
+			Served:  served,
+			Devices: columnDevices,
+		}
+	}
+}
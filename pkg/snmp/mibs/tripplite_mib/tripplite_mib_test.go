@@ -0,0 +1,80 @@
+package tripplitemib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core/coretest"
+)
+
+// TestTrippliteMib tests the TRIPPLITE-PRODUCTS devices of the Tripp Lite
+// UPS walk.
+func TestTrippliteMib(t *testing.T) {
+	trippliteMib, err := NewTrippliteMib(coretest.NewReplayServerBase(t, "../../../../emulator/ups/tripplite_ups/data/public.snmpwalk"))
+	assert.NoError(t, err)
+	assert.Equal(t, MibName, trippliteMib.Name)
+
+	devices, err := trippliteMib.EnumerateDevices(map[string]interface{}{})
+	assert.NoError(t, err)
+
+	// The scalars are served without the .0 instance. The UPS serial number
+	// is empty, so only the card has one.
+	cardSerial := coretest.FindInstance(devices, "identity", "tlUpsSnmpCardSerialNum")
+	assert.NotNil(t, cardSerial)
+	assert.Equal(t, ".1.3.6.1.4.1.850.100.1.1.4", cardSerial.Data["oid"])
+	assert.Equal(t, 1, coretest.CountInstances(devices, "identity"))
+
+	// Three load banks, which may be switched.
+	assert.Equal(t, 3, coretest.CountInstances(devices, "outlet"))
+	loadBank := coretest.FindInstance(devices, "outlet", "tlUpsLoadBankState 2")
+	assert.NotNil(t, loadBank)
+	assert.Equal(t, ".1.3.6.1.4.1.850.100.1.4.7.1.2.2", loadBank.Data["oid"])
+	assert.Equal(t, "on", loadBank.Data["enumeration1"])
+	assert.Equal(t, ".1.3.6.1.4.1.850.100.1.4.7.1.3.2", loadBank.Data["action_oid_off"])
+	assert.Equal(t, "2", loadBank.Data["action_value_off"])
+	assert.Equal(t, map[string]string{"index": "2", "tlUpsLoadBankIndex": "2"}, loadBank.Context)
+
+	// No outlets, battery age or probe.
+	assert.Len(t, trippliteMib.TlUpsOutletTable.Rows, 0)
+	assert.Nil(t, coretest.FindInstance(devices, "status", "tlUpsBatteryAge"))
+	assert.Equal(t, 0, coretest.CountInstances(devices, "temperature"))
+}
+
+// TestTrippliteMibOutlets tests the devices of a card with switchable
+// outlets and an EnviroSense probe.
+func TestTrippliteMibOutlets(t *testing.T) {
+	trippliteMib, err := NewTrippliteMib(coretest.NewReplayServerBase(t, "testdata/tripplite.snmpwalk"))
+	assert.NoError(t, err)
+
+	devices, err := trippliteMib.EnumerateDevices(map[string]interface{}{})
+	assert.NoError(t, err)
+
+	serial := coretest.FindInstance(devices, "identity", "tlUpsIdentSerialNum")
+	assert.NotNil(t, serial)
+	assert.Equal(t, ".1.3.6.1.4.1.850.100.1.1.2.0", serial.Data["oid"])
+	assert.Nil(t, coretest.FindInstance(devices, "identity", "tlUpsIdentID"))
+	assert.NotNil(t, coretest.FindInstance(devices, "status", "tlUpsBatteryAge"))
+
+	assert.Equal(t, 6, coretest.CountInstances(devices, "outlet"))
+	outlet := coretest.FindInstance(devices, "outlet", "tlUpsOutletState 3")
+	assert.NotNil(t, outlet)
+	assert.Equal(t, ".1.3.6.1.4.1.850.100.1.10.2.1.3.3", outlet.Data["oid"])
+	assert.Equal(t, "off", outlet.Data["enumeration2"])
+	assert.Equal(t, ".1.3.6.1.4.1.850.100.1.10.2.1.4.3", outlet.Data["action_oid_on"])
+	assert.Equal(t, "1", outlet.Data["action_value_on"])
+	assert.Equal(t, "3", outlet.Data["action_value_cycle"])
+	assert.Equal(t, map[string]string{"index": "3", "tlUpsOutletIndex": "3"}, outlet.Context)
+	// Outlet control is not enabled by the MIB.
+	assert.NotContains(t, outlet.Data, "outlet_control")
+
+	temperature := coretest.FindInstance(devices, "temperature", "tlEnvTemperatureC")
+	assert.NotNil(t, temperature)
+	assert.Equal(t, ".1.3.6.1.4.1.850.101.1.1.2.0", temperature.Data["oid"])
+	assert.Equal(t, 1, coretest.CountInstances(devices, "temperature"))
+	assert.NotContains(t, temperature.Data, "limit_low")
+	humidity := coretest.FindInstance(devices, "humidity", "tlEnvHumidity")
+	assert.NotNil(t, humidity)
+	assert.Equal(t, ".1.3.6.1.4.1.850.101.1.2.1.0", humidity.Data["oid"])
+	assert.Equal(t, 20, humidity.Data["limit_low"])
+	assert.Equal(t, 80, humidity.Data["limit_high"])
+}
@@ -0,0 +1,49 @@
+package uiomib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// inputContactStatusTable is the definition of SNMP OID
+// .1.3.6.1.4.1.318.1.1.25.2.2, the state of the dry contact inputs.
+var inputContactStatusTable = &core.TableDefinition{
+	Name:        "PowerNet-MIB-uioInputContactStatusTable",
+	WalkOid:     uioOid + ".2.2",
+	RowBase:     "1",
+	IndexColumn: "1",
+	Index: []core.IndexComponent{
+		{Name: "uioInputContactStatusPortID", Type: core.IndexInteger},
+		{Name: "uioInputContactStatusContactID", Type: core.IndexInteger},
+	},
+	Columns: []*core.ColumnDefinition{
+		{Name: "uioInputContactStatusPortID"},          // Universal I/O port of the contact.
+		{Name: "uioInputContactStatusContactID"},       // Contact on the port.
+		{Name: "uioInputContactStatusContactName"},     // User assigned name of the contact.
+		{Name: "uioInputContactStatusContactLocation"}, // User assigned location of the contact.
+		{Name: "uioInputContactStatusCurrentState"},    // e.g. uioInputClosed(1).
+		{Name: "uioInputContactStatusAlarmStatus"},     // e.g. uioNormal(1).
+		{Name: "uioInputContactStatusCommStatus"},      // e.g. commsOK(2).
+	},
+}
+
+// contactState enumerates uioInputContactStatusCurrentState.
+var contactState = map[int]string{
+	1: "uioInputClosed",
+	2: "uioInputOpen",
+	3: "uioInputDisabled",
+	4: "uioInputStateNotApplicable",
+}
+
+// contactAlarmStatus enumerates uioInputContactStatusAlarmStatus, the state
+// of the contact against its configured normal state.
+var contactAlarmStatus = map[int]string{
+	1: "uioNormal",
+	2: "uioWarning",
+	3: "uioCritical",
+	4: "inputStatusNotApplicable",
+}
+
+// inputContactStatusDevices are the devices of each contact.
+var inputContactStatusDevices = []core.ColumnDevice{
+	{Column: 5, DeviceType: "status", Enumeration: contactState},       // uioInputContactStatusCurrentState
+	{Column: 6, DeviceType: "status", Enumeration: contactAlarmStatus}, // uioInputContactStatusAlarmStatus
+	{Column: 7, DeviceType: "status", Enumeration: commStatus},         // uioInputContactStatusCommStatus
+}
@@ -0,0 +1,131 @@
+package uiomib
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// MibName is the name the uio subtree of PowerNet-MIB is registered with.
+// See core.RegisterMib.
+const MibName = "PowerNet-MIB-uio"
+
+// powerNetModule is the MIB module which defines the subtree. The column
+// names are registered with it.
+const powerNetModule = "PowerNet-MIB"
+
+// uioOid is the uio (universal I/O) subtree of PowerNet-MIB.
+const uioOid = ".1.3.6.1.4.1.318.1.1.25"
+
+func init() {
+	err := core.RegisterMib(MibName, func(server *core.SnmpServerBase) (core.Mib, error) {
+		uioMib, err := NewUioMib(server)
+		if err != nil {
+			return nil, err
+		}
+		return uioMib, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	// APC agents do not fill in the sysORTable, and the universal I/O ports
+	// are on the network cards of many products. Agents with probes or
+	// contacts attached have rows in the status tables.
+	err = core.RegisterMibDetection(MibName, core.MibDetection{
+		ProbeOids: []string{uioOid + ".1.2", uioOid + ".2.2"},
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// UioMib is the class for the uio subtree of the APC (Schneider)
+// PowerNet-MIB. It covers the temperature and humidity probes, e.g. the
+// AP9335TH, and the dry contact inputs, e.g. the AP9810, attached to the
+// universal I/O ports of APC network management cards and NetBotz rack
+// monitors. Rows are indexed by port and by sensor or contact on the port.
+type UioMib struct {
+	*core.SnmpMib // base class
+
+	// Tables defined in this MIB
+	UioSensorStatusTable       *core.SnmpTable
+	UioSensorConfigTable       *core.SnmpTable
+	UioInputContactStatusTable *core.SnmpTable
+}
+
+// NewUioMib constructs the UioMib.
+func NewUioMib(server *core.SnmpServerBase) (uioMib *UioMib, err error) {
+	log.Debugf("[snmp] initializing UioMib")
+
+	// Arg checks.
+	if server == nil {
+		return nil, fmt.Errorf("unable to create new UioMib: server is nil")
+	}
+
+	// Initialize Tables.
+	uioMib = &UioMib{}
+	uioMib.UioSensorStatusTable, err = core.NewColumnTable(sensorStatusTable, server,
+		enumeration(3, sensorConfigTable.Name, sensorStatusDevices))
+	if err != nil {
+		return nil, err
+	}
+	uioMib.UioSensorConfigTable, err = core.NewTable(sensorConfigTable, server)
+	if err != nil {
+		return nil, err
+	}
+	// The thresholds of each probe are in the config table.
+	err = uioMib.UioSensorStatusTable.Join(sensorConfigTable.Name, uioMib.UioSensorConfigTable)
+	if err != nil {
+		return nil, err
+	}
+	uioMib.UioInputContactStatusTable, err = core.NewColumnTable(inputContactStatusTable, server,
+		enumeration(3, "", inputContactStatusDevices))
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize the base class.
+	snmpMib, err := core.NewSnmpMib(MibName, []*core.SnmpTable{
+		uioMib.UioSensorStatusTable,
+		uioMib.UioSensorConfigTable,
+		uioMib.UioInputContactStatusTable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	snmpMib.RegisterNames(powerNetModule)
+	uioMib.SnmpMib = snmpMib
+
+	// Update mib pointer for each table.
+	for _, table := range uioMib.Tables {
+		table.Mib = uioMib
+	}
+
+	log.Debugf("Initialized UioMib")
+	return uioMib, nil
+}
+
+// commStatus enumerates the communication status of a probe or contact.
+var commStatus = map[int]string{
+	1: "notInstalled",
+	2: "commsOK",
+	3: "commsLost",
+}
+
+// enumeration gets the devices for the columns of a table. The name column is
+// added to the context of the devices as the name, e.g. the probe name. The
+// limits are from the row of the joined limits table with the same index, if
+// one is named. See core.NewColumnTable.
+func enumeration(nameColumn int, limitsTable string, columnDevices []core.ColumnDevice) func(
+	table *core.SnmpTable) core.ColumnEnumeration {
+
+	return func(table *core.SnmpTable) core.ColumnEnumeration {
+		return core.ColumnEnumeration{
+			NameColumn:  nameColumn,
+			LimitsTable: limitsTable,
+			Devices:     columnDevices,
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package uiomib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core/coretest"
+)
+
+// TestUioMib tests the uio devices of a temperature and humidity probe, a
+// temperature only probe and two dry contacts.
+func TestUioMib(t *testing.T) {
+	uioMib, err := NewUioMib(coretest.NewReplayServerBase(t, "testdata/uio.snmpwalk"))
+	assert.NoError(t, err)
+	assert.Equal(t, MibName, uioMib.Name)
+
+	devices, err := uioMib.EnumerateDevices(map[string]interface{}{})
+	assert.NoError(t, err)
+
+	// Probes.
+	assert.Equal(t, 2, coretest.CountInstances(devices, "temperature"))
+	temperature := coretest.FindInstance(devices, "temperature", "uioSensorStatusTemperatureDegC 1.1")
+	assert.NotNil(t, temperature)
+	assert.Equal(t, ".1.3.6.1.4.1.318.1.1.25.1.2.1.6.1.1", temperature.Data["oid"])
+	assert.Equal(t, map[string]string{
+		"index":                   "1.1",
+		"uioSensorStatusPortID":   "1",
+		"uioSensorStatusSensorID": "1",
+		"name":                    "Rack A12 Front",
+	}, temperature.Context)
+	// The temperature thresholds are not in degrees C.
+	assert.NotContains(t, temperature.Data, "limit_low")
+	assert.NotContains(t, temperature.Data, "limit_high")
+
+	// The rear probe has no humidity sensor.
+	assert.Equal(t, 1, coretest.CountInstances(devices, "humidity"))
+	humidity := coretest.FindInstance(devices, "humidity", "uioSensorStatusHumidity 1.1")
+	assert.NotNil(t, humidity)
+	assert.Equal(t, ".1.3.6.1.4.1.318.1.1.25.1.2.1.7.1.1", humidity.Data["oid"])
+	assert.Equal(t, 20, humidity.Data["limit_low"])
+	assert.Equal(t, 80, humidity.Data["limit_high"])
+	assert.Nil(t, coretest.FindInstance(devices, "humidity", "uioSensorStatusHumidity 3.1"))
+
+	alarm := coretest.FindInstance(devices, "status", "uioSensorStatusAlarmStatus 3.1")
+	assert.NotNil(t, alarm)
+	assert.Equal(t, "true", alarm.Data["enumeration"])
+	assert.Equal(t, "uioWarning", alarm.Data["enumeration2"])
+	assert.NotNil(t, coretest.FindInstance(devices, "status", "uioSensorStatusCommStatus 1.1"))
+
+	// Contacts.
+	state := coretest.FindInstance(devices, "status", "uioInputContactStatusCurrentState 2.2")
+	assert.NotNil(t, state)
+	assert.Equal(t, ".1.3.6.1.4.1.318.1.1.25.2.2.1.5.2.2", state.Data["oid"])
+	assert.Equal(t, "uioInputOpen", state.Data["enumeration2"])
+	assert.Equal(t, "Rack A12 Rear Door", state.Context["name"])
+	assert.Equal(t, "2", state.Context["uioInputContactStatusContactID"])
+	assert.NotNil(t, coretest.FindInstance(devices, "status", "uioInputContactStatusAlarmStatus 2.1"))
+
+	// Four probe and six contact status devices.
+	assert.Equal(t, 10, coretest.CountInstances(devices, "status"))
+}
+
+// TestUioMibNoPorts tests an agent with nothing attached to its universal
+// I/O ports.
+func TestUioMibNoPorts(t *testing.T) {
+	uioMib, err := NewUioMib(coretest.NewReplayServerBase(t, "../rpdu2_mib/testdata/rpdu2.snmpwalk"))
+	assert.NoError(t, err)
+
+	devices, err := uioMib.EnumerateDevices(map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Len(t, devices, 0)
+}
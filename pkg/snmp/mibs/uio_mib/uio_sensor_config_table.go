@@ -0,0 +1,42 @@
+package uiomib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// sensorConfigTable is the definition of SNMP OID .1.3.6.1.4.1.318.1.1.25.1.3,
+// the thresholds of the temperature and humidity probes. It has no devices of
+// its own. It is joined to the uioSensorStatusTable for the thresholds of the
+// devices.
+var sensorConfigTable = &core.TableDefinition{
+	Name:        "PowerNet-MIB-uioSensorConfigTable",
+	WalkOid:     uioOid + ".1.3",
+	RowBase:     "1",
+	IndexColumn: "1",
+	Index: []core.IndexComponent{
+		{Name: "uioSensorConfigPortID", Type: core.IndexInteger},
+		{Name: "uioSensorConfigSensorID", Type: core.IndexInteger},
+	},
+	Columns: []*core.ColumnDefinition{
+		{Name: "uioSensorConfigPortID"},                  // Universal I/O port of the probe.
+		{Name: "uioSensorConfigSensorID"},                // Probe on the port.
+		{Name: "uioSensorConfigSensorName"},              // User assigned name of the probe.
+		{Name: "uioSensorConfigSensorLocation"},          // User assigned location of the probe.
+		{Name: "uioSensorConfigMinTemperatureThreshold"}, // Critical, in the configured unit.
+		{Name: "uioSensorConfigLowTemperatureThreshold"}, // Warning, in the configured unit.
+		{Name: "uioSensorConfigHighTemperatureThreshold"},
+		{Name: "uioSensorConfigMaxTemperatureThreshold"},
+		{Name: "uioSensorConfigTemperatureHysteresis"},
+		{Name: "uioSensorConfigMinTemperatureEnable"}, // enabled(2) if the threshold alarms.
+		{Name: "uioSensorConfigLowTemperatureEnable"},
+		{Name: "uioSensorConfigHighTemperatureEnable"},
+		{Name: "uioSensorConfigMaxTemperatureEnable"},
+		{Name: "uioSensorConfigMinHumidityThreshold"}, // Critical, percent relative humidity.
+		{Name: "uioSensorConfigLowHumidityThreshold"}, // Warning, percent relative humidity.
+		{Name: "uioSensorConfigHighHumidityThreshold"},
+		{Name: "uioSensorConfigMaxHumidityThreshold"},
+		{Name: "uioSensorConfigHumidityHysteresis"},
+		{Name: "uioSensorConfigMinHumidityEnable"},
+		{Name: "uioSensorConfigLowHumidityEnable"},
+		{Name: "uioSensorConfigHighHumidityEnable"},
+		{Name: "uioSensorConfigMaxHumidityEnable"},
+	},
+}
@@ -0,0 +1,54 @@
+package uiomib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// sensorStatusTable is the definition of SNMP OID .1.3.6.1.4.1.318.1.1.25.1.2,
+// the readings of the temperature and humidity probes.
+var sensorStatusTable = &core.TableDefinition{
+	Name:        "PowerNet-MIB-uioSensorStatusTable",
+	WalkOid:     uioOid + ".1.2",
+	RowBase:     "1",
+	IndexColumn: "1",
+	Index: []core.IndexComponent{
+		{Name: "uioSensorStatusPortID", Type: core.IndexInteger},
+		{Name: "uioSensorStatusSensorID", Type: core.IndexInteger},
+	},
+	Columns: []*core.ColumnDefinition{
+		{Name: "uioSensorStatusPortID"},          // Universal I/O port of the probe.
+		{Name: "uioSensorStatusSensorID"},        // Probe on the port.
+		{Name: "uioSensorStatusSensorName"},      // User assigned name of the probe.
+		{Name: "uioSensorStatusSensorLocation"},  // User assigned location of the probe.
+		{Name: "uioSensorStatusTemperatureDegF"}, // Degrees F
+		{Name: "uioSensorStatusTemperatureDegC"}, // Degrees C
+		{Name: "uioSensorStatusHumidity"},        // Percent relative humidity, or -1 without a humidity sensor.
+		{Name: "uioSensorStatusViolationStatus"}, // Bit mask of the thresholds violated.
+		{Name: "uioSensorStatusAlarmStatus"},     // e.g. uioNormal(1).
+		{Name: "uioSensorStatusCommStatus"},      // e.g. commsOK(2).
+	},
+}
+
+// sensorAlarmStatus enumerates uioSensorStatusAlarmStatus, the state of the
+// probe against its thresholds.
+var sensorAlarmStatus = map[int]string{
+	1: "uioNormal",
+	2: "uioWarning",
+	3: "uioCritical",
+	4: "sensorStatusNotApplicable",
+}
+
+// sensorStatusDevices are the devices of each probe. The high and low
+// humidity thresholds of the probe are the thresholds of the humidity device.
+// The temperature thresholds are in the temperature unit configured on the
+// card, so they are not the thresholds of the temperature device.
+var sensorStatusDevices = []core.ColumnDevice{
+	{Column: 6, DeviceType: "temperature"}, // uioSensorStatusTemperatureDegC
+	{
+		Column:        7, // uioSensorStatusHumidity
+		DeviceType:    "humidity",
+		NotApplicable: -1,
+		LowLimit:      15, // uioSensorConfigLowHumidityThreshold
+		HighLimit:     16, // uioSensorConfigHighHumidityThreshold
+	},
+	{Column: 9, DeviceType: "status", Enumeration: sensorAlarmStatus}, // uioSensorStatusAlarmStatus
+	{Column: 10, DeviceType: "status", Enumeration: commStatus},       // uioSensorStatusCommStatus
+}
@@ -8,8 +8,9 @@ import (
 	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
 )
 
-// upsAlarmsInfo is what the device info will be under SNMP OID
+// upsAlarmsInfo are the names of the well-known alarms under SNMP OID
 // .1.3.6.1.2.1.33.1.6.3 view it in a MIB browser to see the names.
+// These are used when the UPS does not support the well-known alarms table.
 var upsAlarmsInfo = []string{
 	"upsAlarmBatteryBad",
 	"upsAlarmOnBattery",
@@ -39,7 +40,6 @@ var upsAlarmsInfo = []string{
 
 // UpsAlarmsTable represents SNMP OID .1.3.6.1.2.1.33.1.6.2
 // There are no rows in this table when no alarms are present.
-// We have no real data for this row at this time (5/16/2018)
 type UpsAlarmsTable struct {
 	*core.SnmpTable // base class
 }
@@ -83,26 +83,38 @@ func NewUpsAlarmsTable(snmpServerBase *core.SnmpServerBase) (table *UpsAlarmsTab
 }
 
 // UpsAlarmsTableDeviceEnumerator overrides the default SnmpTable device
-// enumerator for the alarms headers table.
+// enumerator for the alarms table.
 type UpsAlarmsTableDeviceEnumerator struct {
 	Table *UpsAlarmsTable // Pointer back to the table.
 }
 
 // DeviceEnumerator overrides the default SnmpTable device enumerator.
+// Alarms come and go, so rather than a device per row present at startup,
+// there is a single active alarms device which walks the table on each read,
+// and an alarm history device for the lifecycle of each alarm.
 func (enumerator UpsAlarmsTableDeviceEnumerator) DeviceEnumerator(
 	data map[string]interface{}) (devices []*config.DeviceProto, err error) {
 
 	table := enumerator.Table
 	mib := table.Mib.(*UpsMib)
-	model := mib.UpsIdentityTable.UpsIdentity.Model
+	model := mib.Model()
 
 	snmpDeviceConfigMap, err := table.SnmpServerBase.DeviceConfig.ToMap()
 	if err != nil {
 		return
 	}
 
-	statusProto := &config.DeviceProto{
-		Type: "status",
+	alarmsProto := &config.DeviceProto{
+		Type: "alarms",
+		Context: map[string]string{
+			"model": model,
+		},
+		Instances: []*config.DeviceInstance{},
+		Tags:      snmpDeviceConfigMap["deviceTags"].([]string),
+	}
+
+	alarmHistoryProto := &config.DeviceProto{
+		Type: "alarm-history",
 		Context: map[string]string{
 			"model": model,
 		},
@@ -111,50 +123,49 @@ func (enumerator UpsAlarmsTableDeviceEnumerator) DeviceEnumerator(
 	}
 
 	devices = []*config.DeviceProto{
-		statusProto,
+		alarmsProto,
+		alarmHistoryProto,
+	}
+
+	// Both devices poll the alarm table the same way.
+	alarmData := map[string]interface{}{
+		"table_name":  table.Name,
+		"walk_oid":    table.WalkOid,                                        // Walked on each read.
+		"present_oid": ".1.3.6.1.2.1.33.1.6.1.0",                            // upsAlarmsPresent
+		"descr_oid":   fmt.Sprintf("%s.%s.2", table.WalkOid, table.RowBase), // upsAlarmDescr
+		"time_oid":    fmt.Sprintf("%s.%s.3", table.WalkOid, table.RowBase), // upsAlarmTime
+		// upsAlarmDescr is an OID under upsWellKnownAlarms. Similar to an
+		// enumeration, the name for upsWellKnownAlarms.N is well_knownN.
+		"well_known_oid": upsWellKnownAlarmsOid,
 	}
+	for i, name := range mib.WellKnownAlarmNames() {
+		alarmData[fmt.Sprintf("well_known%d", i+1)] = name
+	}
+	alarmData, err = core.MergeMapStringInterface(snmpDeviceConfigMap, alarmData)
+	if err != nil {
+		return nil, err
+	}
+
+	// upsActiveAlarms ---------------------------------------------------------
+	deviceData := core.CopyMapStringInterface(alarmData)
+	deviceData["oid"] = table.WalkOid
 
-	for i := 0; i < len(table.Rows); i++ {
-
-		// upsAlarmDescr ---------------------------------------------------------
-		deviceData := map[string]interface{}{
-			"base_oid":   table.Rows[i].BaseOid,
-			"table_name": table.Name,
-			"row":        fmt.Sprintf("%d", i),
-			"column":     "2",
-			"oid":        fmt.Sprintf(table.Rows[i].BaseOid, 2), // base_oid and integer column.
-		}
-		deviceData, err = core.MergeMapStringInterface(snmpDeviceConfigMap, deviceData)
-		if err != nil {
-			return nil, err
-		}
-
-		device := &config.DeviceInstance{
-			Info: upsAlarmsInfo[i],
-			Data: deviceData,
-		}
-		statusProto.Instances = append(statusProto.Instances, device)
-
-		// upsAlarmTime ---------------------------------------------------------
-		deviceData = map[string]interface{}{
-			"base_oid":   table.Rows[i].BaseOid,
-			"table_name": table.Name,
-			"row":        fmt.Sprintf("%d", i),
-			"column":     "3",
-			"oid":        fmt.Sprintf(table.Rows[i].BaseOid, 3), // base_oid and integer column.
-		}
-		deviceData, err = core.MergeMapStringInterface(snmpDeviceConfigMap, deviceData)
-		if err != nil {
-			return nil, err
-		}
-
-		device = &config.DeviceInstance{
-			Info: fmt.Sprintf("%sTime", upsAlarmsInfo[i]),
-			Data: deviceData,
-		}
-		statusProto.Instances = append(statusProto.Instances, device)
-
-	} // end for
+	device := &config.DeviceInstance{
+		Info: "upsActiveAlarms",
+		Data: deviceData,
+	}
+	alarmsProto.Instances = append(alarmsProto.Instances, device)
+
+	// upsAlarmHistory ---------------------------------------------------------
+	// The history of the alarms tracked from polling and traps.
+	deviceData = core.CopyMapStringInterface(alarmData)
+	deviceData["oid"] = fmt.Sprintf("%s.%s", table.WalkOid, table.RowBase) // upsAlarmEntry
+
+	device = &config.DeviceInstance{
+		Info: "upsAlarmHistory",
+		Data: deviceData,
+	}
+	alarmHistoryProto.Instances = append(alarmHistoryProto.Instances, device)
 
 	return
 }
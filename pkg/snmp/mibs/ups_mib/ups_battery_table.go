@@ -69,7 +69,17 @@ func (enumerator UpsBatteryTableDeviceEnumerator) DeviceEnumerator(
 	// Pull out the table, mib, device model, SNMP DeviceConfig
 	table := enumerator.Table
 	mib := table.Mib.(*UpsMib)
-	model := mib.UpsIdentityTable.UpsIdentity.Model
+	model := mib.Model()
+
+	// This is a single row table. If there are no rows, the UPS does not
+	// support this group and there are no devices to create.
+	if len(table.Rows) == 0 {
+		log.WithFields(log.Fields{
+			"table": table.Name,
+			"oid":   table.WalkOid,
+		}).Warn("[snmp] table has no rows, will not create any devices for it")
+		return
+	}
 
 	snmpDeviceConfigMap, err := table.SnmpServerBase.DeviceConfig.ToMap()
 	if err != nil {
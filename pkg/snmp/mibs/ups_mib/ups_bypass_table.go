@@ -65,7 +65,7 @@ func (enumerator UpsBypassTableDeviceEnumerator) DeviceEnumerator(
 	// Pull out the table, mib, device model, SNMP DeviceConfig.
 	table := enumerator.Table
 	mib := table.Mib.(*UpsMib)
-	model := mib.UpsIdentityTable.UpsIdentity.Model
+	model := mib.Model()
 
 	snmpDeviceConfigMap, err := table.SnmpServerBase.DeviceConfig.ToMap()
 	if err != nil {
@@ -149,7 +149,7 @@ func (enumerator UpsIdentityTableDeviceEnumerator) DeviceEnumerator(
 	// Pull out the table, mib, device model, SNMP DeviceConfig.
 	table := enumerator.Table
 	mib := table.Mib.(*UpsMib)
-	model := mib.UpsIdentityTable.UpsIdentity.Model
+	model := mib.Model()
 
 	// If there are no rows (e.g. the UPS has no identity information), then there
 	// are no devices to create.
@@ -7,6 +7,32 @@ import (
 	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
 )
 
+// MibName is the name UPS-MIB is registered with. See core.RegisterMib.
+const MibName = "UPS-MIB"
+
+func init() {
+	err := core.RegisterMib(MibName, func(server *core.SnmpServerBase) (core.Mib, error) {
+		upsMib, err := NewUpsMib(server)
+		if err != nil {
+			return nil, err
+		}
+		return upsMib, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	// Not every UPS lists UPS-MIB in the sysORTable, so probe the identity
+	// group too.
+	err = core.RegisterMibDetection(MibName, core.MibDetection{
+		SysORIDs:  []string{".1.3.6.1.2.1.33"},
+		ProbeOids: []string{".1.3.6.1.2.1.33.1.1"},
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
 // UpsMib is the class for all SNMP operations on UPS-MIB, rfc 1628.
 type UpsMib struct {
 	*core.SnmpMib // base class
@@ -43,6 +69,10 @@ func NewUpsMib(server *core.SnmpServerBase) (upsMib *UpsMib, err error) { // nol
 	}
 
 	// Initialize Tables.
+	// A UPS is not required to implement every group in the MIB. The walk of
+	// a group the UPS does not implement is empty, so its table has no rows
+	// and no devices. Any error, e.g. a timeout or bad credentials, fails the
+	// whole MIB.
 	upsIdentityTable, err := NewUpsIdentityTable(server)
 	if err != nil {
 		return nil, err
@@ -140,7 +170,7 @@ func NewUpsMib(server *core.SnmpServerBase) (upsMib *UpsMib, err error) { // nol
 
 	// Initialize the base class.
 	snmpMib, err := core.NewSnmpMib(
-		"UPS-MIB",
+		MibName,
 		[]*core.SnmpTable{
 			upsIdentityTable.SnmpTable,
 			upsBatteryTable.SnmpTable,
@@ -165,6 +195,7 @@ func NewUpsMib(server *core.SnmpServerBase) (upsMib *UpsMib, err error) { // nol
 	if err != nil {
 		return nil, err
 	}
+	snmpMib.RegisterNames(MibName)
 
 	// Initialize class.
 	upsMib = &UpsMib{SnmpMib: snmpMib} // base mib class
@@ -213,3 +244,21 @@ func NewUpsMib(server *core.SnmpServerBase) (upsMib *UpsMib, err error) { // nol
 	log.Debugf("Initialized UpsMib")
 	return upsMib, nil
 }
+
+// Model returns the UPS model from the identity table, or an empty string if
+// the UPS does not support the identity group.
+func (upsMib *UpsMib) Model() string {
+	if upsMib.UpsIdentityTable == nil || upsMib.UpsIdentityTable.UpsIdentity == nil {
+		return ""
+	}
+	return upsMib.UpsIdentityTable.UpsIdentity.Model
+}
+
+// WellKnownAlarmNames returns the names of the well-known alarms in OID order.
+// upsWellKnownAlarms.N is named by WellKnownAlarmNames()[N-1].
+func (upsMib *UpsMib) WellKnownAlarmNames() []string {
+	if upsMib.UpsWellKnownAlarmsTable == nil {
+		return upsAlarmsInfo
+	}
+	return upsMib.UpsWellKnownAlarmsTable.ColumnList
+}
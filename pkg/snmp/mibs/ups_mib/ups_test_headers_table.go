@@ -1,7 +1,10 @@
 package mibs
 
 import (
+	"fmt"
+
 	log "github.com/sirupsen/logrus"
+	"github.com/vapor-ware/synse-sdk/sdk/config"
 	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
 )
 
@@ -47,5 +50,77 @@ func NewUpsTestHeadersTable(snmpServerBase *core.SnmpServerBase) (table *UpsTest
 	}
 
 	table = &UpsTestHeadersTable{SnmpTable: snmpTable}
+	// Override the default Device Enumerator
+	table.DevEnumerator = UpsTestHeadersTableDeviceEnumerator{table}
 	return table, nil
 }
+
+// UpsTestHeadersTableDeviceEnumerator overrides the default SnmpTable device
+// enumerator for the test headers table.
+type UpsTestHeadersTableDeviceEnumerator struct {
+	Table *UpsTestHeadersTable // Pointer back to the table.
+}
+
+// DeviceEnumerator overrides the default SnmpTable device enumerator.
+func (enumerator UpsTestHeadersTableDeviceEnumerator) DeviceEnumerator(
+	data map[string]interface{}) (devices []*config.DeviceProto, err error) {
+
+	// Pull out the table, mib, device model, SNMP DeviceConfig
+	table := enumerator.Table
+	mib := table.Mib.(*UpsMib)
+	model := mib.Model()
+
+	// This is a single row table. If there are no rows, the UPS does not
+	// support this group and there are no devices to create.
+	if len(table.Rows) == 0 {
+		log.WithFields(log.Fields{
+			"table": table.Name,
+			"oid":   table.WalkOid,
+		}).Warn("[snmp] table has no rows, will not create any devices for it")
+		return
+	}
+
+	snmpDeviceConfigMap, err := table.SnmpServerBase.DeviceConfig.ToMap()
+	if err != nil {
+		return nil, err
+	}
+
+	timestampProto := &config.DeviceProto{
+		Type: "timestamp",
+		Context: map[string]string{
+			"model": model,
+		},
+		Instances: []*config.DeviceInstance{},
+		Tags:      snmpDeviceConfigMap["deviceTags"].([]string),
+	}
+
+	devices = []*config.DeviceProto{
+		timestampProto,
+	}
+
+	// This is always a single row table.
+
+	// upsTestStartTime -----------------------------------------------------
+	// This is a TimeStamp. Some UPSes do not report it.
+	if table.Rows[0].RowData[4].Data != nil {
+		deviceData := map[string]interface{}{
+			"base_oid":   table.Rows[0].BaseOid,
+			"table_name": table.Name,
+			"row":        "0",
+			"column":     "5",
+			"oid":        fmt.Sprintf(table.Rows[0].BaseOid, 5), // base_oid and integer column.
+		}
+		deviceData, err = core.MergeMapStringInterface(snmpDeviceConfigMap, deviceData)
+		if err != nil {
+			return nil, err
+		}
+
+		device := &config.DeviceInstance{
+			Info: "upsTestStartTime",
+			Data: deviceData,
+		}
+		timestampProto.Instances = append(timestampProto.Instances, device)
+	}
+
+	return
+}
@@ -5,6 +5,10 @@ import (
 	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
 )
 
+// upsWellKnownAlarmsOid is the OID of upsWellKnownAlarms. The upsAlarmDescr
+// of an active alarm is an OID under this one.
+const upsWellKnownAlarmsOid = ".1.3.6.1.2.1.33.1.6.3"
+
 // UpsWellKnownAlarmsTable represents SNMP OID .1.3.6.1.2.1.33.1.6.3
 type UpsWellKnownAlarmsTable struct {
 	*core.SnmpTable // base class
@@ -13,7 +17,7 @@ type UpsWellKnownAlarmsTable struct {
 // NewUpsWellKnownAlarmsTable constructs the UpsWellKnownAlarmsTable.
 func NewUpsWellKnownAlarmsTable(snmpServerBase *core.SnmpServerBase) (table *UpsWellKnownAlarmsTable, err error) {
 	var tableName = "UPS-MIB-UPS-Well-Known-Alarms-Table"
-	var walkOid = ".1.3.6.1.2.1.33.1.6.3"
+	var walkOid = upsWellKnownAlarmsOid
 
 	log.WithFields(log.Fields{
 		"name": tableName,
@@ -34,7 +38,7 @@ func NewUpsWellKnownAlarmsTable(snmpServerBase *core.SnmpServerBase) (table *Ups
 			"upsAlarmOutputBad",
 			"upsAlarmOutputOverload",
 			"upsAlarmOnBypass",
-			"upsAlarmOnBypassBad",
+			"upsAlarmBypassBad",
 			"upsAlarmOutputOffAsRequested",
 			"upsAlarmUpsOffAsRequested",
 			"upsAlarmChargerFailed",
@@ -43,7 +47,7 @@ func NewUpsWellKnownAlarmsTable(snmpServerBase *core.SnmpServerBase) (table *Ups
 			"upsAlarmFanFailure",
 			"upsAlarmFuseFailure",
 			"upsAlarmGeneralFault",
-			"upsAlarmUpsDiagnosticTestFailed",
+			"upsAlarmDiagnosticTestFailed",
 			"upsAlarmCommunicationsLost",
 			"upsAlarmAwaitingPower",
 			"upsAlarmShutdownPending",
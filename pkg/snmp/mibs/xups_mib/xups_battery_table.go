@@ -0,0 +1,39 @@
+package xupsmib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// batteryTable is the definition of SNMP OID .1.3.6.1.4.1.534.1.2, the battery
+// group.
+var batteryTable = &core.TableDefinition{
+	Name:      "XUPS-MIB-Battery-Table",
+	WalkOid:   ".1.3.6.1.4.1.534.1.2",
+	Flattened: true,
+	Columns: []*core.ColumnDefinition{
+		{Name: "xupsBatTimeRemaining"},        // Seconds of battery run time at the present load.
+		{Name: "xupsBatVoltage"},              // Volts DC
+		{Name: "xupsBatCurrent"},              // Amps DC
+		{Name: "xupsBatCapacity"},             // Percent of full charge.
+		{Name: "xupsBatteryAbmStatus"},        // State of the Advanced Battery Management charger.
+		{Name: "xupsBatteryLastReplacedDate"}, // Date the batteries were last replaced, as entered by the user.
+	},
+}
+
+// abmStatus enumerates xupsBatteryAbmStatus.
+var abmStatus = map[int]string{
+	1: "batteryCharging",
+	2: "batteryDischarging",
+	3: "batteryFloating",
+	4: "batteryResting",
+	5: "unknown",
+	6: "batteryDisconnected",
+	7: "batteryUnderTest",
+	8: "checkBattery",
+}
+
+// batteryDevices are the devices of the battery group. The voltage, current
+// and capacity are left to UPS-MIB.
+var batteryDevices = []core.ColumnDevice{
+	{Column: 1, DeviceType: "seconds"},                        // xupsBatTimeRemaining
+	{Column: 5, DeviceType: "status", Enumeration: abmStatus}, // xupsBatteryAbmStatus
+	{Column: 6, DeviceType: "battery-replace"},                // xupsBatteryLastReplacedDate
+}
@@ -0,0 +1,42 @@
+package xupsmib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// contactSenseTable is the definition of SNMP OID .1.3.6.1.4.1.534.1.6.8, the
+// dry contact inputs of the UPS and the Environmental Monitoring Probe (EMP).
+var contactSenseTable = &core.TableDefinition{
+	Name:        "XUPS-MIB-xupsContactSenseTable",
+	WalkOid:     ".1.3.6.1.4.1.534.1.6.8",
+	RowBase:     "1",
+	IndexColumn: "1",
+	Index:       []core.IndexComponent{{Name: "xupsContactIndex", Type: core.IndexInteger}},
+	Columns: []*core.ColumnDefinition{
+		{Name: "xupsContactIndex"}, // Index of the contact.
+		{Name: "xupsContactType"},  // How the contact is wired, e.g. normallyOpen(1).
+		{Name: "xupsContactState"}, // State of the contact, e.g. closed(2).
+		{Name: "xupsContactDescr"}, // Description of the contact.
+	},
+}
+
+// contactType enumerates xupsContactType.
+var contactType = map[int]string{
+	1: "normallyOpen",
+	2: "normallyClosed",
+	3: "anyChange",
+	4: "notUsed",
+}
+
+// contactState enumerates xupsContactState. The WithNotice states are those
+// which are an alarm for the xupsContactType.
+var contactState = map[int]string{
+	1: "open",
+	2: "closed",
+	3: "openWithNotice",
+	4: "closedWithNotice",
+}
+
+// contactSenseDevices are the devices of each contact.
+var contactSenseDevices = []core.ColumnDevice{
+	{Column: 2, DeviceType: "status", Enumeration: contactType},  // xupsContactType
+	{Column: 3, DeviceType: "status", Enumeration: contactState}, // xupsContactState
+}
@@ -0,0 +1,36 @@
+package xupsmib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// environmentTable is the definition of SNMP OID .1.3.6.1.4.1.534.1.6, the
+// environment group. The ambient objects are the sensor of the UPS and the
+// remote objects are those of an Environmental Monitoring Probe (EMP).
+var environmentTable = &core.TableDefinition{
+	Name:      "XUPS-MIB-Environment-Table",
+	WalkOid:   ".1.3.6.1.4.1.534.1.6",
+	Flattened: true,
+	Columns: []*core.ColumnDefinition{
+		{Name: "xupsEnvAmbientTemp"},              // Degrees C
+		{Name: "xupsEnvAmbientLowerLimit"},        // Degrees C
+		{Name: "xupsEnvAmbientUpperLimit"},        // Degrees C
+		{Name: "xupsEnvAmbientHumidity"},          // Percent relative humidity.
+		{Name: "xupsEnvRemoteTemp"},               // Degrees C, from the EMP.
+		{Name: "xupsEnvRemoteHumidity"},           // Percent relative humidity, from the EMP.
+		{Name: "xupsEnvNumContacts"},              // Number of rows in xupsContactSenseTable.
+		{Name: "xupsContactSenseTable"},           // Not a scalar. Placeholder for the column number.
+		{Name: "xupsEnvRemoteTempLowerLimit"},     // Degrees C
+		{Name: "xupsEnvRemoteTempUpperLimit"},     // Degrees C
+		{Name: "xupsEnvRemoteHumidityLowerLimit"}, // Percent relative humidity.
+		{Name: "xupsEnvRemoteHumidityUpperLimit"}, // Percent relative humidity.
+	},
+}
+
+// environmentDevices are the devices of the environment group. The limits
+// configured on the UPS and the EMP are the thresholds of the devices. See
+// core.AddLimits.
+var environmentDevices = []core.ColumnDevice{
+	{Column: 1, DeviceType: "temperature", LowLimit: 2, HighLimit: 3},  // xupsEnvAmbientTemp
+	{Column: 4, DeviceType: "humidity"},                                // xupsEnvAmbientHumidity
+	{Column: 5, DeviceType: "temperature", LowLimit: 9, HighLimit: 10}, // xupsEnvRemoteTemp
+	{Column: 6, DeviceType: "humidity", LowLimit: 11, HighLimit: 12},   // xupsEnvRemoteHumidity
+}
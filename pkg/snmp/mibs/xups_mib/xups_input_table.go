@@ -0,0 +1,25 @@
+package xupsmib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// inputTable is the definition of SNMP OID .1.3.6.1.4.1.534.1.3.4, the input
+// phases.
+var inputTable = &core.TableDefinition{
+	Name:        "XUPS-MIB-xupsInputTable",
+	WalkOid:     ".1.3.6.1.4.1.534.1.3.4",
+	RowBase:     "1",
+	IndexColumn: "1",
+	Index:       []core.IndexComponent{{Name: "xupsInputPhase", Type: core.IndexInteger}},
+	Columns: []*core.ColumnDefinition{
+		{Name: "xupsInputPhase"},   // Index of the phase.
+		{Name: "xupsInputVoltage"}, // Volts RMS
+		{Name: "xupsInputCurrent"}, // Amps RMS
+		{Name: "xupsInputWatts"},   // Watts
+	},
+}
+
+// inputDevices are the devices of each input phase. The voltage and current
+// are left to UPS-MIB. XUPS-MIB has no input volt-amperes.
+var inputDevices = []core.ColumnDevice{
+	{Column: 4, DeviceType: "power"}, // xupsInputWatts
+}
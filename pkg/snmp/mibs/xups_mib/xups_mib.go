@@ -0,0 +1,131 @@
+package xupsmib
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// MibName is the name XUPS-MIB is registered with. See core.RegisterMib.
+const MibName = "XUPS-MIB"
+
+func init() {
+	err := core.RegisterMib(MibName, func(server *core.SnmpServerBase) (core.Mib, error) {
+		xupsMib, err := NewXupsMib(server)
+		if err != nil {
+			return nil, err
+		}
+		return xupsMib, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	// xupsIdentManufacturer is served by every XUPS-MIB agent.
+	err = core.RegisterMibDetection(MibName, core.MibDetection{
+		SysORIDs:  []string{".1.3.6.1.4.1.534.1"},
+		ProbeOids: []string{".1.3.6.1.4.1.534.1.1.1.0"},
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// XupsMib is the class for the Eaton (Powerware) XUPS-MIB, served by the
+// PowerXpert and PXGMS network cards. It covers what the generic UPS-MIB does
+// not: the ABM battery charger, per phase power, the environment and its
+// contacts, the topology and the receptacles, which are outlets or load
+// segments.
+type XupsMib struct {
+	*core.SnmpMib // base class
+
+	// Tables defined in this MIB
+	XupsBatteryTable      *core.SnmpTable
+	XupsInputTable        *core.SnmpTable
+	XupsOutputTable       *core.SnmpTable
+	XupsEnvironmentTable  *core.SnmpTable
+	XupsContactSenseTable *core.SnmpTable
+	XupsRecepTable        *core.SnmpTable
+	XupsTopologyTable     *core.SnmpTable
+}
+
+// NewXupsMib constructs the XupsMib.
+func NewXupsMib(server *core.SnmpServerBase) (xupsMib *XupsMib, err error) {
+	log.Debugf("[snmp] initializing XupsMib")
+
+	// Arg checks.
+	if server == nil {
+		return nil, fmt.Errorf("unable to create new XupsMib: server is nil")
+	}
+
+	// Initialize Tables.
+	xupsMib = &XupsMib{}
+	for _, table := range []struct {
+		table      **core.SnmpTable
+		definition *core.TableDefinition
+		devices    []core.ColumnDevice
+	}{
+		{&xupsMib.XupsBatteryTable, batteryTable, batteryDevices},
+		{&xupsMib.XupsInputTable, inputTable, inputDevices},
+		{&xupsMib.XupsOutputTable, outputTable, outputDevices},
+		{&xupsMib.XupsEnvironmentTable, environmentTable, environmentDevices},
+		{&xupsMib.XupsContactSenseTable, contactSenseTable, contactSenseDevices},
+		{&xupsMib.XupsRecepTable, recepTable, recepDevices},
+		{&xupsMib.XupsTopologyTable, topologyTable, topologyDevices},
+	} {
+		*table.table, err = core.NewColumnTable(table.definition, server, enumeration(table.devices))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Initialize the base class.
+	snmpMib, err := core.NewSnmpMib(MibName, []*core.SnmpTable{
+		xupsMib.XupsBatteryTable,
+		xupsMib.XupsInputTable,
+		xupsMib.XupsOutputTable,
+		xupsMib.XupsEnvironmentTable,
+		xupsMib.XupsContactSenseTable,
+		xupsMib.XupsRecepTable,
+		xupsMib.XupsTopologyTable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	snmpMib.RegisterNames(MibName)
+	xupsMib.SnmpMib = snmpMib
+
+	// Update mib pointer for each table.
+	for _, table := range xupsMib.Tables {
+		table.Mib = xupsMib
+	}
+
+	log.Debugf("Initialized XupsMib")
+	return xupsMib, nil
+}
+
+// Model gets the UPS model, xupsIdentModel, or the empty string if the agent
+// does not serve it.
+func (xupsMib *XupsMib) Model() string {
+	result, err := xupsMib.XupsBatteryTable.SnmpServerBase.SnmpClient.Get(".1.3.6.1.4.1.534.1.1.2.0")
+	if err != nil {
+		log.WithError(err).Warn("[snmp] unable to get xupsIdentModel")
+		return ""
+	}
+	if model, ok := result.Data.(string); ok {
+		return model
+	}
+	return ""
+}
+
+// enumeration gets the devices for the columns of a table, with the UPS model
+// in their context. See core.NewColumnTable.
+func enumeration(columnDevices []core.ColumnDevice) func(table *core.SnmpTable) core.ColumnEnumeration {
+	return func(table *core.SnmpTable) core.ColumnEnumeration {
+		return core.ColumnEnumeration{
+			Model:   table.Mib.(*XupsMib).Model(),
+			Devices: columnDevices,
+		}
+	}
+}
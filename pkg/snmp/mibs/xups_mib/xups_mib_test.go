@@ -0,0 +1,128 @@
+package xupsmib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core/coretest"
+)
+
+// TestXupsMib tests the XUPS-MIB devices of the PXGMS UPS walk.
+func TestXupsMib(t *testing.T) {
+	xupsMib, err := NewXupsMib(coretest.NewReplayServerBase(t, "../../../../emulator/ups/pxgms_ups/data/public.snmpwalk"))
+	assert.NoError(t, err)
+	assert.Equal(t, MibName, xupsMib.Name)
+
+	devices, err := xupsMib.EnumerateDevices(map[string]interface{}{})
+	assert.NoError(t, err)
+
+	// Battery.
+	timeRemaining := coretest.FindInstance(devices, "seconds", "xupsBatTimeRemaining")
+	assert.NotNil(t, timeRemaining)
+	assert.Equal(t, ".1.3.6.1.4.1.534.1.2.1.0", timeRemaining.Data["oid"])
+	assert.NotNil(t, coretest.FindInstance(devices, "battery-replace", "xupsBatteryLastReplacedDate"))
+	// The PXGMS does not serve the ABM status.
+	assert.Nil(t, coretest.FindInstance(devices, "status", "xupsBatteryAbmStatus"))
+
+	// Per phase power.
+	assert.Equal(t, 6, coretest.CountInstances(devices, "power"))
+	inputWatts := coretest.FindInstance(devices, "power", "xupsInputWatts 1")
+	assert.NotNil(t, inputWatts)
+	assert.Equal(t, ".1.3.6.1.4.1.534.1.3.4.1.4.1", inputWatts.Data["oid"])
+	assert.Equal(t, "XUPS-MIB-xupsInputTable", inputWatts.Data["table_name"])
+	assert.Equal(t, map[string]string{"index": "1", "xupsInputPhase": "1"}, inputWatts.Context)
+	outputWatts := coretest.FindInstance(devices, "power", "xupsOutputWatts 3")
+	assert.NotNil(t, outputWatts)
+	assert.Equal(t, ".1.3.6.1.4.1.534.1.4.4.1.4.3", outputWatts.Data["oid"])
+	// The PXGMS only serves the first four output columns.
+	assert.Equal(t, 0, coretest.CountInstances(devices, "apparent-power"))
+
+	// Environment, from the EMP.
+	assert.Equal(t, 2, coretest.CountInstances(devices, "temperature"))
+	remoteTemp := coretest.FindInstance(devices, "temperature", "xupsEnvRemoteTemp")
+	assert.NotNil(t, remoteTemp)
+	assert.Equal(t, ".1.3.6.1.4.1.534.1.6.5.0", remoteTemp.Data["oid"])
+	// The EMP limits are the thresholds. The ambient limits are not served.
+	assert.Equal(t, 0, remoteTemp.Data["limit_low"])
+	assert.Equal(t, 70, remoteTemp.Data["limit_high"])
+	ambientTemp := coretest.FindInstance(devices, "temperature", "xupsEnvAmbientTemp")
+	assert.NotNil(t, ambientTemp)
+	assert.NotContains(t, ambientTemp.Data, "limit_low")
+	assert.NotContains(t, ambientTemp.Data, "limit_high")
+	remoteHumidity := coretest.FindInstance(devices, "humidity", "xupsEnvRemoteHumidity")
+	assert.NotNil(t, remoteHumidity)
+	assert.Equal(t, 0, remoteHumidity.Data["limit_low"])
+	assert.Equal(t, 90, remoteHumidity.Data["limit_high"])
+	assert.Nil(t, coretest.FindInstance(devices, "humidity", "xupsEnvAmbientHumidity"))
+
+	// Contacts.
+	assert.Len(t, xupsMib.XupsContactSenseTable.Rows, 2)
+	contactState := coretest.FindInstance(devices, "status", "xupsContactState 2")
+	assert.NotNil(t, contactState)
+	assert.Equal(t, ".1.3.6.1.4.1.534.1.6.8.1.3.2", contactState.Data["oid"])
+	assert.Equal(t, "closedWithNotice", contactState.Data["enumeration4"])
+	assert.Equal(t, map[string]string{"index": "2", "xupsContactIndex": "2"}, contactState.Context)
+	assert.NotNil(t, coretest.FindInstance(devices, "status", "xupsContactType 1"))
+
+	// Topology.
+	assert.NotNil(t, coretest.FindInstance(devices, "status", "xupsTopologyType"))
+	assert.NotNil(t, coretest.FindInstance(devices, "status", "xupsTopoUnitNumber"))
+
+	// The PXGMS has no receptacles.
+	assert.Equal(t, 0, coretest.CountInstances(devices, "outlet"))
+	assert.Len(t, xupsMib.XupsRecepTable.Rows, 0)
+}
+
+// TestXupsMibReceptacles tests the devices of a newer card which serves the
+// ABM status, output volt-amperes and switchable receptacles.
+func TestXupsMibReceptacles(t *testing.T) {
+	xupsMib, err := NewXupsMib(coretest.NewReplayServerBase(t, "testdata/xups.snmpwalk"))
+	assert.NoError(t, err)
+
+	devices, err := xupsMib.EnumerateDevices(map[string]interface{}{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Eaton 9PX 6000i", xupsMib.Model())
+	for _, proto := range devices {
+		assert.Equal(t, "Eaton 9PX 6000i", proto.Context["model"])
+	}
+
+	abmStatus := coretest.FindInstance(devices, "status", "xupsBatteryAbmStatus")
+	assert.NotNil(t, abmStatus)
+	assert.Equal(t, "true", abmStatus.Data["enumeration"])
+	assert.Equal(t, "batteryResting", abmStatus.Data["enumeration4"])
+
+	va := coretest.FindInstance(devices, "apparent-power", "xupsOutputVA 1")
+	assert.NotNil(t, va)
+	assert.Equal(t, ".1.3.6.1.4.1.534.1.4.4.1.8.1", va.Data["oid"])
+	assert.NotNil(t, coretest.FindInstance(devices, "percentage", "xupsOutputPercentLoad 1"))
+	assert.NotNil(t, coretest.FindInstance(devices, "humidity", "xupsEnvAmbientHumidity"))
+	ambientTemp := coretest.FindInstance(devices, "temperature", "xupsEnvAmbientTemp")
+	assert.NotNil(t, ambientTemp)
+	assert.Equal(t, 5, ambientTemp.Data["limit_low"])
+	assert.Equal(t, 40, ambientTemp.Data["limit_high"])
+
+	strategy := coretest.FindInstance(devices, "status", "xupsTopoPowerStrategy")
+	assert.NotNil(t, strategy)
+	assert.Equal(t, "enableHighEfficiency", strategy.Data["enumeration3"])
+
+	assert.Equal(t, 2, coretest.CountInstances(devices, "outlet"))
+	outlet := coretest.FindInstance(devices, "outlet", "xupsRecepStatus 2")
+	assert.NotNil(t, outlet)
+	assert.Equal(t, ".1.3.6.1.4.1.534.1.12.2.1.2.2", outlet.Data["oid"])
+	assert.Equal(t, "off", outlet.Data["enumeration2"])
+	assert.Equal(t, ".1.3.6.1.4.1.534.1.12.2.1.3.2", outlet.Data["action_oid_off"])
+	assert.Equal(t, "0", outlet.Data["action_value_off"])
+	assert.Equal(t, ".1.3.6.1.4.1.534.1.12.2.1.4.2", outlet.Data["action_oid_on"])
+	assert.Equal(t, "0", outlet.Data["action_value_on"])
+	assert.Equal(t, map[string]string{"index": "2", "xupsRecepIndex": "2"}, outlet.Context)
+	// Outlet control is not enabled by the MIB.
+	assert.NotContains(t, outlet.Data, "outlet_control")
+
+	// Switching the outlet on is read back.
+	client := xupsMib.XupsRecepTable.SnmpServerBase.SnmpClient
+	assert.NoError(t, client.SetInteger(outlet.Data["action_oid_on"].(string), 0))
+	result, err := client.Get(".1.3.6.1.4.1.534.1.12.2.1.4.2")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.Data)
+}
@@ -0,0 +1,32 @@
+package xupsmib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// outputTable is the definition of SNMP OID .1.3.6.1.4.1.534.1.4.4, the output
+// phases.
+var outputTable = &core.TableDefinition{
+	Name:        "XUPS-MIB-xupsOutputTable",
+	WalkOid:     ".1.3.6.1.4.1.534.1.4.4",
+	RowBase:     "1",
+	IndexColumn: "1",
+	Index:       []core.IndexComponent{{Name: "xupsOutputPhase", Type: core.IndexInteger}},
+	Columns: []*core.ColumnDefinition{
+		{Name: "xupsOutputPhase"},                // Index of the phase.
+		{Name: "xupsOutputVoltage"},              // Volts RMS
+		{Name: "xupsOutputCurrent"},              // Amps RMS
+		{Name: "xupsOutputWatts"},                // Watts
+		{Name: "xupsOutputId"},                   // Phase identifier, e.g. phase1toN(4).
+		{Name: "xupsOutputCurrentHighPrecision"}, // Tenths of Amps RMS.
+		{Name: "xupsOutputPercentLoad"},          // Percent of rated capacity.
+		{Name: "xupsOutputVA"},                   // Volt-amperes
+	},
+}
+
+// outputDevices are the devices of each output phase. The voltage and
+// current are left to UPS-MIB. Older firmware serves only the first four
+// columns.
+var outputDevices = []core.ColumnDevice{
+	{Column: 4, DeviceType: "power"},          // xupsOutputWatts
+	{Column: 7, DeviceType: "percentage"},     // xupsOutputPercentLoad
+	{Column: 8, DeviceType: "apparent-power"}, // xupsOutputVA
+}
@@ -0,0 +1,49 @@
+package xupsmib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// recepTable is the definition of SNMP OID .1.3.6.1.4.1.534.1.12.2, the
+// receptacles. Depending on the UPS, these are outlets or load segments.
+var recepTable = &core.TableDefinition{
+	Name:        "XUPS-MIB-xupsRecepTable",
+	WalkOid:     ".1.3.6.1.4.1.534.1.12.2",
+	RowBase:     "1",
+	IndexColumn: "1",
+	Index:       []core.IndexComponent{{Name: "xupsRecepIndex", Type: core.IndexInteger}},
+	Columns: []*core.ColumnDefinition{
+		{Name: "xupsRecepIndex"},               // Index of the receptacle.
+		{Name: "xupsRecepStatus"},              // State of the receptacle, e.g. on(1).
+		{Name: "xupsRecepOffDelaySecs"},        // Seconds until off. Setting 0 switches off immediately.
+		{Name: "xupsRecepOnDelaySecs"},         // Seconds until on. Setting 0 switches on immediately.
+		{Name: "xupsRecepAutoOffDelay"},        // Seconds on battery before load shedding.
+		{Name: "xupsRecepAutoOnDelay"},         // Seconds after utility returns before restart.
+		{Name: "xupsRecepShedSecsWithRestart"}, // Seconds until off, then on once utility returns.
+	},
+}
+
+// recepStatus enumerates xupsRecepStatus.
+var recepStatus = map[int]string{
+	1: "on",
+	2: "off",
+	3: "pendingOff",
+	4: "pendingOn",
+	5: "unknown",
+	6: "reserved",
+	7: "failedClosed",
+	8: "failedOpen",
+}
+
+// recepDevices are the devices of each receptacle. Receptacles are switched
+// immediately by setting their off or on delay to 0. See devices.SnmpOutlet
+// for how writes are guarded.
+var recepDevices = []core.ColumnDevice{
+	{
+		Column:      2, // xupsRecepStatus
+		DeviceType:  "outlet",
+		Enumeration: recepStatus,
+		Actions: map[string]core.ColumnAction{
+			"off": {Column: 3, Value: 0}, // xupsRecepOffDelaySecs
+			"on":  {Column: 4, Value: 0}, // xupsRecepOnDelaySecs
+		},
+	},
+}
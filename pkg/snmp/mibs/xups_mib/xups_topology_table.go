@@ -0,0 +1,34 @@
+package xupsmib
+
+import "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+
+// topologyTable is the definition of SNMP OID .1.3.6.1.4.1.534.1.13, the
+// topology group.
+var topologyTable = &core.TableDefinition{
+	Name:      "XUPS-MIB-Topology-Table",
+	WalkOid:   ".1.3.6.1.4.1.534.1.13",
+	Flattened: true,
+	Columns: []*core.ColumnDefinition{
+		{Name: "xupsTopologyType"},      // Eaton topology code, e.g. 531 for a double conversion 93PM.
+		{Name: "xupsTopoMachineCode"},   // Eaton machine type code.
+		{Name: "xupsTopoUnitNumber"},    // Unit number in a parallel system, 0 if not parallel.
+		{Name: "xupsTopoPowerStrategy"}, // Efficiency strategy, e.g. enableHighEfficiency(3).
+	},
+}
+
+// powerStrategy enumerates xupsTopoPowerStrategy.
+var powerStrategy = map[int]string{
+	1: "highAlert",
+	2: "standard",
+	3: "enableHighEfficiency",
+	4: "immediateHighEfficiency",
+}
+
+// topologyDevices are the devices of the topology group. The topology and
+// machine codes are Eaton product codes, reported as is.
+var topologyDevices = []core.ColumnDevice{
+	{Column: 1, DeviceType: "status"},                             // xupsTopologyType
+	{Column: 2, DeviceType: "status"},                             // xupsTopoMachineCode
+	{Column: 3, DeviceType: "status"},                             // xupsTopoUnitNumber
+	{Column: 4, DeviceType: "status", Enumeration: powerStrategy}, // xupsTopoPowerStrategy
+}
@@ -24,15 +24,15 @@ func TestGalaxyUps(t *testing.T) {
 	data["model"] = "Galaxy VM 180 kVA"
 	data["version"] = "v3"
 
-	galaxyUps, err := NewGalaxyUps(data)
+	galaxyUps, err := CreateSnmpServer(data)
 	assert.NoError(t, err)
 	assert.NotNil(t, galaxyUps)
-	assert.NotNil(t, galaxyUps.SnmpServer)
-	assert.NotNil(t, galaxyUps.SnmpServer.SnmpServerBase)
-	assert.NotNil(t, galaxyUps.SnmpServer.SnmpServerBase.SnmpClient)
-	assert.NotNil(t, galaxyUps.SnmpServer.SnmpServerBase.SnmpClient.DeviceConfig)
+	assert.Equal(t, "apc-galaxy-ups", galaxyUps.Profile.Name)
+	assert.NotNil(t, galaxyUps.SnmpServerBase)
+	assert.NotNil(t, galaxyUps.SnmpServerBase.SnmpClient)
+	assert.NotNil(t, galaxyUps.SnmpServerBase.SnmpClient.DeviceConfig)
 
-	clientDeviceConfig := galaxyUps.SnmpServer.SnmpServerBase.SnmpClient.DeviceConfig
+	clientDeviceConfig := galaxyUps.SnmpServerBase.SnmpClient.DeviceConfig
 	assert.Equal(t, clientDeviceConfig.Version, "V3")
 	assert.Equal(t, clientDeviceConfig.Endpoint, "127.0.0.1")
 	assert.Equal(t, clientDeviceConfig.ContextName, "public")
@@ -46,8 +46,8 @@ func TestGalaxyUps(t *testing.T) {
 	assert.Equal(t, clientDeviceConfig.SecurityParameters.PrivacyPassphrase, "privatus")
 	assert.Equal(t, clientDeviceConfig.Port, uint16(1024))
 
-	assert.NotNil(t, galaxyUps.SnmpServer.SnmpServerBase.DeviceConfig)
-	serverDeviceConfig := galaxyUps.SnmpServer.SnmpServerBase.SnmpClient.DeviceConfig
+	assert.NotNil(t, galaxyUps.SnmpServerBase.DeviceConfig)
+	serverDeviceConfig := galaxyUps.SnmpServerBase.SnmpClient.DeviceConfig
 	assert.Equal(t, serverDeviceConfig.Version, "V3")
 	assert.Equal(t, serverDeviceConfig.Endpoint, "127.0.0.1")
 	assert.Equal(t, serverDeviceConfig.ContextName, "public")
@@ -66,8 +66,8 @@ func TestGalaxyUps(t *testing.T) {
 
 	// Verify device handlers by type.
 	deviceHandlersByType := map[string]int{}
-	for i := 0; i < len(galaxyUps.SnmpServer.DeviceConfigs); i++ {
-		dhType := galaxyUps.SnmpServer.DeviceConfigs[i].Type
+	for i := 0; i < len(galaxyUps.DeviceConfigs); i++ {
+		dhType := galaxyUps.DeviceConfigs[i].Type
 		count, ok := deviceHandlersByType[dhType]
 		if ok {
 			deviceHandlersByType[dhType] = count + 1
@@ -76,16 +76,20 @@ func TestGalaxyUps(t *testing.T) {
 		}
 	}
 
-	assert.Equal(t, 10, len(deviceHandlersByType))
+	assert.Equal(t, 14, len(deviceHandlersByType))
+	assert.Equal(t, 1, deviceHandlersByType["alarm-history"])
+	assert.Equal(t, 1, deviceHandlersByType["alarms"])
 	assert.Equal(t, 4, deviceHandlersByType["current"])
 	assert.Equal(t, 2, deviceHandlersByType["frequency"])
-	assert.Equal(t, 1, deviceHandlersByType["identity"])
+	assert.Equal(t, 2, deviceHandlersByType["identity"]) // UPS-MIB and SNMPv2-MIB.
 	assert.Equal(t, 1, deviceHandlersByType["minutes"])
 	assert.Equal(t, 2, deviceHandlersByType["percentage"])
 	assert.Equal(t, 3, deviceHandlersByType["power"])
 	assert.Equal(t, 1, deviceHandlersByType["seconds"])
-	assert.Equal(t, 5, deviceHandlersByType["status"])
+	assert.Equal(t, 4, deviceHandlersByType["status"])
 	assert.Equal(t, 1, deviceHandlersByType["temperature"])
+	assert.Equal(t, 1, deviceHandlersByType["timestamp"])
+	assert.Equal(t, 1, deviceHandlersByType["uptime"])
 	assert.Equal(t, 4, deviceHandlersByType["voltage"])
 
 }
@@ -108,7 +112,7 @@ func TestGalaxyUpsInitializationFailure(t *testing.T) {
 	data["model"] = "Galaxy VM 180 kVA"
 	data["version"] = "v3"
 
-	_, err := NewGalaxyUps(data)
+	_, err := CreateSnmpServer(data)
 	assert.Error(t, err)
 	assert.Equal(t, "incoming packet is not authentic, discarding", err.Error())
 }
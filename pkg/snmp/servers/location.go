@@ -0,0 +1,313 @@
+package servers
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// LocationLabels are the location labels an agent may be placed with, from
+// the widest to the narrowest.
+var LocationLabels = []string{"site", "room", "row", "rack"}
+
+// LocationTagNamespace is the tag namespace of the location labels, e.g.
+// location/rack:r12.
+const LocationTagNamespace = "location"
+
+// invalidTagLabel matches the characters which are not allowed in a tag
+// label.
+var invalidTagLabel = regexp.MustCompile(`[\s:/]+`)
+
+// Location is where the devices of an agent are placed. The location labels
+// are added to the context of each device and as tags, along with any extra
+// tags. Labels and tags are templates, e.g. {{.upsIdentName}}, so they may
+// come from agent data. See ApplyLocation.
+type Location struct {
+	// The location labels by name, e.g. rack. See LocationLabels.
+	Labels map[string]string
+	// Extra tags for each device, e.g. vapor/ups:primary.
+	Tags []string
+}
+
+// ApplyLocation places the enumerated devices with the location and tags in
+// the dynamic registration configuration, e.g.
+//
+//	location:
+//	  site: dc-1
+//	  rack: '{{.sysLocation}}'
+//	tags:
+//	  - vapor/ups:{{.upsIdentModel}}
+//
+// Template fields are the registered names of agent objects, which are read
+// from the agent, and endpoint, model and profile. Values in tags have the
+// characters not allowed in tag labels replaced. Labels and tags with an
+// object the agent does not have are skipped. This does nothing without a
+// location or tags.
+func (server *SnmpServer) ApplyLocation(data map[string]interface{}) error {
+	location, err := configuredLocation(data)
+	if err != nil || location == nil {
+		return err
+	}
+
+	values, err := server.locationValues(location)
+	if err != nil {
+		return err
+	}
+	tagValues := map[string]string{}
+	for name, value := range values {
+		tagValues[name] = tagLabel(value)
+	}
+
+	resolved := &Location{Labels: map[string]string{}}
+	var tags []string
+	for _, name := range LocationLabels {
+		text, ok := location.Labels[name]
+		if !ok || !server.hasLocationValues(name, text, values) {
+			continue
+		}
+		label, err := executeLocationTemplate(name, text, values)
+		if err != nil {
+			return err
+		}
+		if label == "" {
+			continue
+		}
+		resolved.Labels[name] = label
+		tags = append(tags, fmt.Sprintf("%s/%s:%s", LocationTagNamespace, name, tagLabel(label)))
+	}
+	for i, text := range location.Tags {
+		if !server.hasLocationValues(fmt.Sprintf("tags[%d]", i), text, tagValues) {
+			continue
+		}
+		tag, err := executeLocationTemplate(fmt.Sprintf("tags[%d]", i), text, tagValues)
+		if err != nil {
+			return err
+		}
+		if tag == "" {
+			continue
+		}
+		resolved.Tags = append(resolved.Tags, tag)
+		tags = append(tags, tag)
+	}
+	server.Location = resolved
+
+	for _, proto := range server.DeviceConfigs {
+		if proto.Context == nil {
+			proto.Context = map[string]string{}
+		}
+		for name, label := range resolved.Labels {
+			proto.Context[name] = label
+		}
+		proto.Tags = mergeTags(proto.Tags, tags)
+	}
+	log.WithFields(log.Fields{
+		"endpoint": server.DeviceConfig.Endpoint,
+		"location": resolved.Labels,
+		"tags":     resolved.Tags,
+	}).Info("[snmp] applied location")
+	return nil
+}
+
+// configuredLocation gets the location and tags templates from the dynamic
+// registration configuration, or nil without either.
+func configuredLocation(data map[string]interface{}) (*Location, error) {
+	rawLabels, hasLabels := data["location"]
+	rawTags, hasTags := data["tags"]
+	if !hasLabels && !hasTags {
+		return nil, nil
+	}
+
+	location := &Location{Labels: map[string]string{}}
+	if hasLabels {
+		labels, err := core.ToStringMap(rawLabels)
+		if err != nil {
+			return nil, fmt.Errorf("location: %v", err)
+		}
+		for name, rawLabel := range labels {
+			if !isLocationLabel(name) {
+				return nil, fmt.Errorf("location: unknown label %v, labels are %v", name, LocationLabels)
+			}
+			label, ok := rawLabel.(string)
+			if !ok {
+				return nil, fmt.Errorf("location %v should be a string, %T, %+v", name, rawLabel, rawLabel)
+			}
+			location.Labels[name] = label
+		}
+	}
+	if hasTags {
+		tagList, ok := rawTags.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("tags should be a list, %T, %+v", rawTags, rawTags)
+		}
+		for _, rawTag := range tagList {
+			tag, ok := rawTag.(string)
+			if !ok {
+				return nil, fmt.Errorf("tag should be a string, %T, %+v", rawTag, rawTag)
+			}
+			location.Tags = append(location.Tags, tag)
+		}
+	}
+	return location, nil
+}
+
+// locationValues gets the values of the fields in the location templates.
+// Object values are read from the agent. Scalars are read at the .0 instance,
+// or without it for agents which serve them without one. Objects the agent
+// does not have are left out. See hasLocationValues.
+func (server *SnmpServer) locationValues(location *Location) (map[string]string, error) {
+	values := map[string]string{
+		"endpoint": server.DeviceConfig.Endpoint,
+		"model":    server.Model,
+	}
+	if server.Profile != nil {
+		values["profile"] = server.Profile.Name
+	}
+
+	var texts []string
+	for _, text := range location.Labels {
+		texts = append(texts, text)
+	}
+	texts = append(texts, location.Tags...)
+	for _, text := range texts {
+		fields, err := templateFields(text)
+		if err != nil {
+			return nil, err
+		}
+		for _, field := range fields {
+			if _, ok := values[field]; ok {
+				continue
+			}
+			oid, err := core.OidNames.Oid(field)
+			if err != nil {
+				return nil, fmt.Errorf("location template %v: %v", text, err)
+			}
+			result, err := server.SnmpClient.Get(oid + ".0")
+			if err == nil && result.Data == nil {
+				result, err = server.SnmpClient.Get(oid)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("location template %v: failed to read %v: %v", text, field, err)
+			}
+			if result.Data != nil {
+				values[field] = strings.TrimSpace(fmt.Sprint(result.Data))
+			}
+		}
+	}
+	return values, nil
+}
+
+// hasLocationValues is true if the values have every field in a location
+// label or tag template. A template with a field the agent does not have is
+// logged and skipped, rather than failing the agent.
+func (server *SnmpServer) hasLocationValues(name string, text string, values map[string]string) bool {
+	fields, err := templateFields(text)
+	if err != nil {
+		return true // Executing the template reports the error.
+	}
+	for _, field := range fields {
+		if _, ok := values[field]; !ok {
+			log.WithFields(log.Fields{
+				"endpoint": server.DeviceConfig.Endpoint,
+				"template": name,
+				"field":    field,
+			}).Warn("[snmp] agent has no value for location template, skipping")
+			return false
+		}
+	}
+	return true
+}
+
+// templateFields gets the names of the fields in a template, e.g.
+// upsIdentName for {{.upsIdentName}}.
+func templateFields(text string) (fields []string, err error) {
+	tmpl, err := template.New("location").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	if tmpl.Tree == nil {
+		return nil, nil
+	}
+	var walk func(node parse.Node)
+	walk = func(node parse.Node) {
+		switch node := node.(type) {
+		case *parse.ListNode:
+			if node != nil {
+				for _, child := range node.Nodes {
+					walk(child)
+				}
+			}
+		case *parse.ActionNode:
+			walk(node.Pipe)
+		case *parse.IfNode:
+			walk(node.Pipe)
+			walk(node.List)
+			walk(node.ElseList)
+		case *parse.PipeNode:
+			if node != nil {
+				for _, command := range node.Cmds {
+					for _, arg := range command.Args {
+						walk(arg)
+					}
+				}
+			}
+		case *parse.FieldNode:
+			fields = append(fields, node.Ident[0])
+		}
+	}
+	walk(tmpl.Tree.Root)
+	return fields, nil
+}
+
+// executeLocationTemplate executes a location label or tag template.
+func executeLocationTemplate(name string, text string, values map[string]string) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("location template %v: %v", name, err)
+	}
+	var result bytes.Buffer
+	if err = tmpl.Execute(&result, values); err != nil {
+		return "", fmt.Errorf("location template %v: %v", name, err)
+	}
+	return strings.TrimSpace(result.String()), nil
+}
+
+// tagLabel replaces the characters not allowed in a tag label, e.g.
+// "Rack 12" is Rack-12.
+func tagLabel(value string) string {
+	return invalidTagLabel.ReplaceAllString(strings.TrimSpace(value), "-")
+}
+
+// mergeTags appends tags which are not present yet. The tags slice is copied,
+// since enumerators share it between device protos.
+func mergeTags(tags []string, extra []string) []string {
+	merged := append([]string{}, tags...)
+	for _, tag := range extra {
+		present := false
+		for _, existing := range merged {
+			if existing == tag {
+				present = true
+				break
+			}
+		}
+		if !present {
+			merged = append(merged, tag)
+		}
+	}
+	return merged
+}
+
+// isLocationLabel is true if the name is one of the LocationLabels.
+func isLocationLabel(name string) bool {
+	for _, label := range LocationLabels {
+		if label == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package servers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestApplyLocation tests placing the devices of an agent with location
+// labels and tags from templates.
+func TestApplyLocation(t *testing.T) {
+	data := map[string]interface{}{
+		"location": map[interface{}]interface{}{
+			"site": "dc-1",
+			"room": "",
+			"rack": "{{.upsIdentAttachedDevices}}",
+		},
+		"tags": []interface{}{
+			"vapor/ups:{{.upsIdentModel}}",
+			"vapor/profile:{{.profile}}",
+			"location/site:dc-1",
+		},
+	}
+	server := newReplaySnmpServer(t, "pxgms_ups", data)
+	assert.NoError(t, server.ApplyLocation(data))
+
+	assert.Equal(t, map[string]string{
+		"site": "dc-1",
+		"rack": "Attached Devices not set",
+	}, server.Location.Labels)
+	assert.Equal(t, []string{
+		"vapor/ups:PXGMS-UPS-+-EATON-93PM",
+		"vapor/profile:eaton-pxgms-ups",
+		"location/site:dc-1",
+	}, server.Location.Tags)
+
+	assert.NotEmpty(t, server.DeviceConfigs)
+	for _, proto := range server.DeviceConfigs {
+		assert.Equal(t, "dc-1", proto.Context["site"])
+		assert.Equal(t, "Attached Devices not set", proto.Context["rack"])
+		assert.Equal(t, "PXGMS UPS + EATON 93PM", proto.Context["model"])
+		assert.Equal(t, []string{
+			"location/site:dc-1",
+			"location/rack:Attached-Devices-not-set",
+			"vapor/ups:PXGMS-UPS-+-EATON-93PM",
+			"vapor/profile:eaton-pxgms-ups",
+		}, proto.Tags)
+	}
+}
+
+// TestApplyLocationScalarWithoutInstance tests reading template values from
+// an agent which serves scalars without the .0 instance.
+func TestApplyLocationScalarWithoutInstance(t *testing.T) {
+	data := map[string]interface{}{
+		"location": map[string]interface{}{"rack": "{{.upsIdentManufacturer}}-{{.endpoint}}"},
+	}
+	server := newReplaySnmpServer(t, "tripplite_ups", data)
+	assert.NoError(t, server.ApplyLocation(data))
+	assert.Equal(t, "TrippLite-127.0.0.1", server.Location.Labels["rack"])
+	assert.Equal(t, "TrippLite-127.0.0.1", server.DeviceConfigs[0].Context["rack"])
+	assert.Contains(t, server.DeviceConfigs[0].Tags, "location/rack:TrippLite-127.0.0.1")
+}
+
+// TestApplyLocationMissingObject tests that labels and tags with an object
+// the agent does not have are skipped.
+func TestApplyLocationMissingObject(t *testing.T) {
+	// The PXGMS card does not serve upsTestId.
+	data := map[string]interface{}{
+		"location": map[string]interface{}{
+			"site": "dc-1",
+			"row":  "{{.sysLocation}}",
+			"rack": "{{.upsTestId}}",
+		},
+		"tags": []interface{}{"vapor/test:{{.upsTestId}}", "vapor/ups:primary"},
+	}
+	server := newReplaySnmpServer(t, "pxgms_ups", data)
+	server.LoadSystemMibs() // For sysLocation.
+	assert.NoError(t, server.ApplyLocation(data))
+	assert.Equal(t, map[string]string{
+		"site": "dc-1",
+		"row":  "Your Location",
+	}, server.Location.Labels)
+	assert.Equal(t, []string{"vapor/ups:primary"}, server.Location.Tags)
+	assert.NotContains(t, server.DeviceConfigs[0].Context, "rack")
+}
+
+// TestApplyLocationErrors tests invalid location configurations.
+func TestApplyLocationErrors(t *testing.T) {
+	server := newReplaySnmpServer(t, "pxgms_ups", map[string]interface{}{})
+
+	// Nothing to apply.
+	assert.NoError(t, server.ApplyLocation(map[string]interface{}{}))
+	assert.Nil(t, server.Location)
+
+	for _, data := range []map[string]interface{}{
+		{"location": "rack-1"},
+		{"location": map[string]interface{}{"board": "ups"}},
+		{"location": map[string]interface{}{"rack": 12}},
+		{"location": map[string]interface{}{"rack": "{{.upsIdentName"}},
+		{"location": map[string]interface{}{"rack": "{{.noSuchObject}}"}},
+		{"tags": "vapor/ups:primary"},
+		{"tags": []interface{}{12}},
+	} {
+		assert.Error(t, server.ApplyLocation(data), data)
+	}
+}
+
+// TestTemplateFields tests finding the fields of location templates.
+func TestTemplateFields(t *testing.T) {
+	fields, err := templateFields("{{.upsIdentName}}/{{if .model}}{{.model}}{{else}}{{.endpoint}}{{end}}")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"upsIdentName", "model", "model", "endpoint"}, fields)
+
+	fields, err = templateFields("rack-1")
+	assert.NoError(t, err)
+	assert.Empty(t, fields)
+}
@@ -0,0 +1,181 @@
+package servers
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// UpsIdentModelOid is upsIdentModel.0 from UPS-MIB, the UPS model name given
+// by the manufacturer, e.g. PXGMS UPS + EATON 93PM.
+const UpsIdentModelOid = ".1.3.6.1.2.1.33.1.1.2.0"
+
+// Profile describes the SNMP agents of a vendor or model: how to recognize
+// them and which MIBs they serve.
+type Profile struct {
+	// The name of the profile, e.g. eaton-pxgms-ups.
+	Name string
+	// A human readable description of the agents.
+	Description string
+	// Prefixes of the model names of the agents, e.g. PXGMS UPS. Models are
+	// matched first, since they are the most specific.
+	Models []string
+	// Prefixes of the sysObjectID of the agents, e.g. the enterprise OID of
+	// the vendor. Used when the model does not match any profile.
+	SysObjectIDs []string
+	// The registered MIBs to enable when the configuration does not list any.
+	Mibs []string
+	// The name of the registered quirks of the agents, if any. See
+	// RegisterQuirks.
+	Quirks string
+}
+
+// MatchesModel is true if the model starts with one of the profile models.
+func (profile *Profile) MatchesModel(model string) bool {
+	if model == "" {
+		return false
+	}
+	for _, prefix := range profile.Models {
+		if strings.HasPrefix(model, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesSysObjectID is true if the sysObjectID is under one of the profile
+// sysObjectIDs.
+func (profile *Profile) MatchesSysObjectID(sysObjectID string) bool {
+	if sysObjectID == "" {
+		return false
+	}
+	if !strings.HasPrefix(sysObjectID, ".") {
+		sysObjectID = "." + sysObjectID
+	}
+	for _, prefix := range profile.SysObjectIDs {
+		if sysObjectID == prefix || strings.HasPrefix(sysObjectID, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// profiles are the registered vendor profiles in registration order.
+	profiles []*Profile
+	// profilesLock protects profiles.
+	profilesLock sync.RWMutex
+)
+
+// RegisterProfile registers a vendor profile. Profiles are matched in the
+// order they are registered. Names must be unique.
+func RegisterProfile(profile *Profile) error {
+	if profile == nil || profile.Name == "" {
+		return fmt.Errorf("RegisterProfile. profile has no name")
+	}
+	if len(profile.Models)+len(profile.SysObjectIDs) == 0 {
+		return fmt.Errorf("RegisterProfile. profile %v has no models or sysObjectIDs", profile.Name)
+	}
+	if len(profile.Mibs) == 0 {
+		return fmt.Errorf("RegisterProfile. profile %v has no mibs", profile.Name)
+	}
+	for _, prefix := range profile.SysObjectIDs {
+		if !strings.HasPrefix(prefix, ".") {
+			return fmt.Errorf("RegisterProfile. profile %v sysObjectID must start with a period, %v", profile.Name, prefix)
+		}
+	}
+	if profile.Quirks != "" {
+		if _, err := GetQuirks(profile.Quirks); err != nil {
+			return fmt.Errorf("RegisterProfile. profile %v: %v", profile.Name, err)
+		}
+	}
+
+	profilesLock.Lock()
+	defer profilesLock.Unlock()
+	for _, registered := range profiles {
+		if registered.Name == profile.Name {
+			return fmt.Errorf("profile %v is already registered", profile.Name)
+		}
+	}
+	profiles = append(profiles, profile)
+	return nil
+}
+
+// FindProfile gets the registered profile for an agent with the given model
+// and sysObjectID, either of which may be empty. Models are matched first,
+// then sysObjectIDs. Agents which match no profile get GenericUpsProfile.
+func FindProfile(model string, sysObjectID string) *Profile {
+	profilesLock.RLock()
+	defer profilesLock.RUnlock()
+	for _, profile := range profiles {
+		if profile.MatchesModel(model) {
+			return profile
+		}
+	}
+	for _, profile := range profiles {
+		if profile.MatchesSysObjectID(sysObjectID) {
+			return profile
+		}
+	}
+	return GenericUpsProfile
+}
+
+// identify sets the model and profile of the agent. The model comes from the
+// configuration, or is read from upsIdentModel when not configured. The
+// sysObjectID is only read when the model does not match a profile.
+func (server *SnmpServer) identify(data map[string]interface{}) error {
+	model, err := configuredModel(data)
+	if err != nil {
+		return err
+	}
+
+	if model == "" {
+		result, err := server.SnmpClient.Get(UpsIdentModelOid)
+		if err != nil {
+			log.WithError(err).Error("[snmp] failed to read the model")
+			return err
+		}
+		if result.Data != nil {
+			model = strings.TrimSpace(fmt.Sprint(result.Data))
+		}
+	}
+	server.Model = model
+
+	var sysObjectID string
+	profile := FindProfile(model, "")
+	if profile == GenericUpsProfile {
+		result, err := server.SnmpClient.Get(core.SysObjectIDOid)
+		if err != nil {
+			log.WithError(err).Warn("[snmp] failed to read the sysObjectID, skipping")
+		} else if result.Data != nil {
+			sysObjectID = fmt.Sprint(result.Data)
+			profile = FindProfile(model, sysObjectID)
+		}
+	}
+	server.Profile = profile
+
+	log.WithFields(log.Fields{
+		"endpoint":    server.DeviceConfig.Endpoint,
+		"model":       model,
+		"sysObjectID": sysObjectID,
+		"profile":     profile.Name,
+	}).Info("[snmp] identified SNMP agent")
+	return nil
+}
+
+// configuredModel gets the model from the dynamic registration configuration,
+// or an empty string if it is not configured.
+func configuredModel(data map[string]interface{}) (string, error) {
+	rawModel, ok := data["model"]
+	if !ok || rawModel == nil {
+		return "", nil
+	}
+	model, ok := rawModel.(string)
+	if !ok {
+		return "", fmt.Errorf("model should be a string, %T, %+v", rawModel, rawModel)
+	}
+	return model, nil
+}
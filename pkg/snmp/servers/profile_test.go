@@ -0,0 +1,94 @@
+package servers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFindProfile tests matching agents to vendor profiles.
+func TestFindProfile(t *testing.T) {
+	pxgmsMibs := []string{"UPS-MIB", "XUPS-MIB"}
+	apcMibs := []string{"PowerNet-MIB", "UPS-MIB", "PowerNet-MIB-uio"}
+	trippliteMibs := []string{"UPS-MIB", "TRIPPLITE-PRODUCTS"}
+	pduMibs := []string{"PowerNet-MIB-rPDU2"}
+	atsMibs := []string{"PowerNet-MIB-ats"}
+	eatonAtsMibs := []string{"EATON-ATS2-MIB"}
+	liebertMibs := []string{"LIEBERT-GP-ENVIRONMENTAL-MIB", "LIEBERT-GP-FLEXIBLE-MIB"}
+	upsMibs := []string{"UPS-MIB"}
+	for _, test := range []struct {
+		model       string
+		sysObjectID string
+		profile     string
+		mibs        []string
+	}{
+		{"PXGMS UPS + EATON 93PM", "", "eaton-pxgms-ups", pxgmsMibs},
+		{"Galaxy VM 180 kVA", "", "apc-galaxy-ups", apcMibs},
+		{"Smart-UPS 3000", "", "apc-galaxy-ups", apcMibs},
+		{"SU10000RT3UPM", "", "tripplite-ups", trippliteMibs},
+		// The model wins over the sysObjectID.
+		{"Galaxy VM 180 kVA", ".1.3.6.1.4.1.534.2.12", "apc-galaxy-ups", apcMibs},
+		// The sysObjectID is used for unknown models.
+		{"", ".1.3.6.1.4.1.534.2.12", "eaton-pxgms-ups", pxgmsMibs},
+		{"SMART1500", "1.3.6.1.4.1.850.100.1", "tripplite-ups", trippliteMibs},
+		{"AP8853", "", "apc-rack-pdu", pduMibs},
+		{"AP7920B", "", "apc-rack-pdu", pduMibs},
+		// The AP772x rack ATSs are not rack PDUs.
+		{"AP7724", "", "apc-ats", atsMibs},
+		{"AP7724", ".1.3.6.1.4.1.318.1.3.11", "apc-ats", atsMibs},
+		{"", ".1.3.6.1.4.1.318.1.3.4.6", "apc-rack-pdu", pduMibs},
+		{"", ".1.3.6.1.4.1.318.1.3.27", "apc-galaxy-ups", apcMibs},
+		{"AP4423", "", "apc-ats", atsMibs},
+		{"", ".1.3.6.1.4.1.318.1.3.11", "apc-ats", atsMibs},
+		{"EATS16N", "", "eaton-ats", eatonAtsMibs},
+		// The Eaton transfer switches are not PXGMS UPSs.
+		{"", ".1.3.6.1.4.1.534.10.2", "eaton-ats", eatonAtsMibs},
+		{"Liebert CRV", "", "liebert-cooling", liebertMibs},
+		{"Liebert DSE", "", "liebert-cooling", liebertMibs},
+		// Liebert UPSs are generic.
+		{"Liebert GXT4", ".1.3.6.1.4.1.476.1.42", "rfc1628-ups", upsMibs},
+		// Anything else is a generic UPS.
+		{"Some Other UPS", ".1.3.6.1.4.1.5340.1", "rfc1628-ups", upsMibs},
+		{"", "", "rfc1628-ups", upsMibs},
+	} {
+		profile := FindProfile(test.model, test.sysObjectID)
+		assert.Equal(t, test.profile, profile.Name, test)
+		assert.Equal(t, test.mibs, profile.Mibs, test)
+	}
+}
+
+// TestRegisterProfile tests registering vendor profiles.
+func TestRegisterProfile(t *testing.T) {
+	assert.NoError(t, RegisterProfile(&Profile{
+		Name:   "test-ups",
+		Models: []string{"TEST UPS"},
+		Mibs:   []string{"UPS-MIB"},
+	}))
+	assert.Equal(t, "test-ups", FindProfile("TEST UPS 3000", "").Name)
+
+	for _, profile := range []*Profile{
+		nil,
+		{Models: []string{"X"}, Mibs: []string{"UPS-MIB"}},
+		{Name: "test-ups", Models: []string{"X"}, Mibs: []string{"UPS-MIB"}},
+		{Name: "test-no-match", Mibs: []string{"UPS-MIB"}},
+		{Name: "test-no-mibs", Models: []string{"X"}},
+		{Name: "test-bad-oid", SysObjectIDs: []string{"1.3.6"}, Mibs: []string{"UPS-MIB"}},
+		{Name: "test-bad-quirks", Models: []string{"X"}, Mibs: []string{"UPS-MIB"}, Quirks: "missing"},
+	} {
+		assert.Error(t, RegisterProfile(profile), profile)
+	}
+}
+
+// TestConfiguredModel tests getting the model from the configuration.
+func TestConfiguredModel(t *testing.T) {
+	model, err := configuredModel(map[string]interface{}{"model": "SU10000RT3UPM"})
+	assert.NoError(t, err)
+	assert.Equal(t, "SU10000RT3UPM", model)
+
+	model, err = configuredModel(map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, "", model)
+
+	_, err = configuredModel(map[string]interface{}{"model": 3})
+	assert.Error(t, err)
+}
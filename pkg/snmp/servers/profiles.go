@@ -0,0 +1,128 @@
+package servers
+
+import (
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+	atsmib "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/mibs/ats_mib"
+	eatonatsmib "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/mibs/eaton_ats_mib"
+	lgpenvmib "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/mibs/lgp_env_mib"
+	lgpflexiblemib "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/mibs/lgp_flexible_mib"
+	powernetmib "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/mibs/powernet_mib"
+	rpdu2mib "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/mibs/rpdu2_mib"
+	tripplitemib "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/mibs/tripplite_mib"
+	uiomib "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/mibs/uio_mib"
+	mibs "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/mibs/ups_mib"
+	xupsmib "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/mibs/xups_mib"
+)
+
+// GenericUpsProfile is the fallback profile for agents which match no
+// registered profile. Any UPS compliant with RFC 1628 is supported by it.
+var GenericUpsProfile = &Profile{
+	Name:        "rfc1628-ups",
+	Description: "Generic UPS implementing the UPS-MIB from RFC 1628",
+	Mibs:        []string{mibs.MibName},
+}
+
+func init() {
+	for _, registered := range []*core.Quirks{
+		{
+			// Not the quirks of any profile, since the PXGMS and Tripp Lite
+			// cards report upsBatteryCurrent in 0.1 Amps DC. Configured for
+			// agents whose firmware reports whole Amps instead.
+			Name:        "ups-battery-current-amps",
+			Description: "The firmware reports upsBatteryCurrent in Amps DC rather than 0.1 Amps DC",
+			Multipliers: map[string]float32{
+				"UPS-MIB::upsBatteryCurrent": 1,
+			},
+		},
+		{
+			// Scalars served without the .0 instance are handled by the
+			// tables. See core.SnmpTable.
+			Name:        "tripplite-ups",
+			Description: "The SU10000RT3UPM firmware does not serve most battery and output objects",
+			Disabled: []string{
+				"upsEstimatedMinutesRemaining",
+				"upsEstimatedChargeRemaining",
+				"upsBatteryVoltage",
+				"upsBatteryCurrent",
+				"upsBatteryTemperature",
+				"upsOutputFrequency",
+				"upsOutputNumLines",
+			},
+		},
+	} {
+		if err := RegisterQuirks(registered); err != nil {
+			panic(err)
+		}
+	}
+
+	for _, profile := range []*Profile{
+		{
+			// Registered ahead of eaton-pxgms-ups, which has the whole Eaton
+			// enterprise OID.
+			Name:         "eaton-ats",
+			Description:  "Eaton rack automatic transfer switches",
+			Models:       []string{"EATS16", "EATS30"},
+			SysObjectIDs: []string{".1.3.6.1.4.1.534.10.2"},
+			Mibs:         []string{eatonatsmib.MibName},
+		},
+		{
+			Name:         "eaton-pxgms-ups",
+			Description:  "PXGMS UPS + EATON 93PM",
+			Models:       []string{"PXGMS UPS"},
+			SysObjectIDs: []string{".1.3.6.1.4.1.534"},
+			Mibs:         []string{mibs.MibName, xupsmib.MibName},
+		},
+		{
+			// Registered ahead of apc-galaxy-ups, which has the whole APC
+			// enterprise OID.
+			Name:         "apc-ats",
+			Description:  "Schneider APC rack automatic transfer switches",
+			Models:       []string{"AP44", "AP772"},
+			SysObjectIDs: []string{".1.3.6.1.4.1.318.1.3.11"},
+			Mibs:         []string{atsmib.MibName},
+		},
+		{
+			// Registered ahead of apc-galaxy-ups, which has the whole APC
+			// enterprise OID. The models are the rack PDU series only, since
+			// the AP772x rack ATSs have the same AP7 prefix.
+			Name:         "apc-rack-pdu",
+			Description:  "Schneider APC metered and switched rack PDUs",
+			Models:       []string{"AP78", "AP79", "AP84", "AP86", "AP88", "AP89"},
+			SysObjectIDs: []string{".1.3.6.1.4.1.318.1.3.4"},
+			Mibs:         []string{rpdu2mib.MibName},
+		},
+		{
+			Name:         "apc-galaxy-ups",
+			Description:  "Schneider APC Galaxy VM and Smart-UPS",
+			Models:       []string{"Galaxy VM", "Smart-UPS"},
+			SysObjectIDs: []string{".1.3.6.1.4.1.318"},
+			// PowerNet-MIB is enabled first so that its devices win over the
+			// UPS-MIB devices they duplicate. The network cards have universal
+			// I/O ports for environmental probes.
+			Mibs: []string{powernetmib.MibName, mibs.MibName, uiomib.MibName},
+		},
+		{
+			// The IntelliSlot cards have the same sysObjectID for UPSs and
+			// cooling units, so cooling units are only matched by the
+			// configured model.
+			Name:        "liebert-cooling",
+			Description: "Liebert (Vertiv) CRV, DS and PDX air conditioners and CW air handlers",
+			Models:      []string{"Liebert CRV", "Liebert CW", "Liebert DS", "Liebert PDX"},
+			// LIEBERT-GP-ENVIRONMENTAL-MIB is enabled first so that its
+			// identity devices win over the same objects in the flexible MIB.
+			Mibs: []string{lgpenvmib.MibName, lgpflexiblemib.MibName},
+		},
+		{
+			Name:         "tripplite-ups",
+			Description:  "Tripplite SU10000RT3UPM",
+			Models:       []string{"SU10000RT3UPM"},
+			SysObjectIDs: []string{".1.3.6.1.4.1.850"},
+			Mibs:         []string{mibs.MibName, tripplitemib.MibName},
+			Quirks:       "tripplite-ups",
+		},
+	} {
+		if err := RegisterProfile(profile); err != nil {
+			panic(err)
+		}
+	}
+}
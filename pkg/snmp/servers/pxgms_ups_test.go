@@ -25,15 +25,15 @@ func TestPxgmsUps(t *testing.T) {
 	data["version"] = "v3"
 
 	// Verify data.
-	pxgmsUps, err := NewPxgmsUps(data)
+	pxgmsUps, err := CreateSnmpServer(data)
 	assert.NoError(t, err)
 	assert.NotNil(t, pxgmsUps)
-	assert.NotNil(t, pxgmsUps.SnmpServer)
-	assert.NotNil(t, pxgmsUps.SnmpServer.SnmpServerBase)
-	assert.NotNil(t, pxgmsUps.SnmpServer.SnmpServerBase.SnmpClient)
-	assert.NotNil(t, pxgmsUps.SnmpServer.SnmpServerBase.SnmpClient.DeviceConfig)
+	assert.Equal(t, "eaton-pxgms-ups", pxgmsUps.Profile.Name)
+	assert.NotNil(t, pxgmsUps.SnmpServerBase)
+	assert.NotNil(t, pxgmsUps.SnmpServerBase.SnmpClient)
+	assert.NotNil(t, pxgmsUps.SnmpServerBase.SnmpClient.DeviceConfig)
 
-	clientDeviceConfig := pxgmsUps.SnmpServer.SnmpServerBase.SnmpClient.DeviceConfig
+	clientDeviceConfig := pxgmsUps.SnmpServerBase.SnmpClient.DeviceConfig
 	assert.Equal(t, clientDeviceConfig.Version, "V3")
 	assert.Equal(t, clientDeviceConfig.Endpoint, "127.0.0.1")
 	assert.Equal(t, clientDeviceConfig.ContextName, "public")
@@ -47,8 +47,8 @@ func TestPxgmsUps(t *testing.T) {
 	assert.Equal(t, clientDeviceConfig.SecurityParameters.PrivacyPassphrase, "privatus")
 	assert.Equal(t, clientDeviceConfig.Port, uint16(1024))
 
-	assert.NotNil(t, pxgmsUps.SnmpServer.SnmpServerBase.DeviceConfig)
-	serverDeviceConfig := pxgmsUps.SnmpServer.SnmpServerBase.SnmpClient.DeviceConfig
+	assert.NotNil(t, pxgmsUps.SnmpServerBase.DeviceConfig)
+	serverDeviceConfig := pxgmsUps.SnmpServerBase.SnmpClient.DeviceConfig
 	assert.Equal(t, serverDeviceConfig.Version, "V3")
 	assert.Equal(t, serverDeviceConfig.Endpoint, "127.0.0.1")
 	assert.Equal(t, serverDeviceConfig.ContextName, "public")
@@ -67,8 +67,8 @@ func TestPxgmsUps(t *testing.T) {
 
 	// Verify device handlers by type.
 	deviceHandlersByType := map[string]int{}
-	for i := 0; i < len(pxgmsUps.SnmpServer.DeviceConfigs); i++ {
-		dhType := pxgmsUps.SnmpServer.DeviceConfigs[i].Type
+	for i := 0; i < len(pxgmsUps.DeviceConfigs); i++ {
+		dhType := pxgmsUps.DeviceConfigs[i].Type
 		count, ok := deviceHandlersByType[dhType]
 		if ok {
 			deviceHandlersByType[dhType] = count + 1
@@ -77,16 +77,22 @@ func TestPxgmsUps(t *testing.T) {
 		}
 	}
 
-	assert.Equal(t, 10, len(deviceHandlersByType))
+	assert.Equal(t, 16, len(deviceHandlersByType))
+	assert.Equal(t, 1, deviceHandlersByType["alarm-history"])
+	assert.Equal(t, 1, deviceHandlersByType["alarms"])
+	assert.Equal(t, 1, deviceHandlersByType["battery-replace"])
 	assert.Equal(t, 4, deviceHandlersByType["current"])
 	assert.Equal(t, 2, deviceHandlersByType["frequency"])
-	assert.Equal(t, 1, deviceHandlersByType["identity"])
+	assert.Equal(t, 1, deviceHandlersByType["humidity"])
+	assert.Equal(t, 2, deviceHandlersByType["identity"]) // UPS-MIB and SNMPv2-MIB.
 	assert.Equal(t, 1, deviceHandlersByType["minutes"])
 	assert.Equal(t, 2, deviceHandlersByType["percentage"])
-	assert.Equal(t, 3, deviceHandlersByType["power"])
-	assert.Equal(t, 1, deviceHandlersByType["seconds"])
-	assert.Equal(t, 5, deviceHandlersByType["status"])
-	assert.Equal(t, 1, deviceHandlersByType["temperature"])
+	assert.Equal(t, 5, deviceHandlersByType["power"])
+	assert.Equal(t, 2, deviceHandlersByType["seconds"])
+	assert.Equal(t, 6, deviceHandlersByType["status"])
+	assert.Equal(t, 2, deviceHandlersByType["temperature"])
+	assert.Equal(t, 1, deviceHandlersByType["timestamp"])
+	assert.Equal(t, 1, deviceHandlersByType["uptime"])
 	assert.Equal(t, 4, deviceHandlersByType["voltage"])
 }
 
@@ -108,7 +114,7 @@ func TestPxgmsUpsInitializationFailure(t *testing.T) {
 	data["model"] = "PXGMS UPS + EATON 93PM"
 	data["version"] = "v3"
 
-	_, err := NewPxgmsUps(data)
+	_, err := CreateSnmpServer(data)
 	assert.Error(t, err)
 	assert.Equal(t, "incoming packet is not authentic, discarding", err.Error())
 }
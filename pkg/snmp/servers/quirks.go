@@ -0,0 +1,88 @@
+package servers
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// NoQuirks is the quirks configuration to apply no quirks, even those of the
+// profile.
+const NoQuirks = "none"
+
+var (
+	// quirks are the registered quirks by name.
+	quirks = map[string]*core.Quirks{}
+	// quirksLock protects quirks.
+	quirksLock sync.RWMutex
+)
+
+// RegisterQuirks registers the quirks of a vendor or model, so that a
+// profile or the quirks configuration can select them. Names must be unique.
+func RegisterQuirks(registered *core.Quirks) error {
+	if registered == nil {
+		return fmt.Errorf("RegisterQuirks. quirks are nil")
+	}
+	if err := registered.Validate(); err != nil {
+		return fmt.Errorf("RegisterQuirks. %v", err)
+	}
+
+	quirksLock.Lock()
+	defer quirksLock.Unlock()
+	if _, exists := quirks[registered.Name]; exists {
+		return fmt.Errorf("quirks %v are already registered", registered.Name)
+	}
+	quirks[registered.Name] = registered
+	return nil
+}
+
+// GetQuirks gets the registered quirks with the given name.
+func GetQuirks(name string) (*core.Quirks, error) {
+	quirksLock.RLock()
+	defer quirksLock.RUnlock()
+	registered, ok := quirks[name]
+	if !ok {
+		var names []string
+		for registeredName := range quirks {
+			names = append(names, registeredName)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown quirks %v, registered quirks are %v", name, names)
+	}
+	return registered, nil
+}
+
+// ApplyQuirks corrects the enumerated devices with the quirks in the
+// configuration, or the quirks of the profile without one. The quirks
+// configuration may be none to apply no quirks.
+func (server *SnmpServer) ApplyQuirks(data map[string]interface{}) error {
+	var name string
+	if server.Profile != nil {
+		name = server.Profile.Quirks
+	}
+	if rawName, ok := data["quirks"]; ok {
+		configured, ok := rawName.(string)
+		if !ok {
+			return fmt.Errorf("quirks should be a string, %T, %+v", rawName, rawName)
+		}
+		name = configured
+	}
+	if name == "" || name == NoQuirks {
+		return nil
+	}
+
+	applied, err := GetQuirks(name)
+	if err != nil {
+		return err
+	}
+	server.Quirks = applied
+	server.DeviceConfigs = applied.Apply(server.DeviceConfigs)
+	log.WithFields(log.Fields{
+		"endpoint": server.DeviceConfig.Endpoint,
+		"quirks":   applied.Name,
+	}).Info("[snmp] applied quirks")
+	return nil
+}
@@ -0,0 +1,136 @@
+package servers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-sdk/sdk/config"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core/coretest"
+)
+
+// newReplaySnmpServer creates the SnmpServer for a recorded emulator walk,
+// identified and with its MIBs loaded.
+func newReplaySnmpServer(t *testing.T, emulator string, data map[string]interface{}) *SnmpServer {
+	replay, err := coretest.LoadWalkFile("../../../emulator/ups/" + emulator + "/data/public.snmpwalk")
+	assert.NoError(t, err)
+	server := newOfflineSnmpServer(t)
+	server.SnmpClient.Dial = replay.Dial
+	assert.NoError(t, server.identify(data))
+	assert.NoError(t, server.LoadMibs(data))
+	return server
+}
+
+// findDevice finds an enumerated device by info, or nil if not found.
+func findDevice(devices []*config.DeviceProto, info string) *config.DeviceInstance {
+	for _, proto := range devices {
+		for _, instance := range proto.Instances {
+			if instance.Info == info {
+				return instance
+			}
+		}
+	}
+	return nil
+}
+
+// TestPxgmsUpsQuirks tests the quirks for the PXGMS UPS with its walk.
+func TestPxgmsUpsQuirks(t *testing.T) {
+	server := newReplaySnmpServer(t, "pxgms_ups", map[string]interface{}{})
+	assert.Equal(t, "eaton-pxgms-ups", server.Profile.Name)
+	assert.NoError(t, server.ApplyQuirks(map[string]interface{}{}))
+	assert.Nil(t, server.Quirks)
+
+	// The output reads 0 in both UPS-MIB and XUPS-MIB, as it does with no
+	// load, so the readings are kept.
+	for _, info := range []string{"upsOutputCurrent0", "upsOutputPower1", "upsOutputPercentLoad2", "xupsOutputWatts 1"} {
+		device := findDevice(server.DeviceConfigs, info)
+		assert.NotNil(t, device, info)
+		assert.Nil(t, device.Data[core.UnsupportedKey], info)
+	}
+
+	// upsBatteryCurrent is 17, in 0.1 Amps DC as xupsBatCurrent is 1 Amp DC.
+	device := findDevice(server.DeviceConfigs, "upsBatteryCurrent")
+	assert.Equal(t, float32(0.1), device.Data["multiplier"])
+	result, err := server.SnmpClient.Get(device.Data["oid"].(string))
+	assert.NoError(t, err)
+	assert.Equal(t, 17, result.Data)
+	result, err = server.SnmpClient.Get(".1.3.6.1.4.1.534.1.2.3.0")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Data)
+
+	// Firmware which reports whole Amps is configured with quirks.
+	data := map[string]interface{}{"quirks": "ups-battery-current-amps"}
+	server = newReplaySnmpServer(t, "pxgms_ups", data)
+	assert.NoError(t, server.ApplyQuirks(data))
+	assert.Equal(t, "ups-battery-current-amps", server.Quirks.Name)
+	assert.Equal(t, float32(1), findDevice(server.DeviceConfigs, "upsBatteryCurrent").Data["multiplier"])
+	assert.Equal(t, float32(0.1), findDevice(server.DeviceConfigs, "upsBatteryVoltage").Data["multiplier"])
+}
+
+// TestTrippliteUpsQuirks tests the quirks of the Tripp Lite UPS with its walk.
+func TestTrippliteUpsQuirks(t *testing.T) {
+	// The walk has no model, so the profile is from the sysObjectID.
+	server := newReplaySnmpServer(t, "tripplite_ups", map[string]interface{}{})
+	assert.Equal(t, "", server.Model)
+	assert.Equal(t, "tripplite-ups", server.Profile.Name)
+
+	// The firmware serves scalars without the .0 instance.
+	device := findDevice(server.DeviceConfigs, "upsBatteryStatus")
+	assert.NotNil(t, device)
+	assert.Equal(t, ".1.3.6.1.2.1.33.1.2.1", device.Data["oid"])
+	result, err := server.SnmpClient.Get(device.Data["oid"].(string))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Data)
+
+	// Objects the firmware does not serve are disabled.
+	assert.NotNil(t, findDevice(server.DeviceConfigs, "upsBatteryVoltage"))
+	assert.NoError(t, server.ApplyQuirks(map[string]interface{}{}))
+	assert.Equal(t, "tripplite-ups", server.Quirks.Name)
+	for _, info := range []string{"upsBatteryVoltage", "upsBatteryTemperature", "upsEstimatedMinutesRemaining", "upsOutputFrequency"} {
+		assert.Nil(t, findDevice(server.DeviceConfigs, info), info)
+	}
+	for _, info := range []string{"upsBatteryStatus", "upsSecondsOnBattery", "upsOutputSource", "upsIdentManufacturer"} {
+		assert.NotNil(t, findDevice(server.DeviceConfigs, info), info)
+	}
+	for _, proto := range server.DeviceConfigs {
+		assert.NotEmpty(t, proto.Instances, proto.Type)
+	}
+}
+
+// TestApplyQuirksConfiguration tests selecting quirks in the configuration.
+func TestApplyQuirksConfiguration(t *testing.T) {
+	assert.NoError(t, RegisterQuirks(&core.Quirks{
+		Name:        "test-pxgms-volts",
+		Multipliers: map[string]float32{"UPS-MIB::upsBatteryVoltage": 1},
+		Remap:       map[string]map[int]int{"upsBatteryStatus": {0: 2}},
+	}))
+
+	// Configured quirks replace those of the profile.
+	data := map[string]interface{}{"quirks": "test-pxgms-volts"}
+	server := newReplaySnmpServer(t, "pxgms_ups", data)
+	assert.NoError(t, server.ApplyQuirks(data))
+	assert.Equal(t, "test-pxgms-volts", server.Quirks.Name)
+	assert.Equal(t, float32(1), findDevice(server.DeviceConfigs, "upsBatteryVoltage").Data["multiplier"])
+	status := findDevice(server.DeviceConfigs, "upsBatteryStatus")
+	assert.Equal(t, "batteryNormal", status.Data["enumeration0"])
+	assert.Nil(t, findDevice(server.DeviceConfigs, "upsOutputCurrent0").Data[core.UnsupportedKey])
+
+	// No quirks at all.
+	data = map[string]interface{}{"quirks": NoQuirks}
+	server = newReplaySnmpServer(t, "tripplite_ups", data)
+	assert.NoError(t, server.ApplyQuirks(data))
+	assert.Nil(t, server.Quirks)
+	assert.NotNil(t, findDevice(server.DeviceConfigs, "upsBatteryVoltage"))
+
+	// Generic agents have no quirks.
+	server = newOfflineSnmpServer(t)
+	server.Profile = GenericUpsProfile
+	assert.NoError(t, server.ApplyQuirks(map[string]interface{}{}))
+	assert.Nil(t, server.Quirks)
+
+	assert.Error(t, server.ApplyQuirks(map[string]interface{}{"quirks": "missing"}))
+	assert.Error(t, server.ApplyQuirks(map[string]interface{}{"quirks": 3}))
+	assert.Error(t, RegisterQuirks(&core.Quirks{Name: "test-pxgms-volts", Disabled: []string{"x"}}))
+	assert.Error(t, RegisterQuirks(&core.Quirks{Name: "test-nothing"}))
+	assert.Error(t, RegisterQuirks(nil))
+}
@@ -1,17 +1,382 @@
 package servers
 
 import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
 	"github.com/vapor-ware/synse-sdk/sdk/config"
 	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+	_ "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/mibs/entity_sensor_mib" // Registers ENTITY-SENSOR-MIB.
+	_ "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/mibs/if_mib"            // Registers IF-MIB.
+	snmpv2mib "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/mibs/snmpv2_mib"
 	mibs "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/mibs/ups_mib"
 )
 
+// DefaultMibs are the MIBs enabled for an SNMP agent without a mibs list in
+// its configuration or a profile.
+var DefaultMibs = []string{mibs.MibName}
+
+// SystemMibs are the MIBs enabled for every SNMP agent, whatever else it is
+// configured with. See LoadSystemMibs.
+var SystemMibs = []string{snmpv2mib.MibName}
+
+// AutoDetectMibs is the mibs configuration to detect the MIBs an SNMP agent
+// implements. See core.DetectMibs.
+const AutoDetectMibs = "auto"
+
+// EnabledMib is a MIB served by an SnmpServer.
+type EnabledMib struct {
+	Name string   // The registered name of the MIB, or the defined MIB name.
+	Mib  core.Mib // The MIB implementation.
+}
+
 // SnmpServer is a base class for all SnmpServers.
 // This is meant to represnt any device serving SNMP.
-// Currently only the parts of the UPS-MIB are supported.
-// TODO: Replace UpsMib with []*core.SnmpMib so each snmp server supports whatever mibs it supports.
+// It is a container of the MIB implementations enabled for the device. See
+// core.RegisterMib.
 type SnmpServer struct {
 	*core.SnmpServerBase                       // base class.
-	UpsMib               *mibs.UpsMib          // UpsMib is the only Mib currently supported. In the future this will be a slice or map of supported Mibs.
+	Mibs                 []*EnabledMib         // Enabled MIBs, in the order they were enabled.
+	FailedMibs           map[string]error      // MIBs which were skipped, keyed by name.
 	DeviceConfigs        []*config.DeviceProto // Enumerated device configs.
+	// The detected MIBs when the mibs configuration is auto, otherwise nil.
+	Detection *core.MibDetectionResult
+	// The model of the agent, configured or read from upsIdentModel.
+	Model string
+	// The vendor profile of the agent. See FindProfile.
+	Profile *Profile
+	// The quirks applied to the devices, if any. See ApplyQuirks.
+	Quirks *core.Quirks
+	// The location of the devices, if any. See ApplyLocation.
+	Location *Location
+}
+
+// NewSnmpServer creates the SnmpServer for an SNMP agent from the dynamic
+// registration configuration, identifies the vendor profile of the agent,
+// then enables the MIBs in the mibs list of the configuration or the profile.
+// See LoadMibs.
+func NewSnmpServer(data map[string]interface{}) (*SnmpServer, error) {
+	// Create the SNMP DeviceConfig,
+	snmpDeviceConfig, err := core.GetDeviceConfig(data)
+	if err != nil {
+		log.WithError(err).Error("[snmp] failed to load device config")
+		return nil, err
+	}
+	log.WithField("config", snmpDeviceConfig).Info("[snmp] loaded device config")
+
+	if err := snmpDeviceConfig.CheckPrivacyAndAuthFromData(data); err != nil {
+		return nil, err
+	}
+
+	// Create SNMP client.
+	snmpClient, err := core.NewSnmpClient(snmpDeviceConfig)
+	if err != nil {
+		log.WithError(err).Error("[snmp] failed to create new SNMP client")
+		return nil, err
+	}
+	log.Debug("[snmp] created new SNMP client")
+
+	// Create SnmpServerBase.
+	snmpServerBase, err := core.NewSnmpServerBase(snmpClient, snmpDeviceConfig)
+	if err != nil {
+		log.WithError(err).Error("[snmp] failed to create SNMP server base")
+		return nil, err
+	}
+	log.Debug("[snmp] created SNMP server base")
+
+	server := &SnmpServer{
+		SnmpServerBase: snmpServerBase,
+		FailedMibs:     map[string]error{},
+	}
+	if err = server.identify(data); err != nil {
+		return nil, err
+	}
+	if err = server.LoadMibs(data); err != nil {
+		return nil, err
+	}
+	server.LoadSystemMibs()
+	return server, nil
+}
+
+// LoadSystemMibs enables the SystemMibs which are not enabled yet, e.g. by
+// detection. These are not what the agent is configured for, so MIBs which
+// fail to load are only recorded in FailedMibs.
+func (server *SnmpServer) LoadSystemMibs() {
+	for _, name := range SystemMibs {
+		if server.Mib(name) != nil {
+			continue
+		}
+		mib, err := core.NewRegisteredMib(name, server.SnmpServerBase)
+		if err == nil {
+			err = server.EnableMib(name, mib)
+		}
+		if err != nil {
+			log.WithFields(log.Fields{
+				"error":    err,
+				"mib":      name,
+				"endpoint": server.DeviceConfig.Endpoint,
+			}).Warn("[snmp] failed to load system MIB, skipping")
+			if server.FailedMibs == nil {
+				server.FailedMibs = map[string]error{}
+			}
+			server.FailedMibs[name] = err
+		}
+	}
+}
+
+// LoadMibs creates the registered MIBs in the mibs list of the dynamic
+// registration configuration, or the MIBs of the profile without one, and
+// enables them.
+// With mibs set to auto, the MIBs the agent implements are detected instead.
+// An SNMP agent is not required to implement every MIB it is configured with,
+// so MIBs whose walks show that the agent implements none of their tables are
+// recorded in FailedMibs and skipped. Any error creating a MIB, e.g. a
+// timeout, fails the agent, as does an agent which implements none of the
+// MIBs.
+func (server *SnmpServer) LoadMibs(data map[string]interface{}) error {
+	var names []string
+	var err error
+	if data["mibs"] == AutoDetectMibs {
+		names, err = server.detectMibs()
+	} else {
+		defaults := DefaultMibs
+		if server.Profile != nil {
+			defaults = server.Profile.Mibs
+		}
+		names, err = mibNames(data, defaults)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		mib, err := core.NewRegisteredMib(name, server.SnmpServerBase)
+		if err != nil {
+			return fmt.Errorf("failed to load mib %v: %v", name, err)
+		}
+		if !implemented(mib) {
+			log.WithFields(log.Fields{
+				"mib":      name,
+				"endpoint": server.DeviceConfig.Endpoint,
+			}).Warn("[snmp] agent does not implement MIB, skipping")
+			if server.FailedMibs == nil {
+				server.FailedMibs = map[string]error{}
+			}
+			server.FailedMibs[name] = fmt.Errorf("no table of mib %v has rows", name)
+			continue
+		}
+		if err = server.EnableMib(name, mib); err != nil {
+			return err
+		}
+	}
+
+	if len(server.Mibs) == 0 {
+		return fmt.Errorf("agent %v implements none of the mibs %v", server.DeviceConfig.Endpoint, names)
+	}
+	return nil
+}
+
+// implemented is false if the walks of a loaded MIB show that the agent
+// implements none of its tables. MIBs which do not summarize their tables
+// are taken to be implemented. See core.SnmpMib.Support.
+func implemented(mib core.Mib) bool {
+	summary, ok := mib.(interface{ Support() core.TableSupport })
+	if !ok {
+		return true
+	}
+	return len(summary.Support().Supported) > 0
+}
+
+// detectMibs detects the registered MIBs the agent implements. The
+// detection is kept for the agent metadata.
+func (server *SnmpServer) detectMibs() ([]string, error) {
+	detection, err := core.DetectMibs(server.SnmpClient)
+	if err != nil {
+		return nil, err
+	}
+	server.Detection = detection
+	if len(detection.Mibs) == 0 {
+		return nil, fmt.Errorf("no registered mibs detected for %v, sysObjectID %v",
+			server.DeviceConfig.Endpoint, detection.SysObjectID)
+	}
+	return detection.Mibs, nil
+}
+
+// ApplyMetadata adds the agent metadata to the context of each enumerated
+// device, so that devices can be traced back to the detection. This does
+// nothing unless the MIBs were detected.
+func (server *SnmpServer) ApplyMetadata() {
+	if server.Detection == nil {
+		return
+	}
+	metadata := server.Detection.Metadata()
+	for _, proto := range server.DeviceConfigs {
+		if proto.Context == nil {
+			proto.Context = map[string]string{}
+		}
+		for key, value := range metadata {
+			proto.Context[key] = value
+		}
+	}
+}
+
+// mibNames gets the mibs list from the dynamic registration configuration,
+// or the defaults without one.
+func mibNames(data map[string]interface{}, defaults []string) ([]string, error) {
+	rawNames, ok := data["mibs"]
+	if !ok {
+		return defaults, nil
+	}
+	rawNameList, ok := rawNames.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("mibs should be a list or %v, %T, %+v", AutoDetectMibs, rawNames, rawNames)
+	}
+	if len(rawNameList) == 0 {
+		return nil, fmt.Errorf("mibs is empty, registered mibs are %v", core.RegisteredMibs())
+	}
+
+	var names []string
+	seen := map[string]bool{}
+	for _, rawName := range rawNameList {
+		name, ok := rawName.(string)
+		if !ok {
+			return nil, fmt.Errorf("mibs name should be a string, %T, %+v", rawName, rawName)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("mib %v is listed more than once", name)
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// EnableMib adds a loaded MIB to the server and enumerates its devices. The
+// devices are merged with the devices of the MIBs already enabled. The first
+// MIB enabled wins for duplicated objects, see core.DuplicatesKey. Devices are
+// placed once all MIBs are enabled, see ApplyLocation.
+func (server *SnmpServer) EnableMib(name string, mib core.Mib) error {
+	if mib == nil {
+		return fmt.Errorf("mib %v is nil", name)
+	}
+	if server.Mib(name) != nil {
+		return fmt.Errorf("mib %v is already enabled", name)
+	}
+
+	devices, err := mib.EnumerateDevices(map[string]interface{}{})
+	if err != nil {
+		return fmt.Errorf("failed to enumerate mib %v: %v", name, err)
+	}
+	// Devices which duplicate those of the MIBs already enabled are dropped.
+	devices = core.RemoveDuplicates(server.DeviceConfigs, devices)
+	log.WithFields(log.Fields{
+		"mib":     name,
+		"devices": len(devices),
+	}).Info("[snmp] enumerated MIB")
+
+	// Output enumerated devices.
+	for _, dev := range devices {
+		log.WithField("device", dev).Debug("[snmp] enumerated device")
+	}
+
+	server.Mibs = append(server.Mibs, &EnabledMib{Name: name, Mib: mib})
+	server.DeviceConfigs = append(server.DeviceConfigs, devices...)
+	return nil
+}
+
+// Mib gets an enabled MIB by name, or nil if it is not enabled.
+func (server *SnmpServer) Mib(name string) core.Mib {
+	for _, enabled := range server.Mibs {
+		if enabled.Name == name {
+			return enabled.Mib
+		}
+	}
+	return nil
+}
+
+// EnumerateDevices enumerates the devices of all enabled MIBs, merged in the
+// order the MIBs were enabled.
+func (server *SnmpServer) EnumerateDevices(data map[string]interface{}) (devices []*config.DeviceProto, err error) {
+	for _, enabled := range server.Mibs {
+		mibDevices, err := enabled.Mib.EnumerateDevices(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to enumerate mib %v: %v", enabled.Name, err)
+		}
+		devices = append(devices, mibDevices...)
+	}
+	return devices, nil
+}
+
+// Load the data for all enabled MIBs from the SNMP server.
+func (server *SnmpServer) Load() error {
+	for _, enabled := range server.Mibs {
+		if err := enabled.Mib.Load(); err != nil {
+			return fmt.Errorf("failed to load mib %v: %v", enabled.Name, err)
+		}
+	}
+	return nil
+}
+
+// Unload cached data for all enabled MIBs.
+func (server *SnmpServer) Unload() {
+	for _, enabled := range server.Mibs {
+		enabled.Mib.Unload()
+	}
+}
+
+// LogSupport logs a summary of which tables the SNMP server supports for each
+// enabled MIB which can summarize them, and the MIBs which failed to load.
+func (server *SnmpServer) LogSupport() {
+	for _, enabled := range server.Mibs {
+		if mib, ok := enabled.Mib.(interface{ LogSupport(endpoint string) }); ok {
+			mib.LogSupport(server.DeviceConfig.Endpoint)
+		}
+	}
+	for name, err := range server.FailedMibs {
+		log.WithFields(log.Fields{
+			"endpoint": server.DeviceConfig.Endpoint,
+			"mib":      name,
+			"error":    err,
+		}).Infof("[snmp] %s: not supported", name)
+	}
+}
+
+// LoadDefinedMibs loads the MIB definition files in the tableDefinitions list
+// of the dynamic registration configuration and enables them. This allows
+// vendor MIBs to be supported without writing Go.
+func (server *SnmpServer) LoadDefinedMibs(data map[string]interface{}) error {
+	rawPaths, ok := data["tableDefinitions"]
+	if !ok {
+		return nil
+	}
+	paths, ok := rawPaths.([]interface{})
+	if !ok {
+		return fmt.Errorf("tableDefinitions should be a list, %T, %+v", rawPaths, rawPaths)
+	}
+
+	for _, rawPath := range paths {
+		path, ok := rawPath.(string)
+		if !ok {
+			return fmt.Errorf("tableDefinitions path should be a string, %T, %+v", rawPath, rawPath)
+		}
+
+		definition, err := core.LoadMibDefinition(path)
+		if err != nil {
+			return err
+		}
+
+		mib, err := core.NewDefinedMib(definition, server.SnmpServerBase)
+		if err != nil {
+			return fmt.Errorf("%v: %v", path, err)
+		}
+
+		if err = server.EnableMib(mib.Name, mib); err != nil {
+			return fmt.Errorf("%v: %v", path, err)
+		}
+		log.WithFields(log.Fields{
+			"mib":  mib.Name,
+			"file": path,
+		}).Info("[snmp] enabled defined MIB")
+	}
+	return nil
 }
@@ -2,51 +2,77 @@ package servers
 
 import (
 	"fmt"
+	"path/filepath"
+
 	log "github.com/sirupsen/logrus"
-	"strings"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/smi"
 )
 
-// CreateSnmpServer creates a SnmpServer from the configuration data model string.
+// CreateSnmpServer creates a SnmpServer from the configuration data, with the
+// vendor profile for the configured or detected model. The names in any MIB
+// files are loaded first, so that they may be used in place of OIDs. Then it
+// adds the devices from any table definition files, corrects the devices with
+// the quirks of the agent, places them at the configured location and adds any
+// agent metadata from MIB detection.
 func CreateSnmpServer(data map[string]interface{}) (server *SnmpServer, err error) {
-	model, ok := data["model"].(string)
-	if !ok {
-		err = fmt.Errorf("No snmp server model")
-		return
-	}
-
-	// The model string comes from the configuration.
-	// The string itself is a prefix of what is returned from snmpget on OID .1.3.6.1.2.1.33.1.1.2.0
-	if strings.HasPrefix(model, "PXGMS UPS") {
-		var pxgmups *PxgmsUps
-		pxgmups, err = NewPxgmsUps(data)
-		if err == nil {
-			server = pxgmups.SnmpServer
-			return
-		}
+	if err = loadMibNames(data); err != nil {
 		return nil, err
 	}
 
-	if strings.HasPrefix(model, "Galaxy VM") {
-		var galaxyups *GalaxyUps
-		galaxyups, err = NewGalaxyUps(data)
-		if err == nil {
-			server = galaxyups.SnmpServer
-			return
-		}
+	server, err = NewSnmpServer(data)
+	if err != nil {
 		return nil, err
 	}
 
-	if model == "SU10000RT3UPM" {
-		var trippliteups *TrippliteUps
-		trippliteups, err = NewTrippliteUps(data)
-		log.Errorf("Error is: [%s]", err)
-		if err == nil {
-			server = trippliteups.SnmpServer
-			return
-		}
+	if err = server.LoadDefinedMibs(data); err != nil {
 		return nil, err
 	}
+	if err = server.ApplyQuirks(data); err != nil {
+		return nil, err
+	}
+	if err = server.ApplyLocation(data); err != nil {
+		return nil, err
+	}
+	server.ApplyMetadata()
+	return server, nil
+}
 
-	err = fmt.Errorf("Unkown snmp server model: %v", model)
-	return
+// loadMibNames loads the MIB files in the mibFiles list of the dynamic
+// registration configuration and registers their object names. Imports are
+// loaded from the directories of the listed files.
+func loadMibNames(data map[string]interface{}) error {
+	rawPaths, ok := data["mibFiles"]
+	if !ok {
+		return nil
+	}
+	rawPathList, ok := rawPaths.([]interface{})
+	if !ok {
+		return fmt.Errorf("mibFiles should be a list, %T, %+v", rawPaths, rawPaths)
+	}
+
+	var paths, dirs []string
+	for _, rawPath := range rawPathList {
+		path, ok := rawPath.(string)
+		if !ok {
+			return fmt.Errorf("mibFiles path should be a string, %T, %+v", rawPath, rawPath)
+		}
+		paths = append(paths, path)
+		dirs = append(dirs, filepath.Dir(path))
+	}
+
+	loader := smi.NewLoader(dirs...)
+	for _, path := range paths {
+		modules, err := loader.LoadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, module := range modules {
+			log.WithFields(log.Fields{
+				"module":  module.Name,
+				"file":    path,
+				"objects": len(module.Objects),
+			}).Info("[snmp] loaded MIB names")
+		}
+	}
+	return nil
 }
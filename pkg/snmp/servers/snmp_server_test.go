@@ -0,0 +1,300 @@
+package servers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-sdk/sdk/config"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core/coretest"
+	snmpv2mib "github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/mibs/snmpv2_mib"
+)
+
+// testMib is a MIB implementation which does not need an SNMP server.
+type testMib struct {
+	devices []*config.DeviceProto
+	loads   int
+	unloads int
+}
+
+func (mib *testMib) Load() error {
+	mib.loads++
+	return nil
+}
+
+func (mib *testMib) EnumerateDevices(data map[string]interface{}) ([]*config.DeviceProto, error) {
+	return mib.devices, nil
+}
+
+func (mib *testMib) Unload() {
+	mib.unloads++
+}
+
+// registerTestMib registers a test MIB with a device of the given type.
+func registerTestMib(t *testing.T, name string, deviceType string) {
+	err := core.RegisterMib(name, func(server *core.SnmpServerBase) (core.Mib, error) {
+		return &testMib{devices: []*config.DeviceProto{{Type: deviceType}}}, nil
+	})
+	assert.NoError(t, err)
+}
+
+// newOfflineSnmpServer creates an SnmpServer which is never connected.
+func newOfflineSnmpServer(t *testing.T) *SnmpServer {
+	securityParameters, err := core.NewSecurityParameters("simulator", core.SHA, "auctoritas", core.AES, "privatus")
+	assert.NoError(t, err)
+	deviceConfig, err := core.NewDeviceConfig("v3", "127.0.0.1", 1024, securityParameters, "public", []string{})
+	assert.NoError(t, err)
+	client, err := core.NewSnmpClient(deviceConfig)
+	assert.NoError(t, err)
+	base, err := core.NewSnmpServerBase(client, deviceConfig)
+	assert.NoError(t, err)
+	return &SnmpServer{SnmpServerBase: base, FailedMibs: map[string]error{}}
+}
+
+// testEmptyMib is a MIB implementation whose tables have no rows.
+type testEmptyMib struct {
+	testMib
+}
+
+func (mib *testEmptyMib) Support() core.TableSupport {
+	return core.TableSupport{Empty: []string{"TEST-EMPTY-TABLE"}}
+}
+
+func init() {
+	err := core.RegisterMib("TEST-FAILING-MIB", func(server *core.SnmpServerBase) (core.Mib, error) {
+		return nil, fmt.Errorf("request timeout")
+	})
+	if err != nil {
+		panic(err)
+	}
+	err = core.RegisterMib("TEST-LOADED-MIB", func(server *core.SnmpServerBase) (core.Mib, error) {
+		return &testMib{devices: []*config.DeviceProto{{Type: "status"}}}, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+	err = core.RegisterMib("TEST-EMPTY-MIB", func(server *core.SnmpServerBase) (core.Mib, error) {
+		return &testEmptyMib{}, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// TestSnmpServerLoadMibs tests enabling the MIBs in the configuration and
+// merging their devices.
+func TestSnmpServerLoadMibs(t *testing.T) {
+	registerTestMib(t, "TEST-A-MIB", "temperature")
+	registerTestMib(t, "TEST-B-MIB", "voltage")
+
+	server := newOfflineSnmpServer(t)
+	err := server.LoadMibs(map[string]interface{}{
+		"mibs": []interface{}{"TEST-B-MIB", "TEST-EMPTY-MIB", "TEST-A-MIB"},
+	})
+	assert.NoError(t, err)
+
+	// Devices are merged in MIB order.
+	assert.Len(t, server.Mibs, 2)
+	assert.Equal(t, "TEST-B-MIB", server.Mibs[0].Name)
+	assert.Len(t, server.DeviceConfigs, 2)
+	assert.Equal(t, "voltage", server.DeviceConfigs[0].Type)
+	assert.Equal(t, "temperature", server.DeviceConfigs[1].Type)
+	assert.Contains(t, server.FailedMibs, "TEST-EMPTY-MIB")
+
+	devices, err := server.EnumerateDevices(map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Len(t, devices, 2)
+
+	assert.NotNil(t, server.Mib("TEST-A-MIB"))
+	assert.Nil(t, server.Mib("TEST-EMPTY-MIB"))
+
+	assert.NoError(t, server.Load())
+	server.Unload()
+	mib := server.Mib("TEST-A-MIB").(*testMib)
+	assert.Equal(t, 1, mib.loads)
+	assert.Equal(t, 1, mib.unloads)
+
+	// A MIB is only enabled once.
+	assert.Error(t, server.EnableMib("TEST-A-MIB", &testMib{}))
+	assert.Error(t, server.EnableMib("TEST-C-MIB", nil))
+}
+
+// TestSnmpServerLoadMibsErrors tests invalid mibs lists.
+func TestSnmpServerLoadMibsErrors(t *testing.T) {
+	for _, mibs := range []interface{}{
+		"UPS-MIB",
+		[]interface{}{},
+		[]interface{}{1},
+		[]interface{}{"UPS-MIB", "UPS-MIB"},
+		[]interface{}{"TEST-MISSING-MIB"},
+		// MIBs which fail to load are not skipped.
+		[]interface{}{"TEST-LOADED-MIB", "TEST-FAILING-MIB"},
+		// Nothing is implemented.
+		[]interface{}{"TEST-EMPTY-MIB"},
+	} {
+		server := newOfflineSnmpServer(t)
+		assert.Error(t, server.LoadMibs(map[string]interface{}{"mibs": mibs}), mibs)
+	}
+}
+
+// TestMibNamesDefault tests the MIBs enabled without a mibs list.
+func TestMibNamesDefault(t *testing.T) {
+	names, err := mibNames(map[string]interface{}{}, DefaultMibs)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"UPS-MIB"}, names)
+}
+
+// TestSnmpServerApplyMetadata tests adding the detected MIBs to the device
+// context.
+func TestSnmpServerApplyMetadata(t *testing.T) {
+	server := newOfflineSnmpServer(t)
+	server.DeviceConfigs = []*config.DeviceProto{
+		{Type: "voltage"},
+		{Type: "status", Context: map[string]string{"model": "test"}},
+	}
+
+	// Nothing without detection.
+	server.ApplyMetadata()
+	assert.Nil(t, server.DeviceConfigs[0].Context)
+
+	server.Detection = &core.MibDetectionResult{
+		SysObjectID: ".1.3.6.1.4.1.534.2.12",
+		Mibs:        []string{"UPS-MIB"},
+	}
+	server.ApplyMetadata()
+	assert.Equal(t, "UPS-MIB", server.DeviceConfigs[0].Context["detectedMibs"])
+	assert.Equal(t, map[string]string{
+		"model":        "test",
+		"sysObjectID":  ".1.3.6.1.4.1.534.2.12",
+		"detectedMibs": "UPS-MIB",
+	}, server.DeviceConfigs[1].Context)
+}
+
+// TestLoadSystemMibs tests enabling the system MIBs for every agent.
+func TestLoadSystemMibs(t *testing.T) {
+	server := newReplaySnmpServer(t, "tripplite_ups", map[string]interface{}{})
+	assert.Nil(t, server.Mib(snmpv2mib.MibName))
+
+	server.LoadSystemMibs()
+	assert.NotNil(t, server.Mib(snmpv2mib.MibName))
+	assert.Empty(t, server.FailedMibs)
+	sysName := findDevice(server.DeviceConfigs, "sysName")
+	assert.NotNil(t, sysName)
+	assert.Equal(t, "SNMPv2-MIB-System-Table", sysName.Data["table_name"])
+	assert.NotNil(t, findDevice(server.DeviceConfigs, "sysUpTime"))
+
+	// Already enabled MIBs are not enabled again.
+	count := len(server.Mibs)
+	server.LoadSystemMibs()
+	assert.Equal(t, count, len(server.Mibs))
+}
+
+// TestLoadMibsIfMib tests enabling IF-MIB by name and by detection.
+func TestLoadMibsIfMib(t *testing.T) {
+	server := newReplaySnmpServer(t, "pxgms_ups", map[string]interface{}{
+		"mibs": []interface{}{"UPS-MIB", "IF-MIB"},
+	})
+	assert.NotNil(t, server.Mib("IF-MIB"))
+	assert.NotNil(t, findDevice(server.DeviceConfigs, "ifOperStatus eth0"))
+	assert.NotNil(t, findDevice(server.DeviceConfigs, "upsBatteryStatus"))
+
+	server = newReplaySnmpServer(t, "tripplite_ups", map[string]interface{}{"mibs": AutoDetectMibs})
+	assert.Contains(t, server.Detection.Mibs, "IF-MIB")
+	assert.NotNil(t, findDevice(server.DeviceConfigs, "ifInOctets eth0............"))
+}
+
+// TestPxgmsUpsXupsMib tests that the PXGMS profile enables XUPS-MIB along
+// with UPS-MIB.
+func TestPxgmsUpsXupsMib(t *testing.T) {
+	server := newReplaySnmpServer(t, "pxgms_ups", map[string]interface{}{})
+	assert.Equal(t, "eaton-pxgms-ups", server.Profile.Name)
+	assert.NotNil(t, server.Mib("UPS-MIB"))
+	assert.NotNil(t, server.Mib("XUPS-MIB"))
+	assert.Empty(t, server.FailedMibs)
+
+	assert.NotNil(t, findDevice(server.DeviceConfigs, "upsBatteryStatus"))
+	inputWatts := findDevice(server.DeviceConfigs, "xupsInputWatts 2")
+	assert.NotNil(t, inputWatts)
+	assert.Equal(t, ".1.3.6.1.4.1.534.1.3.4.1.4.2", inputWatts.Data["oid"])
+	assert.NotNil(t, findDevice(server.DeviceConfigs, "xupsEnvRemoteHumidity"))
+	assert.NotNil(t, findDevice(server.DeviceConfigs, "xupsTopologyType"))
+}
+
+// TestGalaxyUpsPowerNetMib tests that the APC profile enables PowerNet-MIB
+// ahead of UPS-MIB, so that its devices win over the UPS-MIB devices they
+// duplicate.
+func TestGalaxyUpsPowerNetMib(t *testing.T) {
+	// The UPS-MIB objects of the PXGMS walk stand in for those of the Galaxy.
+	replay, err := coretest.LoadWalkFile("../../../emulator/ups/pxgms_ups/data/public.snmpwalk")
+	assert.NoError(t, err)
+	powerNet, err := coretest.LoadWalkFile("../mibs/powernet_mib/testdata/powernet.snmpwalk")
+	assert.NoError(t, err)
+	replay.Results = append(replay.Results, powerNet.Results...)
+
+	data := map[string]interface{}{"model": "Galaxy VM 180 kVA"}
+	server := newOfflineSnmpServer(t)
+	server.SnmpClient.Dial = replay.Dial
+	assert.NoError(t, server.identify(data))
+	assert.NoError(t, server.LoadMibs(data))
+	assert.Equal(t, "apc-galaxy-ups", server.Profile.Name)
+	assert.NotNil(t, server.Mib("PowerNet-MIB"))
+	assert.NotNil(t, server.Mib("UPS-MIB"))
+
+	// The Galaxy has no probes on its universal I/O ports.
+	assert.Nil(t, server.Mib("PowerNet-MIB-uio"))
+	assert.Len(t, server.FailedMibs, 1)
+	assert.Contains(t, server.FailedMibs, "PowerNet-MIB-uio")
+
+	// Duplicated objects are read from PowerNet-MIB only.
+	assert.NotNil(t, findDevice(server.DeviceConfigs, "upsBasicIdentModel"))
+	assert.Nil(t, findDevice(server.DeviceConfigs, "upsIdentModel"))
+	assert.NotNil(t, findDevice(server.DeviceConfigs, "upsHighPrecBatteryTemperature"))
+	assert.Nil(t, findDevice(server.DeviceConfigs, "upsBatteryTemperature"))
+	assert.NotNil(t, findDevice(server.DeviceConfigs, "upsBasicBatteryStatus"))
+	assert.Nil(t, findDevice(server.DeviceConfigs, "upsBatteryStatus"))
+
+	// The rest of UPS-MIB is kept.
+	assert.NotNil(t, findDevice(server.DeviceConfigs, "upsIdentManufacturer"))
+	assert.NotNil(t, findDevice(server.DeviceConfigs, "upsBasicStateOutputState"))
+}
+
+// TestTrippliteUpsTrippliteMib tests that the Tripp Lite profile enables
+// TRIPPLITE-PRODUCTS along with UPS-MIB.
+func TestTrippliteUpsTrippliteMib(t *testing.T) {
+	server := newReplaySnmpServer(t, "tripplite_ups", map[string]interface{}{})
+	assert.Equal(t, "tripplite-ups", server.Profile.Name)
+	assert.NotNil(t, server.Mib("UPS-MIB"))
+	assert.NotNil(t, server.Mib("TRIPPLITE-PRODUCTS"))
+	assert.Empty(t, server.FailedMibs)
+
+	assert.NotNil(t, findDevice(server.DeviceConfigs, "upsBatteryStatus"))
+	assert.NotNil(t, findDevice(server.DeviceConfigs, "tlUpsSnmpCardSerialNum"))
+	loadBank := findDevice(server.DeviceConfigs, "tlUpsLoadBankState 1")
+	assert.NotNil(t, loadBank)
+	assert.Equal(t, ".1.3.6.1.4.1.850.100.1.4.7.1.2.1", loadBank.Data["oid"])
+}
+
+// TestApcRackPdu tests that APC rack PDUs are identified by their sysObjectID
+// and get the rPDU2 devices.
+func TestApcRackPdu(t *testing.T) {
+	replay, err := coretest.LoadWalkFile("../mibs/rpdu2_mib/testdata/rpdu2.snmpwalk")
+	assert.NoError(t, err)
+	replay.Results = append(replay.Results, core.ReadResult{
+		Oid:  core.SysObjectIDOid,
+		Data: ".1.3.6.1.4.1.318.1.3.4.6",
+	})
+
+	data := map[string]interface{}{}
+	server := newOfflineSnmpServer(t)
+	server.SnmpClient.Dial = replay.Dial
+	assert.NoError(t, server.identify(data))
+	assert.NoError(t, server.LoadMibs(data))
+	assert.Equal(t, "apc-rack-pdu", server.Profile.Name)
+	assert.NotNil(t, server.Mib("PowerNet-MIB-rPDU2"))
+	assert.Empty(t, server.FailedMibs)
+
+	assert.NotNil(t, findDevice(server.DeviceConfigs, "rPDU2DeviceStatusEnergy 1"))
+	assert.NotNil(t, findDevice(server.DeviceConfigs, "rPDU2OutletSwitchedStatusState 1"))
+	assert.NotNil(t, findDevice(server.DeviceConfigs, "rPDU2OutletMeteredStatusPower 1"))
+}
@@ -26,15 +26,15 @@ func TestTrippliteUps(t *testing.T) {
 	data["model"] = "SU10000RT3UPM"
 	data["version"] = "v3"
 
-	trippliteUps, err := NewTrippliteUps(data)
+	trippliteUps, err := CreateSnmpServer(data)
 	assert.NoError(t, err)
 	assert.NotNil(t, trippliteUps)
-	assert.NotNil(t, trippliteUps.SnmpServer)
-	assert.NotNil(t, trippliteUps.SnmpServer.SnmpServerBase)
-	assert.NotNil(t, trippliteUps.SnmpServer.SnmpServerBase.SnmpClient)
-	assert.NotNil(t, trippliteUps.SnmpServer.SnmpServerBase.SnmpClient.DeviceConfig)
+	assert.Equal(t, "tripplite-ups", trippliteUps.Profile.Name)
+	assert.NotNil(t, trippliteUps.SnmpServerBase)
+	assert.NotNil(t, trippliteUps.SnmpServerBase.SnmpClient)
+	assert.NotNil(t, trippliteUps.SnmpServerBase.SnmpClient.DeviceConfig)
 
-	clientDeviceConfig := trippliteUps.SnmpServer.SnmpServerBase.SnmpClient.DeviceConfig
+	clientDeviceConfig := trippliteUps.SnmpServerBase.SnmpClient.DeviceConfig
 	assert.Equal(t, clientDeviceConfig.Version, "V3")
 	assert.Equal(t, clientDeviceConfig.Endpoint, "127.0.0.1")
 	assert.Equal(t, clientDeviceConfig.ContextName, "public")
@@ -48,8 +48,8 @@ func TestTrippliteUps(t *testing.T) {
 	assert.Equal(t, clientDeviceConfig.SecurityParameters.PrivacyPassphrase, "privatus")
 	assert.Equal(t, clientDeviceConfig.Port, uint16(1025))
 
-	assert.NotNil(t, trippliteUps.SnmpServer.SnmpServerBase.DeviceConfig)
-	serverDeviceConfig := trippliteUps.SnmpServer.SnmpServerBase.SnmpClient.DeviceConfig
+	assert.NotNil(t, trippliteUps.SnmpServerBase.DeviceConfig)
+	serverDeviceConfig := trippliteUps.SnmpServerBase.SnmpClient.DeviceConfig
 	assert.Equal(t, serverDeviceConfig.Version, "V3")
 	assert.Equal(t, serverDeviceConfig.Endpoint, "127.0.0.1")
 	assert.Equal(t, serverDeviceConfig.ContextName, "public")
@@ -64,8 +64,8 @@ func TestTrippliteUps(t *testing.T) {
 
 	// Verify device handlers by type.
 	deviceHandlersByType := map[string]int{}
-	for i := 0; i < len(trippliteUps.SnmpServer.DeviceConfigs); i++ {
-		dhType := trippliteUps.SnmpServer.DeviceConfigs[i].Type
+	for i := 0; i < len(trippliteUps.DeviceConfigs); i++ {
+		dhType := trippliteUps.DeviceConfigs[i].Type
 		count, ok := deviceHandlersByType[dhType]
 		if ok {
 			deviceHandlersByType[dhType] = count + 1
@@ -74,16 +74,21 @@ func TestTrippliteUps(t *testing.T) {
 		}
 	}
 
-	assert.Equal(t, 10, len(deviceHandlersByType))
+	assert.Equal(t, 15, len(deviceHandlersByType))
+	assert.Equal(t, 1, deviceHandlersByType["alarm-history"])
+	assert.Equal(t, 1, deviceHandlersByType["alarms"])
 	assert.Equal(t, 4, deviceHandlersByType["current"])
 	assert.Equal(t, 2, deviceHandlersByType["frequency"])
-	assert.Equal(t, 1, deviceHandlersByType["identity"])
+	assert.Equal(t, 3, deviceHandlersByType["identity"]) // UPS-MIB, TRIPPLITE-PRODUCTS and SNMPv2-MIB.
 	assert.Equal(t, 1, deviceHandlersByType["minutes"])
+	assert.Equal(t, 1, deviceHandlersByType["outlet"])
 	assert.Equal(t, 2, deviceHandlersByType["percentage"])
 	assert.Equal(t, 3, deviceHandlersByType["power"])
 	assert.Equal(t, 1, deviceHandlersByType["seconds"])
-	assert.Equal(t, 5, deviceHandlersByType["status"])
+	assert.Equal(t, 4, deviceHandlersByType["status"])
 	assert.Equal(t, 1, deviceHandlersByType["temperature"])
+	assert.Equal(t, 1, deviceHandlersByType["timestamp"])
+	assert.Equal(t, 1, deviceHandlersByType["uptime"])
 	assert.Equal(t, 4, deviceHandlersByType["voltage"])
 
 }
@@ -106,7 +111,7 @@ func TestTrippliteUpsInitializationFailure(t *testing.T) {
 	data["model"] = "SU10000RT3UPM"
 	data["version"] = "v3"
 
-	_, err := NewTrippliteUps(data)
+	_, err := CreateSnmpServer(data)
 	assert.Error(t, err)
 	assert.Equal(t, "incoming packet is not authentic, discarding", err.Error())
 }
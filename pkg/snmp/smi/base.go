@@ -0,0 +1,94 @@
+package smi
+
+// The base SMI modules define the ASN.1 macros, the root of the OID tree and
+// the common textual conventions. They are built in rather than parsed, since
+// their macro definitions are not SMI, and vendors do not ship them
+// consistently. RFC1213-MIB is SMI, so it is parsed from a built in source
+// instead. See builtinSources.
+
+// baseNodes are the nodes defined by SNMPv2-SMI and RFC1155-SMI.
+var baseNodes = []struct {
+	Name string
+	Oid  string
+}{
+	{"iso", ".1"},
+	{"org", ".1.3"},
+	{"dod", ".1.3.6"},
+	{"internet", ".1.3.6.1"},
+	{"directory", ".1.3.6.1.1"},
+	{"mgmt", ".1.3.6.1.2"},
+	{"mib-2", ".1.3.6.1.2.1"},
+	{"transmission", ".1.3.6.1.2.1.10"},
+	{"experimental", ".1.3.6.1.3"},
+	{"private", ".1.3.6.1.4"},
+	{"enterprises", ".1.3.6.1.4.1"},
+	{"security", ".1.3.6.1.5"},
+	{"snmpV2", ".1.3.6.1.6"},
+	{"snmpDomains", ".1.3.6.1.6.1"},
+	{"snmpProxys", ".1.3.6.1.6.2"},
+	{"snmpModules", ".1.3.6.1.6.3"},
+	{"zeroDotZero", ".0.0"},
+}
+
+// baseTextualConventions are the textual conventions defined by SNMPv2-TC.
+var baseTextualConventions = []*TextualConvention{
+	{Name: "DisplayString", DisplayHint: "255a", Syntax: &Syntax{Type: "OCTET STRING"}},
+	{Name: "PhysAddress", DisplayHint: "1x:", Syntax: &Syntax{Type: "OCTET STRING"}},
+	{Name: "MacAddress", DisplayHint: "1x:", Syntax: &Syntax{Type: "OCTET STRING"}},
+	{Name: "TruthValue", Syntax: &Syntax{Type: "INTEGER", Enumeration: map[int]string{1: "true", 2: "false"}}},
+	{Name: "TestAndIncr", Syntax: &Syntax{Type: "INTEGER"}},
+	{Name: "AutonomousType", Syntax: &Syntax{Type: "OBJECT IDENTIFIER"}},
+	{Name: "InstancePointer", Syntax: &Syntax{Type: "OBJECT IDENTIFIER"}},
+	{Name: "VariablePointer", Syntax: &Syntax{Type: "OBJECT IDENTIFIER"}},
+	{Name: "RowPointer", Syntax: &Syntax{Type: "OBJECT IDENTIFIER"}},
+	{Name: "RowStatus", Syntax: &Syntax{Type: "INTEGER", Enumeration: map[int]string{
+		1: "active", 2: "notInService", 3: "notReady", 4: "createAndGo", 5: "createAndWait", 6: "destroy"}}},
+	{Name: "TimeStamp", Syntax: &Syntax{Type: "TimeTicks"}},
+	{Name: "TimeInterval", Syntax: &Syntax{Type: "INTEGER"}},
+	{Name: "DateAndTime", DisplayHint: "2d-1d-1d,1d:1d:1d.1d,1a1d:1d", Syntax: &Syntax{Type: "OCTET STRING"}},
+	{Name: "StorageType", Syntax: &Syntax{Type: "INTEGER", Enumeration: map[int]string{
+		1: "other", 2: "volatile", 3: "nonVolatile", 4: "permanent", 5: "readOnly"}}},
+	{Name: "TDomain", Syntax: &Syntax{Type: "OBJECT IDENTIFIER"}},
+	{Name: "TAddress", Syntax: &Syntax{Type: "OCTET STRING"}},
+}
+
+// baseTypes are the application types defined by SNMPv2-SMI and RFC1155-SMI.
+var baseTypes = []string{
+	"Integer32", "Unsigned32", "Gauge32", "Counter32", "Counter64", "TimeTicks",
+	"IpAddress", "Opaque", "Counter", "Gauge", "NetworkAddress",
+}
+
+// newBaseModules creates the built in base modules by name.
+func newBaseModules() map[string]*Module {
+	smi := newModule("SNMPv2-SMI")
+	for _, node := range baseNodes {
+		smi.addObject(&Object{Name: node.Name, Kind: KindObjectIdentifier, Oid: node.Oid})
+	}
+	for _, name := range baseTypes {
+		smi.Types[name] = &Syntax{Type: name, BaseType: name}
+	}
+
+	tc := newModule("SNMPv2-TC")
+	for _, convention := range baseTextualConventions {
+		syntax := *convention.Syntax
+		syntax.BaseType = syntax.Type
+		copied := *convention
+		copied.Syntax = &syntax
+		tc.TextualConventions[copied.Name] = &copied
+	}
+
+	// SMIv1 modules have the same definitions, under other names.
+	v1smi := newModule("RFC1155-SMI")
+	v1smi.objects = smi.objects
+	v1smi.Objects = smi.Objects
+	v1smi.Types = smi.Types
+
+	return map[string]*Module{
+		smi.Name:      smi,
+		tc.Name:       tc,
+		v1smi.Name:    v1smi,
+		"SNMPv2-CONF": newModule("SNMPv2-CONF"),
+		"RFC-1212":    newModule("RFC-1212"),
+		"RFC-1215":    newModule("RFC-1215"),
+	}
+}
@@ -0,0 +1,215 @@
+package smi
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// DeviceTypes maps object names to synse device types, e.g.
+// upsInputVoltage: voltage. Columns without a device type are read, but do
+// not create devices.
+type DeviceTypes map[string]string
+
+// TableDefinition generates a core.TableDefinition for an object in the
+// loaded modules. A table object (SEQUENCE OF an entry) generates a table of
+// the entry columns. Any other object generates a flattened table of the
+// scalars directly under it, e.g. the upsInput group for upsInputLineBads and
+// upsInputNumLines.
+//
+// Columns in a core table are positional, so missing OID components become
+// placeholder columns without devices. Device enumerations come from the
+// object syntax, and multipliers from the DISPLAY-HINT (d-1 is 0.1) or UNITS
+// (0.1 Hertz is 0.1).
+func (loader *Loader) TableDefinition(name string, devices DeviceTypes) (*core.TableDefinition, error) {
+	object, err := loader.Object(name)
+	if err != nil {
+		return nil, err
+	}
+
+	definition := &core.TableDefinition{
+		Name:    fmt.Sprintf("%s-%s", object.Module, object.Name),
+		WalkOid: object.Oid,
+	}
+
+	var columns []*Object
+	if object.IsTable() {
+		entries := loader.Children(object)
+		if len(entries) != 1 {
+			return nil, fmt.Errorf("table %v has %d entries, expected 1", name, len(entries))
+		}
+		definition.RowBase = strconv.FormatUint(lastArc(entries[0]), 10)
+		columns = loader.Children(entries[0])
+		if definition.Index, err = loader.indexComponents(entries[0]); err != nil {
+			return nil, fmt.Errorf("table %v: %v", name, err)
+		}
+		if definition.Augments, err = loader.augmentedTable(entries[0]); err != nil {
+			return nil, fmt.Errorf("table %v: %v", name, err)
+		}
+	} else {
+		definition.Flattened = true
+		for _, child := range loader.Children(object) {
+			if child.Kind == KindObjectType && !child.IsTable() {
+				columns = append(columns, child)
+			}
+		}
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("%v has no columns", name)
+	}
+
+	for _, column := range columns {
+		arc := int(lastArc(column))
+		for len(definition.Columns) < arc-1 {
+			definition.Columns = append(definition.Columns, &core.ColumnDefinition{
+				Name: fmt.Sprintf("%sColumn%d", object.Name, len(definition.Columns)+1),
+			})
+		}
+		if len(definition.Columns) >= arc {
+			return nil, fmt.Errorf("%v has a duplicate column %d", name, arc)
+		}
+
+		columnDefinition := &core.ColumnDefinition{Name: column.Name}
+		if deviceType, ok := devices[column.Name]; ok {
+			columnDefinition.Device = newColumnDevice(column, deviceType)
+		}
+		definition.Columns = append(definition.Columns, columnDefinition)
+
+		// Tables need a readable column to find the rows. Index columns are
+		// generally not-accessible.
+		if !definition.Flattened && definition.ReadableColumn == "" && column.IsReadable() {
+			definition.ReadableColumn = strconv.Itoa(arc)
+		}
+	}
+
+	return definition, definition.Validate()
+}
+
+// indexComponents gets the INDEX clause of a table entry. An entry which
+// AUGMENTS another has the index of the other entry.
+func (loader *Loader) indexComponents(entry *Object) ([]core.IndexComponent, error) {
+	module := loader.modules[entry.Module]
+	if entry.Augments != "" {
+		augmented, _ := loader.lookup(module, entry.Augments)
+		if augmented == nil {
+			return nil, fmt.Errorf("unknown augmented entry %v", entry.Augments)
+		}
+		return loader.indexComponents(augmented)
+	}
+
+	var index []core.IndexComponent
+	for i, name := range entry.Index {
+		object, _ := loader.lookup(module, name)
+		if object == nil || object.Syntax == nil {
+			return nil, fmt.Errorf("unknown index object %v", name)
+		}
+		component := core.IndexComponent{Name: name}
+		switch object.Syntax.BaseType {
+		case "INTEGER", "Integer32", "Unsigned32", "Gauge32", "Gauge", "Counter32", "Counter", "TimeTicks":
+			component.Type = core.IndexInteger
+		case "IpAddress", "NetworkAddress":
+			component.Type = core.IndexIPAddress
+		case "OCTET STRING":
+			component.Type = core.IndexString
+			if object.Syntax.TextualConvention == "MacAddress" {
+				component.Length = 6
+			}
+			// Binary strings have hex display hints, e.g. 1x: for MacAddress.
+			component.Hex = strings.Contains(object.Syntax.DisplayHint, "x")
+		case "OBJECT IDENTIFIER":
+			component.Type = core.IndexOid
+		default:
+			return nil, fmt.Errorf("index object %v has unsupported type %v", name, object.Syntax.BaseType)
+		}
+		if entry.Implied && i == len(entry.Index)-1 && component.Length == 0 &&
+			(component.Type == core.IndexString || component.Type == core.IndexOid) {
+			component.Implied = true
+		}
+		index = append(index, component)
+	}
+	return index, nil
+}
+
+// augmentedTable gets the name of the table definition for the table which
+// an entry AUGMENTS, or an empty string if the entry does not augment
+// another.
+func (loader *Loader) augmentedTable(entry *Object) (string, error) {
+	if entry.Augments == "" {
+		return "", nil
+	}
+	augmented, _ := loader.lookup(loader.modules[entry.Module], entry.Augments)
+	if augmented == nil {
+		return "", fmt.Errorf("unknown augmented entry %v", entry.Augments)
+	}
+	table, ok := loader.ObjectByOid(augmented.Oid[:strings.LastIndex(augmented.Oid, ".")])
+	if !ok {
+		return "", fmt.Errorf("augmented entry %v has no table", entry.Augments)
+	}
+	return fmt.Sprintf("%s-%s", table.Module, table.Name), nil
+}
+
+// MibDefinition generates a core.MibDefinition with a table for each of the
+// named objects. See TableDefinition. A table which AUGMENTS a table not in
+// the MIB definition is not joined.
+func (loader *Loader) MibDefinition(mibName string, tables []string, devices DeviceTypes) (*core.MibDefinition, error) {
+	definition := &core.MibDefinition{Name: mibName}
+	names := map[string]bool{}
+	for _, table := range tables {
+		tableDefinition, err := loader.TableDefinition(table, devices)
+		if err != nil {
+			return nil, err
+		}
+		definition.Tables = append(definition.Tables, tableDefinition)
+		names[tableDefinition.Name] = true
+	}
+	for _, tableDefinition := range definition.Tables {
+		if !names[tableDefinition.Augments] {
+			tableDefinition.Augments = ""
+		}
+	}
+	return definition, definition.Validate()
+}
+
+// newColumnDevice creates the device definition for a column.
+func newColumnDevice(column *Object, deviceType string) *core.ColumnDeviceDefinition {
+	device := &core.ColumnDeviceDefinition{Type: deviceType}
+	if column.Syntax == nil {
+		return device
+	}
+	if len(column.Syntax.Enumeration) > 0 {
+		device.Enumeration = column.Syntax.Enumeration
+	}
+	if multiplier, ok := Multiplier(column.Syntax.DisplayHint, column.Units); ok {
+		device.Multiplier = &multiplier
+	}
+	return device
+}
+
+var (
+	// decimalHint matches an integer DISPLAY-HINT with an implied decimal point.
+	decimalHint = regexp.MustCompile(`^d-([0-9]+)$`)
+	// scaledUnits matches UNITS with a scale, e.g. 0.1 RMS Amp.
+	scaledUnits = regexp.MustCompile(`^\s*([0-9]*\.[0-9]+)\s`)
+)
+
+// Multiplier gets the multiplier for raw readings from the DISPLAY-HINT or
+// UNITS of an object. ok is false if the readings are not scaled.
+func Multiplier(displayHint, units string) (multiplier float32, ok bool) {
+	if match := decimalHint.FindStringSubmatch(displayHint); match != nil {
+		places, err := strconv.Atoi(match[1])
+		if err == nil && places > 0 {
+			return float32(math.Pow10(-places)), true
+		}
+	}
+	if match := scaledUnits.FindStringSubmatch(units); match != nil {
+		scale, err := strconv.ParseFloat(match[1], 32)
+		if err == nil && scale > 0 && scale != 1 {
+			return float32(scale), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,171 @@
+package smi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// TestTableDefinition tests generating the UPS-MIB input table, which matches
+// the table written in Go.
+func TestTableDefinition(t *testing.T) {
+	loader := NewLoader("testdata")
+	_, err := loader.Load("UPS-MIB")
+	assert.NoError(t, err)
+
+	definition, err := loader.TableDefinition("upsInputTable", DeviceTypes{
+		"upsInputFrequency": "frequency",
+		"upsInputVoltage":   "voltage",
+		"upsInputCurrent":   "current",
+		"upsInputTruePower": "power",
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "UPS-MIB-upsInputTable", definition.Name)
+	assert.Equal(t, ".1.3.6.1.2.1.33.1.3.3", definition.WalkOid)
+	assert.Equal(t, "1", definition.RowBase)
+	assert.Equal(t, "2", definition.ReadableColumn)
+	assert.False(t, definition.Flattened)
+	assert.Equal(t, []string{
+		"upsInputLineIndex",
+		"upsInputFrequency",
+		"upsInputVoltage",
+		"upsInputCurrent",
+		"upsInputTruePower",
+	}, definition.ColumnNames())
+
+	assert.Nil(t, definition.Columns[0].Device)
+	assert.Equal(t, "frequency", definition.Columns[1].Device.Type)
+	assert.Equal(t, float32(0.1), *definition.Columns[1].Device.Multiplier)
+	assert.Nil(t, definition.Columns[2].Device.Multiplier)
+	assert.Equal(t, float32(0.1), *definition.Columns[3].Device.Multiplier)
+	assert.Nil(t, definition.Columns[4].Device.Multiplier)
+}
+
+// TestTableDefinitionScalars tests generating a flattened table for a group
+// of scalars.
+func TestTableDefinitionScalars(t *testing.T) {
+	loader := NewLoader("testdata")
+	_, err := loader.Load("UPS-MIB")
+	assert.NoError(t, err)
+
+	// The upsInputTable under upsInput is not a column.
+	definition, err := loader.TableDefinition("upsInput", nil)
+	assert.NoError(t, err)
+	assert.True(t, definition.Flattened)
+	assert.Equal(t, []string{"upsInputLineBads", "upsInputNumLines"}, definition.ColumnNames())
+
+	// Missing OIDs are placeholder columns.
+	definition, err = loader.TableDefinition("upsBattery", DeviceTypes{
+		"upsBatteryStatus":  "status",
+		"upsBatteryVoltage": "voltage",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"upsBatteryStatus",
+		"upsSecondsOnBattery",
+		"upsBatteryColumn3",
+		"upsBatteryColumn4",
+		"upsBatteryVoltage",
+	}, definition.ColumnNames())
+	assert.Equal(t, map[int]string{
+		1: "unknown", 2: "batteryNormal", 3: "batteryLow", 4: "batteryDepleted",
+	}, definition.Columns[0].Device.Enumeration)
+	assert.Nil(t, definition.Columns[2].Device)
+	assert.Equal(t, float32(0.1), *definition.Columns[4].Device.Multiplier)
+}
+
+// TestTableDefinitionVendor tests generating a vendor table with display
+// hints, textual convention enumerations and a missing column.
+func TestTableDefinitionVendor(t *testing.T) {
+	loader := NewLoader("testdata")
+	_, err := loader.Load("TEST-VENDOR-MIB")
+	assert.NoError(t, err)
+
+	definition, err := loader.TableDefinition("testSensorTable", DeviceTypes{
+		"testSensorTemperature": "temperature",
+		"testSensorState":       "status",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "3", definition.ReadableColumn)
+	assert.Len(t, definition.Columns, 7)
+	assert.Equal(t, "testSensorTableColumn5", definition.Columns[4].Name)
+	assert.Equal(t, float32(0.1), *definition.Columns[2].Device.Multiplier)
+	assert.Equal(t, "critical", definition.Columns[3].Device.Enumeration[3])
+	assert.Equal(t, []core.IndexComponent{
+		{Name: "testSensorIndex", Type: core.IndexInteger},
+		{Name: "testSensorName", Type: core.IndexString, Implied: true},
+	}, definition.Index)
+
+	// A table which AUGMENTS another has the same index.
+	ext, err := loader.TableDefinition("testSensorExtTable", DeviceTypes{})
+	assert.NoError(t, err)
+	assert.Equal(t, definition.Index, ext.Index)
+	assert.Equal(t, "TEST-VENDOR-MIB-testSensorTable", ext.Augments)
+
+	// The augmented table is joined when it is in the MIB definition.
+	mib, err := loader.MibDefinition("TEST-VENDOR-MIB", []string{"testSensorTable", "testSensorExtTable"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "TEST-VENDOR-MIB-testSensorTable", mib.Tables[1].Augments)
+	mib, err = loader.MibDefinition("TEST-VENDOR-MIB", []string{"testSensorExtTable"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "", mib.Tables[0].Augments)
+}
+
+// TestTableDefinitionHexIndex tests that binary string index components are
+// formatted as hex.
+func TestTableDefinitionHexIndex(t *testing.T) {
+	loader := NewLoader("testdata")
+	_, err := loader.Load("TEST-V1-MIB")
+	assert.NoError(t, err)
+
+	definition, err := loader.TableDefinition("testV1PortTable", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []core.IndexComponent{
+		{Name: "ifIndex", Type: core.IndexInteger},
+		{Name: "testV1PortAddress", Type: core.IndexString, Hex: true},
+	}, definition.Index)
+}
+
+// TestMibDefinition tests generating a MIB definition.
+func TestMibDefinition(t *testing.T) {
+	loader := NewLoader("testdata")
+	_, err := loader.Load("UPS-MIB")
+	assert.NoError(t, err)
+
+	definition, err := loader.MibDefinition("UPS-MIB", []string{"upsInput", "upsInputTable", "upsOutput"}, DeviceTypes{
+		"upsInputVoltage": "voltage",
+		"upsOutputSource": "status",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, definition.Tables, 3)
+	assert.Equal(t, "battery", definition.Tables[2].Columns[0].Device.Enumeration[5])
+
+	_, err = loader.MibDefinition("UPS-MIB", []string{"upsMissing"}, nil)
+	assert.Error(t, err)
+	_, err = loader.MibDefinition("UPS-MIB", []string{"upsAlarmsPresent"}, nil)
+	assert.Error(t, err)
+}
+
+// TestMultiplier tests scaling from display hints and units.
+func TestMultiplier(t *testing.T) {
+	for _, test := range []struct {
+		hint       string
+		units      string
+		multiplier float32
+		ok         bool
+	}{
+		{"d-1", "", 0.1, true},
+		{"d-2", "percent", 0.01, true},
+		{"d", "0.1 Hertz", 0.1, true},
+		{"", "0.01 Amps", 0.01, true},
+		{"d", "RMS Volts", 0, false},
+		{"255a", "", 0, false},
+		{"", "1.0 Volts", 0, false},
+	} {
+		multiplier, ok := Multiplier(test.hint, test.units)
+		assert.Equal(t, test.ok, ok, test)
+		assert.Equal(t, test.multiplier, multiplier, test)
+	}
+}
@@ -0,0 +1,163 @@
+package smi
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// tokenKind is the kind of a lexical token in a MIB module.
+type tokenKind int
+
+const (
+	tokenIdentifier tokenKind = iota // Identifiers and keywords, e.g. upsMIB, OBJECT-TYPE.
+	tokenNumber                      // Decimal numbers, possibly negative.
+	tokenString                      // Quoted strings, without the quotes.
+	tokenBinary                      // Binary and hex strings, e.g. '00'H.
+	tokenSymbol                      // Punctuation, e.g. ::= { } ( ) , ; .. |
+	tokenEOF                         // The end of the input.
+)
+
+// token is a lexical token in a MIB module.
+type token struct {
+	Kind tokenKind
+	Text string
+	Line int
+}
+
+// String formats the token for error messages.
+func (t token) String() string {
+	switch t.Kind {
+	case tokenEOF:
+		return "end of file"
+	case tokenString:
+		return fmt.Sprintf("string %q", t.Text)
+	default:
+		return fmt.Sprintf("%q", t.Text)
+	}
+}
+
+// tokenize splits the text of a MIB file into tokens. ASN.1 comments start
+// with -- and end at the end of the line or at the next --.
+func tokenize(text string) ([]token, error) {
+	var tokens []token
+	runes := []rune(text)
+	line := 1
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case r == '\n':
+			line++
+			i++
+
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			// Comment.
+			i += 2
+			for i < len(runes) && runes[i] != '\n' {
+				if runes[i] == '-' && i+1 < len(runes) && runes[i+1] == '-' {
+					i += 2
+					break
+				}
+				i++
+			}
+
+		case r == '"':
+			// Quoted strings may span lines. A doubled quote is a literal quote.
+			start := line
+			var builder strings.Builder
+			i++
+			for {
+				if i >= len(runes) {
+					return nil, fmt.Errorf("line %d: unterminated string", start)
+				}
+				if runes[i] == '"' {
+					if i+1 < len(runes) && runes[i+1] == '"' {
+						builder.WriteRune('"')
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				if runes[i] == '\n' {
+					line++
+				}
+				builder.WriteRune(runes[i])
+				i++
+			}
+			tokens = append(tokens, token{Kind: tokenString, Text: builder.String(), Line: start})
+
+		case r == '\'':
+			// Binary or hex string, e.g. '0A'H.
+			j := i + 1
+			for j < len(runes) && runes[j] != '\'' {
+				j++
+			}
+			if j+1 >= len(runes) {
+				return nil, fmt.Errorf("line %d: unterminated binary string", line)
+			}
+			tokens = append(tokens, token{Kind: tokenBinary, Text: string(runes[i : j+2]), Line: line})
+			i = j + 2
+
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			j := i + 1
+			for j < len(runes) && unicode.IsDigit(runes[j]) {
+				j++
+			}
+			tokens = append(tokens, token{Kind: tokenNumber, Text: string(runes[i:j]), Line: line})
+			i = j
+
+		case unicode.IsLetter(r):
+			// Identifiers may contain single hyphens, but -- starts a comment.
+			j := i + 1
+			for j < len(runes) {
+				if unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_' {
+					j++
+					continue
+				}
+				if runes[j] == '-' && j+1 < len(runes) && runes[j+1] != '-' &&
+					(unicode.IsLetter(runes[j+1]) || unicode.IsDigit(runes[j+1])) {
+					j++
+					continue
+				}
+				break
+			}
+			tokens = append(tokens, token{Kind: tokenIdentifier, Text: string(runes[i:j]), Line: line})
+			i = j
+
+		case hasPrefix(runes, i, "::="):
+			tokens = append(tokens, token{Kind: tokenSymbol, Text: "::=", Line: line})
+			i += 3
+
+		case hasPrefix(runes, i, ".."):
+			tokens = append(tokens, token{Kind: tokenSymbol, Text: "..", Line: line})
+			i += 2
+
+		case strings.ContainsRune("{}(),;|[]<>.:=-", r):
+			tokens = append(tokens, token{Kind: tokenSymbol, Text: string(r), Line: line})
+			i++
+
+		default:
+			return nil, fmt.Errorf("line %d: unexpected character %q", line, r)
+		}
+	}
+
+	tokens = append(tokens, token{Kind: tokenEOF, Line: line})
+	return tokens, nil
+}
+
+// hasPrefix returns true if the runes at i start with prefix.
+func hasPrefix(runes []rune, i int, prefix string) bool {
+	for _, r := range prefix {
+		if i >= len(runes) || runes[i] != r {
+			return false
+		}
+		i++
+	}
+	return true
+}
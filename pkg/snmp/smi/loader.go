@@ -0,0 +1,438 @@
+package smi
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// moduleHeader matches the start of a module in a MIB file.
+var moduleHeader = regexp.MustCompile(`(?m)^\s*([A-Za-z][A-Za-z0-9-]*)\s+DEFINITIONS\s*(?:[A-Z]+\s+TAGS\s*)?::=\s*BEGIN`)
+
+// Loader loads MIB modules from local MIB directories. Imports are loaded
+// from the same directories, so that OIDs and textual conventions resolve
+// across modules. The base SMI modules, e.g. SNMPv2-SMI and SNMPv2-TC, are
+// built in.
+type Loader struct {
+	// The directories to find MIB files in.
+	Dirs []string
+
+	modules map[string]*Module // Loaded modules by name.
+	files   map[string]string  // MIB files by module name. nil until indexed.
+	loading map[string]bool    // Modules being loaded, for import cycles.
+	byOid   map[string]*Object // Objects of loaded modules by OID.
+}
+
+// NewLoader creates a Loader for the MIB directories.
+func NewLoader(dirs ...string) *Loader {
+	loader := &Loader{
+		Dirs:    dirs,
+		modules: newBaseModules(),
+		loading: map[string]bool{},
+		byOid:   map[string]*Object{},
+	}
+	for _, module := range loader.modules {
+		loader.addObjects(module)
+	}
+	return loader
+}
+
+// indexFiles finds the modules in each file in the MIB directories.
+func (loader *Loader) indexFiles() error {
+	loader.files = map[string]string{}
+	for _, dir := range loader.Dirs {
+		entries, err := ioutil.ReadDir(dir)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			for _, match := range moduleHeader.FindAllStringSubmatch(string(content), -1) {
+				// The first directory wins.
+				if _, ok := loader.files[match[1]]; !ok {
+					loader.files[match[1]] = path
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Load loads a module by name from the MIB directories, along with its
+// imports. Modules with a built in source, e.g. RFC1213-MIB, are loaded from
+// it when the MIB directories do not have them.
+func (loader *Loader) Load(name string) (*Module, error) {
+	if module, ok := loader.modules[name]; ok {
+		return module, nil
+	}
+
+	if loader.files == nil {
+		if err := loader.indexFiles(); err != nil {
+			return nil, err
+		}
+	}
+	path, ok := loader.files[name]
+	if !ok {
+		return loader.loadBuiltin(name)
+	}
+
+	modules, err := loader.parseFile(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, module := range modules {
+		if module.Name == name {
+			return module, loader.load(module)
+		}
+	}
+	return nil, fmt.Errorf("MIB module %v not found in %v", name, path)
+}
+
+// loadBuiltin loads a module from its built in source.
+func (loader *Loader) loadBuiltin(name string) (*Module, error) {
+	source, ok := builtinSources[name]
+	if !ok {
+		return nil, fmt.Errorf("MIB module %v not found in %v", name, loader.Dirs)
+	}
+	modules, err := ParseModules(source)
+	if err != nil {
+		return nil, fmt.Errorf("built in %v: %v", name, err)
+	}
+	return modules[0], loader.load(modules[0])
+}
+
+// LoadFile loads the modules in a MIB file, along with their imports from
+// the MIB directories.
+func (loader *Loader) LoadFile(path string) ([]*Module, error) {
+	modules, err := loader.parseFile(path)
+	if err != nil {
+		return nil, err
+	}
+	for i, module := range modules {
+		if existing, ok := loader.modules[module.Name]; ok {
+			if existing.File != path {
+				return nil, fmt.Errorf("MIB module %v in %v is already loaded from %v", module.Name, path, existing.File)
+			}
+			modules[i] = existing
+			continue
+		}
+		if err = loader.load(module); err != nil {
+			return nil, err
+		}
+	}
+	return modules, nil
+}
+
+// parseFile parses the modules in a MIB file.
+func (loader *Loader) parseFile(path string) ([]*Module, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	modules, err := ParseModules(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", path, err)
+	}
+	for _, module := range modules {
+		module.File = path
+	}
+	return modules, nil
+}
+
+// load loads the imports of a parsed module and resolves it.
+func (loader *Loader) load(module *Module) error {
+	if _, ok := loader.modules[module.Name]; ok || loader.loading[module.Name] {
+		return nil
+	}
+	loader.loading[module.Name] = true
+	defer delete(loader.loading, module.Name)
+
+	// Import each module once.
+	var imports []string
+	for _, from := range module.Imports {
+		imports = append(imports, from)
+	}
+	sort.Strings(imports)
+	for i, from := range imports {
+		if (i > 0 && from == imports[i-1]) || loader.loading[from] {
+			continue
+		}
+		if _, err := loader.Load(from); err != nil {
+			return fmt.Errorf("%v: %v", module.Name, err)
+		}
+	}
+
+	// The module is loaded before it resolves, so cyclic imports find it.
+	loader.modules[module.Name] = module
+	if err := loader.resolve(module); err != nil {
+		delete(loader.modules, module.Name)
+		return err
+	}
+	loader.addObjects(module)
+	loader.RegisterNames(module, core.OidNames)
+
+	log.WithFields(log.Fields{
+		"module":  module.Name,
+		"file":    module.File,
+		"objects": len(module.Objects),
+	}).Debug("[snmp] loaded MIB module")
+	return nil
+}
+
+// addObjects adds the objects of a resolved module to the OID index.
+func (loader *Loader) addObjects(module *Module) {
+	for _, object := range module.Objects {
+		if _, ok := loader.byOid[object.Oid]; !ok {
+			loader.byOid[object.Oid] = object
+		}
+	}
+}
+
+// RegisterNames registers the names of the objects in a module, so that they
+// may be used in place of OIDs. Modules are registered with core.OidNames as
+// they are loaded.
+func (loader *Loader) RegisterNames(module *Module, registry *core.NameRegistry) {
+	for _, object := range module.Objects {
+		if err := registry.Register(module.Name, object.Name, object.Oid); err != nil {
+			log.WithFields(log.Fields{
+				"error":  err,
+				"module": module.Name,
+			}).Warn("[snmp] failed to register oid name")
+		}
+	}
+}
+
+// resolve resolves the OIDs and syntaxes of the objects in a module.
+func (loader *Loader) resolve(module *Module) error {
+	for _, object := range module.Objects {
+		if _, err := loader.resolveOid(module, object, map[*Object]bool{}); err != nil {
+			return fmt.Errorf("%v: %v", module.Name, err)
+		}
+		if object.Syntax != nil {
+			loader.resolveSyntax(module, object.Syntax, 0)
+		}
+	}
+	for _, tc := range module.TextualConventions {
+		loader.resolveSyntax(module, tc.Syntax, 0)
+	}
+	return nil
+}
+
+// lookup finds a symbol in the scope of a module: the module itself, its
+// imports, then the base SMI nodes, which modules often forget to import.
+func (loader *Loader) lookup(module *Module, name string) (*Object, *Module) {
+	if object, ok := module.objects[name]; ok {
+		return object, module
+	}
+	if from, ok := module.Imports[name]; ok {
+		if imported, ok := loader.modules[from]; ok {
+			if object, ok := imported.objects[name]; ok {
+				return object, imported
+			}
+		}
+	}
+	base := loader.modules["SNMPv2-SMI"]
+	if object, ok := base.objects[name]; ok {
+		return object, base
+	}
+	return nil, nil
+}
+
+// resolveOid resolves the OID of an object from its OID value.
+func (loader *Loader) resolveOid(module *Module, object *Object, visiting map[*Object]bool) (string, error) {
+	if object.Oid != "" {
+		return object.Oid, nil
+	}
+	if visiting[object] {
+		return "", fmt.Errorf("%v: OID value is circular", object.Name)
+	}
+	visiting[object] = true
+
+	var oid string
+	for i, component := range object.value {
+		switch {
+		case i == 0 && !component.HasNumber:
+			parent, parentModule := loader.lookup(module, component.Name)
+			if parent == nil {
+				return "", fmt.Errorf("%v: unknown OID parent %v", object.Name, component.Name)
+			}
+			parentOid, err := loader.resolveOid(parentModule, parent, visiting)
+			if err != nil {
+				return "", err
+			}
+			oid = parentOid
+		case component.HasNumber:
+			oid = fmt.Sprintf("%s.%d", oid, component.Number)
+		default:
+			return "", fmt.Errorf("%v: OID component %v has no number", object.Name, component.Name)
+		}
+	}
+	object.Oid = oid
+	return oid, nil
+}
+
+// primitiveTypes are types which are not defined by any module.
+var primitiveTypes = map[string]bool{
+	"INTEGER":           true,
+	"OCTET STRING":      true,
+	"OBJECT IDENTIFIER": true,
+	"BITS":              true,
+	"SEQUENCE":          true,
+	"SEQUENCE OF":       true,
+	"CHOICE":            true,
+	"NULL":              true,
+}
+
+// resolveSyntax follows textual conventions and type assignments to fill in
+// the base type, display hint and enumeration of a syntax.
+func (loader *Loader) resolveSyntax(module *Module, syntax *Syntax, depth int) {
+	if syntax.BaseType != "" {
+		return
+	}
+	syntax.BaseType = syntax.Type
+	if primitiveTypes[syntax.Type] || depth > 16 {
+		return
+	}
+
+	// Find the type in the module, its imports, or the base modules.
+	var scopes []*Module
+	scopes = append(scopes, module)
+	if from, ok := module.Imports[syntax.Type]; ok {
+		if imported, ok := loader.modules[from]; ok {
+			scopes = append(scopes, imported)
+		}
+	}
+	scopes = append(scopes, loader.modules["SNMPv2-TC"], loader.modules["SNMPv2-SMI"])
+
+	for _, scope := range scopes {
+		if tc, ok := scope.TextualConventions[syntax.Type]; ok {
+			loader.resolveSyntax(scope, tc.Syntax, depth+1)
+			syntax.TextualConvention = tc.Name
+			syntax.DisplayHint = tc.DisplayHint
+			syntax.BaseType = tc.Syntax.BaseType
+			if len(syntax.Enumeration) == 0 {
+				syntax.Enumeration = tc.Syntax.Enumeration
+			}
+			return
+		}
+		if alias, ok := scope.Types[syntax.Type]; ok {
+			loader.resolveSyntax(scope, alias, depth+1)
+			syntax.TextualConvention = alias.TextualConvention
+			syntax.DisplayHint = alias.DisplayHint
+			syntax.BaseType = alias.BaseType
+			if len(syntax.Enumeration) == 0 {
+				syntax.Enumeration = alias.Enumeration
+			}
+			return
+		}
+	}
+}
+
+// Module gets a loaded module by name.
+func (loader *Loader) Module(name string) (*Module, bool) {
+	module, ok := loader.modules[name]
+	return module, ok
+}
+
+// Object finds an object in the loaded modules by name. The name may be
+// qualified with the module, e.g. UPS-MIB::upsInputVoltage. An unqualified
+// name which is defined by more than one module is an error.
+func (loader *Loader) Object(name string) (*Object, error) {
+	if i := strings.Index(name, "::"); i >= 0 {
+		module, ok := loader.modules[name[:i]]
+		if !ok {
+			return nil, fmt.Errorf("MIB module %v is not loaded", name[:i])
+		}
+		object, ok := module.objects[name[i+2:]]
+		if !ok {
+			return nil, fmt.Errorf("object %v not found", name)
+		}
+		return object, nil
+	}
+
+	var found *Object
+	for _, module := range loader.modules {
+		if object, ok := module.objects[name]; ok {
+			if found != nil && found.Oid != object.Oid {
+				return nil, fmt.Errorf("object %v is defined by %v and %v", name, found.Module, object.Module)
+			}
+			found = object
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("object %v not found", name)
+	}
+	return found, nil
+}
+
+// ObjectByOid finds an object in the loaded modules by OID.
+func (loader *Loader) ObjectByOid(oid string) (*Object, bool) {
+	object, ok := loader.byOid[oid]
+	return object, ok
+}
+
+// Children gets the loaded objects directly under an object, ordered by
+// their last OID component.
+func (loader *Loader) Children(object *Object) []*Object {
+	var children []*Object
+	prefix := object.Oid + "."
+	for oid, child := range loader.byOid {
+		if strings.HasPrefix(oid, prefix) && !strings.Contains(oid[len(prefix):], ".") {
+			children = append(children, child)
+		}
+	}
+	sort.Slice(children, func(i, j int) bool {
+		return lastArc(children[i]) < lastArc(children[j])
+	})
+	return children
+}
+
+// ChildNames gets the names of the objects directly under the named object,
+// in OID order. This is useful for lists of OBJECT-IDENTITY values such as
+// upsWellKnownAlarms.
+func (loader *Loader) ChildNames(name string) ([]string, error) {
+	object, err := loader.Object(name)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, child := range loader.Children(object) {
+		names = append(names, child.Name)
+	}
+	return names, nil
+}
+
+// Enumeration gets the named numbers of an object's syntax, following its
+// textual convention.
+func (loader *Loader) Enumeration(name string) (map[int]string, error) {
+	object, err := loader.Object(name)
+	if err != nil {
+		return nil, err
+	}
+	if object.Syntax == nil || len(object.Syntax.Enumeration) == 0 {
+		return nil, fmt.Errorf("object %v has no enumeration", name)
+	}
+	return object.Syntax.Enumeration, nil
+}
+
+// lastArc gets the last component of the OID of an object.
+func lastArc(object *Object) uint64 {
+	oid, err := core.NewOid(object.Oid)
+	if err != nil {
+		return 0
+	}
+	return oid.ToSlice[len(oid.ToSlice)-1]
+}
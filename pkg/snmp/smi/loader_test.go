@@ -0,0 +1,244 @@
+package smi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vapor-ware/synse-snmp-plugin/pkg/snmp/core"
+)
+
+// TestLoaderUpsMib tests loading the UPS-MIB.
+func TestLoaderUpsMib(t *testing.T) {
+	loader := NewLoader("testdata")
+	module, err := loader.Load("UPS-MIB")
+	assert.NoError(t, err)
+	assert.Equal(t, "testdata/UPS-MIB.txt", module.File)
+
+	object, err := loader.Object("upsInputVoltage")
+	assert.NoError(t, err)
+	assert.Equal(t, ".1.3.6.1.2.1.33.1.3.3.1.3", object.Oid)
+	assert.Equal(t, "RMS Volts", object.Units)
+	assert.Equal(t, "NonNegativeInteger", object.Syntax.TextualConvention)
+	assert.Equal(t, "d", object.Syntax.DisplayHint)
+	assert.Equal(t, "INTEGER", object.Syntax.BaseType)
+
+	entry, err := loader.Object("UPS-MIB::upsInputEntry")
+	assert.NoError(t, err)
+	assert.True(t, entry.IsEntry())
+	assert.Equal(t, []string{"upsInputLineIndex"}, entry.Index)
+
+	table, err := loader.Object("upsInputTable")
+	assert.NoError(t, err)
+	assert.True(t, table.IsTable())
+	assert.Equal(t, "UpsInputEntry", table.Syntax.Entry)
+
+	// Textual conventions from SNMPv2-TC.
+	model, err := loader.Object("upsIdentModel")
+	assert.NoError(t, err)
+	assert.Equal(t, "255a", model.Syntax.DisplayHint)
+	assert.Equal(t, "OCTET STRING", model.Syntax.BaseType)
+
+	alarmTime, err := loader.Object("upsAlarmTime")
+	assert.NoError(t, err)
+	assert.Equal(t, "TimeStamp", alarmTime.Syntax.TextualConvention)
+	assert.Equal(t, "TimeTicks", alarmTime.Syntax.BaseType)
+
+	// The compliance statement does not change the syntax.
+	enumeration, err := loader.Enumeration("upsBatteryStatus")
+	assert.NoError(t, err)
+	assert.Len(t, enumeration, 4)
+
+	found, ok := loader.ObjectByOid(".1.3.6.1.2.1.33.1.6.3.10")
+	assert.True(t, ok)
+	assert.Equal(t, "upsAlarmBypassBad", found.Name)
+
+	// The names are registered for use in place of OIDs.
+	oid, err := core.OidNames.Oid("UPS-MIB::upsBatteryVoltage.0")
+	assert.NoError(t, err)
+	assert.Equal(t, ".1.3.6.1.2.1.33.1.2.5.0", oid)
+	name, ok := core.OidNames.Name(".1.3.6.1.2.1.33.1.3.3.1.3.1")
+	assert.True(t, ok)
+	assert.Equal(t, "UPS-MIB::upsInputVoltage.1", name)
+}
+
+// TestLoaderWellKnownAlarms tests that the well-known alarm names are in OID
+// order, since upsAlarmDescr values are resolved by position.
+func TestLoaderWellKnownAlarms(t *testing.T) {
+	loader := NewLoader("testdata")
+	_, err := loader.Load("UPS-MIB")
+	assert.NoError(t, err)
+
+	names, err := loader.ChildNames("upsWellKnownAlarms")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"upsAlarmBatteryBad",
+		"upsAlarmOnBattery",
+		"upsAlarmLowBattery",
+		"upsAlarmDepletedBattery",
+		"upsAlarmTempBad",
+		"upsAlarmInputBad",
+		"upsAlarmOutputBad",
+		"upsAlarmOutputOverload",
+		"upsAlarmOnBypass",
+		"upsAlarmBypassBad",
+		"upsAlarmOutputOffAsRequested",
+		"upsAlarmUpsOffAsRequested",
+		"upsAlarmChargerFailed",
+		"upsAlarmUpsOutputOff",
+		"upsAlarmUpsSystemOff",
+		"upsAlarmFanFailure",
+		"upsAlarmFuseFailure",
+		"upsAlarmGeneralFault",
+		"upsAlarmDiagnosticTestFailed",
+		"upsAlarmCommunicationsLost",
+		"upsAlarmAwaitingPower",
+		"upsAlarmShutdownPending",
+		"upsAlarmShutdownImminent",
+		"upsAlarmTestInProgress",
+	}, names)
+}
+
+// TestLoaderImports tests resolving a vendor MIB which imports from another
+// module in the MIB directory.
+func TestLoaderImports(t *testing.T) {
+	loader := NewLoader("testdata")
+	_, err := loader.Load("TEST-VENDOR-MIB")
+	assert.NoError(t, err)
+
+	// The import was loaded too.
+	_, ok := loader.Module("UPS-MIB")
+	assert.True(t, ok)
+
+	index, err := loader.Object("testSensorIndex")
+	assert.NoError(t, err)
+	assert.Equal(t, ".1.3.6.1.4.1.99999.1.2.1.1", index.Oid)
+	assert.Equal(t, "PositiveInteger", index.Syntax.TextualConvention)
+
+	entry, err := loader.Object("testSensorEntry")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"testSensorIndex", "testSensorName"}, entry.Index)
+	assert.True(t, entry.Implied)
+
+	ext, err := loader.Object("testSensorExtEntry")
+	assert.NoError(t, err)
+	assert.True(t, ext.IsEntry())
+	assert.Equal(t, "testSensorEntry", ext.Augments)
+
+	// An alias of a textual convention has its display hint.
+	temperature, err := loader.Object("testSensorTemperature")
+	assert.NoError(t, err)
+	assert.Equal(t, "TenthsOfDegrees", temperature.Syntax.TextualConvention)
+	assert.Equal(t, "d-1", temperature.Syntax.DisplayHint)
+	assert.Equal(t, "Integer32", temperature.Syntax.BaseType)
+
+	// Enumerations come from textual conventions.
+	enumeration, err := loader.Enumeration("testSensorState")
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]string{1: "normal", 2: "warning", 3: "critical"}, enumeration)
+	enumeration, err = loader.Enumeration("testSensorEnabled")
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]string{1: "true", 2: "false"}, enumeration)
+
+	vendor, err := loader.Object("testVendor")
+	assert.NoError(t, err)
+	assert.Equal(t, `A "quoted" description.`, vendor.Description)
+}
+
+// TestLoaderRfc1213 tests an SMIv1 module which imports from the built in
+// RFC1213-MIB.
+func TestLoaderRfc1213(t *testing.T) {
+	loader := NewLoader("testdata")
+	_, err := loader.Load("TEST-V1-MIB")
+	assert.NoError(t, err)
+
+	module, ok := loader.Module("RFC1213-MIB")
+	assert.True(t, ok)
+	assert.Empty(t, module.File)
+
+	entry, err := loader.Object("testV1PortEntry")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ifIndex", "testV1PortAddress"}, entry.Index)
+	label, err := loader.Object("testV1PortLabel")
+	assert.NoError(t, err)
+	assert.Equal(t, "DisplayString", label.Syntax.TextualConvention)
+	assert.Equal(t, "255a", label.Syntax.DisplayHint)
+	address, err := loader.Object("testV1PortAddress")
+	assert.NoError(t, err)
+	assert.Equal(t, "1x:", address.Syntax.DisplayHint)
+
+	// The system and interfaces groups.
+	index, err := loader.Object("RFC1213-MIB::ifIndex")
+	assert.NoError(t, err)
+	assert.Equal(t, ".1.3.6.1.2.1.2.2.1.1", index.Oid)
+	sysName, err := loader.Object("RFC1213-MIB::sysName")
+	assert.NoError(t, err)
+	assert.Equal(t, ".1.3.6.1.2.1.1.5", sysName.Oid)
+	assert.Equal(t, "read-write", sysName.MaxAccess)
+	enumeration, err := loader.Enumeration("RFC1213-MIB::ifOperStatus")
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]string{1: "up", 2: "down", 3: "testing"}, enumeration)
+	ifEntry, err := loader.Object("RFC1213-MIB::ifEntry")
+	assert.NoError(t, err)
+	assert.Len(t, loader.Children(ifEntry), 22)
+}
+
+// TestLoaderRfc1213Override tests that an RFC1213-MIB file in the MIB
+// directories takes precedence over the built in module.
+func TestLoaderRfc1213Override(t *testing.T) {
+	loader := NewLoader("testdata/rfc1213", "testdata")
+	_, err := loader.Load("TEST-V1-MIB")
+	assert.NoError(t, err)
+
+	module, ok := loader.Module("RFC1213-MIB")
+	assert.True(t, ok)
+	assert.Equal(t, "testdata/rfc1213/RFC1213-MIB.txt", module.File)
+	sysDescr, err := loader.Object("RFC1213-MIB::sysDescr")
+	assert.NoError(t, err)
+	assert.Equal(t, ".1.3.6.1.2.1.1.1", sysDescr.Oid)
+	_, err = loader.Object("RFC1213-MIB::ifIndex")
+	assert.Error(t, err)
+
+	// DisplayString is a plain type in the file.
+	label, err := loader.Object("testV1PortLabel")
+	assert.NoError(t, err)
+	assert.Equal(t, "OCTET STRING", label.Syntax.BaseType)
+	assert.Empty(t, label.Syntax.DisplayHint)
+}
+
+// TestLoaderLoadFile tests loading a MIB file by path.
+func TestLoaderLoadFile(t *testing.T) {
+	loader := NewLoader("testdata")
+	modules, err := loader.LoadFile("testdata/TEST-VENDOR-MIB.my")
+	assert.NoError(t, err)
+	assert.Len(t, modules, 1)
+	assert.Equal(t, "TEST-VENDOR-MIB", modules[0].Name)
+
+	// Loading again is fine.
+	_, err = loader.LoadFile("testdata/TEST-VENDOR-MIB.my")
+	assert.NoError(t, err)
+}
+
+// TestLoaderErrors tests loading and lookup errors.
+func TestLoaderErrors(t *testing.T) {
+	loader := NewLoader("testdata")
+	_, err := loader.Load("MISSING-MIB")
+	assert.Error(t, err)
+
+	_, err = NewLoader("missing").Load("UPS-MIB")
+	assert.Error(t, err)
+
+	// The vendor MIB cannot import UPS-MIB without the MIB directory.
+	_, err = NewLoader().LoadFile("testdata/TEST-VENDOR-MIB.my")
+	assert.Error(t, err)
+
+	_, err = loader.Load("UPS-MIB")
+	assert.NoError(t, err)
+	_, err = loader.Object("upsMissing")
+	assert.Error(t, err)
+	_, err = loader.Object("MISSING-MIB::upsInputVoltage")
+	assert.Error(t, err)
+	_, err = loader.Object("UPS-MIB::upsMissing")
+	assert.Error(t, err)
+	_, err = loader.Enumeration("upsInputVoltage")
+	assert.Error(t, err)
+}
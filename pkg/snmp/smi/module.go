@@ -0,0 +1,145 @@
+// Package smi parses SMIv2 (and most SMIv1) MIB modules, so that tables,
+// enumerations and well-known OIDs can be generated from the vendor MIB files
+// rather than transcribed into Go. See Loader.TableDefinition.
+package smi
+
+// Module is a parsed SMI MIB module, e.g. UPS-MIB.
+type Module struct {
+	// The module name.
+	Name string
+	// The file the module was parsed from. Empty for the built in base modules.
+	File string
+	// Imported symbols, mapped to the module they are imported from.
+	Imports map[string]string
+	// The objects defined in the module, in definition order.
+	Objects []*Object
+	// The textual conventions defined in the module, by name.
+	TextualConventions map[string]*TextualConvention
+	// Other type assignments in the module by name, e.g. entry SEQUENCE types
+	// and SMIv1 style type aliases.
+	Types map[string]*Syntax
+
+	objects map[string]*Object // Objects by name.
+}
+
+// newModule creates an empty module.
+func newModule(name string) *Module {
+	return &Module{
+		Name:               name,
+		Imports:            map[string]string{},
+		TextualConventions: map[string]*TextualConvention{},
+		Types:              map[string]*Syntax{},
+		objects:            map[string]*Object{},
+	}
+}
+
+// addObject adds an object to the module.
+func (module *Module) addObject(object *Object) {
+	object.Module = module.Name
+	module.Objects = append(module.Objects, object)
+	module.objects[object.Name] = object
+}
+
+// Object gets an object defined in the module by name.
+func (module *Module) Object(name string) (*Object, bool) {
+	object, ok := module.objects[name]
+	return object, ok
+}
+
+// Object kinds are the macro, or OBJECT IDENTIFIER, used to define an object.
+const (
+	KindObjectIdentifier = "OBJECT IDENTIFIER"
+	KindObjectType       = "OBJECT-TYPE"
+	KindObjectIdentity   = "OBJECT-IDENTITY"
+	KindModuleIdentity   = "MODULE-IDENTITY"
+	KindNotificationType = "NOTIFICATION-TYPE"
+)
+
+// Object is an object with an OID in a module. Most are OBJECT-TYPE
+// definitions, but nodes such as OBJECT IDENTIFIER, OBJECT-IDENTITY and
+// MODULE-IDENTITY definitions are objects too.
+type Object struct {
+	// The object name, e.g. upsInputVoltage.
+	Name string
+	// The name of the module which defines the object.
+	Module string
+	// The macro used to define the object. See the Kind constants.
+	Kind string
+	// The resolved OID with a leading dot, e.g. .1.3.6.1.2.1.33.1.3.3.1.3.
+	Oid string
+
+	// The SYNTAX of an OBJECT-TYPE. nil for other kinds.
+	Syntax *Syntax
+	// The UNITS of an OBJECT-TYPE, e.g. "0.1 Hertz".
+	Units string
+	// The MAX-ACCESS (or SMIv1 ACCESS), e.g. read-only.
+	MaxAccess string
+	// The STATUS, e.g. current.
+	Status string
+	// The DESCRIPTION.
+	Description string
+	// The INDEX objects of a table entry.
+	Index []string
+	// True when the last INDEX object is IMPLIED.
+	Implied bool
+	// The entry augmented by a table entry with AUGMENTS.
+	Augments string
+
+	value []oidComponent // The unresolved OID value.
+}
+
+// IsTable returns true if the object is a table, i.e. SEQUENCE OF an entry.
+func (object *Object) IsTable() bool {
+	return object.Syntax != nil && object.Syntax.Entry != ""
+}
+
+// IsEntry returns true if the object is a table entry.
+func (object *Object) IsEntry() bool {
+	return object.Kind == KindObjectType && (len(object.Index) > 0 || object.Augments != "")
+}
+
+// IsReadable returns true if the object may be read with a get or walk.
+func (object *Object) IsReadable() bool {
+	switch object.MaxAccess {
+	case "read-only", "read-write", "read-create", "write-only":
+		return true
+	default:
+		return false
+	}
+}
+
+// Syntax is the SYNTAX of an object or textual convention.
+type Syntax struct {
+	// The type as written, e.g. INTEGER, OCTET STRING or DisplayString.
+	Type string
+	// Named numbers of an INTEGER or BITS, e.g. battery(5).
+	Enumeration map[int]string
+	// The entry type name for SEQUENCE OF, i.e. tables.
+	Entry string
+
+	// Resolved with the module imports.
+
+	// The base SMI type after following textual conventions, e.g. OCTET STRING.
+	BaseType string
+	// The textual convention of the type, if any, e.g. DisplayString.
+	TextualConvention string
+	// The DISPLAY-HINT of the textual convention, if any, e.g. 255a.
+	DisplayHint string
+}
+
+// TextualConvention is a TEXTUAL-CONVENTION type assignment.
+type TextualConvention struct {
+	Name        string
+	DisplayHint string
+	Status      string
+	Description string
+	Syntax      *Syntax
+}
+
+// oidComponent is one component of an unresolved OID value, e.g. upsMIB, 1 or
+// org(3).
+type oidComponent struct {
+	Name      string
+	Number    uint64
+	HasNumber bool
+}
@@ -0,0 +1,507 @@
+package smi
+
+import (
+	"fmt"
+	"strconv"
+	"unicode"
+)
+
+// parser parses the tokens of a MIB file into modules.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// ParseModules parses the modules in the text of a MIB file. Most files have
+// a single module. The OIDs and types of the modules are not resolved, see
+// Loader.
+func ParseModules(text string) ([]*Module, error) {
+	tokens, err := tokenize(text)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	var modules []*Module
+	for p.peek().Kind != tokenEOF {
+		module, err := p.parseModule()
+		if err != nil {
+			return nil, err
+		}
+		modules = append(modules, module)
+	}
+	if len(modules) == 0 {
+		return nil, fmt.Errorf("no MIB modules")
+	}
+	return modules, nil
+}
+
+// peek returns the next token without consuming it.
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+// peekAt returns the token n after the next token without consuming it.
+func (p *parser) peekAt(n int) token {
+	if p.pos+n >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos+n]
+}
+
+// next consumes and returns the next token.
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.Kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// is returns true if the next token has the given text.
+func (p *parser) is(text string) bool {
+	t := p.peek()
+	return t.Kind != tokenString && t.Kind != tokenEOF && t.Text == text
+}
+
+// errorf creates an error at the line of the next token.
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", p.peek().Line, fmt.Sprintf(format, args...))
+}
+
+// expect consumes the next token, which must have the given text.
+func (p *parser) expect(text string) error {
+	if !p.is(text) {
+		return p.errorf("expected %q, got %v", text, p.peek())
+	}
+	p.next()
+	return nil
+}
+
+// identifier consumes the next token, which must be an identifier.
+func (p *parser) identifier() (string, error) {
+	t := p.peek()
+	if t.Kind != tokenIdentifier {
+		return "", p.errorf("expected identifier, got %v", t)
+	}
+	p.next()
+	return t.Text, nil
+}
+
+// quoted consumes the next token, which must be a quoted string.
+func (p *parser) quoted() (string, error) {
+	t := p.peek()
+	if t.Kind != tokenString {
+		return "", p.errorf("expected string, got %v", t)
+	}
+	p.next()
+	return t.Text, nil
+}
+
+// skipBalanced skips from an opening bracket to the matching close.
+func (p *parser) skipBalanced(open, close string) error {
+	if err := p.expect(open); err != nil {
+		return err
+	}
+	for depth := 1; depth > 0; {
+		t := p.next()
+		switch {
+		case t.Kind == tokenEOF:
+			return fmt.Errorf("line %d: unterminated %q", t.Line, open)
+		case t.Kind == tokenSymbol && t.Text == open:
+			depth++
+		case t.Kind == tokenSymbol && t.Text == close:
+			depth--
+		}
+	}
+	return nil
+}
+
+// parseModule parses NAME DEFINITIONS ::= BEGIN ... END.
+func (p *parser) parseModule() (*Module, error) {
+	name, err := p.identifier()
+	if err != nil {
+		return nil, err
+	}
+	module := newModule(name)
+
+	if err = p.expect("DEFINITIONS"); err != nil {
+		return nil, err
+	}
+	// Tagging defaults, e.g. DEFINITIONS IMPLICIT TAGS ::= BEGIN.
+	for !p.is("::=") && p.peek().Kind == tokenIdentifier {
+		p.next()
+	}
+	if err = p.expect("::="); err != nil {
+		return nil, err
+	}
+	if err = p.expect("BEGIN"); err != nil {
+		return nil, err
+	}
+
+	if p.is("EXPORTS") {
+		for !p.is(";") {
+			if p.next().Kind == tokenEOF {
+				return nil, p.errorf("unterminated EXPORTS")
+			}
+		}
+		p.next()
+	}
+
+	if p.is("IMPORTS") {
+		p.next()
+		if err = p.parseImports(module); err != nil {
+			return nil, err
+		}
+	}
+
+	for !p.is("END") {
+		if p.peek().Kind == tokenEOF {
+			return nil, p.errorf("module %v has no END", name)
+		}
+		if err = p.parseAssignment(module); err != nil {
+			return nil, fmt.Errorf("%v: %v", name, err)
+		}
+	}
+	p.next()
+	return module, nil
+}
+
+// parseImports parses the symbols FROM each module up to the semicolon.
+func (p *parser) parseImports(module *Module) error {
+	var symbols []string
+	for !p.is(";") {
+		if p.is("FROM") {
+			p.next()
+			from, err := p.identifier()
+			if err != nil {
+				return err
+			}
+			for _, symbol := range symbols {
+				module.Imports[symbol] = from
+			}
+			symbols = nil
+			continue
+		}
+		if p.is(",") {
+			p.next()
+			continue
+		}
+		symbol, err := p.identifier()
+		if err != nil {
+			return err
+		}
+		symbols = append(symbols, symbol)
+	}
+	p.next()
+	if len(symbols) > 0 {
+		return p.errorf("imports %v have no FROM", symbols)
+	}
+	return nil
+}
+
+// parseAssignment parses a type assignment (Name ::= ...), a value assignment
+// (name MACRO-NAME ... ::= value) or a macro definition (NAME MACRO ::= BEGIN
+// ... END), which is skipped.
+func (p *parser) parseAssignment(module *Module) error {
+	name, err := p.identifier()
+	if err != nil {
+		return err
+	}
+
+	if p.is("MACRO") {
+		for !p.is("END") {
+			if p.next().Kind == tokenEOF {
+				return p.errorf("macro %v has no END", name)
+			}
+		}
+		p.next()
+		return nil
+	}
+
+	if p.is("::=") {
+		p.next()
+		return p.parseTypeAssignment(module, name)
+	}
+
+	if unicode.IsUpper([]rune(name)[0]) {
+		return p.errorf("expected ::= after type %v, got %v", name, p.peek())
+	}
+	object, err := p.parseValueAssignment(name)
+	if err != nil {
+		return fmt.Errorf("%v: %v", name, err)
+	}
+	if object != nil {
+		module.addObject(object)
+	}
+	return nil
+}
+
+// parseTypeAssignment parses the right hand side of Name ::= ..., either a
+// TEXTUAL-CONVENTION or a type.
+func (p *parser) parseTypeAssignment(module *Module, name string) error {
+	if !p.is("TEXTUAL-CONVENTION") {
+		syntax, err := p.parseType()
+		if err != nil {
+			return fmt.Errorf("%v: %v", name, err)
+		}
+		module.Types[name] = syntax
+		return nil
+	}
+	p.next()
+
+	tc := &TextualConvention{Name: name}
+	for tc.Syntax == nil {
+		clause, err := p.identifier()
+		if err != nil {
+			return fmt.Errorf("%v: %v", name, err)
+		}
+		switch clause {
+		case "DISPLAY-HINT":
+			tc.DisplayHint, err = p.quoted()
+		case "STATUS":
+			tc.Status, err = p.identifier()
+		case "DESCRIPTION":
+			tc.Description, err = p.quoted()
+		case "REFERENCE":
+			_, err = p.quoted()
+		case "SYNTAX":
+			tc.Syntax, err = p.parseType()
+		default:
+			err = fmt.Errorf("unexpected clause %v", clause)
+		}
+		if err != nil {
+			return fmt.Errorf("%v: %v", name, err)
+		}
+	}
+	module.TextualConventions[name] = tc
+	return nil
+}
+
+// parseValueAssignment parses a macro value assignment such as OBJECT-TYPE.
+// The clauses used by this package are kept and others are skipped. Values
+// which are not OIDs, such as SMIv1 TRAP-TYPE numbers, return a nil object.
+func (p *parser) parseValueAssignment(name string) (object *Object, err error) {
+	object = &Object{Name: name}
+	if p.is("OBJECT") && p.peekAt(1).Text == "IDENTIFIER" {
+		p.pos += 2
+		object.Kind = KindObjectIdentifier
+	} else {
+		if object.Kind, err = p.identifier(); err != nil {
+			return nil, err
+		}
+	}
+
+	for !p.is("::=") {
+		t := p.peek()
+		switch {
+		case t.Kind == tokenEOF:
+			return nil, p.errorf("expected ::=")
+		case t.Kind == tokenSymbol && t.Text == "{":
+			err = p.skipBalanced("{", "}")
+		case t.Kind != tokenIdentifier:
+			p.next()
+		default:
+			p.next()
+			err = p.parseClause(object, t.Text)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	p.next()
+
+	if !p.is("{") {
+		// e.g. TRAP-TYPE ::= 1
+		p.next()
+		return nil, nil
+	}
+	object.value, err = p.parseOidValue()
+	if err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// parseClause parses a clause of a macro value assignment.
+func (p *parser) parseClause(object *Object, clause string) (err error) {
+	switch clause {
+	case "SYNTAX":
+		// Compliance statements may refine the SYNTAX of other objects. Only
+		// the first SYNTAX is the syntax of the object.
+		var syntax *Syntax
+		if syntax, err = p.parseType(); err == nil && object.Syntax == nil {
+			object.Syntax = syntax
+		}
+	case "UNITS":
+		object.Units, err = p.quoted()
+	case "MAX-ACCESS", "ACCESS":
+		object.MaxAccess, err = p.identifier()
+	case "STATUS":
+		object.Status, err = p.identifier()
+	case "DESCRIPTION":
+		// Later descriptions are of revisions or compliance refinements.
+		var description string
+		if description, err = p.quoted(); err == nil && object.Description == "" {
+			object.Description = description
+		}
+	case "INDEX":
+		object.Index, object.Implied, err = p.parseIndex()
+	case "AUGMENTS":
+		if err = p.expect("{"); err != nil {
+			return err
+		}
+		if object.Augments, err = p.identifier(); err != nil {
+			return err
+		}
+		err = p.expect("}")
+	}
+	return err
+}
+
+// parseIndex parses { [IMPLIED] name, ... }.
+func (p *parser) parseIndex() (index []string, implied bool, err error) {
+	if err = p.expect("{"); err != nil {
+		return nil, false, err
+	}
+	for !p.is("}") {
+		if p.is(",") {
+			p.next()
+			continue
+		}
+		if p.is("IMPLIED") {
+			p.next()
+			implied = true
+		}
+		name, err := p.identifier()
+		if err != nil {
+			return nil, false, err
+		}
+		index = append(index, name)
+	}
+	p.next()
+	return index, implied, nil
+}
+
+// parseOidValue parses { parent 1 }, { iso org(3) 6 } and the like.
+func (p *parser) parseOidValue() (value []oidComponent, err error) {
+	if err = p.expect("{"); err != nil {
+		return nil, err
+	}
+	for !p.is("}") {
+		t := p.next()
+		switch t.Kind {
+		case tokenNumber:
+			number, err := strconv.ParseUint(t.Text, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid OID component %v", t.Line, t.Text)
+			}
+			value = append(value, oidComponent{Number: number, HasNumber: true})
+		case tokenIdentifier:
+			component := oidComponent{Name: t.Text}
+			if p.is("(") {
+				p.next()
+				n := p.next()
+				number, err := strconv.ParseUint(n.Text, 10, 64)
+				if n.Kind != tokenNumber || err != nil {
+					return nil, fmt.Errorf("line %d: invalid OID component %v(%v)", t.Line, t.Text, n.Text)
+				}
+				component.Number = number
+				component.HasNumber = true
+				if err = p.expect(")"); err != nil {
+					return nil, err
+				}
+			}
+			value = append(value, component)
+		default:
+			return nil, fmt.Errorf("line %d: unexpected %v in OID value", t.Line, t)
+		}
+	}
+	p.next()
+	if len(value) == 0 {
+		return nil, p.errorf("empty OID value")
+	}
+	return value, nil
+}
+
+// parseType parses a type, e.g. INTEGER { a(1), b(2) }, OCTET STRING
+// (SIZE(0..255)), SEQUENCE OF UpsInputEntry or DisplayString. Tags, sizes and
+// ranges are skipped.
+func (p *parser) parseType() (syntax *Syntax, err error) {
+	syntax = &Syntax{}
+
+	// Tags, e.g. [APPLICATION 4] IMPLICIT.
+	if p.is("[") {
+		if err = p.skipBalanced("[", "]"); err != nil {
+			return nil, err
+		}
+	}
+	if p.is("IMPLICIT") || p.is("EXPLICIT") {
+		p.next()
+	}
+
+	name, err := p.identifier()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case name == "OCTET" && p.is("STRING"), name == "OBJECT" && p.is("IDENTIFIER"):
+		syntax.Type = name + " " + p.next().Text
+	case name == "SEQUENCE" && p.is("OF"):
+		p.next()
+		syntax.Type = "SEQUENCE OF"
+		if syntax.Entry, err = p.identifier(); err != nil {
+			return nil, err
+		}
+		return syntax, nil
+	case name == "SEQUENCE" || name == "CHOICE":
+		syntax.Type = name
+		return syntax, p.skipBalanced("{", "}")
+	default:
+		syntax.Type = name
+	}
+
+	if p.is("{") {
+		if syntax.Enumeration, err = p.parseNamedNumbers(); err != nil {
+			return nil, err
+		}
+	}
+	if p.is("(") {
+		if err = p.skipBalanced("(", ")"); err != nil {
+			return nil, err
+		}
+	}
+	return syntax, nil
+}
+
+// parseNamedNumbers parses { name(1), other(2) }.
+func (p *parser) parseNamedNumbers() (map[int]string, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	enumeration := map[int]string{}
+	for !p.is("}") {
+		if p.is(",") {
+			p.next()
+			continue
+		}
+		name, err := p.identifier()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expect("("); err != nil {
+			return nil, err
+		}
+		t := p.next()
+		number, err := strconv.Atoi(t.Text)
+		if t.Kind != tokenNumber || err != nil {
+			return nil, fmt.Errorf("line %d: invalid number %v for %v", t.Line, t.Text, name)
+		}
+		if err = p.expect(")"); err != nil {
+			return nil, err
+		}
+		enumeration[number] = name
+	}
+	p.next()
+	return enumeration, nil
+}
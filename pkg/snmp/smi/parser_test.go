@@ -0,0 +1,122 @@
+package smi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTokenize tests splitting MIB text into tokens.
+func TestTokenize(t *testing.T) {
+	tokens, err := tokenize("a-b ::= { c 1 } -- comment\n\"x \"\"y\"\"\" -- inline -- d(-1) '0A'H ..")
+	assert.NoError(t, err)
+
+	var texts []string
+	for _, token := range tokens {
+		texts = append(texts, token.Text)
+	}
+	assert.Equal(t, []string{
+		"a-b", "::=", "{", "c", "1", "}", `x "y"`, "d", "(", "-1", ")", "'0A'H", "..", "",
+	}, texts)
+	assert.Equal(t, tokenString, tokens[6].Kind)
+	assert.Equal(t, 2, tokens[6].Line)
+	assert.Equal(t, tokenEOF, tokens[len(tokens)-1].Kind)
+}
+
+// TestTokenizeErrors tests invalid MIB text.
+func TestTokenizeErrors(t *testing.T) {
+	for _, text := range []string{
+		`"unterminated`,
+		`'unterminated`,
+		`a # b`,
+	} {
+		_, err := tokenize(text)
+		assert.Error(t, err, text)
+	}
+}
+
+// TestParseModules tests parsing a small module.
+func TestParseModules(t *testing.T) {
+	modules, err := ParseModules(`
+TEST-MIB DEFINITIONS ::= BEGIN
+IMPORTS OBJECT-TYPE, enterprises FROM SNMPv2-SMI
+        DisplayString FROM SNMPv2-TC;
+
+test OBJECT IDENTIFIER ::= { enterprises 1 }
+
+Level ::= TEXTUAL-CONVENTION
+    DISPLAY-HINT "d-2"
+    STATUS current
+    DESCRIPTION "A level."
+    SYNTAX INTEGER (0..100)
+
+testLevel OBJECT-TYPE
+    SYNTAX Level
+    UNITS "0.01 percent"
+    MAX-ACCESS read-only
+    STATUS current
+    DESCRIPTION "The level."
+    ::= { test 1 }
+
+testMode OBJECT-TYPE
+    SYNTAX INTEGER { off(0), on(1) }
+    ACCESS read-write
+    STATUS mandatory
+    ::= { iso org(3) dod(6) 1 4 1 1 2 }
+
+testTrap TRAP-TYPE
+    ENTERPRISE test
+    VARIABLES { testLevel }
+    ::= 1
+END`)
+	assert.NoError(t, err)
+	assert.Len(t, modules, 1)
+
+	module := modules[0]
+	assert.Equal(t, "TEST-MIB", module.Name)
+	assert.Equal(t, map[string]string{
+		"OBJECT-TYPE":   "SNMPv2-SMI",
+		"enterprises":   "SNMPv2-SMI",
+		"DisplayString": "SNMPv2-TC",
+	}, module.Imports)
+
+	// The trap is not an OID, so is not an object.
+	assert.Len(t, module.Objects, 3)
+
+	assert.Equal(t, "d-2", module.TextualConventions["Level"].DisplayHint)
+	assert.Equal(t, "INTEGER", module.TextualConventions["Level"].Syntax.Type)
+
+	level, ok := module.Object("testLevel")
+	assert.True(t, ok)
+	assert.Equal(t, KindObjectType, level.Kind)
+	assert.Equal(t, "TEST-MIB", level.Module)
+	assert.Equal(t, "Level", level.Syntax.Type)
+	assert.Equal(t, "0.01 percent", level.Units)
+	assert.Equal(t, "read-only", level.MaxAccess)
+	assert.Equal(t, "The level.", level.Description)
+	assert.Equal(t, []oidComponent{{Name: "test"}, {Number: 1, HasNumber: true}}, level.value)
+
+	mode, ok := module.Object("testMode")
+	assert.True(t, ok)
+	assert.Equal(t, "read-write", mode.MaxAccess)
+	assert.Equal(t, map[int]string{0: "off", 1: "on"}, mode.Syntax.Enumeration)
+	assert.Len(t, mode.value, 8)
+	assert.Equal(t, oidComponent{Name: "org", Number: 3, HasNumber: true}, mode.value[1])
+}
+
+// TestParseModulesErrors tests invalid modules.
+func TestParseModulesErrors(t *testing.T) {
+	for _, text := range []string{
+		"",
+		"TEST-MIB ::= BEGIN END",
+		"TEST-MIB DEFINITIONS ::= BEGIN",
+		"TEST-MIB DEFINITIONS ::= BEGIN IMPORTS a FROM B c; END",
+		"TEST-MIB DEFINITIONS ::= BEGIN x OBJECT IDENTIFIER ::= { } END",
+		"TEST-MIB DEFINITIONS ::= BEGIN x OBJECT-TYPE SYNTAX INTEGER { a } ::= { b 1 } END",
+		"TEST-MIB DEFINITIONS ::= BEGIN T ::= TEXTUAL-CONVENTION BOGUS END",
+		"TEST-MIB DEFINITIONS ::= BEGIN T OBJECT-TYPE END",
+	} {
+		_, err := ParseModules(text)
+		assert.Error(t, err, text)
+	}
+}
@@ -0,0 +1,271 @@
+package smi
+
+// builtinSources are the sources of the modules built in as a fallback for
+// MIB directories which do not have them. A MIB file with the module takes
+// precedence. See Loader.Load.
+var builtinSources = map[string]string{
+	"RFC1213-MIB": rfc1213Source,
+}
+
+// rfc1213Source is the system and interfaces groups of RFC1213-MIB (MIB-II),
+// which SMIv1 modules import DisplayString, PhysAddress and mib-2 from. The
+// other groups are rarely imported. DisplayString and PhysAddress are textual
+// conventions here, rather than plain types, so that they keep the display
+// hints of their SNMPv2-TC counterparts.
+const rfc1213Source = `
+RFC1213-MIB DEFINITIONS ::= BEGIN
+
+IMPORTS
+    mgmt, TimeTicks, Counter, Gauge
+        FROM RFC1155-SMI
+    OBJECT-TYPE
+        FROM RFC-1212;
+
+mib-2      OBJECT IDENTIFIER ::= { mgmt 1 }
+
+DisplayString ::= TEXTUAL-CONVENTION
+    DISPLAY-HINT "255a"
+    STATUS       current
+    DESCRIPTION  "NVT ASCII text."
+    SYNTAX       OCTET STRING (SIZE (0..255))
+
+PhysAddress ::= TEXTUAL-CONVENTION
+    DISPLAY-HINT "1x:"
+    STATUS       current
+    DESCRIPTION  "A media- or physical-level address."
+    SYNTAX       OCTET STRING
+
+system     OBJECT IDENTIFIER ::= { mib-2 1 }
+interfaces OBJECT IDENTIFIER ::= { mib-2 2 }
+
+sysDescr OBJECT-TYPE
+    SYNTAX  DisplayString (SIZE (0..255))
+    ACCESS  read-only
+    STATUS  mandatory
+    ::= { system 1 }
+
+sysObjectID OBJECT-TYPE
+    SYNTAX  OBJECT IDENTIFIER
+    ACCESS  read-only
+    STATUS  mandatory
+    ::= { system 2 }
+
+sysUpTime OBJECT-TYPE
+    SYNTAX  TimeTicks
+    ACCESS  read-only
+    STATUS  mandatory
+    ::= { system 3 }
+
+sysContact OBJECT-TYPE
+    SYNTAX  DisplayString (SIZE (0..255))
+    ACCESS  read-write
+    STATUS  mandatory
+    ::= { system 4 }
+
+sysName OBJECT-TYPE
+    SYNTAX  DisplayString (SIZE (0..255))
+    ACCESS  read-write
+    STATUS  mandatory
+    ::= { system 5 }
+
+sysLocation OBJECT-TYPE
+    SYNTAX  DisplayString (SIZE (0..255))
+    ACCESS  read-write
+    STATUS  mandatory
+    ::= { system 6 }
+
+sysServices OBJECT-TYPE
+    SYNTAX  INTEGER (0..127)
+    ACCESS  read-only
+    STATUS  mandatory
+    ::= { system 7 }
+
+ifNumber OBJECT-TYPE
+    SYNTAX  INTEGER
+    ACCESS  read-only
+    STATUS  mandatory
+    ::= { interfaces 1 }
+
+ifTable OBJECT-TYPE
+    SYNTAX  SEQUENCE OF IfEntry
+    ACCESS  not-accessible
+    STATUS  mandatory
+    ::= { interfaces 2 }
+
+ifEntry OBJECT-TYPE
+    SYNTAX  IfEntry
+    ACCESS  not-accessible
+    STATUS  mandatory
+    INDEX   { ifIndex }
+    ::= { ifTable 1 }
+
+IfEntry ::=
+    SEQUENCE {
+        ifIndex           INTEGER,
+        ifDescr           DisplayString,
+        ifType            INTEGER,
+        ifMtu             INTEGER,
+        ifSpeed           Gauge,
+        ifPhysAddress     PhysAddress,
+        ifAdminStatus     INTEGER,
+        ifOperStatus      INTEGER,
+        ifLastChange      TimeTicks,
+        ifInOctets        Counter,
+        ifInUcastPkts     Counter,
+        ifInNUcastPkts    Counter,
+        ifInDiscards      Counter,
+        ifInErrors        Counter,
+        ifInUnknownProtos Counter,
+        ifOutOctets       Counter,
+        ifOutUcastPkts    Counter,
+        ifOutNUcastPkts   Counter,
+        ifOutDiscards     Counter,
+        ifOutErrors       Counter,
+        ifOutQLen         Gauge,
+        ifSpecific        OBJECT IDENTIFIER
+    }
+
+ifIndex OBJECT-TYPE
+    SYNTAX  INTEGER
+    ACCESS  read-only
+    STATUS  mandatory
+    ::= { ifEntry 1 }
+
+ifDescr OBJECT-TYPE
+    SYNTAX  DisplayString (SIZE (0..255))
+    ACCESS  read-only
+    STATUS  mandatory
+    ::= { ifEntry 2 }
+
+ifType OBJECT-TYPE
+    SYNTAX  INTEGER {
+                other(1), regular1822(2), hdh1822(3), ddn-x25(4),
+                rfc877-x25(5), ethernet-csmacd(6), iso88023-csmacd(7),
+                iso88024-tokenBus(8), iso88025-tokenRing(9),
+                iso88026-man(10), starLan(11), proteon-10Mbit(12),
+                proteon-80Mbit(13), hyperchannel(14), fddi(15), lapb(16),
+                sdlc(17), ds1(18), e1(19), basicISDN(20), primaryISDN(21),
+                propPointToPointSerial(22), ppp(23), softwareLoopback(24),
+                eon(25), ethernet-3Mbit(26), nsip(27), slip(28), ultra(29),
+                ds3(30), sip(31), frame-relay(32)
+            }
+    ACCESS  read-only
+    STATUS  mandatory
+    ::= { ifEntry 3 }
+
+ifMtu OBJECT-TYPE
+    SYNTAX  INTEGER
+    ACCESS  read-only
+    STATUS  mandatory
+    ::= { ifEntry 4 }
+
+ifSpeed OBJECT-TYPE
+    SYNTAX  Gauge
+    ACCESS  read-only
+    STATUS  mandatory
+    ::= { ifEntry 5 }
+
+ifPhysAddress OBJECT-TYPE
+    SYNTAX  PhysAddress
+    ACCESS  read-only
+    STATUS  mandatory
+    ::= { ifEntry 6 }
+
+ifAdminStatus OBJECT-TYPE
+    SYNTAX  INTEGER { up(1), down(2), testing(3) }
+    ACCESS  read-write
+    STATUS  mandatory
+    ::= { ifEntry 7 }
+
+ifOperStatus OBJECT-TYPE
+    SYNTAX  INTEGER { up(1), down(2), testing(3) }
+    ACCESS  read-only
+    STATUS  mandatory
+    ::= { ifEntry 8 }
+
+ifLastChange OBJECT-TYPE
+    SYNTAX  TimeTicks
+    ACCESS  read-only
+    STATUS  mandatory
+    ::= { ifEntry 9 }
+
+ifInOctets OBJECT-TYPE
+    SYNTAX  Counter
+    ACCESS  read-only
+    STATUS  mandatory
+    ::= { ifEntry 10 }
+
+ifInUcastPkts OBJECT-TYPE
+    SYNTAX  Counter
+    ACCESS  read-only
+    STATUS  mandatory
+    ::= { ifEntry 11 }
+
+ifInNUcastPkts OBJECT-TYPE
+    SYNTAX  Counter
+    ACCESS  read-only
+    STATUS  mandatory
+    ::= { ifEntry 12 }
+
+ifInDiscards OBJECT-TYPE
+    SYNTAX  Counter
+    ACCESS  read-only
+    STATUS  mandatory
+    ::= { ifEntry 13 }
+
+ifInErrors OBJECT-TYPE
+    SYNTAX  Counter
+    ACCESS  read-only
+    STATUS  mandatory
+    ::= { ifEntry 14 }
+
+ifInUnknownProtos OBJECT-TYPE
+    SYNTAX  Counter
+    ACCESS  read-only
+    STATUS  mandatory
+    ::= { ifEntry 15 }
+
+ifOutOctets OBJECT-TYPE
+    SYNTAX  Counter
+    ACCESS  read-only
+    STATUS  mandatory
+    ::= { ifEntry 16 }
+
+ifOutUcastPkts OBJECT-TYPE
+    SYNTAX  Counter
+    ACCESS  read-only
+    STATUS  mandatory
+    ::= { ifEntry 17 }
+
+ifOutNUcastPkts OBJECT-TYPE
+    SYNTAX  Counter
+    ACCESS  read-only
+    STATUS  mandatory
+    ::= { ifEntry 18 }
+
+ifOutDiscards OBJECT-TYPE
+    SYNTAX  Counter
+    ACCESS  read-only
+    STATUS  mandatory
+    ::= { ifEntry 19 }
+
+ifOutErrors OBJECT-TYPE
+    SYNTAX  Counter
+    ACCESS  read-only
+    STATUS  mandatory
+    ::= { ifEntry 20 }
+
+ifOutQLen OBJECT-TYPE
+    SYNTAX  Gauge
+    ACCESS  read-only
+    STATUS  mandatory
+    ::= { ifEntry 21 }
+
+ifSpecific OBJECT-TYPE
+    SYNTAX  OBJECT IDENTIFIER
+    ACCESS  read-only
+    STATUS  mandatory
+    ::= { ifEntry 22 }
+
+END
+`